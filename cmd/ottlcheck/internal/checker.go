@@ -0,0 +1,98 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal // import "github.com/open-telemetry/opentelemetry-collector-contrib/cmd/ottlcheck/internal"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+// Result is the result of checking a group.
+type Result struct {
+	Group Group
+	// Context is the context the group was parsed with, if it was parsed successfully.
+	Context  string
+	Err      error
+	Warnings []ottl.Warning
+	program  program
+}
+
+// Run runs the statements or conditions of a successfully checked group on a sample.
+func (r Result) Run(ctx context.Context, sample any) error {
+	return r.program.run(ctx, sample)
+}
+
+// Checker parses groups of statements and conditions with the contexts and functions of the
+// transform processor.
+type Checker struct {
+	functionDefinitions []string
+	collections         map[Signal]*ottl.ParserCollection[program]
+	// warnings collects the warnings of the group being checked.
+	warnings []ottl.Warning
+}
+
+// NewChecker returns a Checker whose statements can call the given user-defined functions.
+func NewChecker(functionDefinitions []string) *Checker {
+	return &Checker{
+		functionDefinitions: functionDefinitions,
+		collections:         map[Signal]*ottl.ParserCollection[program]{},
+	}
+}
+
+// Check parses a group, returning the errors and warnings found.
+func (c *Checker) Check(group Group) Result {
+	result := Result{Group: group}
+	signal := group.Signal
+	if signal == "" {
+		signal = Logs
+	}
+	pc, err := c.collection(signal)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+
+	c.warnings = nil
+	var parsed program
+	switch {
+	case group.ConditionsOnly && group.Context != "":
+		parsed, err = pc.ParseConditionsWithContext(group.Context, group, true)
+	case group.ConditionsOnly:
+		parsed, err = pc.ParseConditions(group)
+	case group.Context != "":
+		parsed, err = pc.ParseStatementsWithContext(group.Context, group, true)
+	default:
+		parsed, err = pc.ParseStatements(group, ottl.WithContextInferenceConditions(group.Conditions))
+	}
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	result.Context = parsed.contextName()
+	result.Warnings = c.warnings
+	result.program = parsed
+	return result
+}
+
+func (c *Checker) collection(signal Signal) (*ottl.ParserCollection[program], error) {
+	if pc, ok := c.collections[signal]; ok {
+		return pc, nil
+	}
+	options, err := contextOptions(signal, func(w ottl.Warning) {
+		c.warnings = append(c.warnings, w)
+	})
+	if err != nil {
+		return nil, err
+	}
+	options = append(options, ottl.WithParserCollectionFunctionDefinitions[program](c.functionDefinitions))
+	pc, err := ottl.NewParserCollection(componenttest.NewNopTelemetrySettings(), options...)
+	if err != nil {
+		return nil, err
+	}
+	c.collections[signal] = pc
+	return pc, nil
+}
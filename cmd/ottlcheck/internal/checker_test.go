@@ -0,0 +1,141 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func TestChecker(t *testing.T) {
+	tests := []struct {
+		name     string
+		group    Group
+		context  string
+		err      string
+		warnings []ottl.Warning
+	}{
+		{
+			name:    "inferred context",
+			group:   Group{Signal: Logs, Statements: []string{`set(log.attributes["a"], resource.attributes["b"])`}},
+			context: "log",
+		},
+		{
+			name:    "context",
+			group:   Group{Signal: Traces, Context: "spanevent", Statements: []string{`set(attributes["a"], name)`}, Conditions: []string{`name == "exception"`}},
+			context: "spanevent",
+		},
+		{
+			name:    "conditions",
+			group:   Group{Signal: Metrics, ConditionsOnly: true, Conditions: []string{`metric.name == "a"`, `datapoint.attributes["b"] == nil`}},
+			context: "datapoint",
+		},
+		{
+			name:  "unknown path",
+			group: Group{Signal: Logs, Context: "log", Statements: []string{`set(attributes["a"], unknown)`}},
+			err:   `segment "unknown" from path "log.unknown" is not a valid path`,
+		},
+		{
+			name:  "unknown function",
+			group: Group{Signal: Logs, Context: "log", Statements: []string{`unknown(attributes["a"])`}},
+			err:   `undefined function "unknown"`,
+		},
+		{
+			name:  "function of another context",
+			group: Group{Signal: Metrics, Context: "datapoint", Statements: []string{`convert_sum_to_gauge()`}},
+			err:   `undefined function "convert_sum_to_gauge"`,
+		},
+		{
+			name:    "unreachable condition",
+			group:   Group{Signal: Logs, Context: "log", Statements: []string{`set(attributes["a"], 1) where body == "a" and body == "b"`}},
+			context: "log",
+			warnings: []ottl.Warning{{
+				Expression: `set(log.attributes["a"], 1) where log.body == "a" and log.body == "b"`,
+				Message:    "the condition is never met, so the statement is never executed",
+			}},
+		},
+		{
+			name:    "type mismatch",
+			group:   Group{Signal: Logs, Context: "log", Statements: []string{`set(attributes["a"], SHA256(1))`}},
+			context: "log",
+			warnings: []ottl.Warning{{
+				Expression: `set(log.attributes["a"], SHA256(1))`,
+				Message:    "the argument at position 0 of SHA256 is of type int, but the parameter requires a value of type string",
+			}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := NewChecker(nil).Check(tt.group)
+			if tt.err != "" {
+				require.ErrorContains(t, result.Err, tt.err)
+				return
+			}
+			require.NoError(t, result.Err)
+			assert.Equal(t, tt.context, result.Context)
+			assert.Equal(t, tt.warnings, result.Warnings)
+		})
+	}
+}
+
+func TestChecker_FunctionDefinitions(t *testing.T) {
+	checker := NewChecker([]string{`Greet(name) = Concat(["hello", name], " ")`})
+	result := checker.Check(Group{Signal: Logs, Statements: []string{`set(log.body, Greet(log.attributes["user"]))`}})
+	require.NoError(t, result.Err)
+	assert.Equal(t, "log", result.Context)
+}
+
+func TestSample(t *testing.T) {
+	sample, err := ReadSample(filepath.Join("testdata", "logs.json"))
+	require.NoError(t, err)
+	assert.Equal(t, Logs, sample.Signal)
+
+	statements, err := ReadStatements(filepath.Join("testdata", "statements.ottl"))
+	require.NoError(t, err)
+	assert.Len(t, statements, 2)
+
+	result := NewChecker(nil).Check(Group{Signal: Logs, Context: "log", Statements: statements})
+	require.NoError(t, result.Err)
+
+	before, err := sample.JSON()
+	require.NoError(t, err)
+	require.NoError(t, sample.Apply(context.Background(), []Result{result}))
+	after, err := sample.JSON()
+	require.NoError(t, err)
+
+	diff := Diff(before, after)
+	assert.Contains(t, diff, `-                "stringValue": "payment accepted"`)
+	assert.Contains(t, diff, `+                "stringValue": "payment accepted!"`)
+	assert.Contains(t, diff, `+                    "stringValue": "checkout"`)
+}
+
+func TestReadCollectorConfig(t *testing.T) {
+	processors, err := ReadCollectorConfig(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+	require.Len(t, processors, 2)
+
+	assert.Equal(t, "transform", processors[0].ID)
+	assert.Equal(t, []Group{{
+		Source:  "transform log_statements[0]",
+		Signal:  Logs,
+		Context: "log",
+		Statements: []string{
+			`set(attributes["checked"], true)`,
+			`set(severity_text, "INFO") where severity_number == 9 and severity_number == 13`,
+		},
+	}}, processors[0].Groups)
+
+	assert.Equal(t, "transform/unknown", processors[1].ID)
+	require.Len(t, processors[1].Groups, 2)
+	assert.Equal(t, "transform/unknown trace_statements[0]", processors[1].Groups[0].Source)
+	assert.Equal(t, Traces, processors[1].Groups[0].Signal)
+	assert.Equal(t, "transform/unknown metric_statements[0]", processors[1].Groups[1].Source)
+	assert.Equal(t, Metrics, processors[1].Groups[1].Signal)
+}
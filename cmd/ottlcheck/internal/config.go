@@ -0,0 +1,90 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal // import "github.com/open-telemetry/opentelemetry-collector-contrib/cmd/ottlcheck/internal"
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+type Config struct {
+	// CollectorConfigFile is a collector configuration whose transform processors are checked.
+	CollectorConfigFile string
+	// StatementFiles are files of statements, or conditions if Conditions is true, checked as a
+	// single group.
+	StatementFiles []string
+	Context        string
+	Signal         Signal
+	Conditions     bool
+	SampleFile     string
+	FailOnWarnings bool
+}
+
+func ReadConfig(args []string) (*Config, error) {
+	cfg := &Config{}
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch arg {
+		case "--config":
+			i++
+			if i == len(args) {
+				return nil, errors.New("--config requires an argument")
+			}
+			cfg.CollectorConfigFile = args[i]
+		case "--context":
+			i++
+			if i == len(args) {
+				return nil, errors.New("--context requires an argument")
+			}
+			cfg.Context = args[i]
+		case "--signal":
+			i++
+			if i == len(args) {
+				return nil, errors.New("--signal requires an argument")
+			}
+			cfg.Signal = Signal(args[i])
+			if _, ok := signalContexts[cfg.Signal]; !ok {
+				return nil, fmt.Errorf("unknown signal %q, must be one of logs, traces, metrics or profiles", args[i])
+			}
+		case "--sample":
+			i++
+			if i == len(args) {
+				return nil, errors.New("--sample requires an argument")
+			}
+			cfg.SampleFile = args[i]
+		case "--conditions":
+			cfg.Conditions = true
+		case "--fail-on-warnings":
+			cfg.FailOnWarnings = true
+		default:
+			if strings.HasPrefix(arg, "--") {
+				return nil, fmt.Errorf("unknown flag %s", arg)
+			}
+			cfg.StatementFiles = append(cfg.StatementFiles, arg)
+		}
+	}
+
+	switch {
+	case cfg.CollectorConfigFile == "" && len(cfg.StatementFiles) == 0:
+		return nil, errors.New("either --config or statement files are required")
+	case cfg.CollectorConfigFile != "" && len(cfg.StatementFiles) > 0:
+		return nil, errors.New("--config can't be used with statement files")
+	case cfg.CollectorConfigFile != "" && (cfg.Context != "" || cfg.Signal != "" || cfg.Conditions):
+		return nil, errors.New("--context, --signal and --conditions can only be used with statement files")
+	}
+	if cfg.Context != "" {
+		signal, err := SignalOf(cfg.Context)
+		if err != nil {
+			return nil, err
+		}
+		if signal != "" && cfg.Signal != "" && signal != cfg.Signal {
+			return nil, fmt.Errorf("the %q context doesn't belong to the %s signal", cfg.Context, cfg.Signal)
+		}
+		if cfg.Signal == "" {
+			cfg.Signal = signal
+		}
+	}
+	return cfg, nil
+}
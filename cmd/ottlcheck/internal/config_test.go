@@ -0,0 +1,87 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ReadConfig(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		cfg  *Config
+		err  string
+	}{
+		{
+			name: "collector config",
+			args: []string{"--config", "config.yaml", "--sample", "logs.json", "--fail-on-warnings"},
+			cfg:  &Config{CollectorConfigFile: "config.yaml", SampleFile: "logs.json", FailOnWarnings: true},
+		},
+		{
+			name: "statement files",
+			args: []string{"a.ottl", "--context", "span", "b.ottl"},
+			cfg:  &Config{StatementFiles: []string{"a.ottl", "b.ottl"}, Context: "span", Signal: Traces},
+		},
+		{
+			name: "conditions",
+			args: []string{"--conditions", "--signal", "metrics", "--context", "resource", "a.ottl"},
+			cfg:  &Config{StatementFiles: []string{"a.ottl"}, Context: "resource", Signal: Metrics, Conditions: true},
+		},
+		{
+			name: "missing files",
+			args: []string{"--context", "log"},
+			err:  "either --config or statement files are required",
+		},
+		{
+			name: "config and files",
+			args: []string{"--config", "config.yaml", "a.ottl"},
+			err:  "--config can't be used with statement files",
+		},
+		{
+			name: "config and context",
+			args: []string{"--config", "config.yaml", "--context", "log"},
+			err:  "--context, --signal and --conditions can only be used with statement files",
+		},
+		{
+			name: "missing argument",
+			args: []string{"a.ottl", "--sample"},
+			err:  "--sample requires an argument",
+		},
+		{
+			name: "unknown signal",
+			args: []string{"a.ottl", "--signal", "events"},
+			err:  `unknown signal "events", must be one of logs, traces, metrics or profiles`,
+		},
+		{
+			name: "unknown context",
+			args: []string{"a.ottl", "--context", "event"},
+			err:  `unknown context "event"`,
+		},
+		{
+			name: "context of another signal",
+			args: []string{"a.ottl", "--signal", "logs", "--context", "datapoint"},
+			err:  `the "datapoint" context doesn't belong to the logs signal`,
+		},
+		{
+			name: "unknown flag",
+			args: []string{"a.ottl", "--verbose"},
+			err:  "unknown flag --verbose",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := ReadConfig(tt.args)
+			if tt.err != "" {
+				require.EqualError(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.cfg, cfg)
+		})
+	}
+}
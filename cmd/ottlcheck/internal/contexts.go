@@ -0,0 +1,419 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal // import "github.com/open-telemetry/opentelemetry-collector-contrib/cmd/ottlcheck/internal"
+
+import (
+	"context"
+	"fmt"
+	"iter"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottldatapoint"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottllog"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlmetric"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlprofile"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlresource"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlscope"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspan"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspanevent"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor"
+)
+
+// Signal is the type of telemetry the statements are checked against.
+type Signal string
+
+const (
+	Logs     Signal = "logs"
+	Traces   Signal = "traces"
+	Metrics  Signal = "metrics"
+	Profiles Signal = "profiles"
+)
+
+// signalContexts are the contexts of each signal, with the same functions as the transform processor.
+var signalContexts = map[Signal][]string{
+	Logs:     {ottlresource.ContextName, ottlscope.ContextName, ottllog.ContextName},
+	Traces:   {ottlresource.ContextName, ottlscope.ContextName, ottlspan.ContextName, ottlspanevent.ContextName},
+	Metrics:  {ottlresource.ContextName, ottlscope.ContextName, ottlmetric.ContextName, ottldatapoint.ContextName},
+	Profiles: {ottlresource.ContextName, ottlscope.ContextName, ottlprofile.ContextName},
+}
+
+// SignalOf returns the signal of a context, or an empty signal if the context belongs to all of them.
+func SignalOf(contextName string) (Signal, error) {
+	switch contextName {
+	case ottlresource.ContextName, ottlscope.ContextName:
+		return "", nil
+	case ottllog.ContextName:
+		return Logs, nil
+	case ottlspan.ContextName, ottlspanevent.ContextName:
+		return Traces, nil
+	case ottlmetric.ContextName, ottldatapoint.ContextName:
+		return Metrics, nil
+	case ottlprofile.ContextName:
+		return Profiles, nil
+	}
+	return "", fmt.Errorf("unknown context %q", contextName)
+}
+
+// program runs parsed statements or conditions on the items of a sample.
+type program interface {
+	// contextName returns the context the statements or conditions were parsed with.
+	contextName() string
+	run(ctx context.Context, sample any) error
+}
+
+// ottlContext describes how to parse and run the statements and conditions of a context.
+type ottlContext[K any] struct {
+	name             string
+	newParser        func(map[string]ottl.Factory[K], component.TelemetrySettings, ...ottl.Option[K]) (ottl.Parser[K], error)
+	functions        map[string]ottl.Factory[K]
+	pathContextNames ottl.Option[K]
+	// transformContexts calls fn with the transform context of each item of a sample.
+	transformContexts func(sample any, fn func(K) error) error
+}
+
+// withContext adds the context to a ParserCollection, reporting the warnings of its parsers.
+func withContext[K any](c ottlContext[K], warn func(ottl.Warning)) ottl.ParserCollectionOption[program] {
+	return func(pc *ottl.ParserCollection[program]) error {
+		parser, err := c.newParser(c.functions, pc.Settings, c.pathContextNames, ottl.WithWarningHandler[K](warn))
+		if err != nil {
+			return err
+		}
+		// like in the transform processor, the conditions of a group with a context have
+		// context-less paths
+		contextlessParser, err := c.newParser(c.functions, pc.Settings, ottl.WithWarningHandler[K](warn))
+		if err != nil {
+			return err
+		}
+
+		convertStatements := func(_ *ottl.ParserCollection[program], statements ottl.StatementsGetter, parsed []*ottl.Statement[K]) (program, error) {
+			group, ok := statements.(Group)
+			if !ok {
+				return nil, fmt.Errorf("unexpected statements type %T", statements)
+			}
+			p := &statementsProgram[K]{
+				name:              c.name,
+				transformContexts: c.transformContexts,
+				statements:        ottl.NewStatementSequence(parsed, pc.Settings, ottl.WithStatementSequenceErrorMode[K](ottl.PropagateError)),
+			}
+			if len(group.Conditions) == 0 {
+				return p, nil
+			}
+			conditionsParser := &parser
+			if group.Context != "" {
+				conditionsParser = &contextlessParser
+			}
+			conditions, err := conditionsParser.ParseConditions(group.Conditions)
+			if err != nil {
+				return nil, err
+			}
+			sequence := ottl.NewConditionSequence(conditions, pc.Settings, ottl.WithLogicOperation[K](ottl.Or))
+			p.conditions = &sequence
+			return p, nil
+		}
+		convertConditions := func(_ *ottl.ParserCollection[program], _ ottl.ConditionsGetter, parsed []*ottl.Condition[K]) (program, error) {
+			sequence := ottl.NewConditionSequence(parsed, pc.Settings, ottl.WithLogicOperation[K](ottl.Or))
+			return &conditionsProgram[K]{name: c.name, transformContexts: c.transformContexts, conditions: sequence}, nil
+		}
+
+		return ottl.WithParserCollectionContext(c.name, &parser,
+			ottl.WithStatementConverter(convertStatements),
+			ottl.WithConditionConverter(convertConditions),
+		)(pc)
+	}
+}
+
+type statementsProgram[K any] struct {
+	name              string
+	transformContexts func(sample any, fn func(K) error) error
+	conditions        *ottl.ConditionSequence[K]
+	statements        ottl.StatementSequence[K]
+}
+
+func (p *statementsProgram[K]) contextName() string {
+	return p.name
+}
+
+func (p *statementsProgram[K]) run(ctx context.Context, sample any) error {
+	return p.transformContexts(sample, func(tCtx K) error {
+		if p.conditions != nil {
+			met, err := p.conditions.Eval(ctx, tCtx)
+			if err != nil || !met {
+				return err
+			}
+		}
+		return p.statements.Execute(ctx, tCtx)
+	})
+}
+
+// conditionsProgram evaluates conditions, which can't modify the sample, to report their errors.
+type conditionsProgram[K any] struct {
+	name              string
+	transformContexts func(sample any, fn func(K) error) error
+	conditions        ottl.ConditionSequence[K]
+}
+
+func (p *conditionsProgram[K]) contextName() string {
+	return p.name
+}
+
+func (p *conditionsProgram[K]) run(ctx context.Context, sample any) error {
+	return p.transformContexts(sample, func(tCtx K) error {
+		_, err := p.conditions.Eval(ctx, tCtx)
+		return err
+	})
+}
+
+// contextOptions returns the ParserCollection options adding the contexts of a signal.
+func contextOptions(signal Signal, warn func(ottl.Warning)) ([]ottl.ParserCollectionOption[program], error) {
+	options := []ottl.ParserCollectionOption[program]{
+		withContext(ottlContext[ottlresource.TransformContext]{
+			name:              ottlresource.ContextName,
+			newParser:         ottlresource.NewParser,
+			functions:         ottlfuncs.StandardFuncs[ottlresource.TransformContext](),
+			pathContextNames:  ottlresource.EnablePathContextNames(),
+			transformContexts: resources,
+		}, warn),
+		withContext(ottlContext[ottlscope.TransformContext]{
+			name:              ottlscope.ContextName,
+			newParser:         ottlscope.NewParser,
+			functions:         ottlfuncs.StandardFuncs[ottlscope.TransformContext](),
+			pathContextNames:  ottlscope.EnablePathContextNames(),
+			transformContexts: scopes,
+		}, warn),
+	}
+	switch signal {
+	case Logs:
+		options = append(options, withContext(ottlContext[ottllog.TransformContext]{
+			name:              ottllog.ContextName,
+			newParser:         ottllog.NewParser,
+			functions:         ottl.CreateFactoryMap(transformprocessor.DefaultLogFunctions()...),
+			pathContextNames:  ottllog.EnablePathContextNames(),
+			transformContexts: logRecords,
+		}, warn))
+	case Traces:
+		options = append(options, withContext(ottlContext[ottlspan.TransformContext]{
+			name:              ottlspan.ContextName,
+			newParser:         ottlspan.NewParser,
+			functions:         ottl.CreateFactoryMap(transformprocessor.DefaultSpanFunctions()...),
+			pathContextNames:  ottlspan.EnablePathContextNames(),
+			transformContexts: spans,
+		}, warn), withContext(ottlContext[ottlspanevent.TransformContext]{
+			name:              ottlspanevent.ContextName,
+			newParser:         ottlspanevent.NewParser,
+			functions:         ottl.CreateFactoryMap(transformprocessor.DefaultSpanEventFunctions()...),
+			pathContextNames:  ottlspanevent.EnablePathContextNames(),
+			transformContexts: spanEvents,
+		}, warn))
+	case Metrics:
+		options = append(options, withContext(ottlContext[ottlmetric.TransformContext]{
+			name:              ottlmetric.ContextName,
+			newParser:         ottlmetric.NewParser,
+			functions:         ottl.CreateFactoryMap(transformprocessor.DefaultMetricFunctions()...),
+			pathContextNames:  ottlmetric.EnablePathContextNames(),
+			transformContexts: metrics,
+		}, warn), withContext(ottlContext[ottldatapoint.TransformContext]{
+			name:              ottldatapoint.ContextName,
+			newParser:         ottldatapoint.NewParser,
+			functions:         ottl.CreateFactoryMap(transformprocessor.DefaultDataPointFunctions()...),
+			pathContextNames:  ottldatapoint.EnablePathContextNames(),
+			transformContexts: dataPoints,
+		}, warn))
+	case Profiles:
+		options = append(options, withContext(ottlContext[ottlprofile.TransformContext]{
+			name:              ottlprofile.ContextName,
+			newParser:         ottlprofile.NewParser,
+			functions:         ottl.CreateFactoryMap(transformprocessor.DefaultProfileFunctions()...),
+			pathContextNames:  ottlprofile.EnablePathContextNames(),
+			transformContexts: profiles,
+		}, warn))
+	default:
+		return nil, fmt.Errorf("unknown signal %q", signal)
+	}
+	return options, nil
+}
+
+func resources(sample any, fn func(ottlresource.TransformContext) error) error {
+	switch s := sample.(type) {
+	case plog.Logs:
+		for _, rl := range s.ResourceLogs().All() {
+			if err := fn(ottlresource.NewTransformContext(rl.Resource(), rl)); err != nil {
+				return err
+			}
+		}
+	case ptrace.Traces:
+		for _, rs := range s.ResourceSpans().All() {
+			if err := fn(ottlresource.NewTransformContext(rs.Resource(), rs)); err != nil {
+				return err
+			}
+		}
+	case pmetric.Metrics:
+		for _, rm := range s.ResourceMetrics().All() {
+			if err := fn(ottlresource.NewTransformContext(rm.Resource(), rm)); err != nil {
+				return err
+			}
+		}
+	case pprofile.Profiles:
+		for _, rp := range s.ResourceProfiles().All() {
+			if err := fn(ottlresource.NewTransformContext(rp.Resource(), rp)); err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("unexpected sample type %T", sample)
+	}
+	return nil
+}
+
+func scopes(sample any, fn func(ottlscope.TransformContext) error) error {
+	switch s := sample.(type) {
+	case plog.Logs:
+		for _, rl := range s.ResourceLogs().All() {
+			for _, sl := range rl.ScopeLogs().All() {
+				if err := fn(ottlscope.NewTransformContext(sl.Scope(), rl.Resource(), sl)); err != nil {
+					return err
+				}
+			}
+		}
+	case ptrace.Traces:
+		for _, rs := range s.ResourceSpans().All() {
+			for _, ss := range rs.ScopeSpans().All() {
+				if err := fn(ottlscope.NewTransformContext(ss.Scope(), rs.Resource(), ss)); err != nil {
+					return err
+				}
+			}
+		}
+	case pmetric.Metrics:
+		for _, rm := range s.ResourceMetrics().All() {
+			for _, sm := range rm.ScopeMetrics().All() {
+				if err := fn(ottlscope.NewTransformContext(sm.Scope(), rm.Resource(), sm)); err != nil {
+					return err
+				}
+			}
+		}
+	case pprofile.Profiles:
+		for _, rp := range s.ResourceProfiles().All() {
+			for _, sp := range rp.ScopeProfiles().All() {
+				if err := fn(ottlscope.NewTransformContext(sp.Scope(), rp.Resource(), sp)); err != nil {
+					return err
+				}
+			}
+		}
+	default:
+		return fmt.Errorf("unexpected sample type %T", sample)
+	}
+	return nil
+}
+
+func logRecords(sample any, fn func(ottllog.TransformContext) error) error {
+	for _, rl := range sample.(plog.Logs).ResourceLogs().All() {
+		for _, sl := range rl.ScopeLogs().All() {
+			for _, lr := range sl.LogRecords().All() {
+				if err := fn(ottllog.NewTransformContext(lr, sl.Scope(), rl.Resource(), sl, rl)); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func spans(sample any, fn func(ottlspan.TransformContext) error) error {
+	for _, rs := range sample.(ptrace.Traces).ResourceSpans().All() {
+		for _, ss := range rs.ScopeSpans().All() {
+			for _, span := range ss.Spans().All() {
+				if err := fn(ottlspan.NewTransformContext(span, ss.Scope(), rs.Resource(), ss, rs)); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func spanEvents(sample any, fn func(ottlspanevent.TransformContext) error) error {
+	for _, rs := range sample.(ptrace.Traces).ResourceSpans().All() {
+		for _, ss := range rs.ScopeSpans().All() {
+			for _, span := range ss.Spans().All() {
+				for _, event := range span.Events().All() {
+					if err := fn(ottlspanevent.NewTransformContext(event, span, ss.Scope(), rs.Resource(), ss, rs)); err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func metrics(sample any, fn func(ottlmetric.TransformContext) error) error {
+	for _, rm := range sample.(pmetric.Metrics).ResourceMetrics().All() {
+		for _, sm := range rm.ScopeMetrics().All() {
+			for _, metric := range sm.Metrics().All() {
+				if err := fn(ottlmetric.NewTransformContext(metric, sm.Metrics(), sm.Scope(), rm.Resource(), sm, rm)); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func dataPoints(sample any, fn func(ottldatapoint.TransformContext) error) error {
+	for _, rm := range sample.(pmetric.Metrics).ResourceMetrics().All() {
+		for _, sm := range rm.ScopeMetrics().All() {
+			for _, metric := range sm.Metrics().All() {
+				var dps []any
+				//exhaustive:enforce
+				switch metric.Type() {
+				case pmetric.MetricTypeSum:
+					dps = appendDataPoints(dps, metric.Sum().DataPoints().All())
+				case pmetric.MetricTypeGauge:
+					dps = appendDataPoints(dps, metric.Gauge().DataPoints().All())
+				case pmetric.MetricTypeHistogram:
+					dps = appendDataPoints(dps, metric.Histogram().DataPoints().All())
+				case pmetric.MetricTypeExponentialHistogram:
+					dps = appendDataPoints(dps, metric.ExponentialHistogram().DataPoints().All())
+				case pmetric.MetricTypeSummary:
+					dps = appendDataPoints(dps, metric.Summary().DataPoints().All())
+				case pmetric.MetricTypeEmpty:
+				}
+				for _, dp := range dps {
+					if err := fn(ottldatapoint.NewTransformContext(dp, metric, sm.Metrics(), sm.Scope(), rm.Resource(), sm, rm)); err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func appendDataPoints[T any](dps []any, all iter.Seq2[int, T]) []any {
+	for _, dp := range all {
+		dps = append(dps, dp)
+	}
+	return dps
+}
+
+func profiles(sample any, fn func(ottlprofile.TransformContext) error) error {
+	ps := sample.(pprofile.Profiles)
+	dictionary := ps.ProfilesDictionary()
+	for _, rp := range ps.ResourceProfiles().All() {
+		for _, sp := range rp.ScopeProfiles().All() {
+			for _, profile := range sp.Profiles().All() {
+				if err := fn(ottlprofile.NewTransformContext(profile, dictionary, sp.Scope(), rp.Resource(), sp, rp)); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
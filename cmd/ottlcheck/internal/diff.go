@@ -0,0 +1,79 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal // import "github.com/open-telemetry/opentelemetry-collector-contrib/cmd/ottlcheck/internal"
+
+import (
+	"strings"
+)
+
+// diffContext is the number of unchanged lines printed around the changed ones.
+const diffContext = 3
+
+// Diff returns a line diff of two texts, with lines prefixed by "-" if they were removed, "+" if
+// they were added and " " otherwise. Unchanged lines far from any change are omitted, and an
+// empty string is returned if the texts are equal.
+func Diff(before, after string) string {
+	a := strings.Split(before, "\n")
+	b := strings.Split(after, "\n")
+
+	// lcs[i][j] is the length of the longest common subsequence of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var lines []string
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			lines = append(lines, " "+a[i])
+			i++
+			j++
+		case i < len(a) && (j == len(b) || lcs[i+1][j] >= lcs[i][j+1]):
+			lines = append(lines, "-"+a[i])
+			i++
+		default:
+			lines = append(lines, "+"+b[j])
+			j++
+		}
+	}
+
+	// keep the lines close to a change
+	keep := make([]bool, len(lines))
+	changed := false
+	for k, line := range lines {
+		if line[0] == ' ' {
+			continue
+		}
+		changed = true
+		for l := max(0, k-diffContext); l <= min(len(lines)-1, k+diffContext); l++ {
+			keep[l] = true
+		}
+	}
+	if !changed {
+		return ""
+	}
+	var sb strings.Builder
+	for k, line := range lines {
+		if !keep[k] {
+			if k == 0 || keep[k-1] {
+				sb.WriteString("...\n")
+			}
+			continue
+		}
+		sb.WriteString(line)
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
@@ -0,0 +1,48 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		name     string
+		before   string
+		after    string
+		expected string
+	}{
+		{
+			name:   "equal",
+			before: "a\nb",
+			after:  "a\nb",
+		},
+		{
+			name:     "changed",
+			before:   "a\nb\nc",
+			after:    "a\nx\nc",
+			expected: " a\n-b\n+x\n c\n",
+		},
+		{
+			name:     "added",
+			before:   "a",
+			after:    "a\nb",
+			expected: " a\n+b\n",
+		},
+		{
+			name:     "context",
+			before:   "1\n2\n3\n4\n5\n6\n7\n8\n9",
+			after:    "1\n2\n3\n4\n5\n6\n7\n8\nx",
+			expected: "...\n 6\n 7\n 8\n-9\n+x\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Diff(tt.before, tt.after))
+		})
+	}
+}
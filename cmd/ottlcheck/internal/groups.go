@@ -0,0 +1,138 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal // import "github.com/open-telemetry/opentelemetry-collector-contrib/cmd/ottlcheck/internal"
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"go.opentelemetry.io/collector/confmap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor"
+)
+
+// Group is a group of statements sharing a context and conditions, like the groups of the
+// transform processor, or a group of conditions if ConditionsOnly is true.
+type Group struct {
+	// Source describes where the group was read from.
+	Source string
+	Signal Signal
+	// Context is the context of the group, which is inferred from its paths if empty.
+	Context    string
+	Conditions []string
+	Statements []string
+	// ConditionsOnly is true if the group only has conditions, which are checked on their own.
+	ConditionsOnly bool
+}
+
+func (g Group) GetStatements() []string {
+	return g.Statements
+}
+
+func (g Group) GetConditions() []string {
+	return g.Conditions
+}
+
+// Processor is a transform processor, or a set of statement files checked together.
+type Processor struct {
+	ID                  string
+	FunctionDefinitions []string
+	Groups              []Group
+}
+
+// ReadStatements reads a file with one statement or condition per line. Empty lines and lines
+// starting with # are ignored.
+func ReadStatements(path string) ([]string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var statements []string
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		statements = append(statements, line)
+	}
+	return statements, scanner.Err()
+}
+
+// ReadCollectorConfig returns the transform processors of a collector configuration, sorted by ID.
+func ReadCollectorConfig(path string) ([]Processor, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	retrieved, err := confmap.NewRetrievedFromYAML(content)
+	if err != nil {
+		return nil, err
+	}
+	conf, err := retrieved.AsConf()
+	if err != nil {
+		return nil, err
+	}
+	processorsConf, err := conf.Sub("processors")
+	if err != nil {
+		return nil, err
+	}
+
+	factory := transformprocessor.NewFactory()
+	var processors []Processor
+	for id := range processorsConf.ToStringMap() {
+		if typ, _, _ := strings.Cut(id, "/"); typ != factory.Type().String() {
+			continue
+		}
+		sub, err := processorsConf.Sub(id)
+		if err != nil {
+			return nil, err
+		}
+		cfg := factory.CreateDefaultConfig().(*transformprocessor.Config)
+		if err := cfg.Unmarshal(sub); err != nil {
+			return nil, fmt.Errorf("invalid configuration of %s: %w", id, err)
+		}
+
+		processor := Processor{ID: id, FunctionDefinitions: cfg.FunctionDefinitions}
+		processor.addGroups(Traces, "trace_statements", len(cfg.TraceStatements), func(i int) Group {
+			cs := cfg.TraceStatements[i]
+			return Group{Context: string(cs.Context), Conditions: cs.Conditions, Statements: cs.Statements}
+		})
+		processor.addGroups(Metrics, "metric_statements", len(cfg.MetricStatements), func(i int) Group {
+			cs := cfg.MetricStatements[i]
+			return Group{Context: string(cs.Context), Conditions: cs.Conditions, Statements: cs.Statements}
+		})
+		processor.addGroups(Logs, "log_statements", len(cfg.LogStatements), func(i int) Group {
+			cs := cfg.LogStatements[i]
+			return Group{Context: string(cs.Context), Conditions: cs.Conditions, Statements: cs.Statements}
+		})
+		processor.addGroups(Profiles, "profile_statements", len(cfg.ProfileStatements), func(i int) Group {
+			cs := cfg.ProfileStatements[i]
+			return Group{Context: string(cs.Context), Conditions: cs.Conditions, Statements: cs.Statements}
+		})
+		processors = append(processors, processor)
+	}
+	if len(processors) == 0 {
+		return nil, fmt.Errorf("no %s processor found in %s", factory.Type(), path)
+	}
+	slices.SortFunc(processors, func(a, b Processor) int {
+		return strings.Compare(a.ID, b.ID)
+	})
+	return processors, nil
+}
+
+// addGroups adds the groups of a statements field of the transform processor configuration, whose
+// element type is internal to the transform processor.
+func (p *Processor) addGroups(signal Signal, field string, n int, group func(int) Group) {
+	for i := range n {
+		g := group(i)
+		g.Source = fmt.Sprintf("%s %s[%d]", p.ID, field, i)
+		g.Signal = signal
+		p.Groups = append(p.Groups, g)
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal // import "github.com/open-telemetry/opentelemetry-collector-contrib/cmd/ottlcheck/internal"
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// Sample is OTLP telemetry the checked statements are run on.
+type Sample struct {
+	Signal Signal
+	data   any
+}
+
+// ReadSample reads a file of OTLP JSON telemetry, whose signal is detected from its content.
+func ReadSample(path string) (*Sample, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]json.RawMessage
+	if err = json.Unmarshal(content, &fields); err != nil {
+		return nil, fmt.Errorf("invalid OTLP JSON in %s: %w", path, err)
+	}
+
+	sample := &Sample{}
+	switch {
+	case fields["resourceLogs"] != nil:
+		sample.Signal = Logs
+		sample.data, err = (&plog.JSONUnmarshaler{}).UnmarshalLogs(content)
+	case fields["resourceSpans"] != nil:
+		sample.Signal = Traces
+		sample.data, err = (&ptrace.JSONUnmarshaler{}).UnmarshalTraces(content)
+	case fields["resourceMetrics"] != nil:
+		sample.Signal = Metrics
+		sample.data, err = (&pmetric.JSONUnmarshaler{}).UnmarshalMetrics(content)
+	case fields["resourceProfiles"] != nil:
+		sample.Signal = Profiles
+		sample.data, err = (&pprofile.JSONUnmarshaler{}).UnmarshalProfiles(content)
+	default:
+		return nil, fmt.Errorf("%s has no resourceLogs, resourceSpans, resourceMetrics or resourceProfiles", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid OTLP JSON in %s: %w", path, err)
+	}
+	return sample, nil
+}
+
+// JSON returns the sample as indented OTLP JSON.
+func (s *Sample) JSON() (string, error) {
+	var content []byte
+	var err error
+	switch data := s.data.(type) {
+	case plog.Logs:
+		content, err = (&plog.JSONMarshaler{}).MarshalLogs(data)
+	case ptrace.Traces:
+		content, err = (&ptrace.JSONMarshaler{}).MarshalTraces(data)
+	case pmetric.Metrics:
+		content, err = (&pmetric.JSONMarshaler{}).MarshalMetrics(data)
+	case pprofile.Profiles:
+		content, err = (&pprofile.JSONMarshaler{}).MarshalProfiles(data)
+	default:
+		return "", fmt.Errorf("unexpected sample type %T", s.data)
+	}
+	if err != nil {
+		return "", err
+	}
+	var indented bytes.Buffer
+	if err = json.Indent(&indented, content, "", "  "); err != nil {
+		return "", err
+	}
+	return indented.String(), nil
+}
+
+// Apply runs the statements of the results on the sample, in order. The results must have been
+// checked against the signal of the sample.
+func (s *Sample) Apply(ctx context.Context, results []Result) error {
+	var errs []error
+	for _, r := range results {
+		if r.program == nil {
+			continue
+		}
+		if r.Group.Signal != "" && r.Group.Signal != s.Signal {
+			continue
+		}
+		if err := r.Run(ctx, s.data); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", r.Group.Source, err))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,121 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package main // import "github.com/open-telemetry/opentelemetry-collector-contrib/cmd/ottlcheck"
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/cmd/ottlcheck/internal"
+)
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string, out io.Writer) error {
+	cfg, err := internal.ReadConfig(args)
+	if err != nil {
+		return err
+	}
+
+	processors, err := readProcessors(cfg)
+	if err != nil {
+		return err
+	}
+
+	var sample *internal.Sample
+	if cfg.SampleFile != "" {
+		sample, err = internal.ReadSample(cfg.SampleFile)
+		if err != nil {
+			return err
+		}
+		if cfg.Signal != "" && cfg.Signal != sample.Signal {
+			return fmt.Errorf("the sample contains %s, but the statements are checked against %s", sample.Signal, cfg.Signal)
+		}
+	}
+
+	failures := 0
+	var results []internal.Result
+	for _, processor := range processors {
+		checker := internal.NewChecker(processor.FunctionDefinitions)
+		for _, group := range processor.Groups {
+			if group.Signal == "" && sample != nil {
+				group.Signal = sample.Signal
+			}
+			result := checker.Check(group)
+			if result.Err != nil {
+				failures++
+				fmt.Fprintf(out, "%s: error: %v\n", group.Source, result.Err)
+				continue
+			}
+			fmt.Fprintf(out, "%s: ok (%s)\n", group.Source, result.Context)
+			for _, w := range result.Warnings {
+				fmt.Fprintf(out, "%s: warning: %s\n", group.Source, w)
+			}
+			if cfg.FailOnWarnings {
+				failures += len(result.Warnings)
+			}
+			results = append(results, result)
+		}
+	}
+
+	if sample != nil {
+		before, err := sample.JSON()
+		if err != nil {
+			return err
+		}
+		if err = sample.Apply(context.Background(), results); err != nil {
+			failures++
+			fmt.Fprintf(out, "%s: error: %v\n", cfg.SampleFile, err)
+		}
+		after, err := sample.JSON()
+		if err != nil {
+			return err
+		}
+		if diff := internal.Diff(before, after); diff != "" {
+			fmt.Fprintf(out, "--- %s\n+++ %s (transformed)\n%s", cfg.SampleFile, cfg.SampleFile, diff)
+		} else {
+			fmt.Fprintf(out, "%s: unchanged\n", cfg.SampleFile)
+		}
+	}
+
+	if failures > 0 {
+		return fmt.Errorf("found %d errors", failures)
+	}
+	return nil
+}
+
+// readProcessors returns the transform processors of the collector configuration, or a single
+// processor with the statements of the statement files.
+func readProcessors(cfg *internal.Config) ([]internal.Processor, error) {
+	if cfg.CollectorConfigFile != "" {
+		return internal.ReadCollectorConfig(cfg.CollectorConfigFile)
+	}
+	processor := internal.Processor{}
+	for _, file := range cfg.StatementFiles {
+		statements, err := internal.ReadStatements(file)
+		if err != nil {
+			return nil, err
+		}
+		group := internal.Group{
+			Source:         file,
+			Signal:         cfg.Signal,
+			Context:        cfg.Context,
+			ConditionsOnly: cfg.Conditions,
+		}
+		if cfg.Conditions {
+			group.Conditions = statements
+		} else {
+			group.Statements = statements
+		}
+		processor.Groups = append(processor.Groups, group)
+	}
+	return []internal.Processor{processor}, nil
+}
@@ -0,0 +1,48 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun_CollectorConfig(t *testing.T) {
+	var out bytes.Buffer
+	err := run([]string{"--config", filepath.Join("internal", "testdata", "config.yaml")}, &out)
+	require.EqualError(t, err, "found 1 errors")
+
+	lines := out.String()
+	assert.Contains(t, lines, "transform log_statements[0]: ok (log)\n")
+	assert.Contains(t, lines, `transform log_statements[0]: warning: "set(log.severity_text, \"INFO\") where log.severity_number == 9 and log.severity_number == 13": the condition is never met, so the statement is never executed`)
+	assert.Contains(t, lines, "transform/unknown trace_statements[0]: error: ")
+	assert.Contains(t, lines, "transform/unknown metric_statements[0]: ok (metric)\n")
+}
+
+func TestRun_FailOnWarnings(t *testing.T) {
+	var out bytes.Buffer
+	err := run([]string{"--config", filepath.Join("internal", "testdata", "config.yaml"), "--fail-on-warnings"}, &out)
+	require.EqualError(t, err, "found 2 errors")
+}
+
+func TestRun_Sample(t *testing.T) {
+	var out bytes.Buffer
+	sample := filepath.Join("internal", "testdata", "logs.json")
+	err := run([]string{"--context", "log", "--sample", sample, filepath.Join("internal", "testdata", "statements.ottl")}, &out)
+	require.NoError(t, err)
+
+	lines := out.String()
+	assert.Contains(t, lines, "statements.ottl: ok (log)\n")
+	assert.Contains(t, lines, "--- "+sample+"\n+++ "+sample+" (transformed)\n")
+	assert.Contains(t, lines, `+                "stringValue": "payment accepted!"`)
+}
+
+func TestRun_InvalidArguments(t *testing.T) {
+	err := run([]string{"--sample"}, &bytes.Buffer{})
+	require.EqualError(t, err, "--sample requires an argument")
+}
@@ -0,0 +1,8 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate mdatagen metadata.yaml
+
+// Package main contains the stanza command, which runs a pipeline of stanza operators without
+// running a collector.
+package main // import "github.com/open-telemetry/opentelemetry-collector-contrib/cmd/stanza"
@@ -0,0 +1,83 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package main // import "github.com/open-telemetry/opentelemetry-collector-contrib/cmd/stanza"
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	metricnoop "go.opentelemetry.io/otel/metric/noop"
+	tracenoop "go.opentelemetry.io/otel/trace/noop"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/agent"
+)
+
+func main() {
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	if err := run(ctx, os.Args[1:], os.Stderr); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// run runs the pipeline of the config until the context is done. Logs of the agent are written to logs.
+func run(ctx context.Context, args []string, logs io.Writer) error {
+	flags := flag.NewFlagSet("stanza", flag.ContinueOnError)
+	flags.SetOutput(logs)
+	configFile := flags.String("config", "", "YAML file with a list of operators, or an agent config")
+	checkpointFile := flags.String("checkpoint-file", "", "file in which checkpoints are kept, overriding checkpoint_file of the config")
+	outputFile := flags.String("output-file", "", "file to which entries are written, overriding output_file of the config")
+	logLevel := zapcore.InfoLevel
+	flags.Var(&logLevel, "log-level", "level of the logs of the agent")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *configFile == "" {
+		return errors.New("--config is required")
+	}
+	if flags.NArg() > 0 {
+		return fmt.Errorf("unexpected arguments: %v", flags.Args())
+	}
+
+	cfg, err := agent.LoadConfig(*configFile)
+	if err != nil {
+		return err
+	}
+	if *checkpointFile != "" {
+		cfg.CheckpointFile = *checkpointFile
+	}
+	if *outputFile != "" {
+		cfg.OutputFile = *outputFile
+	}
+
+	logger := zap.New(zapcore.NewCore(
+		zapcore.NewConsoleEncoder(zap.NewDevelopmentEncoderConfig()),
+		zapcore.AddSync(logs),
+		logLevel,
+	))
+	defer func() { _ = logger.Sync() }()
+
+	a, err := cfg.Build(component.TelemetrySettings{
+		Logger:         logger,
+		TracerProvider: tracenoop.NewTracerProvider(),
+		MeterProvider:  metricnoop.NewMeterProvider(),
+		Resource:       pcommon.NewResource(),
+	})
+	if err != nil {
+		return err
+	}
+	return a.Run(ctx)
+}
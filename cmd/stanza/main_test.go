@@ -0,0 +1,44 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun(t *testing.T) {
+	outputFile := filepath.Join(t.TempDir(), "out.json")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- run(ctx, []string{"--config", filepath.Join("testdata", "config.yaml"), "--output-file", outputFile}, &bytes.Buffer{})
+	}()
+
+	assert.Eventually(t, func() bool {
+		content, err := os.ReadFile(outputFile)
+		return err == nil && bytes.Contains(content, []byte(`"message":"payment accepted"`))
+	}, 5*time.Second, 10*time.Millisecond)
+	cancel()
+	require.NoError(t, <-done)
+}
+
+func TestRun_InvalidArguments(t *testing.T) {
+	err := run(context.Background(), []string{}, &bytes.Buffer{})
+	require.EqualError(t, err, "--config is required")
+
+	err = run(context.Background(), []string{"--config", filepath.Join("testdata", "config.yaml"), "extra"}, &bytes.Buffer{})
+	require.EqualError(t, err, "unexpected arguments: [extra]")
+
+	err = run(context.Background(), []string{"--log-level", "loud"}, &bytes.Buffer{})
+	require.ErrorContains(t, err, `invalid value "loud" for flag -log-level`)
+}
@@ -0,0 +1,78 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package fileexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/fileexporter"
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// fileExtensionEncoding is implemented by encoding extensions producing self-contained
+// files, such as Parquet, that can't be appended to each other. The file exporter writes
+// each batch encoded by such an extension to its own file.
+type fileExtensionEncoding interface {
+	FileExtension() string
+}
+
+// batchFileWriteCloser writes each call to Write to a new file, named after the
+// configured path with a timestamp and a sequence number.
+type batchFileWriteCloser struct {
+	prefix    string
+	extension string
+	seq       uint64
+	now       func() time.Time
+}
+
+var _ io.WriteCloser = (*batchFileWriteCloser)(nil)
+
+func newBatchFileWriteCloser(path, extension string) *batchFileWriteCloser {
+	return &batchFileWriteCloser{
+		prefix:    strings.TrimSuffix(path, filepath.Ext(path)),
+		extension: extension,
+		now:       time.Now,
+	}
+}
+
+// nextPath returns the path of the next batch, i.e. <path without extension>-<UTC timestamp>-<sequence>.<extension>.
+func (w *batchFileWriteCloser) nextPath() string {
+	w.seq++
+	return fmt.Sprintf("%s-%s-%d.%s", w.prefix, w.now().UTC().Format("20060102T150405.000000000Z"), w.seq, w.extension)
+}
+
+func (w *batchFileWriteCloser) Write(p []byte) (int, error) {
+	f, err := os.OpenFile(w.nextPath(), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	if err != nil {
+		return 0, err
+	}
+	n, err := f.Write(p)
+	if err != nil {
+		_ = f.Close()
+		return n, err
+	}
+	return n, f.Close()
+}
+
+func (*batchFileWriteCloser) Close() error {
+	return nil
+}
+
+func exportMessageAsFile(w *fileWriter, buf []byte) error {
+	// Ensure only one write operation happens at a time.
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	_, err := w.file.Write(buf)
+	return err
+}
+
+func newBatchFileWriter(path, extension string) *fileWriter {
+	return &fileWriter{
+		path:     path,
+		file:     newBatchFileWriteCloser(path, extension),
+		exporter: exportMessageAsFile,
+	}
+}
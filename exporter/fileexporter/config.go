@@ -15,6 +15,7 @@ import (
 const (
 	rotationFieldName = "rotation"
 	backupsFieldName  = "max_backups"
+	walFieldName      = "wal"
 )
 
 // Config defines configuration for file exporter.
@@ -52,6 +53,10 @@ type Config struct {
 
 	// GroupBy enables writing to separate files based on a resource attribute.
 	GroupBy *GroupBy `mapstructure:"group_by"`
+
+	// WAL enables writing telemetry to a write-ahead log in the directory set by Path,
+	// which the otlpjsonfile receiver can replay. Ignores FormatType.
+	WAL *WAL `mapstructure:"wal"`
 }
 
 // Rotation an option to rolling log files
@@ -92,6 +97,18 @@ type GroupBy struct {
 	MaxOpenFiles int `mapstructure:"max_open_files"`
 }
 
+// WAL an option to write telemetry to a write-ahead log made of segment files
+type WAL struct {
+	// MaxSegmentMegabytes is the maximum size in megabytes of a segment before
+	// a new one is started. It defaults to 64 megabytes.
+	MaxSegmentMegabytes int `mapstructure:"max_segment_megabytes"`
+
+	// MaxSegments is the maximum number of segments to retain for each signal,
+	// the oldest ones being removed first whether or not they were replayed, so
+	// telemetry that was not replayed yet is lost. The default is to retain all segments.
+	MaxSegments int `mapstructure:"max_segments"`
+}
+
 var _ component.Config = (*Config)(nil)
 
 // Validate checks if the exporter configuration is valid
@@ -115,6 +132,18 @@ func (cfg *Config) Validate() error {
 		return errors.New("flush_interval must be larger than zero")
 	}
 
+	if cfg.WAL != nil {
+		if cfg.Append || cfg.Rotation != nil || cfg.Compression != "" || cfg.Encoding != nil || (cfg.GroupBy != nil && cfg.GroupBy.Enabled) {
+			return errors.New("wal enabled at the same time as append, rotation, compression, encoding or group_by is not supported")
+		}
+		if cfg.WAL.MaxSegmentMegabytes <= 0 {
+			return errors.New("max_segment_megabytes must be larger than zero")
+		}
+		if cfg.WAL.MaxSegments < 0 {
+			return errors.New("max_segments must not be negative")
+		}
+	}
+
 	if cfg.GroupBy != nil && cfg.GroupBy.Enabled {
 		pathParts := strings.Split(cfg.Path, "*")
 		if len(pathParts) != 2 {
@@ -150,6 +179,16 @@ func (cfg *Config) Unmarshal(componentParser *confmap.Conf) error {
 		cfg.Rotation = nil
 	}
 
+	// the WAL is enabled as soon as it is present, even without settings.
+	if componentParser.IsSet(walFieldName) {
+		if cfg.WAL == nil {
+			cfg.WAL = &WAL{}
+		}
+		if cfg.WAL.MaxSegmentMegabytes == 0 {
+			cfg.WAL.MaxSegmentMegabytes = defaultMaxSegmentMegabytes
+		}
+	}
+
 	// set flush interval to 1 second if not set.
 	if cfg.FlushInterval == 0 {
 		cfg.FlushInterval = time.Second
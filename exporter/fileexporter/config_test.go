@@ -174,6 +174,41 @@ func TestLoadConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			id: component.NewIDWithName(metadata.Type, "wal"),
+			expected: &Config{
+				Path:          "./wal",
+				FormatType:    formatTypeJSON,
+				FlushInterval: time.Second,
+				GroupBy: &GroupBy{
+					MaxOpenFiles:      defaultMaxOpenFiles,
+					ResourceAttribute: defaultResourceAttribute,
+				},
+				WAL: &WAL{
+					MaxSegmentMegabytes: 16,
+					MaxSegments:         10,
+				},
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "wal_defaults"),
+			expected: &Config{
+				Path:          "./wal",
+				FormatType:    formatTypeJSON,
+				FlushInterval: time.Second,
+				GroupBy: &GroupBy{
+					MaxOpenFiles:      defaultMaxOpenFiles,
+					ResourceAttribute: defaultResourceAttribute,
+				},
+				WAL: &WAL{
+					MaxSegmentMegabytes: defaultMaxSegmentMegabytes,
+				},
+			},
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "wal_with_rotation"),
+			errorMessage: "wal enabled at the same time as append, rotation, compression, encoding or group_by is not supported",
+		},
 		{
 			id:           component.NewIDWithName(metadata.Type, "group_by_invalid_path"),
 			errorMessage: "path must contain exactly one * when group_by is enabled",
@@ -128,3 +128,80 @@ func generateTraces() ptrace.Traces {
 	span.SetEndTimestamp(pcommon.NewTimestampFromTime(time.Now()))
 	return traces
 }
+
+// fileEncoding is an encoding producing self-contained files.
+type fileEncoding struct {
+	component.StartFunc
+	component.ShutdownFunc
+}
+
+func (fileEncoding) FileExtension() string {
+	return "bin"
+}
+
+func (fileEncoding) MarshalTraces(td ptrace.Traces) ([]byte, error) {
+	return []byte(td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Name()), nil
+}
+
+func TestEncodingWithFileExtension(t *testing.T) {
+	dir := t.TempDir()
+	f := NewFactory()
+	cfg := f.CreateDefaultConfig().(*Config)
+	cfg.Path = filepath.Join(dir, "traces.txt")
+	id := component.MustNewID("file_encoding")
+	cfg.Encoding = &id
+	host := hostWithEncoding{
+		map[component.ID]component.Component{id: fileEncoding{}},
+	}
+
+	te, err := f.CreateTraces(context.Background(), exportertest.NewNopSettings(metadata.Type), cfg)
+	require.NoError(t, err)
+	require.NoError(t, te.Start(context.Background(), host))
+	require.NoError(t, te.ConsumeTraces(context.Background(), generateTraces()))
+	require.NoError(t, te.ConsumeTraces(context.Background(), generateTraces()))
+	require.NoError(t, te.Shutdown(context.Background()))
+
+	files, err := filepath.Glob(filepath.Join(dir, "traces-*.bin"))
+	require.NoError(t, err)
+	require.Len(t, files, 2)
+	for _, file := range files {
+		b, err := os.ReadFile(file)
+		require.NoError(t, err)
+		require.Equal(t, "test_span", string(b))
+	}
+	_, err = os.Stat(cfg.Path)
+	require.True(t, os.IsNotExist(err))
+}
+
+func TestEncodingWithFileExtensionRotation(t *testing.T) {
+	id := component.MustNewID("file_encoding")
+	fe := &fileExporter{conf: &Config{
+		Path:     filepath.Join(t.TempDir(), "traces.bin"),
+		Encoding: &id,
+		Rotation: &Rotation{MaxMegabytes: 10},
+	}}
+	host := hostWithEncoding{
+		map[component.ID]component.Component{id: fileEncoding{}},
+	}
+	require.ErrorContains(t, fe.Start(context.Background(), host), "rotation is not supported")
+}
+
+func TestEncodingWithFileExtensionCompression(t *testing.T) {
+	id := component.MustNewID("file_encoding")
+	fe := &fileExporter{conf: &Config{
+		Path:        filepath.Join(t.TempDir(), "traces.bin"),
+		Encoding:    &id,
+		Compression: compressionZSTD,
+	}}
+	host := hostWithEncoding{
+		map[component.ID]component.Component{id: fileEncoding{}},
+	}
+	require.ErrorContains(t, fe.Start(context.Background(), host), "compression is not supported")
+}
+
+func TestBatchFileWriteCloserPath(t *testing.T) {
+	w := newBatchFileWriteCloser("/data/traces.parquet", "parquet")
+	w.now = func() time.Time { return time.Date(2024, 5, 6, 7, 8, 9, 10, time.FixedZone("CET", 3600)) }
+	require.Equal(t, "/data/traces-20240506T060809.000000010Z-1.parquet", w.nextPath())
+	require.Equal(t, "/data/traces-20240506T060809.000000010Z-2.parquet", w.nextPath())
+}
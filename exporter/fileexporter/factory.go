@@ -40,6 +40,8 @@ const (
 	defaultMaxOpenFiles = 100
 
 	defaultResourceAttribute = "fileexporter.path_segment"
+
+	defaultMaxSegmentMegabytes = 64
 )
 
 type FileExporter interface {
@@ -155,6 +157,13 @@ func getOrCreateFileExporter(cfg component.Config, logger *zap.Logger) FileExpor
 }
 
 func newFileExporter(conf *Config, logger *zap.Logger) FileExporter {
+	if conf.WAL != nil {
+		return &walExporter{
+			conf:   conf,
+			logger: logger,
+		}
+	}
+
 	if conf.GroupBy == nil || !conf.GroupBy.Enabled {
 		return &fileExporter{
 			conf: conf,
@@ -5,6 +5,7 @@ package fileexporter // import "github.com/open-telemetry/opentelemetry-collecto
 
 import (
 	"context"
+	"fmt"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/pdata/plog"
@@ -59,6 +60,13 @@ func (e *fileExporter) Start(_ context.Context, host component.Host) error {
 	if err != nil {
 		return err
 	}
+	if e.marshaller.fileExtension != "" {
+		if e.conf.Rotation != nil {
+			return fmt.Errorf("rotation is not supported by encoding %q, which writes each batch to its own file", e.conf.Encoding)
+		}
+		e.writer = newBatchFileWriter(e.conf.Path, e.marshaller.fileExtension)
+		return nil
+	}
 	export := buildExportFunc(e.conf)
 
 	e.writer, err = newFileWriter(e.conf.Path, e.conf.Append, e.conf.Rotation, e.conf.FlushInterval, export)
@@ -298,6 +298,9 @@ func (e *groupingFileExporter) Start(_ context.Context, host component.Host) err
 	e.pathSuffix = pathParts[1]
 	e.maxOpenFiles = e.conf.GroupBy.MaxOpenFiles
 	e.newFileWriter = func(path string) (*fileWriter, error) {
+		if e.marshaller.fileExtension != "" {
+			return newBatchFileWriter(path, e.marshaller.fileExtension), nil
+		}
 		return newFileWriter(path, e.conf.Append, nil, e.conf.FlushInterval, export)
 	}
 
@@ -45,6 +45,10 @@ type marshaller struct {
 	compressor  compressFunc
 
 	formatType string
+
+	// fileExtension is set when the encoding produces self-contained files that
+	// must be written one per batch.
+	fileExtension string
 }
 
 func newMarshaller(conf *Config, host component.Host) (*marshaller, error) {
@@ -58,6 +62,14 @@ func newMarshaller(conf *Config, host component.Host) (*marshaller, error) {
 		mm, _ := encoding.(pmetric.Marshaler)
 		lm, _ := encoding.(plog.Marshaler)
 		pm, _ := encoding.(pprofile.Marshaler)
+		var fileExtension string
+		if fe, ok := encoding.(fileExtensionEncoding); ok {
+			fileExtension = fe.FileExtension()
+			if conf.Compression != "" {
+				// the files are written as produced by the encoding, which may compress them itself
+				return nil, fmt.Errorf("compression is not supported by encoding %q, which writes each batch to its own file", conf.Encoding)
+			}
+		}
 		return &marshaller{
 			tracesMarshaler:   tm,
 			metricsMarshaler:  mm,
@@ -65,6 +77,7 @@ func newMarshaller(conf *Config, host component.Host) (*marshaller, error) {
 			profilesMarshaler: pm,
 			compression:       conf.Compression,
 			compressor:        buildCompressor(conf.Compression),
+			fileExtension:     fileExtension,
 		}, nil
 	}
 	return &marshaller{
@@ -0,0 +1,151 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package fileexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/fileexporter"
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+)
+
+const (
+	walSignalTraces   = "traces"
+	walSignalMetrics  = "metrics"
+	walSignalLogs     = "logs"
+	walSignalProfiles = "profiles"
+)
+
+// walExporter writes each signal to its own write-ahead log in the directory set by Path.
+type walExporter struct {
+	conf   *Config
+	logger *zap.Logger
+
+	tracesMarshaler   ptrace.ProtoMarshaler
+	metricsMarshaler  pmetric.ProtoMarshaler
+	logsMarshaler     plog.ProtoMarshaler
+	profilesMarshaler pprofile.ProtoMarshaler
+
+	mutex   sync.Mutex
+	writers map[string]*walWriter
+
+	stopFlusher chan struct{}
+	flusherDone sync.WaitGroup
+}
+
+func (e *walExporter) consumeTraces(_ context.Context, td ptrace.Traces) error {
+	buf, err := e.tracesMarshaler.MarshalTraces(td)
+	if err != nil {
+		return err
+	}
+	return e.write(walSignalTraces, buf)
+}
+
+func (e *walExporter) consumeMetrics(_ context.Context, md pmetric.Metrics) error {
+	buf, err := e.metricsMarshaler.MarshalMetrics(md)
+	if err != nil {
+		return err
+	}
+	return e.write(walSignalMetrics, buf)
+}
+
+func (e *walExporter) consumeLogs(_ context.Context, ld plog.Logs) error {
+	buf, err := e.logsMarshaler.MarshalLogs(ld)
+	if err != nil {
+		return err
+	}
+	return e.write(walSignalLogs, buf)
+}
+
+func (e *walExporter) consumeProfiles(_ context.Context, pd pprofile.Profiles) error {
+	buf, err := e.profilesMarshaler.MarshalProfiles(pd)
+	if err != nil {
+		return err
+	}
+	return e.write(walSignalProfiles, buf)
+}
+
+func (e *walExporter) write(signal string, buf []byte) error {
+	w, err := e.writer(signal)
+	if err != nil {
+		return err
+	}
+	return w.write(buf)
+}
+
+// writer returns the WAL of the given signal, opening it on first use.
+func (e *walExporter) writer(signal string) (*walWriter, error) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	if w, ok := e.writers[signal]; ok {
+		return w, nil
+	}
+	if e.writers == nil {
+		return nil, errors.New("WAL exporter is not started")
+	}
+	w, err := newWALWriter(e.conf.Path, signal, int64(e.conf.WAL.MaxSegmentMegabytes)*1024*1024, e.conf.WAL.MaxSegments, e.logger)
+	if err != nil {
+		return nil, err
+	}
+	e.writers[signal] = w
+	return w, nil
+}
+
+// Start starts flushing the WALs every FlushInterval.
+func (e *walExporter) Start(context.Context, component.Host) error {
+	e.mutex.Lock()
+	e.writers = make(map[string]*walWriter)
+	e.mutex.Unlock()
+
+	e.stopFlusher = make(chan struct{})
+	e.flusherDone.Add(1)
+	go e.flushPeriodically()
+	return nil
+}
+
+func (e *walExporter) flushPeriodically() {
+	defer e.flusherDone.Done()
+	ticker := time.NewTicker(e.conf.FlushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			e.mutex.Lock()
+			for signal, w := range e.writers {
+				if err := w.flush(); err != nil {
+					e.logger.Error("Failed to flush WAL", zap.String("signal", signal), zap.Error(err))
+				}
+			}
+			e.mutex.Unlock()
+		case <-e.stopFlusher:
+			return
+		}
+	}
+}
+
+// Shutdown flushes and closes the WALs.
+func (e *walExporter) Shutdown(context.Context) error {
+	if e.stopFlusher == nil {
+		return nil
+	}
+	close(e.stopFlusher)
+	e.flusherDone.Wait()
+	e.stopFlusher = nil
+
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	var errs error
+	for _, w := range e.writers {
+		errs = errors.Join(errs, w.close())
+	}
+	e.writers = nil
+	return errs
+}
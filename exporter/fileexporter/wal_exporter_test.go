@@ -0,0 +1,139 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package fileexporter
+
+import (
+	"context"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pdata/testdata"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// readWALRecords returns the records of a segment.
+func readWALRecords(t *testing.T, dir, signal string, seq uint64) [][]byte {
+	data, err := os.ReadFile(filepath.Join(dir, walSegmentName(signal, seq)))
+	require.NoError(t, err)
+	var records [][]byte
+	for len(data) > 0 {
+		require.GreaterOrEqual(t, len(data), walRecordHeaderSize)
+		n := int(binary.BigEndian.Uint32(data))
+		records = append(records, data[walRecordHeaderSize:walRecordHeaderSize+n])
+		data = data[walRecordHeaderSize+n:]
+	}
+	return records
+}
+
+// readWALCheckpoint returns the segment and offset of the checkpoint of a signal.
+func readWALCheckpoint(t *testing.T, dir, signal string) (uint64, uint64) {
+	data, err := os.ReadFile(filepath.Join(dir, signal+walCheckpointSuffix))
+	require.NoError(t, err)
+	require.Len(t, data, walCheckpointSize)
+	return binary.BigEndian.Uint64(data[:8]), binary.BigEndian.Uint64(data[8:])
+}
+
+func TestWALExporter(t *testing.T) {
+	dir := t.TempDir()
+	conf := &Config{
+		Path:          dir,
+		FlushInterval: time.Hour,
+		WAL:           &WAL{MaxSegmentMegabytes: defaultMaxSegmentMegabytes},
+	}
+	fe := newFileExporter(conf, zap.NewNop())
+	require.IsType(t, &walExporter{}, fe)
+	require.NoError(t, fe.Start(context.Background(), componenttest.NewNopHost()))
+
+	td := testdata.GenerateTraces(2)
+	ld := testdata.GenerateLogs(3)
+	require.NoError(t, fe.consumeTraces(context.Background(), td))
+	require.NoError(t, fe.consumeTraces(context.Background(), td))
+	require.NoError(t, fe.consumeLogs(context.Background(), ld))
+
+	// nothing is visible to readers before a flush
+	seq, offset := readWALCheckpoint(t, dir, walSignalTraces)
+	assert.Equal(t, uint64(1), seq)
+	assert.Zero(t, offset)
+
+	require.NoError(t, fe.Shutdown(context.Background()))
+
+	records := readWALRecords(t, dir, walSignalTraces, 1)
+	require.Len(t, records, 2)
+	got, err := (&ptrace.ProtoUnmarshaler{}).UnmarshalTraces(records[1])
+	require.NoError(t, err)
+	assert.Equal(t, td, got)
+	seq, offset = readWALCheckpoint(t, dir, walSignalTraces)
+	assert.Equal(t, uint64(1), seq)
+	assert.Equal(t, uint64(2*walRecordHeaderSize+len(records[0])+len(records[1])), offset)
+
+	records = readWALRecords(t, dir, walSignalLogs, 1)
+	require.Len(t, records, 1)
+	gotLogs, err := (&plog.ProtoUnmarshaler{}).UnmarshalLogs(records[0])
+	require.NoError(t, err)
+	assert.Equal(t, ld, gotLogs)
+
+	_, err = os.Stat(filepath.Join(dir, walSignalMetrics+walCheckpointSuffix))
+	assert.True(t, os.IsNotExist(err), "unused signals must not have a WAL")
+}
+
+func TestWALWriterRotation(t *testing.T) {
+	dir := t.TempDir()
+	zapCore, logs := observer.New(zap.WarnLevel)
+	w, err := newWALWriter(dir, walSignalTraces, 10, 2, zap.New(zapCore))
+	require.NoError(t, err)
+
+	for i := 0; i < 4; i++ {
+		require.NoError(t, w.write([]byte{byte(i), byte(i)}))
+	}
+	require.NoError(t, w.close())
+
+	// every record fills a segment, and only the last two segments are retained
+	seqs, err := walSegments(dir, walSignalTraces)
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{3, 4}, seqs)
+	// removing segments that may not have been replayed is logged
+	require.Equal(t, 2, logs.Len())
+	for i, entry := range logs.All() {
+		assert.Equal(t, walSegmentName(walSignalTraces, uint64(i+1)), entry.ContextMap()["segment"])
+	}
+	assert.Equal(t, [][]byte{{3, 3}}, readWALRecords(t, dir, walSignalTraces, 4))
+
+	seq, offset := readWALCheckpoint(t, dir, walSignalTraces)
+	assert.Equal(t, uint64(4), seq)
+	assert.Equal(t, uint64(walRecordHeaderSize+2), offset)
+}
+
+func TestWALWriterRecovery(t *testing.T) {
+	dir := t.TempDir()
+	w, err := newWALWriter(dir, walSignalTraces, 1024, 0, zap.NewNop())
+	require.NoError(t, err)
+	require.NoError(t, w.write([]byte("complete")))
+	require.NoError(t, w.close())
+
+	// simulate a crash in the middle of a record
+	f, err := os.OpenFile(filepath.Join(dir, walSegmentName(walSignalTraces, 1)), os.O_WRONLY|os.O_APPEND, 0o644)
+	require.NoError(t, err)
+	_, err = f.Write([]byte{0, 0, 0, 42, 'p', 'a', 'r'})
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	w, err = newWALWriter(dir, walSignalTraces, 1024, 0, zap.NewNop())
+	require.NoError(t, err)
+	require.NoError(t, w.write([]byte("next")))
+	require.NoError(t, w.close())
+
+	assert.Equal(t, [][]byte{[]byte("complete")}, readWALRecords(t, dir, walSignalTraces, 1))
+	assert.Equal(t, [][]byte{[]byte("next")}, readWALRecords(t, dir, walSignalTraces, 2))
+	seq, _ := readWALCheckpoint(t, dir, walSignalTraces)
+	assert.Equal(t, uint64(2), seq)
+}
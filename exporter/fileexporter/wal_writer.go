@@ -0,0 +1,284 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package fileexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/fileexporter"
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// walSegmentSuffix is the extension of the segment files, named after the signal
+	// and a sequence number, like "traces-00000000000000000001.wal".
+	walSegmentSuffix = ".wal"
+	// walCheckpointSuffix is the extension of the checkpoint files, like "traces.checkpoint".
+	walCheckpointSuffix = ".checkpoint"
+	// walCheckpointSize is the size of a checkpoint: the sequence number of the last
+	// segment followed by the offset up to which it was synced to disk.
+	walCheckpointSize = 16
+	// walRecordHeaderSize is the size of the length preceding each record.
+	walRecordHeaderSize = 4
+)
+
+// walSegmentName returns the name of the segment file with the given sequence number.
+func walSegmentName(signal string, seq uint64) string {
+	return fmt.Sprintf("%s-%020d%s", signal, seq, walSegmentSuffix)
+}
+
+// walSegments returns the sequence numbers of the existing segments of a signal, in order.
+func walSegments(dir, signal string) ([]uint64, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var seqs []uint64
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, signal+"-") || !strings.HasSuffix(name, walSegmentSuffix) {
+			continue
+		}
+		seq, err := strconv.ParseUint(strings.TrimSuffix(strings.TrimPrefix(name, signal+"-"), walSegmentSuffix), 10, 64)
+		if err != nil {
+			continue
+		}
+		seqs = append(seqs, seq)
+	}
+	sort.Slice(seqs, func(i, j int) bool { return seqs[i] < seqs[j] })
+	return seqs, nil
+}
+
+// walWriter appends length-prefixed records to the segments of a single signal.
+//
+// Records are buffered and only become visible to readers once flushed: flush syncs
+// the current segment to disk and then updates the checkpoint, which tells readers
+// how far the last segment can be read. All the segments before the one referenced
+// by the checkpoint are complete.
+type walWriter struct {
+	dir            string
+	signal         string
+	maxSegmentSize int64
+	maxSegments    int
+	logger         *zap.Logger
+
+	mutex  sync.Mutex
+	seq    uint64
+	file   *os.File
+	buffer *bufio.Writer
+	size   int64
+	synced int64
+}
+
+// newWALWriter opens the WAL of a signal. Any record partially written before a crash is
+// discarded, and writing resumes in a new segment.
+func newWALWriter(dir, signal string, maxSegmentSize int64, maxSegments int, logger *zap.Logger) (*walWriter, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	w := &walWriter{
+		dir:            dir,
+		signal:         signal,
+		maxSegmentSize: maxSegmentSize,
+		maxSegments:    maxSegments,
+		logger:         logger,
+	}
+
+	seqs, err := walSegments(dir, signal)
+	if err != nil {
+		return nil, err
+	}
+	if len(seqs) > 0 {
+		last := seqs[len(seqs)-1]
+		if err := w.recoverSegment(last); err != nil {
+			return nil, err
+		}
+		w.seq = last
+	}
+	if err := w.openSegment(w.seq + 1); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// recoverSegment truncates the given segment after its last complete record.
+func (w *walWriter) recoverSegment(seq uint64) error {
+	path := filepath.Join(w.dir, walSegmentName(w.signal, seq))
+	f, err := os.OpenFile(path, os.O_RDWR, 0o644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	var (
+		valid  int64
+		header [walRecordHeaderSize]byte
+		reader = bufio.NewReader(f)
+	)
+	for {
+		if _, err := io.ReadFull(reader, header[:]); err != nil {
+			break
+		}
+		n := int64(binary.BigEndian.Uint32(header[:]))
+		if _, err := reader.Discard(int(n)); err != nil {
+			break
+		}
+		valid += walRecordHeaderSize + n
+	}
+	if err := f.Truncate(valid); err != nil {
+		return err
+	}
+	return f.Sync()
+}
+
+// openSegment starts writing to the segment with the given sequence number, and points
+// the checkpoint to its beginning.
+func (w *walWriter) openSegment(seq uint64) error {
+	f, err := os.OpenFile(filepath.Join(w.dir, walSegmentName(w.signal, seq)), os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err
+	}
+	w.file = f
+	w.buffer = bufio.NewWriter(f)
+	w.seq = seq
+	w.size = 0
+	w.synced = 0
+	return w.writeCheckpoint()
+}
+
+// write appends a record, starting a new segment first if the current one is full.
+func (w *walWriter) write(buf []byte) error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if w.file == nil {
+		return errors.New("WAL is closed")
+	}
+	if w.size > 0 && w.size+walRecordHeaderSize+int64(len(buf)) > w.maxSegmentSize {
+		if err := w.rotate(); err != nil {
+			return err
+		}
+	}
+
+	var header [walRecordHeaderSize]byte
+	binary.BigEndian.PutUint32(header[:], uint32(len(buf)))
+	if _, err := w.buffer.Write(header[:]); err != nil {
+		return err
+	}
+	if _, err := w.buffer.Write(buf); err != nil {
+		return err
+	}
+	w.size += walRecordHeaderSize + int64(len(buf))
+	return nil
+}
+
+// flush syncs the records written so far and updates the checkpoint.
+func (w *walWriter) flush() error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	if w.file == nil {
+		return nil
+	}
+	return w.sync()
+}
+
+func (w *walWriter) sync() error {
+	if w.size == w.synced {
+		return nil
+	}
+	if err := w.buffer.Flush(); err != nil {
+		return err
+	}
+	if err := w.file.Sync(); err != nil {
+		return err
+	}
+	w.synced = w.size
+	return w.writeCheckpoint()
+}
+
+func (w *walWriter) rotate() error {
+	if err := w.sync(); err != nil {
+		return err
+	}
+	if err := w.file.Close(); err != nil {
+		return err
+	}
+	if err := w.openSegment(w.seq + 1); err != nil {
+		return err
+	}
+	return w.removeOldSegments()
+}
+
+// removeOldSegments enforces the maximum number of segments, removing the oldest ones.
+// The replay position of readers is not known to the writer, so a removed segment
+// may not have been replayed yet, in which case its telemetry is lost.
+func (w *walWriter) removeOldSegments() error {
+	if w.maxSegments <= 0 {
+		return nil
+	}
+	seqs, err := walSegments(w.dir, w.signal)
+	if err != nil {
+		return err
+	}
+	var errs error
+	for len(seqs) > w.maxSegments {
+		name := walSegmentName(w.signal, seqs[0])
+		if err := os.Remove(filepath.Join(w.dir, name)); err != nil && !os.IsNotExist(err) {
+			errs = errors.Join(errs, err)
+		} else if err == nil {
+			w.logger.Warn("Removed WAL segment as max_segments was reached, any telemetry not replayed from it is lost",
+				zap.String("signal", w.signal), zap.String("segment", name))
+		}
+		seqs = seqs[1:]
+	}
+	return errs
+}
+
+// writeCheckpoint atomically replaces the checkpoint with the current segment and synced offset.
+func (w *walWriter) writeCheckpoint() error {
+	var data [walCheckpointSize]byte
+	binary.BigEndian.PutUint64(data[:8], w.seq)
+	binary.BigEndian.PutUint64(data[8:], uint64(w.synced))
+
+	path := filepath.Join(w.dir, w.signal+walCheckpointSuffix)
+	tmp := path + ".tmp"
+	f, err := os.OpenFile(tmp, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data[:]); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// close flushes the pending records and closes the current segment.
+func (w *walWriter) close() error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	if w.file == nil {
+		return nil
+	}
+	err := w.sync()
+	err = errors.Join(err, w.file.Close())
+	w.file = nil
+	return err
+}
@@ -0,0 +1,29 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package parquetencodingextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/encoding/parquetencodingextension"
+
+import "fmt"
+
+const (
+	compressionNone   = "none"
+	compressionSnappy = "snappy"
+	compressionGzip   = "gzip"
+	compressionZstd   = "zstd"
+)
+
+type Config struct {
+	// Compression is the codec used to compress the columns of the Parquet files.
+	Compression string `mapstructure:"compression"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+func (c *Config) Validate() error {
+	switch c.Compression {
+	case compressionNone, compressionSnappy, compressionGzip, compressionZstd:
+		return nil
+	}
+	return fmt.Errorf("unsupported compression %q", c.Compression)
+}
@@ -0,0 +1,23 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package parquetencodingextension
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigValidate(t *testing.T) {
+	c := createDefaultConfig().(*Config)
+	require.NoError(t, c.Validate())
+
+	for _, compression := range []string{compressionNone, compressionGzip, compressionZstd} {
+		c.Compression = compression
+		require.NoError(t, c.Validate())
+	}
+
+	c.Compression = "lz4"
+	require.EqualError(t, c.Validate(), `unsupported compression "lz4"`)
+}
@@ -0,0 +1,87 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package parquetencodingextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/encoding/parquetencodingextension"
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/parquet-go/parquet-go"
+	"github.com/parquet-go/parquet-go/compress"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/encoding"
+)
+
+// fileExtension is the extension of the files produced by the extension.
+const fileExtension = "parquet"
+
+var (
+	_ encoding.TracesMarshalerExtension  = (*parquetExtension)(nil)
+	_ encoding.LogsMarshalerExtension    = (*parquetExtension)(nil)
+	_ encoding.MetricsMarshalerExtension = (*parquetExtension)(nil)
+)
+
+// parquetExtension marshals each batch of telemetry to a complete Parquet file, with
+// one row per span, log record or metric data point.
+type parquetExtension struct {
+	codec compress.Codec
+}
+
+func newExtension(config *Config) *parquetExtension {
+	var codec compress.Codec
+	switch config.Compression {
+	case compressionSnappy:
+		codec = &parquet.Snappy
+	case compressionGzip:
+		codec = &parquet.Gzip
+	case compressionZstd:
+		codec = &parquet.Zstd
+	default:
+		codec = &parquet.Uncompressed
+	}
+	return &parquetExtension{codec: codec}
+}
+
+func (*parquetExtension) Start(context.Context, component.Host) error {
+	return nil
+}
+
+func (*parquetExtension) Shutdown(context.Context) error {
+	return nil
+}
+
+// FileExtension returns the extension of the files produced by the extension. Exporters
+// writing to files use it to write each batch to its own file, as Parquet files can't be
+// concatenated.
+func (*parquetExtension) FileExtension() string {
+	return fileExtension
+}
+
+func (e *parquetExtension) MarshalTraces(td ptrace.Traces) ([]byte, error) {
+	return writeRows(e.codec, spanRows(td))
+}
+
+func (e *parquetExtension) MarshalLogs(ld plog.Logs) ([]byte, error) {
+	return writeRows(e.codec, logRows(ld))
+}
+
+func (e *parquetExtension) MarshalMetrics(md pmetric.Metrics) ([]byte, error) {
+	return writeRows(e.codec, dataPointRows(md))
+}
+
+func writeRows[T any](codec compress.Codec, rows []T) ([]byte, error) {
+	var buf bytes.Buffer
+	w := parquet.NewGenericWriter[T](&buf, parquet.Compression(codec))
+	if _, err := w.Write(rows); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
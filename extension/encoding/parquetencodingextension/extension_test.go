@@ -0,0 +1,203 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package parquetencodingextension
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/parquet-go/parquet-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func readRows[T any](t *testing.T, buf []byte) []T {
+	rows, err := parquet.Read[T](bytes.NewReader(buf), int64(len(buf)))
+	require.NoError(t, err)
+	return rows
+}
+
+func TestExtension_Start_Shutdown(t *testing.T) {
+	e := newExtension(createDefaultConfig().(*Config))
+	require.NoError(t, e.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, e.Shutdown(context.Background()))
+	assert.Equal(t, "parquet", e.FileExtension())
+}
+
+func TestMarshalTraces(t *testing.T) {
+	td := ptrace.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().PutStr("service.name", "checkout")
+	rs.Resource().Attributes().PutInt("host.cpus", 4)
+	ss := rs.ScopeSpans().AppendEmpty()
+	ss.Scope().SetName("tracer")
+	ss.Scope().SetVersion("1.0.0")
+	ss.Scope().Attributes().PutStr("library", "otel-go")
+	span := ss.Spans().AppendEmpty()
+	span.SetTraceID(pcommon.TraceID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
+	span.SetSpanID(pcommon.SpanID{1, 2, 3, 4, 5, 6, 7, 8})
+	span.SetName("GET /cart")
+	span.SetKind(ptrace.SpanKindServer)
+	span.SetStartTimestamp(1000)
+	span.SetEndTimestamp(3500)
+	span.Status().SetCode(ptrace.StatusCodeError)
+	span.Status().SetMessage("boom")
+	span.Attributes().PutBool("retry", true)
+	span.Events().AppendEmpty()
+
+	for _, compression := range []string{compressionNone, compressionSnappy, compressionGzip, compressionZstd} {
+		t.Run(compression, func(t *testing.T) {
+			buf, err := newExtension(&Config{Compression: compression}).MarshalTraces(td)
+			require.NoError(t, err)
+
+			rows := readRows[spanRow](t, buf)
+			require.Len(t, rows, 1)
+			assert.Equal(t, spanRow{
+				TraceID:            "0102030405060708090a0b0c0d0e0f10",
+				SpanID:             "0102030405060708",
+				Name:               "GET /cart",
+				Kind:               "Server",
+				StartTimeUnixNano:  1000,
+				EndTimeUnixNano:    3500,
+				DurationNano:       2500,
+				StatusCode:         "Error",
+				StatusMessage:      "boom",
+				Attributes:         map[string]string{"retry": "true"},
+				EventsCount:        1,
+				ServiceName:        "checkout",
+				ResourceAttributes: map[string]string{"service.name": "checkout", "host.cpus": "4"},
+				ScopeName:          "tracer",
+				ScopeVersion:       "1.0.0",
+				ScopeAttributes:    map[string]string{"library": "otel-go"},
+			}, rows[0])
+		})
+	}
+}
+
+func TestMarshalLogs(t *testing.T) {
+	ld := plog.NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().PutStr("service.name", "checkout")
+	sl := rl.ScopeLogs().AppendEmpty()
+	sl.Scope().Attributes().PutStr("library", "zap")
+	record := sl.LogRecords().AppendEmpty()
+	record.SetTimestamp(10)
+	record.SetObservedTimestamp(20)
+	record.SetSeverityNumber(plog.SeverityNumberWarn)
+	record.SetSeverityText("WARN")
+	record.Body().SetStr("disk almost full")
+	record.SetSpanID(pcommon.SpanID{1, 2, 3, 4, 5, 6, 7, 8})
+	record.Attributes().PutDouble("usage", 0.95)
+	sl.LogRecords().AppendEmpty().Body().SetEmptyMap().PutStr("key", "value")
+
+	buf, err := newExtension(createDefaultConfig().(*Config)).MarshalLogs(ld)
+	require.NoError(t, err)
+
+	rows := readRows[logRow](t, buf)
+	require.Len(t, rows, 2)
+	assert.Equal(t, logRow{
+		TimeUnixNano:         10,
+		ObservedTimeUnixNano: 20,
+		SeverityNumber:       int32(plog.SeverityNumberWarn),
+		SeverityText:         "WARN",
+		Body:                 "disk almost full",
+		SpanID:               "0102030405060708",
+		Attributes:           map[string]string{"usage": "0.95"},
+		ServiceName:          "checkout",
+		ResourceAttributes:   map[string]string{"service.name": "checkout"},
+		ScopeAttributes:      map[string]string{"library": "zap"},
+	}, rows[0])
+	assert.JSONEq(t, `{"key":"value"}`, rows[1].Body)
+}
+
+func TestMarshalMetrics(t *testing.T) {
+	md := pmetric.NewMetrics()
+	sm := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty()
+
+	sum := sm.Metrics().AppendEmpty()
+	sum.SetName("requests")
+	sum.SetUnit("1")
+	sum.SetEmptySum().SetIsMonotonic(true)
+	sum.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	dp := sum.Sum().DataPoints().AppendEmpty()
+	dp.SetIntValue(42)
+	dp.SetTimestamp(100)
+	dp.Attributes().PutStr("method", "GET")
+
+	histogram := sm.Metrics().AppendEmpty()
+	histogram.SetName("latency")
+	histogram.SetEmptyHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+	hdp := histogram.Histogram().DataPoints().AppendEmpty()
+	hdp.SetCount(3)
+	hdp.SetSum(12.5)
+	hdp.SetMin(1)
+	hdp.BucketCounts().FromRaw([]uint64{1, 2, 0})
+	hdp.ExplicitBounds().FromRaw([]float64{5, 10})
+
+	exponential := sm.Metrics().AppendEmpty()
+	exponential.SetName("size")
+	edp := exponential.SetEmptyExponentialHistogram().DataPoints().AppendEmpty()
+	edp.SetCount(6)
+	edp.SetScale(2)
+	edp.SetZeroCount(1)
+	edp.SetZeroThreshold(0.5)
+	edp.Positive().SetOffset(-1)
+	edp.Positive().BucketCounts().FromRaw([]uint64{1, 3})
+	edp.Negative().BucketCounts().FromRaw([]uint64{1})
+
+	summary := sm.Metrics().AppendEmpty()
+	summary.SetName("gc")
+	sdp := summary.SetEmptySummary().DataPoints().AppendEmpty()
+	sdp.SetCount(2)
+	q := sdp.QuantileValues().AppendEmpty()
+	q.SetQuantile(0.99)
+	q.SetValue(7)
+
+	buf, err := newExtension(createDefaultConfig().(*Config)).MarshalMetrics(md)
+	require.NoError(t, err)
+
+	rows := readRows[dataPointRow](t, buf)
+	require.Len(t, rows, 4)
+
+	assert.Equal(t, "requests", rows[0].MetricName)
+	assert.Equal(t, "Sum", rows[0].MetricType)
+	assert.Equal(t, int64(100), rows[0].TimeUnixNano)
+	assert.Equal(t, map[string]string{"method": "GET"}, rows[0].Attributes)
+	require.NotNil(t, rows[0].Value)
+	assert.Equal(t, 42.0, *rows[0].Value)
+	assert.True(t, rows[0].IsMonotonic)
+	assert.Equal(t, "Cumulative", rows[0].AggregationTemporality)
+	assert.Nil(t, rows[0].Count)
+
+	assert.Equal(t, "Histogram", rows[1].MetricType)
+	assert.Nil(t, rows[1].Value)
+	assert.Equal(t, uint64(3), *rows[1].Count)
+	assert.Equal(t, 12.5, *rows[1].Sum)
+	assert.Equal(t, 1.0, *rows[1].Min)
+	assert.Nil(t, rows[1].Max)
+	assert.Equal(t, []uint64{1, 2, 0}, rows[1].BucketCounts)
+	assert.Equal(t, []float64{5, 10}, rows[1].ExplicitBounds)
+
+	assert.Nil(t, rows[1].Scale)
+	assert.Empty(t, rows[1].PositiveBucketCounts)
+
+	assert.Equal(t, "ExponentialHistogram", rows[2].MetricType)
+	assert.Equal(t, uint64(6), *rows[2].Count)
+	assert.Equal(t, int32(2), *rows[2].Scale)
+	assert.Equal(t, uint64(1), *rows[2].ZeroCount)
+	assert.Equal(t, 0.5, *rows[2].ZeroThreshold)
+	assert.Equal(t, int32(-1), *rows[2].PositiveOffset)
+	assert.Equal(t, []uint64{1, 3}, rows[2].PositiveBucketCounts)
+	assert.Equal(t, int32(0), *rows[2].NegativeOffset)
+	assert.Equal(t, []uint64{1}, rows[2].NegativeBucketCounts)
+
+	assert.Equal(t, "Summary", rows[3].MetricType)
+	assert.Equal(t, []quantileValue{{Quantile: 0.99, Value: 7}}, rows[3].QuantileValues)
+}
@@ -0,0 +1,326 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package parquetencodingextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/encoding/parquetencodingextension"
+
+import (
+	"encoding/hex"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	conventions "go.opentelemetry.io/otel/semconv/v1.27.0"
+)
+
+// The rows below flatten the OTLP hierarchy: every row carries the attributes of
+// its resource and scope, so that each file can be queried without joins.
+// Attribute values are converted to strings.
+
+type spanRow struct {
+	TraceID            string            `parquet:"trace_id"`
+	SpanID             string            `parquet:"span_id"`
+	ParentSpanID       string            `parquet:"parent_span_id"`
+	TraceState         string            `parquet:"trace_state"`
+	Name               string            `parquet:"name"`
+	Kind               string            `parquet:"kind"`
+	StartTimeUnixNano  int64             `parquet:"start_time_unix_nano,timestamp(nanosecond)"`
+	EndTimeUnixNano    int64             `parquet:"end_time_unix_nano,timestamp(nanosecond)"`
+	DurationNano       int64             `parquet:"duration_nano"`
+	StatusCode         string            `parquet:"status_code"`
+	StatusMessage      string            `parquet:"status_message"`
+	Attributes         map[string]string `parquet:"attributes"`
+	EventsCount        int32             `parquet:"events_count"`
+	LinksCount         int32             `parquet:"links_count"`
+	ServiceName        string            `parquet:"service_name"`
+	ResourceAttributes map[string]string `parquet:"resource_attributes"`
+	ScopeName          string            `parquet:"scope_name"`
+	ScopeVersion       string            `parquet:"scope_version"`
+	ScopeAttributes    map[string]string `parquet:"scope_attributes"`
+	ResourceSchemaURL  string            `parquet:"resource_schema_url"`
+	ScopeSchemaURL     string            `parquet:"scope_schema_url"`
+	DroppedAttributes  uint32            `parquet:"dropped_attributes_count"`
+	DroppedEventsCount uint32            `parquet:"dropped_events_count"`
+	DroppedLinksCount  uint32            `parquet:"dropped_links_count"`
+}
+
+type logRow struct {
+	TimeUnixNano         int64             `parquet:"time_unix_nano,timestamp(nanosecond)"`
+	ObservedTimeUnixNano int64             `parquet:"observed_time_unix_nano,timestamp(nanosecond)"`
+	SeverityNumber       int32             `parquet:"severity_number"`
+	SeverityText         string            `parquet:"severity_text"`
+	Body                 string            `parquet:"body"`
+	EventName            string            `parquet:"event_name"`
+	TraceID              string            `parquet:"trace_id"`
+	SpanID               string            `parquet:"span_id"`
+	Flags                uint32            `parquet:"flags"`
+	Attributes           map[string]string `parquet:"attributes"`
+	ServiceName          string            `parquet:"service_name"`
+	ResourceAttributes   map[string]string `parquet:"resource_attributes"`
+	ScopeName            string            `parquet:"scope_name"`
+	ScopeVersion         string            `parquet:"scope_version"`
+	ScopeAttributes      map[string]string `parquet:"scope_attributes"`
+}
+
+type dataPointRow struct {
+	MetricName             string            `parquet:"metric_name"`
+	MetricDescription      string            `parquet:"metric_description"`
+	MetricUnit             string            `parquet:"metric_unit"`
+	MetricType             string            `parquet:"metric_type"`
+	StartTimeUnixNano      int64             `parquet:"start_time_unix_nano,timestamp(nanosecond)"`
+	TimeUnixNano           int64             `parquet:"time_unix_nano,timestamp(nanosecond)"`
+	Attributes             map[string]string `parquet:"attributes"`
+	Value                  *float64          `parquet:"value,optional"`
+	IsMonotonic            bool              `parquet:"is_monotonic"`
+	AggregationTemporality string            `parquet:"aggregation_temporality"`
+	Count                  *uint64           `parquet:"count,optional"`
+	Sum                    *float64          `parquet:"sum,optional"`
+	Min                    *float64          `parquet:"min,optional"`
+	Max                    *float64          `parquet:"max,optional"`
+	BucketCounts           []uint64          `parquet:"bucket_counts,list"`
+	ExplicitBounds         []float64         `parquet:"explicit_bounds,list"`
+	QuantileValues         []quantileValue   `parquet:"quantile_values,list"`
+	Scale                  *int32            `parquet:"scale,optional"`
+	ZeroCount              *uint64           `parquet:"zero_count,optional"`
+	ZeroThreshold          *float64          `parquet:"zero_threshold,optional"`
+	PositiveOffset         *int32            `parquet:"positive_offset,optional"`
+	PositiveBucketCounts   []uint64          `parquet:"positive_bucket_counts,list"`
+	NegativeOffset         *int32            `parquet:"negative_offset,optional"`
+	NegativeBucketCounts   []uint64          `parquet:"negative_bucket_counts,list"`
+	Flags                  uint32            `parquet:"flags"`
+	ServiceName            string            `parquet:"service_name"`
+	ResourceAttributes     map[string]string `parquet:"resource_attributes"`
+	ScopeName              string            `parquet:"scope_name"`
+	ScopeVersion           string            `parquet:"scope_version"`
+	ScopeAttributes        map[string]string `parquet:"scope_attributes"`
+}
+
+type quantileValue struct {
+	Quantile float64 `parquet:"quantile"`
+	Value    float64 `parquet:"value"`
+}
+
+// commonColumns holds the resource and scope columns shared by the rows of a scope.
+type commonColumns struct {
+	serviceName        string
+	resourceAttributes map[string]string
+	scopeName          string
+	scopeVersion       string
+	scopeAttributes    map[string]string
+}
+
+func newCommonColumns(resource pcommon.Resource, scope pcommon.InstrumentationScope) commonColumns {
+	var serviceName string
+	if v, ok := resource.Attributes().Get(string(conventions.ServiceNameKey)); ok {
+		serviceName = v.AsString()
+	}
+	return commonColumns{
+		serviceName:        serviceName,
+		resourceAttributes: attributesMap(resource.Attributes()),
+		scopeName:          scope.Name(),
+		scopeVersion:       scope.Version(),
+		scopeAttributes:    attributesMap(scope.Attributes()),
+	}
+}
+
+func spanRows(td ptrace.Traces) []spanRow {
+	rows := make([]spanRow, 0, td.SpanCount())
+	for _, rs := range td.ResourceSpans().All() {
+		for _, ss := range rs.ScopeSpans().All() {
+			common := newCommonColumns(rs.Resource(), ss.Scope())
+			for _, span := range ss.Spans().All() {
+				rows = append(rows, spanRow{
+					TraceID:            traceIDString(span.TraceID()),
+					SpanID:             spanIDString(span.SpanID()),
+					ParentSpanID:       spanIDString(span.ParentSpanID()),
+					TraceState:         span.TraceState().AsRaw(),
+					Name:               span.Name(),
+					Kind:               span.Kind().String(),
+					StartTimeUnixNano:  int64(span.StartTimestamp()),
+					EndTimeUnixNano:    int64(span.EndTimestamp()),
+					DurationNano:       int64(span.EndTimestamp()) - int64(span.StartTimestamp()),
+					StatusCode:         span.Status().Code().String(),
+					StatusMessage:      span.Status().Message(),
+					Attributes:         attributesMap(span.Attributes()),
+					EventsCount:        int32(span.Events().Len()),
+					LinksCount:         int32(span.Links().Len()),
+					ServiceName:        common.serviceName,
+					ResourceAttributes: common.resourceAttributes,
+					ScopeName:          common.scopeName,
+					ScopeVersion:       common.scopeVersion,
+					ScopeAttributes:    common.scopeAttributes,
+					ResourceSchemaURL:  rs.SchemaUrl(),
+					ScopeSchemaURL:     ss.SchemaUrl(),
+					DroppedAttributes:  span.DroppedAttributesCount(),
+					DroppedEventsCount: span.DroppedEventsCount(),
+					DroppedLinksCount:  span.DroppedLinksCount(),
+				})
+			}
+		}
+	}
+	return rows
+}
+
+func logRows(ld plog.Logs) []logRow {
+	rows := make([]logRow, 0, ld.LogRecordCount())
+	for _, rl := range ld.ResourceLogs().All() {
+		for _, sl := range rl.ScopeLogs().All() {
+			common := newCommonColumns(rl.Resource(), sl.Scope())
+			for _, record := range sl.LogRecords().All() {
+				rows = append(rows, logRow{
+					TimeUnixNano:         int64(record.Timestamp()),
+					ObservedTimeUnixNano: int64(record.ObservedTimestamp()),
+					SeverityNumber:       int32(record.SeverityNumber()),
+					SeverityText:         record.SeverityText(),
+					Body:                 record.Body().AsString(),
+					EventName:            record.EventName(),
+					TraceID:              traceIDString(record.TraceID()),
+					SpanID:               spanIDString(record.SpanID()),
+					Flags:                uint32(record.Flags()),
+					Attributes:           attributesMap(record.Attributes()),
+					ServiceName:          common.serviceName,
+					ResourceAttributes:   common.resourceAttributes,
+					ScopeName:            common.scopeName,
+					ScopeVersion:         common.scopeVersion,
+					ScopeAttributes:      common.scopeAttributes,
+				})
+			}
+		}
+	}
+	return rows
+}
+
+func dataPointRows(md pmetric.Metrics) []dataPointRow {
+	rows := make([]dataPointRow, 0, md.DataPointCount())
+	for _, rm := range md.ResourceMetrics().All() {
+		for _, sm := range rm.ScopeMetrics().All() {
+			common := newCommonColumns(rm.Resource(), sm.Scope())
+			for _, metric := range sm.Metrics().All() {
+				newRow := func(start, ts pcommon.Timestamp, attrs pcommon.Map, flags pmetric.DataPointFlags) dataPointRow {
+					return dataPointRow{
+						MetricName:         metric.Name(),
+						MetricDescription:  metric.Description(),
+						MetricUnit:         metric.Unit(),
+						MetricType:         metric.Type().String(),
+						StartTimeUnixNano:  int64(start),
+						TimeUnixNano:       int64(ts),
+						Attributes:         attributesMap(attrs),
+						Flags:              uint32(flags),
+						ServiceName:        common.serviceName,
+						ResourceAttributes: common.resourceAttributes,
+						ScopeName:          common.scopeName,
+						ScopeVersion:       common.scopeVersion,
+						ScopeAttributes:    common.scopeAttributes,
+					}
+				}
+				switch metric.Type() {
+				case pmetric.MetricTypeGauge:
+					for _, dp := range metric.Gauge().DataPoints().All() {
+						row := newRow(dp.StartTimestamp(), dp.Timestamp(), dp.Attributes(), dp.Flags())
+						row.Value = numberValue(dp)
+						rows = append(rows, row)
+					}
+				case pmetric.MetricTypeSum:
+					sum := metric.Sum()
+					for _, dp := range sum.DataPoints().All() {
+						row := newRow(dp.StartTimestamp(), dp.Timestamp(), dp.Attributes(), dp.Flags())
+						row.Value = numberValue(dp)
+						row.IsMonotonic = sum.IsMonotonic()
+						row.AggregationTemporality = sum.AggregationTemporality().String()
+						rows = append(rows, row)
+					}
+				case pmetric.MetricTypeHistogram:
+					histogram := metric.Histogram()
+					for _, dp := range histogram.DataPoints().All() {
+						row := newRow(dp.StartTimestamp(), dp.Timestamp(), dp.Attributes(), dp.Flags())
+						row.AggregationTemporality = histogram.AggregationTemporality().String()
+						row.Count = ptr(dp.Count())
+						if dp.HasSum() {
+							row.Sum = ptr(dp.Sum())
+						}
+						if dp.HasMin() {
+							row.Min = ptr(dp.Min())
+						}
+						if dp.HasMax() {
+							row.Max = ptr(dp.Max())
+						}
+						row.BucketCounts = dp.BucketCounts().AsRaw()
+						row.ExplicitBounds = dp.ExplicitBounds().AsRaw()
+						rows = append(rows, row)
+					}
+				case pmetric.MetricTypeExponentialHistogram:
+					histogram := metric.ExponentialHistogram()
+					for _, dp := range histogram.DataPoints().All() {
+						row := newRow(dp.StartTimestamp(), dp.Timestamp(), dp.Attributes(), dp.Flags())
+						row.AggregationTemporality = histogram.AggregationTemporality().String()
+						row.Count = ptr(dp.Count())
+						if dp.HasSum() {
+							row.Sum = ptr(dp.Sum())
+						}
+						if dp.HasMin() {
+							row.Min = ptr(dp.Min())
+						}
+						if dp.HasMax() {
+							row.Max = ptr(dp.Max())
+						}
+						row.Scale = ptr(dp.Scale())
+						row.ZeroCount = ptr(dp.ZeroCount())
+						row.ZeroThreshold = ptr(dp.ZeroThreshold())
+						row.PositiveOffset = ptr(dp.Positive().Offset())
+						row.PositiveBucketCounts = dp.Positive().BucketCounts().AsRaw()
+						row.NegativeOffset = ptr(dp.Negative().Offset())
+						row.NegativeBucketCounts = dp.Negative().BucketCounts().AsRaw()
+						rows = append(rows, row)
+					}
+				case pmetric.MetricTypeSummary:
+					for _, dp := range metric.Summary().DataPoints().All() {
+						row := newRow(dp.StartTimestamp(), dp.Timestamp(), dp.Attributes(), dp.Flags())
+						row.Count = ptr(dp.Count())
+						row.Sum = ptr(dp.Sum())
+						for _, q := range dp.QuantileValues().All() {
+							row.QuantileValues = append(row.QuantileValues, quantileValue{Quantile: q.Quantile(), Value: q.Value()})
+						}
+						rows = append(rows, row)
+					}
+				}
+			}
+		}
+	}
+	return rows
+}
+
+func numberValue(dp pmetric.NumberDataPoint) *float64 {
+	switch dp.ValueType() {
+	case pmetric.NumberDataPointValueTypeInt:
+		return ptr(float64(dp.IntValue()))
+	case pmetric.NumberDataPointValueTypeDouble:
+		return ptr(dp.DoubleValue())
+	}
+	return nil
+}
+
+func attributesMap(attrs pcommon.Map) map[string]string {
+	m := make(map[string]string, attrs.Len())
+	for k, v := range attrs.All() {
+		m[k] = v.AsString()
+	}
+	return m
+}
+
+func traceIDString(id pcommon.TraceID) string {
+	if id.IsEmpty() {
+		return ""
+	}
+	return hex.EncodeToString(id[:])
+}
+
+func spanIDString(id pcommon.SpanID) string {
+	if id.IsEmpty() {
+		return ""
+	}
+	return hex.EncodeToString(id[:])
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -37,6 +37,10 @@ type fileStorageClient struct {
 	openTimeout     time.Duration
 	cancel          context.CancelFunc
 	closed          bool
+	// encryptor encrypts values at rest, nil if encryption is disabled
+	encryptor *encryptor
+	// quota limits the size of the database, nil if unlimited
+	quota *quota
 }
 
 func bboltOptions(timeout time.Duration, noSync bool) *bbolt.Options {
@@ -48,7 +52,7 @@ func bboltOptions(timeout time.Duration, noSync bool) *bbolt.Options {
 	}
 }
 
-func newClient(logger *zap.Logger, filePath string, timeout time.Duration, compactionCfg *CompactionConfig, noSync bool) (*fileStorageClient, error) {
+func newClient(logger *zap.Logger, filePath string, timeout time.Duration, compactionCfg *CompactionConfig, noSync bool, enc *encryptor, q *quota) (*fileStorageClient, error) {
 	options := bboltOptions(timeout, noSync)
 	db, err := bbolt.Open(filePath, 0o600, options)
 	if err != nil {
@@ -63,8 +67,14 @@ func newClient(logger *zap.Logger, filePath string, timeout time.Duration, compa
 		_ = db.Close()
 		return nil, err
 	}
+	if q != nil {
+		if err := q.init(db); err != nil {
+			_ = db.Close()
+			return nil, err
+		}
+	}
 
-	client := &fileStorageClient{logger: logger, db: db, compactionCfg: compactionCfg, openTimeout: timeout}
+	client := &fileStorageClient{logger: logger, db: db, compactionCfg: compactionCfg, openTimeout: timeout, encryptor: enc, quota: q}
 	if compactionCfg.OnRebound {
 		client.startCompactionLoop(context.Background())
 	}
@@ -95,29 +105,51 @@ func (c *fileStorageClient) Delete(ctx context.Context, key string) error {
 
 // Batch executes the specified operations in order. Get operation results are updated in place
 func (c *fileStorageClient) Batch(_ context.Context, ops ...*storage.Operation) error {
+	var qtx *quotaTx
 	batch := func(tx *bbolt.Tx) error {
 		bucket := tx.Bucket(defaultBucket)
 		if bucket == nil {
 			return errors.New("storage not initialized")
 		}
+		if c.quota != nil {
+			qtx = c.quota.begin(tx, bucket)
+		}
 
 		var err error
 		for _, op := range ops {
 			switch op.Type {
 			case storage.Get:
 				value := bucket.Get([]byte(op.Key))
-				if value != nil {
+				switch {
+				case value != nil && c.encryptor != nil:
+					// decrypting already makes a copy of the value
+					op.Value, err = c.encryptor.decrypt(op.Key, value)
+				case value != nil:
 					// the output of Bucket.Get is only valid within a transaction, so we need to make a copy
 					// to be able to return the value
 					op.Value = make([]byte, len(value))
 					copy(op.Value, value)
-				} else {
+				default:
 					op.Value = nil
 				}
 			case storage.Set:
-				err = bucket.Put([]byte(op.Key), op.Value)
+				value := op.Value
+				if c.encryptor != nil {
+					if value, err = c.encryptor.encrypt(op.Key, op.Value); err != nil {
+						return err
+					}
+				}
+				if qtx != nil {
+					err = qtx.put([]byte(op.Key), value)
+				} else {
+					err = bucket.Put([]byte(op.Key), value)
+				}
 			case storage.Delete:
-				err = bucket.Delete([]byte(op.Key))
+				if qtx != nil {
+					err = qtx.delete([]byte(op.Key))
+				} else {
+					err = bucket.Delete([]byte(op.Key))
+				}
 			default:
 				return errors.New("wrong operation type")
 			}
@@ -132,7 +164,19 @@ func (c *fileStorageClient) Batch(_ context.Context, ops ...*storage.Operation)
 
 	c.compactionMutex.RLock()
 	defer c.compactionMutex.RUnlock()
-	return c.db.Update(batch)
+	if c.quota != nil {
+		c.quota.mu.Lock()
+		defer c.quota.mu.Unlock()
+	}
+	err := c.db.Update(batch)
+	switch {
+	case qtx == nil:
+	case err == nil:
+		qtx.commit()
+	case errors.Is(err, errStorageFull):
+		c.quota.rejected()
+	}
+	return err
 }
 
 // Close will close the database
@@ -144,6 +188,9 @@ func (c *fileStorageClient) Close(_ context.Context) error {
 		c.cancel()
 	}
 	c.closed = true
+	if c.quota != nil {
+		c.quota.close()
+	}
 	return c.db.Close()
 }
 
@@ -195,6 +242,20 @@ func (c *fileStorageClient) Compact(compactionDirectory string, timeout time.Dur
 
 	compactionStart := time.Now()
 
+	// values encrypted with a previous key are re-encrypted with the current one before being compacted
+	if c.encryptor != nil {
+		reencrypted, reencryptErr := c.encryptor.reencrypt(c.db, maxTransactionSize)
+		if reencryptErr != nil {
+			compactedDb.Close()
+			return fmt.Errorf("failed to re-encrypt database: %w", reencryptErr)
+		}
+		if reencrypted > 0 {
+			c.logger.Info("re-encrypted values with the current key",
+				zap.String(directoryKey, c.db.Path()),
+				zap.Int("count", reencrypted))
+		}
+	}
+
 	err = bbolt.Compact(compactedDb, c.db, maxTransactionSize)
 	if err != nil {
 		return err
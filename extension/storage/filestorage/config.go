@@ -33,6 +33,39 @@ type Config struct {
 	directoryPermissionsParsed int64  `mapstructure:"-,omitempty"`
 
 	Recreate bool `mapstructure:"recreate,omitempty"`
+
+	// MaxSizeMiB is the maximum size of the data stored in the database of each client, 0 meaning unlimited.
+	MaxSizeMiB int64 `mapstructure:"max_size_mib,omitempty"`
+	// WhenFull is the policy applied when a write would exceed MaxSizeMiB.
+	WhenFull FullPolicy `mapstructure:"when_full,omitempty"`
+
+	// Encryption enables the encryption of the stored values, nil if disabled.
+	Encryption *EncryptionConfig `mapstructure:"encryption,omitempty"`
+}
+
+// EncryptionConfig defines configuration for the optional encryption of the stored values with AES-GCM.
+type EncryptionConfig struct {
+	// Key is the key used to encrypt values.
+	Key KeySource `mapstructure:"key"`
+	// PreviousKeys are keys that values may still be encrypted with. They are only used to decrypt
+	// values, which are re-encrypted with Key during compaction.
+	PreviousKeys []KeySource `mapstructure:"previous_keys,omitempty"`
+}
+
+// KeySource defines where a base64 encoded AES-128, AES-192 or AES-256 key is read from.
+// Exactly one of File and Env must be set.
+type KeySource struct {
+	// File is the path of a file containing the key.
+	File string `mapstructure:"file,omitempty"`
+	// Env is the name of an environment variable containing the key.
+	Env string `mapstructure:"env,omitempty"`
+}
+
+func (s KeySource) validate() error {
+	if (s.File == "") == (s.Env == "") {
+		return errors.New("exactly one of file or env must be set")
+	}
+	return nil
 }
 
 // CompactionConfig defines configuration for optional file storage compaction.
@@ -94,6 +127,27 @@ func (cfg *Config) Validate() error {
 		return errors.New("compaction check interval must be positive when rebound compaction is set")
 	}
 
+	if cfg.MaxSizeMiB < 0 {
+		return errors.New("max_size_mib cannot be less than 0")
+	}
+
+	switch cfg.WhenFull {
+	case FullPolicyReject, FullPolicyDropOldest:
+	default:
+		return fmt.Errorf("unsupported when_full policy %q, must be one of %q or %q", cfg.WhenFull, FullPolicyReject, FullPolicyDropOldest)
+	}
+
+	if cfg.Encryption != nil {
+		if err := cfg.Encryption.Key.validate(); err != nil {
+			return fmt.Errorf("encryption key: %w", err)
+		}
+		for i, key := range cfg.Encryption.PreviousKeys {
+			if err := key.validate(); err != nil {
+				return fmt.Errorf("encryption previous key %d: %w", i, err)
+			}
+		}
+	}
+
 	if cfg.CreateDirectory {
 		permissions, err := strconv.ParseInt(cfg.DirectoryPermissions, 8, 32)
 		if err != nil {
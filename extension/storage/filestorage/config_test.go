@@ -54,8 +54,31 @@ func TestLoadConfig(t *testing.T) {
 				FSync:                true,
 				CreateDirectory:      false,
 				DirectoryPermissions: "0750",
+				WhenFull:             FullPolicyReject,
 			},
 		},
+		{
+			id: component.NewIDWithName(metadata.Type, "quota"),
+			expected: func() component.Config {
+				ret := NewFactory().CreateDefaultConfig().(*Config)
+				ret.Directory = "."
+				ret.MaxSizeMiB = 512
+				ret.WhenFull = FullPolicyDropOldest
+				return ret
+			}(),
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "encryption"),
+			expected: func() component.Config {
+				ret := NewFactory().CreateDefaultConfig().(*Config)
+				ret.Directory = "."
+				ret.Encryption = &EncryptionConfig{
+					Key:          KeySource{Env: "FILE_STORAGE_KEY"},
+					PreviousKeys: []KeySource{{File: "/etc/otelcol/previous.key"}},
+				}
+				return ret
+			}(),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.id.String(), func(t *testing.T) {
@@ -272,3 +295,93 @@ func TestCompactionDirectory(t *testing.T) {
 		})
 	}
 }
+
+func TestEncryptionConfig(t *testing.T) {
+	tests := []struct {
+		name       string
+		encryption *EncryptionConfig
+		err        string
+	}{
+		{
+			name:       "env",
+			encryption: &EncryptionConfig{Key: KeySource{Env: "KEY"}},
+		},
+		{
+			name:       "file-and-previous-keys",
+			encryption: &EncryptionConfig{Key: KeySource{File: "key"}, PreviousKeys: []KeySource{{Env: "PREVIOUS_KEY"}}},
+		},
+		{
+			name:       "no-key",
+			encryption: &EncryptionConfig{},
+			err:        "encryption key: exactly one of file or env must be set",
+		},
+		{
+			name:       "file-and-env",
+			encryption: &EncryptionConfig{Key: KeySource{File: "key", Env: "KEY"}},
+			err:        "encryption key: exactly one of file or env must be set",
+		},
+		{
+			name:       "invalid-previous-key",
+			encryption: &EncryptionConfig{Key: KeySource{Env: "KEY"}, PreviousKeys: []KeySource{{}}},
+			err:        "encryption previous key 0: exactly one of file or env must be set",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cfg := NewFactory().CreateDefaultConfig().(*Config)
+			cfg.Directory = t.TempDir()
+			cfg.Encryption = test.encryption
+			err := xconfmap.Validate(cfg)
+			if test.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, test.err)
+			}
+		})
+	}
+}
+
+func TestQuotaConfig(t *testing.T) {
+	tests := []struct {
+		name       string
+		maxSizeMiB int64
+		whenFull   FullPolicy
+		err        string
+	}{
+		{
+			name:       "reject",
+			maxSizeMiB: 1,
+			whenFull:   FullPolicyReject,
+		},
+		{
+			name:       "drop-oldest",
+			maxSizeMiB: 1,
+			whenFull:   FullPolicyDropOldest,
+		},
+		{
+			name:       "negative-size",
+			maxSizeMiB: -1,
+			whenFull:   FullPolicyReject,
+			err:        "max_size_mib cannot be less than 0",
+		},
+		{
+			name:     "unknown-policy",
+			whenFull: "drop_newest",
+			err:      `unsupported when_full policy "drop_newest", must be one of "reject" or "drop_oldest"`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cfg := NewFactory().CreateDefaultConfig().(*Config)
+			cfg.Directory = t.TempDir()
+			cfg.MaxSizeMiB = test.maxSizeMiB
+			cfg.WhenFull = test.whenFull
+			err := xconfmap.Validate(cfg)
+			if test.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, test.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,210 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package filestorage // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/filestorage"
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"go.etcd.io/bbolt"
+)
+
+const (
+	// encryptionVersion is the first byte of every encrypted value.
+	encryptionVersion = 1
+	keyIDSize         = 4
+	// encryptionHeaderSize is the size of the version and key ID prefixing every encrypted value.
+	encryptionHeaderSize = 1 + keyIDSize
+)
+
+var errUnknownEncryptionKey = errors.New("value is encrypted with an unknown key")
+
+type keyID [keyIDSize]byte
+
+// encryptor encrypts values with AES-GCM. Every value is prefixed by the ID of the key it was
+// encrypted with, so that values encrypted with a previous key can still be decrypted and are
+// re-encrypted with the current key during compaction.
+type encryptor struct {
+	currentID keyID
+	keys      map[keyID]cipher.AEAD
+}
+
+func newEncryptor(cfg *EncryptionConfig) (*encryptor, error) {
+	if cfg == nil {
+		return nil, nil
+	}
+	e := &encryptor{keys: make(map[keyID]cipher.AEAD)}
+	var err error
+	if e.currentID, err = e.addKey(cfg.Key); err != nil {
+		return nil, fmt.Errorf("invalid encryption key: %w", err)
+	}
+	for i, source := range cfg.PreviousKeys {
+		if _, err := e.addKey(source); err != nil {
+			return nil, fmt.Errorf("invalid previous encryption key %d: %w", i, err)
+		}
+	}
+	return e, nil
+}
+
+func (e *encryptor) addKey(source KeySource) (keyID, error) {
+	key, err := source.load()
+	if err != nil {
+		return keyID{}, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return keyID{}, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return keyID{}, err
+	}
+	sum := sha256.Sum256(key)
+	var id keyID
+	copy(id[:], sum[:keyIDSize])
+	e.keys[id] = aead
+	return id, nil
+}
+
+// encrypt encrypts the value of a key with the current key. The key is used as additional data,
+// so that values can't be swapped between keys.
+func (e *encryptor) encrypt(key string, value []byte) ([]byte, error) {
+	aead := e.keys[e.currentID]
+	out := make([]byte, encryptionHeaderSize+aead.NonceSize(), encryptionHeaderSize+aead.NonceSize()+len(value)+aead.Overhead())
+	out[0] = encryptionVersion
+	copy(out[1:encryptionHeaderSize], e.currentID[:])
+	nonce := out[encryptionHeaderSize:]
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return aead.Seal(out, nonce, value, []byte(key)), nil
+}
+
+// decrypt decrypts the value of a key, with whichever key it was encrypted with. The result
+// doesn't share memory with value.
+func (e *encryptor) decrypt(key string, value []byte) ([]byte, error) {
+	if len(value) < encryptionHeaderSize || value[0] != encryptionVersion {
+		return nil, errors.New("value is not encrypted")
+	}
+	var id keyID
+	copy(id[:], value[1:encryptionHeaderSize])
+	aead, ok := e.keys[id]
+	if !ok {
+		return nil, errUnknownEncryptionKey
+	}
+	value = value[encryptionHeaderSize:]
+	if len(value) < aead.NonceSize() {
+		return nil, errors.New("encrypted value is too short")
+	}
+	plaintext, err := aead.Open(nil, value[:aead.NonceSize()], value[aead.NonceSize():], []byte(key))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt value: %w", err)
+	}
+	return plaintext, nil
+}
+
+// isCurrent returns whether the value is encrypted with the current key.
+func (e *encryptor) isCurrent(value []byte) bool {
+	return len(value) >= encryptionHeaderSize && value[0] == encryptionVersion && bytes.Equal(value[1:encryptionHeaderSize], e.currentID[:])
+}
+
+// reencrypt re-encrypts the values of the default bucket that are encrypted with a previous key,
+// in transactions writing at most maxTransactionSize bytes of keys and values.
+func (e *encryptor) reencrypt(db *bbolt.DB, maxTransactionSize int64) (int, error) {
+	var reencrypted int
+	var after []byte
+	for done := false; !done; {
+		err := db.Update(func(tx *bbolt.Tx) error {
+			bucket := tx.Bucket(defaultBucket)
+			if bucket == nil {
+				done = true
+				return nil
+			}
+			type entry struct{ key, value []byte }
+			var entries []entry
+			c := bucket.Cursor()
+			k, v := c.First()
+			if after != nil {
+				k, v = c.Seek(after)
+				if bytes.Equal(k, after) {
+					k, v = c.Next()
+				}
+			}
+			var size int64
+			for ; k != nil; k, v = c.Next() {
+				if e.isCurrent(v) {
+					// the output of the cursor is only valid within a transaction
+					after = append(after[:0], k...)
+					continue
+				}
+				plaintext, err := e.decrypt(string(k), v)
+				if err != nil {
+					return fmt.Errorf("failed to re-encrypt %q: %w", k, err)
+				}
+				value, err := e.encrypt(string(k), plaintext)
+				if err != nil {
+					return err
+				}
+				// like bbolt.Compact, the size of a transaction is the size of the keys and values it writes
+				sz := int64(len(k) + len(value))
+				if maxTransactionSize > 0 && len(entries) > 0 && size+sz > maxTransactionSize {
+					break
+				}
+				size += sz
+				after = append(after[:0], k...)
+				entries = append(entries, entry{key: bytes.Clone(k), value: value})
+			}
+			done = k == nil
+			// values are updated once the cursor isn't used anymore
+			for _, entry := range entries {
+				if err := bucket.Put(entry.key, entry.value); err != nil {
+					return err
+				}
+			}
+			reencrypted += len(entries)
+			return nil
+		})
+		if err != nil {
+			return reencrypted, err
+		}
+	}
+	return reencrypted, nil
+}
+
+// load returns the key of the source, base64 encoded in a file or an environment variable.
+func (s KeySource) load() ([]byte, error) {
+	var encoded string
+	switch {
+	case s.File != "":
+		content, err := os.ReadFile(s.File)
+		if err != nil {
+			return nil, err
+		}
+		encoded = string(content)
+	case s.Env != "":
+		var ok bool
+		if encoded, ok = os.LookupEnv(s.Env); !ok {
+			return nil, fmt.Errorf("environment variable %q is not set", s.Env)
+		}
+	default:
+		return nil, errors.New("either file or env must be set")
+	}
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil {
+		return nil, fmt.Errorf("key must be base64 encoded: %w", err)
+	}
+	switch len(key) {
+	case 16, 24, 32:
+		return key, nil
+	}
+	return nil, fmt.Errorf("key must be 16, 24 or 32 bytes long, got %d", len(key))
+}
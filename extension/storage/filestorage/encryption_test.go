@@ -0,0 +1,157 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package filestorage
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.etcd.io/bbolt"
+	"go.uber.org/zap"
+)
+
+// newTestKey returns a key source reading a random AES-256 key from an environment variable.
+func newTestKey(t *testing.T, env string) KeySource {
+	key := make([]byte, 32)
+	_, err := rand.Read(key)
+	require.NoError(t, err)
+	t.Setenv(env, base64.StdEncoding.EncodeToString(key))
+	return KeySource{Env: env}
+}
+
+func TestEncryptorRoundTrip(t *testing.T) {
+	enc, err := newEncryptor(&EncryptionConfig{Key: newTestKey(t, "TEST_KEY")})
+	require.NoError(t, err)
+
+	ciphertext, err := enc.encrypt("key", []byte("value"))
+	require.NoError(t, err)
+	assert.False(t, bytes.Contains(ciphertext, []byte("value")))
+	assert.True(t, enc.isCurrent(ciphertext))
+
+	plaintext, err := enc.decrypt("key", ciphertext)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("value"), plaintext)
+
+	// the key is authenticated, so values can't be swapped between keys
+	_, err = enc.decrypt("other", ciphertext)
+	require.Error(t, err)
+
+	_, err = enc.decrypt("key", []byte("value"))
+	require.EqualError(t, err, "value is not encrypted")
+
+	other, err := newEncryptor(&EncryptionConfig{Key: newTestKey(t, "OTHER_KEY")})
+	require.NoError(t, err)
+	_, err = other.decrypt("key", ciphertext)
+	require.ErrorIs(t, err, errUnknownEncryptionKey)
+}
+
+func TestKeySource(t *testing.T) {
+	keyFile := filepath.Join(t.TempDir(), "key")
+	require.NoError(t, os.WriteFile(keyFile, []byte(base64.StdEncoding.EncodeToString(make([]byte, 16))+"\n"), 0o600))
+	key, err := KeySource{File: keyFile}.load()
+	require.NoError(t, err)
+	assert.Len(t, key, 16)
+
+	_, err = KeySource{File: filepath.Join(t.TempDir(), "missing")}.load()
+	require.ErrorIs(t, err, os.ErrNotExist)
+
+	_, err = KeySource{Env: "MISSING_TEST_KEY"}.load()
+	require.EqualError(t, err, `environment variable "MISSING_TEST_KEY" is not set`)
+
+	t.Setenv("INVALID_TEST_KEY", "not base64")
+	_, err = KeySource{Env: "INVALID_TEST_KEY"}.load()
+	require.ErrorContains(t, err, "key must be base64 encoded")
+
+	t.Setenv("SHORT_TEST_KEY", base64.StdEncoding.EncodeToString(make([]byte, 8)))
+	_, err = KeySource{Env: "SHORT_TEST_KEY"}.load()
+	require.EqualError(t, err, "key must be 16, 24 or 32 bytes long, got 8")
+}
+
+func TestClientEncryption(t *testing.T) {
+	dbFile := filepath.Join(t.TempDir(), "my_db")
+	enc, err := newEncryptor(&EncryptionConfig{Key: newTestKey(t, "TEST_KEY")})
+	require.NoError(t, err)
+
+	client, err := newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, false, enc, nil)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	testValue := []byte("very secret value")
+	require.NoError(t, client.Set(ctx, "testKey", testValue))
+	value, err := client.Get(ctx, "testKey")
+	require.NoError(t, err)
+	assert.Equal(t, testValue, value)
+	require.NoError(t, client.Close(ctx))
+
+	// keys are stored in clear, values are not
+	content, err := os.ReadFile(dbFile)
+	require.NoError(t, err)
+	assert.True(t, bytes.Contains(content, []byte("testKey")))
+	assert.False(t, bytes.Contains(content, testValue))
+
+	// the database can't be read without the key
+	client, err = newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, false, nil, nil)
+	require.NoError(t, err)
+	value, err = client.Get(ctx, "testKey")
+	require.NoError(t, err)
+	assert.NotEqual(t, testValue, value)
+	require.NoError(t, client.Close(ctx))
+}
+
+func TestClientEncryptionKeyRotation(t *testing.T) {
+	tempDir := t.TempDir()
+	dbFile := filepath.Join(tempDir, "my_db")
+	oldKey := newTestKey(t, "OLD_TEST_KEY")
+	newKey := newTestKey(t, "NEW_TEST_KEY")
+	ctx := context.Background()
+
+	oldEnc, err := newEncryptor(&EncryptionConfig{Key: oldKey})
+	require.NoError(t, err)
+	client, err := newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, false, oldEnc, nil)
+	require.NoError(t, err)
+	for i := byte(0); i < 10; i++ {
+		require.NoError(t, client.Set(ctx, string('a'+i), []byte{i}))
+	}
+	require.NoError(t, client.Close(ctx))
+
+	// the old key is still usable for reads once rotated
+	rotatedEnc, err := newEncryptor(&EncryptionConfig{Key: newKey, PreviousKeys: []KeySource{oldKey}})
+	require.NoError(t, err)
+	client, err = newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, false, rotatedEnc, nil)
+	require.NoError(t, err)
+	value, err := client.Get(ctx, "a")
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0}, value)
+
+	// compaction re-encrypts all the values with the new key, in several transactions of a
+	// few entries each
+	require.NoError(t, client.Compact(tempDir, time.Second, 128))
+	require.NoError(t, client.db.View(func(tx *bbolt.Tx) error {
+		return tx.Bucket(defaultBucket).ForEach(func(_, v []byte) error {
+			assert.True(t, rotatedEnc.isCurrent(v))
+			return nil
+		})
+	}))
+	require.NoError(t, client.Close(ctx))
+
+	// the old key can then be removed
+	newEnc, err := newEncryptor(&EncryptionConfig{Key: newKey})
+	require.NoError(t, err)
+	client, err = newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, false, newEnc, nil)
+	require.NoError(t, err)
+	for i := byte(0); i < 10; i++ {
+		value, err := client.Get(ctx, string('a'+i))
+		require.NoError(t, err)
+		assert.Equal(t, []byte{i}, value)
+	}
+	require.NoError(t, client.Close(ctx))
+}
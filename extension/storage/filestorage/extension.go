@@ -15,17 +15,21 @@ import (
 	"go.opentelemetry.io/collector/extension"
 	"go.opentelemetry.io/collector/extension/xextension/storage"
 	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/filestorage/internal/metadata"
 )
 
 type localFileStorage struct {
-	cfg    *Config
-	logger *zap.Logger
+	cfg       *Config
+	logger    *zap.Logger
+	telemetry *metadata.TelemetryBuilder
+	encryptor *encryptor
 }
 
 // Ensure this storage extension implements the appropriate interface
 var _ storage.Extension = (*localFileStorage)(nil)
 
-func newLocalFileStorage(logger *zap.Logger, config *Config) (extension.Extension, error) {
+func newLocalFileStorage(logger *zap.Logger, telemetry *metadata.TelemetryBuilder, config *Config) (extension.Extension, error) {
 	if config.CreateDirectory {
 		var dirs []string
 		if config.Compaction.OnStart || config.Compaction.OnRebound {
@@ -39,9 +43,15 @@ func newLocalFileStorage(logger *zap.Logger, config *Config) (extension.Extensio
 			}
 		}
 	}
+	enc, err := newEncryptor(config.Encryption)
+	if err != nil {
+		return nil, err
+	}
 	return &localFileStorage{
-		cfg:    config,
-		logger: logger,
+		cfg:       config,
+		logger:    logger,
+		telemetry: telemetry,
+		encryptor: enc,
 	}, nil
 }
 
@@ -54,9 +64,10 @@ func (lfs *localFileStorage) Start(context.Context, component.Host) error {
 }
 
 // Shutdown will close any open databases
-func (*localFileStorage) Shutdown(context.Context) error {
+func (lfs *localFileStorage) Shutdown(context.Context) error {
 	// TODO clean up data files that did not have a client
 	// and are older than a threshold (possibly configurable)
+	lfs.telemetry.Shutdown()
 	return nil
 }
 
@@ -76,7 +87,11 @@ func (lfs *localFileStorage) GetClient(_ context.Context, kind component.Kind, e
 			return nil, fmt.Errorf("error renaming the database. Please remove %s manually: %w", absoluteName, err)
 		}
 	}
-	client, err := newClient(lfs.logger, absoluteName, lfs.cfg.Timeout, lfs.cfg.Compaction, !lfs.cfg.FSync)
+	var q *quota
+	if lfs.cfg.MaxSizeMiB > 0 {
+		q = newQuota(lfs.cfg.MaxSizeMiB*oneMiB, lfs.cfg.WhenFull, lfs.telemetry, rawName)
+	}
+	client, err := newClient(lfs.logger, absoluteName, lfs.cfg.Timeout, lfs.cfg.Compaction, !lfs.cfg.FSync, lfs.encryptor, q)
 	if err != nil {
 		return nil, err
 	}
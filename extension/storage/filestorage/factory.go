@@ -51,6 +51,7 @@ func createDefaultConfig() component.Config {
 		FSync:                false,
 		CreateDirectory:      false,
 		DirectoryPermissions: "0750",
+		WhenFull:             FullPolicyReject,
 	}
 }
 
@@ -59,5 +60,9 @@ func createExtension(
 	params extension.Settings,
 	cfg component.Config,
 ) (extension.Extension, error) {
-	return newLocalFileStorage(params.Logger, cfg.(*Config))
+	telemetry, err := metadata.NewTelemetryBuilder(params.TelemetrySettings)
+	if err != nil {
+		return nil, err
+	}
+	return newLocalFileStorage(params.Logger, telemetry, cfg.(*Config))
 }
@@ -0,0 +1,277 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package filestorage // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/filestorage"
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"sync"
+
+	"go.etcd.io/bbolt"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/filestorage/internal/metadata"
+)
+
+// FullPolicy is the policy applied when a write would make a database exceed its quota.
+type FullPolicy string
+
+const (
+	// FullPolicyReject rejects the writes that would make a database exceed its quota.
+	FullPolicyReject FullPolicy = "reject"
+	// FullPolicyDropOldest evicts the least recently written entries until the write fits in the quota.
+	FullPolicyDropOldest FullPolicy = "drop_oldest"
+)
+
+var (
+	errStorageFull = errors.New("storage quota exceeded")
+
+	// insertionOrderBucket maps the insertion sequence of the entries to their key, and
+	// insertionSeqBucket the other way around. They are only maintained by the drop_oldest policy.
+	insertionOrderBucket = []byte(`insertion_order`)
+	insertionSeqBucket   = []byte(`insertion_seq`)
+)
+
+// leafElementSize is the size of the header of each key and value in the leaf pages of bbolt.
+const leafElementSize = 16
+
+// quota enforces the maximum size of the data stored in a database: the keys and values
+// written by its client, along with the entries of the buckets tracking their insertion
+// order and the per entry overhead of the leaf pages holding them. The free pages and the
+// unused space of the pages are not accounted for, so the database file is larger.
+type quota struct {
+	maxSize   int64
+	policy    FullPolicy
+	telemetry *metadata.TelemetryBuilder
+	attrs     metric.MeasurementOption
+	// mu serializes the transactions of the database, so that usage is updated
+	// before the next transaction begins.
+	mu sync.Mutex
+	// usage is the size of the data stored in the database, as of the last committed transaction.
+	usage int64
+}
+
+func newQuota(maxSize int64, policy FullPolicy, telemetry *metadata.TelemetryBuilder, database string) *quota {
+	return &quota{
+		maxSize:   maxSize,
+		policy:    policy,
+		telemetry: telemetry,
+		attrs:     metric.WithAttributeSet(attribute.NewSet(attribute.String("database", database))),
+	}
+}
+
+func entrySize(key, value []byte) int64 {
+	return int64(len(key) + len(value) + leafElementSize)
+}
+
+// trackingSize is the size of the entries of insertionOrderBucket and insertionSeqBucket for key.
+func trackingSize(key []byte) int64 {
+	return 2 * (int64(len(key)) + 8 + leafElementSize)
+}
+
+// init computes the usage of the database when it is opened. With the drop_oldest policy,
+// entries written without the policy are considered older than all the others, in key order.
+func (q *quota) init(db *bbolt.DB) error {
+	var usage int64
+	err := db.Update(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(defaultBucket)
+		var order, seqs *bbolt.Bucket
+		if q.policy == FullPolicyDropOldest {
+			var err error
+			if order, err = tx.CreateBucketIfNotExists(insertionOrderBucket); err != nil {
+				return err
+			}
+			if seqs, err = tx.CreateBucketIfNotExists(insertionSeqBucket); err != nil {
+				return err
+			}
+		}
+		err := bucket.ForEach(func(k, v []byte) error {
+			usage += entrySize(k, v)
+			if seqs == nil || seqs.Get(k) != nil {
+				return nil
+			}
+			return track(order, seqs, k)
+		})
+		if err != nil || seqs == nil {
+			return err
+		}
+		// the tracking buckets may also hold entries deleted while the policy wasn't enabled
+		for _, b := range []*bbolt.Bucket{order, seqs} {
+			if err = b.ForEach(func(k, v []byte) error {
+				usage += entrySize(k, v)
+				return nil
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	q.usage = usage
+	q.telemetry.FilestorageQuota.Record(context.Background(), q.maxSize, q.attrs)
+	q.telemetry.FilestorageUsage.Add(context.Background(), usage, q.attrs)
+	return nil
+}
+
+// quotaTx applies the quota to the writes of a single transaction.
+type quotaTx struct {
+	*quota
+	bucket  *bbolt.Bucket
+	order   *bbolt.Bucket
+	seqs    *bbolt.Bucket
+	usage   int64
+	evicted int64
+}
+
+func (q *quota) begin(tx *bbolt.Tx, bucket *bbolt.Bucket) *quotaTx {
+	qtx := &quotaTx{quota: q, bucket: bucket, usage: q.usage}
+	if q.policy == FullPolicyDropOldest {
+		qtx.order = tx.Bucket(insertionOrderBucket)
+		qtx.seqs = tx.Bucket(insertionSeqBucket)
+	}
+	return qtx
+}
+
+// put stores an entry, evicting the oldest entries first if needed and allowed by the policy.
+// Writes that don't grow the entry they replace are always accepted.
+func (qtx *quotaTx) put(key, value []byte) error {
+	previous := qtx.storedSize(key)
+	size := entrySize(key, value)
+	if qtx.seqs != nil {
+		size += trackingSize(key)
+	}
+	if size > qtx.maxSize {
+		return errStorageFull
+	}
+	for size > previous && qtx.usage-previous+size > qtx.maxSize {
+		if qtx.policy != FullPolicyDropOldest {
+			return errStorageFull
+		}
+		evicted, err := qtx.evictOldest(key)
+		if err != nil {
+			return err
+		}
+		if !evicted {
+			return errStorageFull
+		}
+	}
+	if err := qtx.bucket.Put(key, value); err != nil {
+		return err
+	}
+	qtx.usage += size - previous
+	if qtx.seqs == nil {
+		return nil
+	}
+	if err := untrack(qtx.order, qtx.seqs, key); err != nil {
+		return err
+	}
+	return track(qtx.order, qtx.seqs, key)
+}
+
+func (qtx *quotaTx) delete(key []byte) error {
+	qtx.usage -= qtx.storedSize(key)
+	if err := qtx.bucket.Delete(key); err != nil {
+		return err
+	}
+	if qtx.seqs == nil {
+		return nil
+	}
+	return untrack(qtx.order, qtx.seqs, key)
+}
+
+// evictOldest deletes the least recently written entry other than the one being written.
+// It returns false if there is no such entry.
+func (qtx *quotaTx) evictOldest(writing []byte) (bool, error) {
+	for {
+		c := qtx.order.Cursor()
+		seq, key := c.First()
+		if seq != nil && bytes.Equal(key, writing) {
+			seq, key = c.Next()
+		}
+		if seq == nil {
+			return false, nil
+		}
+		// copy the key, as it isn't valid anymore once untracked
+		key = bytes.Clone(key)
+		existing := qtx.bucket.Get(key)
+		qtx.usage -= qtx.storedSize(key)
+		if err := untrack(qtx.order, qtx.seqs, key); err != nil {
+			return false, err
+		}
+		if existing == nil {
+			// the entry was deleted while the policy wasn't enabled
+			continue
+		}
+		if err := qtx.bucket.Delete(key); err != nil {
+			return false, err
+		}
+		qtx.evicted++
+		return true, nil
+	}
+}
+
+// storedSize is the size of the entry of key and of its tracking entries, if any.
+func (qtx *quotaTx) storedSize(key []byte) int64 {
+	var size int64
+	if existing := qtx.bucket.Get(key); existing != nil {
+		size += entrySize(key, existing)
+	}
+	if qtx.seqs != nil && qtx.seqs.Get(key) != nil {
+		size += trackingSize(key)
+	}
+	return size
+}
+
+// commit records the outcome of a committed transaction.
+func (qtx *quotaTx) commit() {
+	delta := qtx.usage - qtx.quota.usage
+	qtx.quota.usage = qtx.usage
+	if delta != 0 {
+		qtx.telemetry.FilestorageUsage.Add(context.Background(), delta, qtx.attrs)
+	}
+	if qtx.evicted > 0 {
+		qtx.telemetry.FilestorageEvictedEntries.Add(context.Background(), qtx.evicted, qtx.attrs)
+	}
+}
+
+// rejected records a transaction rolled back because the quota was exceeded.
+func (q *quota) rejected() {
+	q.telemetry.FilestorageRejectedWrites.Add(context.Background(), 1, q.attrs)
+}
+
+// close removes the usage of the database from the telemetry, as it isn't tracked anymore.
+func (q *quota) close() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.telemetry.FilestorageUsage.Add(context.Background(), -q.usage, q.attrs)
+}
+
+// track records key as the most recently written entry.
+func track(order, seqs *bbolt.Bucket, key []byte) error {
+	seq, err := order.NextSequence()
+	if err != nil {
+		return err
+	}
+	seqKey := binary.BigEndian.AppendUint64(nil, seq)
+	if err := order.Put(seqKey, key); err != nil {
+		return err
+	}
+	return seqs.Put(key, seqKey)
+}
+
+func untrack(order, seqs *bbolt.Bucket, key []byte) error {
+	seqKey := seqs.Get(key)
+	if seqKey == nil {
+		return nil
+	}
+	if err := order.Delete(seqKey); err != nil {
+		return err
+	}
+	return seqs.Delete(key)
+}
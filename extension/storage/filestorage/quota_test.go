@@ -0,0 +1,159 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package filestorage
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/extension/xextension/storage"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/filestorage/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/filestorage/internal/metadatatest"
+)
+
+// testEntrySize is the size accounted for an entry written with testEntryValue.
+func testEntrySize(policy FullPolicy) int64 {
+	size := entrySize([]byte("k1"), testEntryValue('1'))
+	if policy == FullPolicyDropOldest {
+		size += trackingSize([]byte("k1"))
+	}
+	return size
+}
+
+// testQuota is the quota of the test databases, which fits three entries written with testEntryValue.
+func testQuota(policy FullPolicy) int64 {
+	return 3*testEntrySize(policy) + 10
+}
+
+// newQuotaTestClient returns a client of a database limited to testQuota.
+func newQuotaTestClient(t *testing.T, dbFile string, policy FullPolicy) (*fileStorageClient, *componenttest.Telemetry) {
+	tel := componenttest.NewTelemetry()
+	t.Cleanup(func() {
+		require.NoError(t, tel.Shutdown(context.Background()))
+	})
+	telemetry, err := metadata.NewTelemetryBuilder(tel.NewTelemetrySettings())
+	require.NoError(t, err)
+	client, err := newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, false, nil, newQuota(testQuota(policy), policy, telemetry, "my_db"))
+	require.NoError(t, err)
+	return client, tel
+}
+
+// testEntryValue returns the value of the test entries.
+func testEntryValue(b byte) []byte {
+	return bytes.Repeat([]byte{b}, 28)
+}
+
+func TestQuotaReject(t *testing.T) {
+	dbFile := filepath.Join(t.TempDir(), "my_db")
+	client, tel := newQuotaTestClient(t, dbFile, FullPolicyReject)
+	ctx := context.Background()
+
+	for _, key := range []string{"k1", "k2", "k3"} {
+		require.NoError(t, client.Set(ctx, key, testEntryValue(key[1])))
+	}
+	require.ErrorIs(t, client.Set(ctx, "k4", testEntryValue('4')), errStorageFull)
+	// the whole batch is rejected
+	require.ErrorIs(t, client.Batch(ctx, storage.SetOperation("k0", []byte("v")), storage.SetOperation("k4", testEntryValue('4'))), errStorageFull)
+	v, err := client.Get(ctx, "k0")
+	require.NoError(t, err)
+	assert.Nil(t, v)
+
+	// writes that don't grow the database are accepted
+	require.NoError(t, client.Set(ctx, "k1", testEntryValue('1')))
+	require.NoError(t, client.Delete(ctx, "k1"))
+	require.NoError(t, client.Set(ctx, "k4", testEntryValue('4')))
+
+	attrs := attribute.NewSet(attribute.String("database", "my_db"))
+	metadatatest.AssertEqualFilestorageRejectedWrites(t, tel,
+		[]metricdata.DataPoint[int64]{{Value: 2, Attributes: attrs}},
+		metricdatatest.IgnoreTimestamp())
+	metadatatest.AssertEqualFilestorageUsage(t, tel,
+		[]metricdata.DataPoint[int64]{{Value: 3 * testEntrySize(FullPolicyReject), Attributes: attrs}},
+		metricdatatest.IgnoreTimestamp())
+	metadatatest.AssertEqualFilestorageQuota(t, tel,
+		[]metricdata.DataPoint[int64]{{Value: testQuota(FullPolicyReject), Attributes: attrs}},
+		metricdatatest.IgnoreTimestamp())
+
+	require.NoError(t, client.Close(ctx))
+	metadatatest.AssertEqualFilestorageUsage(t, tel,
+		[]metricdata.DataPoint[int64]{{Value: 0, Attributes: attrs}},
+		metricdatatest.IgnoreTimestamp())
+}
+
+func TestQuotaDropOldest(t *testing.T) {
+	dbFile := filepath.Join(t.TempDir(), "my_db")
+	client, tel := newQuotaTestClient(t, dbFile, FullPolicyDropOldest)
+	t.Cleanup(func() {
+		require.NoError(t, client.Close(context.Background()))
+	})
+	ctx := context.Background()
+
+	for _, key := range []string{"k1", "k2", "k3"} {
+		require.NoError(t, client.Set(ctx, key, testEntryValue(key[1])))
+	}
+	// k1 is the least recently written entry
+	require.NoError(t, client.Set(ctx, "k4", testEntryValue('4')))
+	// rewriting k2 makes k3 the least recently written entry
+	require.NoError(t, client.Set(ctx, "k2", testEntryValue('2')))
+	require.NoError(t, client.Set(ctx, "k5", testEntryValue('5')))
+
+	for key, expected := range map[string][]byte{"k1": nil, "k2": testEntryValue('2'), "k3": nil, "k4": testEntryValue('4'), "k5": testEntryValue('5')} {
+		v, err := client.Get(ctx, key)
+		require.NoError(t, err)
+		assert.Equal(t, expected, v, key)
+	}
+
+	// entries larger than the quota are rejected
+	require.ErrorIs(t, client.Set(ctx, "k6", bytes.Repeat([]byte{'6'}, int(testQuota(FullPolicyDropOldest)))), errStorageFull)
+	v, err := client.Get(ctx, "k2")
+	require.NoError(t, err)
+	assert.Equal(t, testEntryValue('2'), v)
+
+	attrs := attribute.NewSet(attribute.String("database", "my_db"))
+	metadatatest.AssertEqualFilestorageEvictedEntries(t, tel,
+		[]metricdata.DataPoint[int64]{{Value: 2, Attributes: attrs}},
+		metricdatatest.IgnoreTimestamp())
+	metadatatest.AssertEqualFilestorageUsage(t, tel,
+		[]metricdata.DataPoint[int64]{{Value: 3 * testEntrySize(FullPolicyDropOldest), Attributes: attrs}},
+		metricdatatest.IgnoreTimestamp())
+}
+
+func TestQuotaExistingDatabase(t *testing.T) {
+	dbFile := filepath.Join(t.TempDir(), "my_db")
+	ctx := context.Background()
+
+	client, err := newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, false, nil, nil)
+	require.NoError(t, err)
+	for _, key := range []string{"k3", "k1", "k2"} {
+		require.NoError(t, client.Set(ctx, key, testEntryValue(key[1])))
+	}
+	require.NoError(t, client.Close(ctx))
+
+	// the usage of the existing entries is accounted for, and they are evicted in key order
+	client, tel := newQuotaTestClient(t, dbFile, FullPolicyDropOldest)
+	require.NoError(t, client.Set(ctx, "k4", testEntryValue('4')))
+	v, err := client.Get(ctx, "k1")
+	require.NoError(t, err)
+	assert.Nil(t, v)
+	v, err = client.Get(ctx, "k3")
+	require.NoError(t, err)
+	assert.Equal(t, testEntryValue('3'), v)
+
+	attrs := attribute.NewSet(attribute.String("database", "my_db"))
+	metadatatest.AssertEqualFilestorageUsage(t, tel,
+		[]metricdata.DataPoint[int64]{{Value: 3 * testEntrySize(FullPolicyDropOldest), Attributes: attrs}},
+		metricdatatest.IgnoreTimestamp())
+	require.NoError(t, client.Close(ctx))
+}
@@ -0,0 +1,82 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package parseutils // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/parseutils"
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+)
+
+// XMLElement is an XML element along with its attributes, text and children.
+// Comments, processing instructions and directives are ignored.
+type XMLElement struct {
+	tag        string
+	attributes []xml.Attr
+	text       string
+	children   []XMLElement
+}
+
+// UnmarshalXML implements xml.Unmarshaler for XMLElement
+func (e *XMLElement) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	e.tag = start.Name.Local
+	e.attributes = start.Attr
+
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return fmt.Errorf("decode next token: %w", err)
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			child := XMLElement{}
+			if err := d.DecodeElement(&child, &t); err != nil {
+				return err
+			}
+			e.children = append(e.children, child)
+		case xml.EndElement:
+			// End element means we've reached the end of parsing
+			return nil
+		case xml.CharData:
+			// Strip leading/trailing spaces to ignore newlines and
+			// indentation in formatted XML
+			e.text += string(bytes.TrimSpace(t))
+		case xml.Comment: // ignore comments
+		case xml.ProcInst: // ignore processing instructions
+		case xml.Directive: // ignore directives
+		default:
+			return fmt.Errorf("unexpected token type %T", t)
+		}
+	}
+}
+
+// AsMap returns the element as a map with its "tag", and its "content", "attributes"
+// and "children" when they are not empty.
+func (e XMLElement) AsMap() map[string]any {
+	m := make(map[string]any, 4)
+	m["tag"] = e.tag
+
+	if e.text != "" {
+		m["content"] = e.text
+	}
+
+	if len(e.attributes) > 0 {
+		attributes := make(map[string]any, len(e.attributes))
+		for _, attr := range e.attributes {
+			attributes[attr.Name.Local] = attr.Value
+		}
+		m["attributes"] = attributes
+	}
+
+	if len(e.children) > 0 {
+		children := make([]any, 0, len(e.children))
+		for _, child := range e.children {
+			children = append(children, child.AsMap())
+		}
+		m["children"] = children
+	}
+
+	return m
+}
@@ -0,0 +1,68 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package parseutils
+
+import (
+	"encoding/xml"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_XMLElement(t *testing.T) {
+	testCases := []struct {
+		name        string
+		input       string
+		expected    map[string]any
+		expectedErr string
+	}{
+		{
+			name:     "empty element",
+			input:    "<a/>",
+			expected: map[string]any{"tag": "a"},
+		},
+		{
+			name:  "attributes, content and children",
+			input: `<a id="1"><!-- comment --> text <b>child</b><c x="y"/></a>`,
+			expected: map[string]any{
+				"tag":        "a",
+				"content":    "text",
+				"attributes": map[string]any{"id": "1"},
+				"children": []any{
+					map[string]any{"tag": "b", "content": "child"},
+					map[string]any{"tag": "c", "attributes": map[string]any{"x": "y"}},
+				},
+			},
+		},
+		{
+			name:  "formatted",
+			input: "<a>\n  <b>\n    text\n  </b>\n</a>",
+			expected: map[string]any{
+				"tag": "a",
+				"children": []any{
+					map[string]any{"tag": "b", "content": "text"},
+				},
+			},
+		},
+		{
+			name:        "unclosed element",
+			input:       "<a><b></a>",
+			expectedErr: "element <b> closed by </a>",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var element XMLElement
+			err := xml.Unmarshal([]byte(tc.input), &element)
+			if tc.expectedErr != "" {
+				assert.ErrorContains(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, element.AsMap())
+		})
+	}
+}
@@ -0,0 +1,239 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottl // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Warning describes a part of a statement or condition that can be parsed and executed, but most
+// likely doesn't behave as intended, such as a condition that is never met.
+type Warning struct {
+	// Expression is the statement or condition the warning was found in.
+	Expression string
+	// Message describes the issue.
+	Message string
+}
+
+func (w Warning) String() string {
+	return fmt.Sprintf("%q: %s", w.Expression, w.Message)
+}
+
+// WithWarningHandler sets a function called with the warnings found when parsing statements and
+// conditions. The warnings of a statement or condition are only reported if it's parsed successfully.
+//
+// Experimental: *NOTE* this option is subject to change or removal in the future.
+func WithWarningHandler[K any](handler func(Warning)) Option[K] {
+	return func(p *Parser[K]) {
+		p.warningHandler = handler
+	}
+}
+
+// withWarnings returns a copy of the parser collecting the warnings found in the expression, and
+// a function reporting them to the warning handler, if any.
+func (p *Parser[K]) withWarnings(expression string) (*Parser[K], func()) {
+	inner := *p
+	if p.warningHandler == nil {
+		return &inner, func() {}
+	}
+	var messages []string
+	inner.warnings = &messages
+	return &inner, func() {
+		for _, message := range messages {
+			p.warningHandler(Warning{Expression: expression, Message: message})
+		}
+	}
+}
+
+func (p *Parser[K]) warnf(format string, args ...any) {
+	if p.warnings != nil {
+		*p.warnings = append(*p.warnings, fmt.Sprintf(format, args...))
+	}
+}
+
+// checkStatementCondition warns about the where clause of a statement if its result doesn't
+// depend on the telemetry.
+func (p *Parser[K]) checkStatementCondition(b *booleanExpression) {
+	if b == nil {
+		return
+	}
+	if result, ok := constantCondition(b); ok {
+		if result {
+			p.warnf("the condition is always met and can be removed")
+		} else {
+			p.warnf("the condition is never met, so the statement is never executed")
+		}
+	}
+}
+
+// checkCondition warns about a condition whose result doesn't depend on the telemetry.
+func (p *Parser[K]) checkCondition(b *booleanExpression) {
+	if result, ok := constantCondition(b); ok {
+		if result {
+			p.warnf("the condition is always met")
+		} else {
+			p.warnf("the condition is never met")
+		}
+	}
+}
+
+// checkArgumentType warns about a literal argument whose type isn't accepted by the typed getter
+// it's passed to, which always fails when the function is executed.
+func (p *Parser[K]) checkArgumentType(function string, position int, argVal value, getterName string) {
+	if argVal.Literal != nil && argVal.Literal.Path != nil {
+		// variables are checked by checkArgument
+		return
+	}
+	expected, ok := getterVariableTypes[strings.SplitN(getterName, "[", 2)[0]]
+	if !ok {
+		return
+	}
+	if typ := p.variables.typeOf(argVal); typ != unknownType && typ != expected {
+		p.warnf("the argument at position %v of %v is of type %v, but the parameter requires a value of type %v", position, function, typ, expected)
+	}
+}
+
+// constantCondition returns the result of a condition if it doesn't depend on the telemetry, such
+// as `1 > 2`, or `name == "a" and name == "b"` whose comparisons contradict each other.
+func constantCondition(b *booleanExpression) (result, ok bool) {
+	result, ok = constantTerm(b.Left)
+	if ok && result {
+		return true, true
+	}
+	allFalse := ok
+	for _, rhs := range b.Right {
+		r, known := constantTerm(rhs.Term)
+		if known && r {
+			return true, true
+		}
+		allFalse = allFalse && known
+	}
+	return false, allFalse
+}
+
+func constantTerm(t *term) (result, ok bool) {
+	values := make([]*booleanValue, 0, len(t.Right)+1)
+	values = append(values, t.Left)
+	for _, rhs := range t.Right {
+		values = append(values, rhs.Value)
+	}
+	allTrue := true
+	for _, v := range values {
+		r, known := constantBooleanValue(v)
+		if known && !r {
+			return false, true
+		}
+		allTrue = allTrue && known
+	}
+	if allTrue {
+		return true, true
+	}
+	if contradictoryComparisons(values) {
+		return false, true
+	}
+	return false, false
+}
+
+func constantBooleanValue(b *booleanValue) (result, ok bool) {
+	switch {
+	case b.Comparison != nil:
+		result, ok = constantComparison(b.Comparison)
+	case b.ConstExpr != nil && b.ConstExpr.Boolean != nil:
+		result, ok = bool(*b.ConstExpr.Boolean), true
+	case b.SubExpr != nil:
+		result, ok = constantCondition(b.SubExpr)
+	}
+	if ok && b.Negation != nil {
+		result = !result
+	}
+	return result, ok
+}
+
+func constantComparison(c *comparison) (result, ok bool) {
+	left, leftOk := constantValue(c.Left)
+	right, rightOk := constantValue(c.Right)
+	if !leftOk || !rightOk {
+		return false, false
+	}
+	return (&ottlValueComparator{}).compare(left, right, c.Op), true
+}
+
+// constantValue returns the value of a literal that isn't a path, a converter or an expression.
+func constantValue(v value) (any, bool) {
+	switch {
+	case v.IsNil != nil:
+		return nil, true
+	case v.String != nil:
+		return *v.String, true
+	case v.Bool != nil:
+		return bool(*v.Bool), true
+	case v.Bytes != nil:
+		return []byte(*v.Bytes), true
+	case v.Literal != nil && v.Literal.Int != nil:
+		return *v.Literal.Int, true
+	case v.Literal != nil && v.Literal.Float != nil:
+		return *v.Literal.Float, true
+	}
+	return nil, false
+}
+
+// contradictoryComparisons returns true if the values compare the same path for equality with
+// different constants, or for both equality and inequality with the same constant.
+func contradictoryComparisons(values []*booleanValue) bool {
+	comparator := NewValueComparator()
+	equal := map[string]any{}
+	notEqual := map[string][]any{}
+	for _, v := range values {
+		if v.Negation != nil || v.Comparison == nil || (v.Comparison.Op != eq && v.Comparison.Op != ne) {
+			continue
+		}
+		name, constant, ok := pathComparison(v.Comparison)
+		if !ok {
+			continue
+		}
+		if v.Comparison.Op == eq {
+			if previous, seen := equal[name]; seen && !comparator.Equal(previous, constant) {
+				return true
+			}
+			equal[name] = constant
+		} else {
+			notEqual[name] = append(notEqual[name], constant)
+		}
+	}
+	for name, constants := range notEqual {
+		if expected, ok := equal[name]; ok {
+			for _, constant := range constants {
+				if comparator.Equal(expected, constant) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// pathComparison returns the text of the path and the constant of a comparison between a path
+// indexed by literal keys and a constant.
+func pathComparison(c *comparison) (string, any, bool) {
+	p, other := c.Left, c.Right
+	if p.Literal == nil || p.Literal.Path == nil {
+		p, other = c.Right, c.Left
+	}
+	if p.Literal == nil || p.Literal.Path == nil {
+		return "", nil, false
+	}
+	for _, f := range p.Literal.Path.Fields {
+		for _, k := range f.Keys {
+			if k.String == nil && k.Int == nil {
+				return "", nil, false
+			}
+		}
+	}
+	constant, ok := constantValue(other)
+	if !ok {
+		return "", nil, false
+	}
+	return buildOriginalText(p.Literal.Path), constant, true
+}
@@ -0,0 +1,111 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+)
+
+func newWarningsTestParser(t *testing.T, warnings *[]Warning) Parser[any] {
+	p, err := NewParser(
+		defaultFunctionsForTests(),
+		testParsePath[any],
+		componenttest.NewNopTelemetrySettings(),
+		WithEnumParser[any](testParseEnum),
+		WithWarningHandler[any](func(w Warning) {
+			*warnings = append(*warnings, w)
+		}),
+	)
+	require.NoError(t, err)
+	return p
+}
+
+func Test_Warnings_Statements(t *testing.T) {
+	tests := []struct {
+		statement string
+		expected  []string
+	}{
+		{
+			statement: `testing_getter(name) where name == "a"`,
+		},
+		{
+			statement: `testing_getter(name) where 1 > 2`,
+			expected:  []string{"the condition is never met, so the statement is never executed"},
+		},
+		{
+			statement: `testing_getter(name) where not (1 == 1.0) or "a" == "b"`,
+			expected:  []string{"the condition is never met, so the statement is never executed"},
+		},
+		{
+			statement: `testing_getter(name) where name == nil or true`,
+			expected:  []string{"the condition is always met and can be removed"},
+		},
+		{
+			statement: `testing_getter(name) where name == "a" and attributes["x"] != nil and name == "b"`,
+			expected:  []string{"the condition is never met, so the statement is never executed"},
+		},
+		{
+			statement: `testing_getter(name) where attributes["x"] == 1 and attributes["x"] != 1.0`,
+			expected:  []string{"the condition is never met, so the statement is never executed"},
+		},
+		{
+			statement: `testing_getter(name) where name == "a" or name == "b"`,
+		},
+		{
+			statement: `testing_getter(name) where attributes[name] == "a" and attributes[name] == "b"`,
+		},
+		{
+			statement: `testing_intgetter("1")`,
+			expected:  []string{"the argument at position 0 of testing_intgetter is of type string, but the parameter requires a value of type int"},
+		},
+		{
+			statement: `testing_floatgetter(1 + 2)`,
+			expected:  []string{"the argument at position 0 of testing_floatgetter is of type int, but the parameter requires a value of type float"},
+		},
+		{
+			statement: `testing_floatgetter(1.5 * 2)`,
+		},
+		{
+			statement: `testing_stringgetter(name)`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.statement, func(t *testing.T) {
+			var warnings []Warning
+			p := newWarningsTestParser(t, &warnings)
+			_, err := p.ParseStatement(tt.statement)
+			require.NoError(t, err)
+
+			var messages []string
+			for _, w := range warnings {
+				assert.Equal(t, tt.statement, w.Expression)
+				messages = append(messages, w.Message)
+			}
+			assert.Equal(t, tt.expected, messages)
+		})
+	}
+}
+
+func Test_Warnings_Conditions(t *testing.T) {
+	var warnings []Warning
+	p := newWarningsTestParser(t, &warnings)
+	_, err := p.ParseConditions([]string{`name == "a"`, `false`, `nil == nil`})
+	require.NoError(t, err)
+	assert.Equal(t, []Warning{
+		{Expression: `false`, Message: "the condition is never met"},
+		{Expression: `nil == nil`, Message: "the condition is always met"},
+	}, warnings)
+}
+
+func Test_Warnings_NotReportedOnError(t *testing.T) {
+	var warnings []Warning
+	p := newWarningsTestParser(t, &warnings)
+	_, err := p.ParseStatement(`testing_intgetter("1") where unknown == 1`)
+	require.Error(t, err)
+	assert.Empty(t, warnings)
+}
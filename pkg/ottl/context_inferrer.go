@@ -44,6 +44,8 @@ type priorityContextInferrer struct {
 	telemetrySettings component.TelemetrySettings
 	contextPriority   map[string]int
 	contextCandidate  map[string]*priorityContextInferrerCandidate
+	// functionDefinitions are replaced by the hints of their body when called.
+	functionDefinitions map[string]*functionDefinition
 }
 
 type priorityContextInferrerCandidate struct {
@@ -90,6 +92,14 @@ func withContextInferrerPriorities(priorities []string) priorityContextInferrerO
 	}
 }
 
+// withContextInferrerFunctionDefinitions sets the user-defined functions the statements
+// might call.
+func withContextInferrerFunctionDefinitions(definitions map[string]*functionDefinition) priorityContextInferrerOption {
+	return func(c *priorityContextInferrer) {
+		c.functionDefinitions = definitions
+	}
+}
+
 func (s *priorityContextInferrer) inferFromConditions(conditions []string) (inferredContext string, err error) {
 	return s.infer(nil, conditions, nil)
 }
@@ -263,7 +273,7 @@ func (s *priorityContextInferrer) sortContextCandidates(candidates []string) {
 // getConditionsHints extracts all path, function names (editor and converter), and enumSymbol
 // from the given condition. These values are used by the context inferrer as hints to
 // select a context in which the function/enum are supported.
-func (*priorityContextInferrer) getConditionsHints(conditions []string) ([]priorityContextInferrerHints, error) {
+func (s *priorityContextInferrer) getConditionsHints(conditions []string) ([]priorityContextInferrerHints, error) {
 	hints := make([]priorityContextInferrerHints, 0, len(conditions))
 	for _, condition := range conditions {
 		parsed, err := parseCondition(condition)
@@ -273,6 +283,9 @@ func (*priorityContextInferrer) getConditionsHints(conditions []string) ([]prior
 
 		visitor := newGrammarContextInferrerVisitor()
 		parsed.accept(&visitor)
+		if err = s.addFunctionDefinitionsHints(&visitor); err != nil {
+			return nil, err
+		}
 		hints = append(hints, visitor)
 	}
 	return hints, nil
@@ -281,17 +294,25 @@ func (*priorityContextInferrer) getConditionsHints(conditions []string) ([]prior
 // getStatementsHints extracts all path, function names (editor and converter), and enumSymbol
 // from the given statement. These values are used by the context inferrer as hints to
 // select a context in which the function/enum are supported.
-func (*priorityContextInferrer) getStatementsHints(statements []string) ([]priorityContextInferrerHints, error) {
+func (s *priorityContextInferrer) getStatementsHints(statements []string) ([]priorityContextInferrerHints, error) {
 	hints := make([]priorityContextInferrerHints, 0, len(statements))
+	variables := map[string]struct{}{}
 	for _, statement := range statements {
 		parsed, err := parseStatement(statement)
 		if err != nil {
 			return nil, err
 		}
 		visitor := newGrammarContextInferrerVisitor()
-		parsed.Editor.accept(&visitor)
-		if parsed.WhereClause != nil {
-			parsed.WhereClause.accept(&visitor)
+		parsed.accept(&visitor)
+		// references to variables are not paths of any context
+		visitor.paths = slices.DeleteFunc(visitor.paths, func(p path) bool {
+			return isVariableReference(&p, variables)
+		})
+		if parsed.Let != nil {
+			variables[parsed.Let.Name] = struct{}{}
+		}
+		if err = s.addFunctionDefinitionsHints(&visitor); err != nil {
+			return nil, err
 		}
 		hints = append(hints, visitor)
 	}
@@ -301,7 +322,7 @@ func (*priorityContextInferrer) getStatementsHints(statements []string) ([]prior
 // getValueExpressionsHints extracts all path, function (converter) names, and enumSymbol
 // from the given value expressions. These values are used by the context inferrer as hints to
 // select a context in which the function/enum are supported.
-func (*priorityContextInferrer) getValueExpressionsHints(exprs []string) ([]priorityContextInferrerHints, error) {
+func (s *priorityContextInferrer) getValueExpressionsHints(exprs []string) ([]priorityContextInferrerHints, error) {
 	hints := make([]priorityContextInferrerHints, 0, len(exprs))
 	for _, expr := range exprs {
 		parsed, err := parseValueExpression(expr)
@@ -311,11 +332,52 @@ func (*priorityContextInferrer) getValueExpressionsHints(exprs []string) ([]prio
 
 		visitor := newGrammarContextInferrerVisitor()
 		parsed.accept(&visitor)
+		if err = s.addFunctionDefinitionsHints(&visitor); err != nil {
+			return nil, err
+		}
 		hints = append(hints, visitor)
 	}
 	return hints, nil
 }
 
+// addFunctionDefinitionsHints replaces the function definitions called in the hints by the
+// hints of their bodies. The context-less paths of the bodies are skipped, as well as their
+// parameters, given they are bound to the context of the calls.
+func (s *priorityContextInferrer) addFunctionDefinitionsHints(hints *priorityContextInferrerHints) error {
+	expanded := map[string]struct{}{}
+	for {
+		var called []*functionDefinition
+		for function := range hints.functions {
+			if definition, ok := s.functionDefinitions[function]; ok {
+				called = append(called, definition)
+				delete(hints.functions, function)
+			}
+		}
+		if len(called) == 0 {
+			return nil
+		}
+		for _, definition := range called {
+			if _, ok := expanded[definition.name]; ok {
+				continue
+			}
+			expanded[definition.name] = struct{}{}
+			parsed, err := parseDefinition(definition.text)
+			if err != nil {
+				return err
+			}
+			visitor := newGrammarContextInferrerVisitor()
+			parsed.accept(&visitor)
+			for _, p := range visitor.paths {
+				if _, ok := s.contextCandidate[p.Context]; ok && !slices.Contains(definition.parameters, p.Context) {
+					hints.paths = append(hints.paths, p)
+				}
+			}
+			maps.Copy(hints.functions, visitor.functions)
+			maps.Copy(hints.enumsSymbols, visitor.enumsSymbols)
+		}
+	}
+}
+
 // priorityContextInferrerHints is a grammarVisitor implementation that collects
 // all path, function names (converter.Function and editor.Function), and enumSymbol.
 type priorityContextInferrerHints struct {
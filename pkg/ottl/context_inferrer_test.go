@@ -275,6 +275,48 @@ func Test_NewPriorityContextInferrer_InferConditions(t *testing.T) {
 	}
 }
 
+func Test_NewPriorityContextInferrer_FunctionDefinitions(t *testing.T) {
+	definitions, err := parseFunctionDefinitions([]string{
+		`tag(target) { set(target["tagged"], true) where spanevent.name != nil }`,
+		`mark() { tag(attributes) }`,
+	})
+	require.NoError(t, err)
+	candidate := &priorityContextInferrerCandidate{
+		hasFunctionName: func(name string) bool {
+			return name == "set"
+		},
+		hasEnumSymbol: func(*EnumSymbol) bool {
+			return true
+		},
+		getLowerContexts: func(string) []string {
+			return nil
+		},
+	}
+	inferrer := newPriorityContextInferrer(
+		componenttest.NewNopTelemetrySettings(),
+		map[string]*priorityContextInferrerCandidate{"spanevent": candidate, "span": candidate, "resource": candidate},
+		withContextInferrerPriorities([]string{"spanevent", "span", "resource"}),
+		withContextInferrerFunctionDefinitions(definitions),
+	)
+
+	// the paths and functions of the called definitions are used as hints
+	inferredContext, err := inferrer.inferFromStatements([]string{`tag(span.attributes)`})
+	require.NoError(t, err)
+	assert.Equal(t, "spanevent", inferredContext)
+
+	inferredContext, err = inferrer.inferFromStatements([]string{`mark() where resource.attributes["marked"] == nil`})
+	require.NoError(t, err)
+	assert.Equal(t, "spanevent", inferredContext)
+
+	definitions, err = parseFunctionDefinitions([]string{`mark() { set(attributes["marked"], true) }`})
+	require.NoError(t, err)
+	inferrer.(*priorityContextInferrer).functionDefinitions = definitions
+	// the context-less paths of the definitions are bound to the context of the statements
+	inferredContext, err = inferrer.inferFromStatements([]string{`mark() where resource.attributes["marked"] == nil`})
+	require.NoError(t, err)
+	assert.Equal(t, "resource", inferredContext)
+}
+
 func Test_NewPriorityContextInferrer_InvalidStatement(t *testing.T) {
 	inferrer := newPriorityContextInferrer(componenttest.NewNopTelemetrySettings(), map[string]*priorityContextInferrerCandidate{})
 	statements := []string{"set(foo.field,"}
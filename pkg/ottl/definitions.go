@@ -0,0 +1,420 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottl // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+)
+
+// functionDefinition is a user-defined function, either a converter defined by a value
+// expression or an editor defined by a block of statements.
+type functionDefinition struct {
+	name       string
+	parameters []string
+	// text is parsed again for every call, so that the arguments can be substituted into
+	// a fresh copy of the definition.
+	text string
+}
+
+func parseFunctionDefinitions(definitions []string) (map[string]*functionDefinition, error) {
+	parsedDefinitions := make(map[string]*functionDefinition, len(definitions))
+	var parseErrs []error
+	for _, definition := range definitions {
+		parsed, err := parseDefinition(definition)
+		if err != nil {
+			parseErrs = append(parseErrs, fmt.Errorf("unable to parse OTTL function definition %q: %w", definition, err))
+			continue
+		}
+		if _, ok := parsedDefinitions[parsed.Name]; ok {
+			parseErrs = append(parseErrs, fmt.Errorf("function %q is defined more than once", parsed.Name))
+			continue
+		}
+		parsedDefinitions[parsed.Name] = &functionDefinition{
+			name:       parsed.Name,
+			parameters: parsed.Parameters,
+			text:       definition,
+		}
+	}
+
+	if len(parseErrs) > 0 {
+		return nil, errors.Join(parseErrs...)
+	}
+
+	return parsedDefinitions, nil
+}
+
+// bindArguments returns the argument of each parameter of the definition, passed either by
+// position or by name.
+func (d *functionDefinition) bindArguments(args []argument) (map[string]value, error) {
+	if len(args) != len(d.parameters) {
+		return nil, fmt.Errorf("incorrect number of arguments. Expected: %d Received: %d", len(d.parameters), len(args))
+	}
+	bound := make(map[string]value, len(args))
+	namedArgs := false
+	for i, arg := range args {
+		if arg.FunctionName != nil {
+			return nil, fmt.Errorf("invalid argument at position %v: functions can't be passed to function definitions", i)
+		}
+		name := arg.Name
+		if name == "" {
+			if namedArgs {
+				return nil, errors.New("unnamed argument used after named argument")
+			}
+			name = d.parameters[i]
+		} else {
+			namedArgs = true
+			if !slices.Contains(d.parameters, name) {
+				return nil, fmt.Errorf("no such parameter: %s", name)
+			}
+		}
+		if _, ok := bound[name]; ok {
+			return nil, fmt.Errorf("parameter %s is set more than once", name)
+		}
+		bound[name] = arg.Value
+	}
+	return bound, nil
+}
+
+// newDefinitionCall substitutes the arguments of a call into the definition, and parses
+// its expression or statements.
+func (p *Parser[K]) newDefinitionCall(def *functionDefinition, args []argument) (Expr[K], error) {
+	if slices.Contains(p.expanding, def.name) {
+		return Expr[K]{}, fmt.Errorf("function definition %q is recursive", def.name)
+	}
+	bound, err := def.bindArguments(args)
+	if err != nil {
+		return Expr[K]{}, fmt.Errorf("error while parsing arguments for call to %q: %w", def.name, err)
+	}
+	parsed, err := parseDefinition(def.text)
+	if err != nil {
+		return Expr[K]{}, err
+	}
+	call := &definitionCall{
+		arguments:    bound,
+		context:      p.definitionsContext,
+		contextNames: p.pathContextNames,
+	}
+	if err = call.definition(parsed); err != nil {
+		return Expr[K]{}, fmt.Errorf("error while expanding call to %q: %w", def.name, err)
+	}
+
+	inner := *p
+	inner.expanding = append(slices.Clone(p.expanding), def.name)
+	if parsed.Value != nil {
+		getter, err := inner.newGetter(*parsed.Value)
+		if err != nil {
+			return Expr[K]{}, fmt.Errorf("error while expanding call to %q: %w", def.name, err)
+		}
+		return Expr[K]{exprFunc: getter.Get}, nil
+	}
+
+	type statement struct {
+		function  Expr[K]
+		condition BoolExpr[K]
+	}
+	statements := make([]statement, len(parsed.Statements))
+	for i, ps := range parsed.Statements {
+		function, err := inner.newFunctionCall(ps.Editor)
+		if err != nil {
+			return Expr[K]{}, fmt.Errorf("error while expanding call to %q: %w", def.name, err)
+		}
+		condition, err := inner.newBoolExpr(ps.WhereClause)
+		if err != nil {
+			return Expr[K]{}, fmt.Errorf("error while expanding call to %q: %w", def.name, err)
+		}
+		statements[i] = statement{function: function, condition: condition}
+	}
+	return Expr[K]{exprFunc: func(ctx context.Context, tCtx K) (any, error) {
+		for _, s := range statements {
+			condition, err := s.condition.Eval(ctx, tCtx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to execute %q: %w", def.name, err)
+			}
+			if !condition {
+				continue
+			}
+			if _, err = s.function.Eval(ctx, tCtx); err != nil {
+				return nil, fmt.Errorf("failed to execute %q: %w", def.name, err)
+			}
+		}
+		return nil, nil
+	}}, nil
+}
+
+// definitionCall substitutes the arguments of a call into the grammar AST of a definition.
+// The arguments themselves are not visited, as they are already resolved in the scope of
+// the call.
+type definitionCall struct {
+	arguments map[string]value
+	// context is set on the context-less paths of the definition, if not empty.
+	context      string
+	contextNames map[string]struct{}
+	// lambdaParameters are the parameters of the lambdas being visited, which hide the
+	// parameters of the definition with the same name.
+	lambdaParameters map[string]struct{}
+}
+
+func (c *definitionCall) definition(d *parsedDefinition) error {
+	if d.Value != nil {
+		return c.value(d.Value)
+	}
+	for _, s := range d.Statements {
+		if err := c.args(s.Editor.Arguments); err != nil {
+			return err
+		}
+		if s.WhereClause != nil {
+			if err := c.booleanExpression(s.WhereClause); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (c *definitionCall) args(args []argument) error {
+	for i := range args {
+		if args[i].FunctionName != nil {
+			continue
+		}
+		if err := c.value(&args[i].Value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *definitionCall) value(v *value) error {
+	switch {
+	case v.Lambda != nil:
+		return c.lambda(v.Lambda)
+	case v.Literal != nil:
+		arg, err := c.literal(v.Literal)
+		if err != nil {
+			return err
+		}
+		if arg != nil {
+			*v = *arg
+		}
+	case v.MathExpression != nil:
+		return c.mathExpression(v.MathExpression)
+	case v.Map != nil:
+		for _, item := range v.Map.Values {
+			if err := c.value(item.Value); err != nil {
+				return err
+			}
+		}
+	case v.List != nil:
+		for i := range v.List.Values {
+			if err := c.value(&v.List.Values[i]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (c *definitionCall) lambda(l *lambda) error {
+	inner := *c
+	inner.arguments = maps.Clone(c.arguments)
+	inner.lambdaParameters = maps.Clone(c.lambdaParameters)
+	if inner.lambdaParameters == nil {
+		inner.lambdaParameters = make(map[string]struct{}, len(l.Parameters))
+	}
+	for _, parameter := range l.Parameters {
+		delete(inner.arguments, parameter)
+		inner.lambdaParameters[parameter] = struct{}{}
+	}
+	if l.Condition != nil {
+		return inner.booleanExpression(l.Condition)
+	}
+	return inner.value(l.Body)
+}
+
+// literal returns the value replacing the literal if it refers to a parameter, or nil.
+func (c *definitionCall) literal(m *mathExprLiteral) (*value, error) {
+	if m.Converter != nil {
+		if err := c.args(m.Converter.Arguments); err != nil {
+			return nil, err
+		}
+		return nil, c.keys(m.Converter.Keys)
+	}
+	if m.Path != nil {
+		return c.path(m.Path)
+	}
+	return nil, nil
+}
+
+// path returns the value replacing the path if it refers to a parameter, or nil. When a
+// parameter is indexed or followed by other fields, its argument must be a path or a converter,
+// which is indexed or extended accordingly.
+func (c *definitionCall) path(p *path) (*value, error) {
+	for i := range p.Fields {
+		if err := c.keys(p.Fields[i].Keys); err != nil {
+			return nil, err
+		}
+	}
+
+	var name string
+	var keys []key
+	var fields []field
+	if _, ok := c.arguments[p.Context]; ok && p.Context != "" {
+		name, fields = p.Context, p.Fields
+	} else if _, ok := c.arguments[p.Fields[0].Name]; ok && p.Context == "" {
+		name, keys, fields = p.Fields[0].Name, p.Fields[0].Keys, p.Fields[1:]
+	} else {
+		if !isVariableReference(p, c.lambdaParameters) {
+			c.bindContext(p)
+		}
+		return nil, nil
+	}
+
+	arg := c.arguments[name]
+	if len(keys) == 0 && len(fields) == 0 {
+		return &arg, nil
+	}
+	switch {
+	case arg.Literal != nil && arg.Literal.Path != nil:
+		argFields := slices.Clone(arg.Literal.Path.Fields)
+		last := &argFields[len(argFields)-1]
+		last.Keys = slices.Concat(last.Keys, keys)
+		return &value{Literal: &mathExprLiteral{Path: &path{
+			Context: arg.Literal.Path.Context,
+			Fields:  append(argFields, fields...),
+		}}}, nil
+	case arg.Literal != nil && arg.Literal.Converter != nil && len(fields) == 0:
+		converter := *arg.Literal.Converter
+		converter.Keys = slices.Concat(converter.Keys, keys)
+		return &value{Literal: &mathExprLiteral{Converter: &converter}}, nil
+	}
+	return nil, fmt.Errorf("parameter %q is used as a path but its argument is not a path", name)
+}
+
+// bindContext sets the context of the definition on a context-less path, the same way the
+// ParserCollection does on the paths of the statements.
+func (c *definitionCall) bindContext(p *path) {
+	if c.context == "" {
+		return
+	}
+	if p.Context == "" {
+		p.Context = c.context
+		return
+	}
+	if _, ok := c.contextNames[p.Context]; !ok {
+		p.Fields = append([]field{{Name: p.Context}}, p.Fields...)
+		p.Context = c.context
+	}
+}
+
+func (c *definitionCall) keys(keys []key) error {
+	for i := range keys {
+		if m := keys[i].MathExpression; m != nil && len(m.Right) == 0 && len(m.Left.Right) == 0 && m.Left.Left.Literal != nil {
+			// a lone path or converter used as a key is parsed as a math expression,
+			// while its parameter may be replaced by any key
+			keys[i] = key{Expression: m.Left.Left.Literal}
+		}
+		if keys[i].MathExpression != nil {
+			if err := c.mathExpression(keys[i].MathExpression); err != nil {
+				return err
+			}
+		}
+		if keys[i].Expression == nil {
+			continue
+		}
+		arg, err := c.literal(keys[i].Expression)
+		if err != nil {
+			return err
+		}
+		switch {
+		case arg == nil:
+		case arg.String != nil:
+			keys[i] = key{String: arg.String}
+		case arg.Literal != nil && arg.Literal.Int != nil:
+			keys[i] = key{Int: arg.Literal.Int}
+		case arg.Literal != nil && (arg.Literal.Path != nil || arg.Literal.Converter != nil):
+			keys[i] = key{Expression: arg.Literal}
+		case arg.MathExpression != nil:
+			keys[i] = key{MathExpression: arg.MathExpression}
+		default:
+			return fmt.Errorf("parameter %q is used as a key but its argument is not a string, an int, a path, a converter or a math expression", buildOriginalText(keys[i].Expression.Path))
+		}
+	}
+	return nil
+}
+
+func (c *definitionCall) mathExpression(m *mathExpression) error {
+	terms := []*addSubTerm{m.Left}
+	for _, r := range m.Right {
+		terms = append(terms, r.Term)
+	}
+	for _, t := range terms {
+		values := []*mathValue{t.Left}
+		for _, r := range t.Right {
+			values = append(values, r.Value)
+		}
+		for _, v := range values {
+			if err := c.mathValue(v); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (c *definitionCall) mathValue(m *mathValue) error {
+	if m.SubExpression != nil {
+		return c.mathExpression(m.SubExpression)
+	}
+	arg, err := c.literal(m.Literal)
+	if err != nil || arg == nil {
+		return err
+	}
+	switch {
+	case arg.Literal != nil:
+		*m = mathValue{Literal: arg.Literal}
+	case arg.MathExpression != nil:
+		*m = mathValue{SubExpression: arg.MathExpression}
+	default:
+		return fmt.Errorf("parameter %q is used in a math expression but its argument is not a number, a path, a converter or a math expression", buildOriginalText(m.Literal.Path))
+	}
+	return nil
+}
+
+func (c *definitionCall) booleanExpression(b *booleanExpression) error {
+	terms := []*term{b.Left}
+	for _, r := range b.Right {
+		terms = append(terms, r.Term)
+	}
+	for _, t := range terms {
+		values := []*booleanValue{t.Left}
+		for _, r := range t.Right {
+			values = append(values, r.Value)
+		}
+		for _, v := range values {
+			if err := c.booleanValue(v); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (c *definitionCall) booleanValue(b *booleanValue) error {
+	switch {
+	case b.Comparison != nil:
+		if err := c.value(&b.Comparison.Left); err != nil {
+			return err
+		}
+		return c.value(&b.Comparison.Right)
+	case b.ConstExpr != nil && b.ConstExpr.Converter != nil:
+		_, err := c.literal(&mathExprLiteral{Converter: b.ConstExpr.Converter})
+		return err
+	case b.SubExpr != nil:
+		return c.booleanExpression(b.SubExpr)
+	}
+	return nil
+}
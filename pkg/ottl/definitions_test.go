@@ -0,0 +1,222 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottl
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+)
+
+type recordArguments[K any] struct {
+	Value Getter[K]
+}
+
+// newRecordFactory returns the factory of a `record` editor appending its argument to recorded.
+func newRecordFactory(recorded *[]any) Factory[any] {
+	return NewFactory("record", &recordArguments[any]{}, func(_ FunctionContext, args Arguments) (ExprFunc[any], error) {
+		value := args.(*recordArguments[any]).Value
+		return func(ctx context.Context, tCtx any) (any, error) {
+			v, err := value.Get(ctx, tCtx)
+			if err != nil {
+				return nil, err
+			}
+			*recorded = append(*recorded, v)
+			return nil, nil
+		}, nil
+	})
+}
+
+func newDefinitionsTestParser(t *testing.T, recorded *[]any, definitions ...string) Parser[any] {
+	p, err := NewParser(
+		CreateFactoryMap(newRecordFactory(recorded)),
+		testParsePath[any],
+		componenttest.NewNopTelemetrySettings(),
+		WithFunctionDefinitions[any](definitions),
+	)
+	require.NoError(t, err)
+	return p
+}
+
+func Test_FunctionDefinitions(t *testing.T) {
+	definitions := []string{
+		`Double(x) = x * 2`,
+		`Name(value) = value`,
+		`record_all(a, b) { record(a); record(Double(b)) where b > 1; record([a, b]); }`,
+		`record_twice(value) { record_all(value, value) }`,
+	}
+	tests := []struct {
+		statement string
+		expected  []any
+	}{
+		{
+			statement: `record(Double(3))`,
+			expected:  []any{int64(6)},
+		},
+		{
+			statement: `record(Double(1 + 2))`,
+			expected:  []any{int64(6)},
+		},
+		{
+			statement: `record(Name("fido"))`,
+			expected:  []any{"fido"},
+		},
+		{
+			statement: `record_all("x", 2)`,
+			expected:  []any{"x", int64(4), []any{"x", int64(2)}},
+		},
+		{
+			statement: `record_all(b = 1, a = "y")`,
+			expected:  []any{"y", []any{"y", int64(1)}},
+		},
+		{
+			statement: `record_twice(3)`,
+			expected:  []any{int64(3), int64(6), []any{int64(3), int64(3)}},
+		},
+		{
+			statement: `record_twice(3) where 1 > 2`,
+			expected:  nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.statement, func(t *testing.T) {
+			var recorded []any
+			p := newDefinitionsTestParser(t, &recorded, definitions...)
+			statement, err := p.ParseStatement(tt.statement)
+			require.NoError(t, err)
+			_, _, err = statement.Execute(context.Background(), nil)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, recorded)
+		})
+	}
+}
+
+func Test_FunctionDefinitions_Paths(t *testing.T) {
+	var recorded []any
+	p := newDefinitionsTestParser(t, &recorded,
+		`Get(m, k) = m[k]`,
+		`Attribute(attrs) = attrs["foo"]`,
+	)
+
+	// the arguments are substituted into the paths, which are resolved by the context
+	for _, expr := range []string{`Get(attributes, "foo")`, `Attribute(attributes)`} {
+		parsed, err := p.ParseValueExpression(expr)
+		require.NoError(t, err)
+		v, err := parsed.Eval(context.Background(), "tCtx")
+		require.NoError(t, err)
+		assert.Equal(t, "tCtx", v)
+	}
+
+	_, err := p.ParseValueExpression(`Attribute("foo")`)
+	require.ErrorContains(t, err, `parameter "attrs" is used as a path but its argument is not a path`)
+	_, err = p.ParseValueExpression(`Get(attributes, [1])`)
+	require.ErrorContains(t, err, `parameter "k" is used as a key`)
+}
+
+func Test_FunctionDefinitions_ContextBinding(t *testing.T) {
+	target := "foo"
+	call := &definitionCall{
+		arguments:    map[string]value{"target": {String: &target}},
+		context:      "log",
+		contextNames: map[string]struct{}{"log": {}, "resource": {}},
+	}
+	parsed, err := parseDefinition(`tag(target) { set(attributes[target], resource.attributes["name"]) where name == target }`)
+	require.NoError(t, err)
+	require.NoError(t, call.definition(parsed))
+
+	var paths []string
+	for _, p := range getParsedStatementPaths(parsed.Statements[0]) {
+		paths = append(paths, buildOriginalText(&p))
+	}
+	assert.Equal(t, []string{"log.attributes[foo]", "resource.attributes[name]", "log.name"}, paths)
+}
+
+func Test_FunctionDefinitions_Error(t *testing.T) {
+	tests := []struct {
+		name        string
+		definitions []string
+		statement   string
+		err         string
+	}{
+		{
+			name:        "invalid syntax",
+			definitions: []string{`record_all(a) record(a)`},
+			err:         "definition has invalid syntax",
+		},
+		{
+			name:        "uppercase block",
+			definitions: []string{`RecordAll(a) { record(a) }`},
+			err:         "converter definitions must be an expression",
+		},
+		{
+			name:        "lowercase expression",
+			definitions: []string{`double(a) = a * 2`},
+			err:         "editor definitions must be a block of statements",
+		},
+		{
+			name:        "let statement",
+			definitions: []string{`record_all(a) { let b = a; record(b) }`},
+			err:         "let statements are not supported in function definitions",
+		},
+		{
+			name:        "duplicate parameter",
+			definitions: []string{`Double(a, a) = a * 2`},
+			err:         "duplicate parameter 'a'",
+		},
+		{
+			name:        "defined twice",
+			definitions: []string{`Double(a) = a * 2`, `Double(b) = b + b`},
+			err:         `function "Double" is defined more than once`,
+		},
+		{
+			name:        "existing function",
+			definitions: []string{`record(a) { record(a) }`},
+			err:         `function definition "record" conflicts with an existing function`,
+		},
+		{
+			name:        "incorrect number of arguments",
+			definitions: []string{`Double(a) = a * 2`},
+			statement:   `record(Double(1, 2))`,
+			err:         "incorrect number of arguments. Expected: 1 Received: 2",
+		},
+		{
+			name:        "unknown parameter",
+			definitions: []string{`Double(a) = a * 2`},
+			statement:   `record(Double(b = 1))`,
+			err:         "no such parameter: b",
+		},
+		{
+			name:        "recursive",
+			definitions: []string{`record_a(a) { record_b(a) }`, `record_b(b) { record_a(b) }`},
+			statement:   `record_a(1)`,
+			err:         `function definition "record_a" is recursive`,
+		},
+		{
+			name:        "string in math expression",
+			definitions: []string{`Double(a) = a * 2`},
+			statement:   `record(Double("a"))`,
+			err:         `parameter "a" is used in a math expression`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := NewParser(
+				CreateFactoryMap(newRecordFactory(nil)),
+				testParsePath[any],
+				componenttest.NewNopTelemetrySettings(),
+				WithFunctionDefinitions[any](tt.definitions),
+			)
+			if tt.statement == "" {
+				require.ErrorContains(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			_, err = p.ParseStatement(tt.statement)
+			require.ErrorContains(t, err, tt.err)
+		})
+	}
+}
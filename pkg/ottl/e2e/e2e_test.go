@@ -54,6 +54,14 @@ func Test_e2e_editors(t *testing.T) {
 				tCtx.GetLogRecord().Attributes().Remove("http.url")
 			},
 		},
+		{
+			statement: `for each (k, v) in attributes { set(attributes[k], ToUpperCase(v)) where IsMatch(k, "^http") and IsString(v) }`,
+			want: func(tCtx ottllog.TransformContext) {
+				tCtx.GetLogRecord().Attributes().PutStr("http.method", "GET")
+				tCtx.GetLogRecord().Attributes().PutStr("http.path", "/HEALTH")
+				tCtx.GetLogRecord().Attributes().PutStr("http.url", "HTTP://LOCALHOST/HEALTH")
+			},
+		},
 		{
 			statement: `keep_matching_keys(attributes, "^http")`,
 			want: func(tCtx ottllog.TransformContext) {
@@ -972,6 +980,29 @@ func Test_e2e_converters(t *testing.T) {
 				s.AppendEmpty().SetStr("A")
 			},
 		},
+		{
+			statement: `set(attributes["test"], Map(Split(attributes["flags"], "|"), (flag) => ToLowerCase(flag)))`,
+			want: func(tCtx ottllog.TransformContext) {
+				s := tCtx.GetLogRecord().Attributes().PutEmptySlice("test")
+				s.AppendEmpty().SetStr("a")
+				s.AppendEmpty().SetStr("b")
+				s.AppendEmpty().SetStr("c")
+			},
+		},
+		{
+			statement: `set(attributes["test"], Filter(Split(attributes["flags"], "|"), (i, flag) => i > 0 and flag != attributes["test"]))`,
+			want: func(tCtx ottllog.TransformContext) {
+				s := tCtx.GetLogRecord().Attributes().PutEmptySlice("test")
+				s.AppendEmpty().SetStr("B")
+				s.AppendEmpty().SetStr("C")
+			},
+		},
+		{
+			statement: `set(attributes["test"], Reduce(Split(attributes["flags"], "|"), "", (acc, flag) => Concat([flag, acc], "")))`,
+			want: func(tCtx ottllog.TransformContext) {
+				tCtx.GetLogRecord().Attributes().PutStr("test", "CBA")
+			},
+		},
 		{
 			statement: `set(attributes["test"], Sort([true, false, false]))`,
 			want: func(tCtx ottllog.TransformContext) {
@@ -1447,6 +1478,70 @@ func Test_e2e_ottl_features(t *testing.T) {
 	}
 }
 
+func Test_e2e_ottl_function_definitions(t *testing.T) {
+	definitions := []string{
+		`normalize_http(attrs) { set(attrs["http.request.method"], ToUpperCase(attrs["http.method"])) where attrs["http.method"] != nil; delete_key(attrs, "http.method") }`,
+		`tag(key, value) { set(attributes[key], value) }`,
+		`IsHealthCheck(url) = IsMatch(url, ".*/health$")`,
+		`Greeting(name) = Concat(["hello", name], " ")`,
+		`Bar(m) = m["bar"]`,
+	}
+	tests := []struct {
+		statement string
+		want      func(tCtx ottllog.TransformContext)
+	}{
+		{
+			statement: `normalize_http(attributes)`,
+			want: func(tCtx ottllog.TransformContext) {
+				tCtx.GetLogRecord().Attributes().Remove("http.method")
+				tCtx.GetLogRecord().Attributes().PutStr("http.request.method", "GET")
+			},
+		},
+		{
+			statement: `tag("test", "pass") where IsHealthCheck(attributes["http.url"])`,
+			want: func(tCtx ottllog.TransformContext) {
+				tCtx.GetLogRecord().Attributes().PutStr("test", "pass")
+			},
+		},
+		{
+			statement: `set(attributes["test"], Greeting(resource.attributes["host.name"]))`,
+			want: func(tCtx ottllog.TransformContext) {
+				tCtx.GetLogRecord().Attributes().PutStr("test", "hello localhost")
+			},
+		},
+		{
+			statement: `tag(value = Greeting("you"), key = "test")`,
+			want: func(tCtx ottllog.TransformContext) {
+				tCtx.GetLogRecord().Attributes().PutStr("test", "hello you")
+			},
+		},
+		{
+			statement: `set(attributes["test"], Bar(attributes["foo"]))`,
+			want: func(tCtx ottllog.TransformContext) {
+				tCtx.GetLogRecord().Attributes().PutStr("test", "pass")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.statement, func(t *testing.T) {
+			logStatements, err := parseStatementWithAndWithoutPathContext(tt.statement, definitions...)
+			assert.NoError(t, err)
+
+			for _, statement := range logStatements {
+				tCtx := constructLogTransformContext()
+				_, _, err = statement.Execute(context.Background(), tCtx)
+				assert.NoError(t, err)
+
+				exTCtx := constructLogTransformContext()
+				tt.want(exTCtx)
+
+				assert.NoError(t, plogtest.CompareResourceLogs(newResourceLogs(exTCtx), newResourceLogs(tCtx)))
+			}
+		})
+	}
+}
+
 func Test_e2e_ottl_statement_sequence(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -1706,9 +1801,9 @@ func Test_ProcessSpanEvents(t *testing.T) {
 	}
 }
 
-func parseStatementWithAndWithoutPathContext(statement string) ([]*ottl.Statement[ottllog.TransformContext], error) {
+func parseStatementWithAndWithoutPathContext(statement string, definitions ...string) ([]*ottl.Statement[ottllog.TransformContext], error) {
 	settings := componenttest.NewNopTelemetrySettings()
-	parserWithoutPathCtx, err := ottllog.NewParser(ottlfuncs.StandardFuncs[ottllog.TransformContext](), settings)
+	parserWithoutPathCtx, err := ottllog.NewParser(ottlfuncs.StandardFuncs[ottllog.TransformContext](), settings, ottl.WithFunctionDefinitions[ottllog.TransformContext](definitions))
 	if err != nil {
 		return nil, err
 	}
@@ -1729,7 +1824,8 @@ func parseStatementWithAndWithoutPathContext(statement string) ([]*ottl.Statemen
 			&parserWithPathCtx,
 			ottl.WithStatementConverter(func(_ *ottl.ParserCollection[*ottl.Statement[ottllog.TransformContext]], _ ottl.StatementsGetter, parsedStatements []*ottl.Statement[ottllog.TransformContext]) (*ottl.Statement[ottllog.TransformContext], error) {
 				return parsedStatements[0], nil
-			})))
+			})),
+		ottl.WithParserCollectionFunctionDefinitions[*ottl.Statement[ottllog.TransformContext]](definitions))
 	if err != nil {
 		return nil, err
 	}
@@ -1951,6 +2047,25 @@ func fillSpanOne(span ptrace.Span) {
 	span.SetTraceID(traceID)
 }
 
+func Test_e2e_literal_replacement_format(t *testing.T) {
+	logParser, err := ottllog.NewParser(ottlfuncs.StandardFuncs[ottllog.TransformContext](), componenttest.NewNopTelemetrySettings())
+	assert.NoError(t, err)
+
+	// an invalid literal format is reported when the statement is executed
+	statement, err := logParser.ParseStatement(`replace_pattern(attributes["http.path"], "/", "@", SHA256, "%d")`)
+	assert.NoError(t, err)
+	_, _, err = statement.Execute(context.Background(), constructLogTransformContext())
+	assert.ErrorContains(t, err, "replacementFormat must be format string containing a single %s")
+
+	statement, err = logParser.ParseStatement(`replace_pattern(attributes["http.path"], "/", "@", SHA256, Concat(["hash:", "%s"], ""))`)
+	assert.NoError(t, err)
+	tCtx := constructLogTransformContext()
+	_, _, err = statement.Execute(context.Background(), tCtx)
+	assert.NoError(t, err)
+	path, _ := tCtx.GetLogRecord().Attributes().Get("http.path")
+	assert.Equal(t, "hash:c3641f8544d7c02f3580b07c0f9887f0c6a27ff5ab1d4a3e29caf197cfc299aehealth", path.Str())
+}
+
 func Benchmark_XML_Functions(b *testing.B) {
 	testXML := `<Data><From><Test>1</Test><Test>2</Test></From><To></To></Data>`
 	tCtxWithTestBody := func() ottllog.TransformContext {
@@ -1984,3 +2099,20 @@ func Benchmark_XML_Functions(b *testing.B) {
 	// Ensure correctness
 	assert.NoError(b, plogtest.CompareResourceLogs(newResourceLogs(tCtxWithTestBody()), newResourceLogs(actualCtx)))
 }
+
+func Benchmark_ConstantConverter(b *testing.B) {
+	settings := componenttest.NewNopTelemetrySettings()
+	logParser, err := ottllog.NewParser(ottlfuncs.StandardFuncs[ottllog.TransformContext](), settings)
+	assert.NoError(b, err)
+
+	// SHA256(Concat(...)) only depends on literals, so it is evaluated when parsing the statement.
+	statement, err := logParser.ParseStatement(`set(attributes["key"], SHA256(Concat(["service", "v1"], "-"))) where attributes["http.method"] == "get"`)
+	assert.NoError(b, err)
+
+	tCtx := constructLogTransformContext()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _, _ = statement.Execute(context.Background(), tCtx)
+	}
+}
@@ -91,67 +91,77 @@ func (g exprGetter[K]) Get(ctx context.Context, tCtx K) (any, error) {
 	}
 
 	for _, k := range g.keys {
-		switch {
-		case k.String != nil:
-			switch r := result.(type) {
-			case pcommon.Map:
-				val, ok := r.Get(*k.String)
-				if !ok {
-					return nil, errors.New("key not found in map")
-				}
-				result = ottlcommon.GetValue(val)
-			case map[string]any:
-				val, ok := r[*k.String]
-				if !ok {
-					return nil, errors.New("key not found in map")
-				}
-				result = val
-			default:
-				return nil, fmt.Errorf("type, %T, does not support string indexing", result)
+		result, err = getIndexedValue(result, k)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// getIndexedValue returns the element of a map or a slice for a string or an int key.
+func getIndexedValue(result any, k key) (any, error) {
+	var err error
+	switch {
+	case k.String != nil:
+		switch r := result.(type) {
+		case pcommon.Map:
+			val, ok := r.Get(*k.String)
+			if !ok {
+				return nil, errors.New("key not found in map")
 			}
-		case k.Int != nil:
-			switch r := result.(type) {
-			case pcommon.Slice:
-				if int(*k.Int) >= r.Len() || int(*k.Int) < 0 {
-					return nil, fmt.Errorf("index %v out of bounds", *k.Int)
-				}
-				result = ottlcommon.GetValue(r.At(int(*k.Int)))
-			case []any:
-				result, err = getElementByIndex(r, k.Int)
-				if err != nil {
-					return nil, err
-				}
-			case []string:
-				result, err = getElementByIndex(r, k.Int)
-				if err != nil {
-					return nil, err
-				}
-			case []bool:
-				result, err = getElementByIndex(r, k.Int)
-				if err != nil {
-					return nil, err
-				}
-			case []float64:
-				result, err = getElementByIndex(r, k.Int)
-				if err != nil {
-					return nil, err
-				}
-			case []int64:
-				result, err = getElementByIndex(r, k.Int)
-				if err != nil {
-					return nil, err
-				}
-			case []byte:
-				result, err = getElementByIndex(r, k.Int)
-				if err != nil {
-					return nil, err
-				}
-			default:
-				return nil, fmt.Errorf("type, %T, does not support int indexing", result)
+			result = ottlcommon.GetValue(val)
+		case map[string]any:
+			val, ok := r[*k.String]
+			if !ok {
+				return nil, errors.New("key not found in map")
 			}
+			result = val
 		default:
-			return nil, errors.New("neither map nor slice index were set; this is an error in OTTL")
+			return nil, fmt.Errorf("type, %T, does not support string indexing", result)
 		}
+	case k.Int != nil:
+		switch r := result.(type) {
+		case pcommon.Slice:
+			if int(*k.Int) >= r.Len() || int(*k.Int) < 0 {
+				return nil, fmt.Errorf("index %v out of bounds", *k.Int)
+			}
+			result = ottlcommon.GetValue(r.At(int(*k.Int)))
+		case []any:
+			result, err = getElementByIndex(r, k.Int)
+			if err != nil {
+				return nil, err
+			}
+		case []string:
+			result, err = getElementByIndex(r, k.Int)
+			if err != nil {
+				return nil, err
+			}
+		case []bool:
+			result, err = getElementByIndex(r, k.Int)
+			if err != nil {
+				return nil, err
+			}
+		case []float64:
+			result, err = getElementByIndex(r, k.Int)
+			if err != nil {
+				return nil, err
+			}
+		case []int64:
+			result, err = getElementByIndex(r, k.Int)
+			if err != nil {
+				return nil, err
+			}
+		case []byte:
+			result, err = getElementByIndex(r, k.Int)
+			if err != nil {
+				return nil, err
+			}
+		default:
+			return nil, fmt.Errorf("type, %T, does not support int indexing", result)
+		}
+	default:
+		return nil, errors.New("neither map nor slice index were set; this is an error in OTTL")
 	}
 	return result, nil
 }
@@ -345,6 +355,26 @@ func (g StandardStringGetter[K]) Get(ctx context.Context, tCtx K) (string, error
 	}
 }
 
+// literalStringGetter is a StringGetter of a literal, or of a converter call evaluated when parsing.
+type literalStringGetter[K any] struct {
+	StandardStringGetter[K]
+	value any
+}
+
+// GetLiteralString returns the value of a StringGetter whose value is known when the statements
+// are parsed, such as a string literal, so that functions can prepare it once. It returns false
+// for any other StringGetter, or if the value isn't a string.
+//
+// Experimental: *NOTE* this function is subject to change or removal in the future.
+func GetLiteralString[K any](getter StringGetter[K]) (string, bool) {
+	lg, ok := getter.(literalStringGetter[K])
+	if !ok {
+		return "", false
+	}
+	s, ok := lg.value.(string)
+	return s, ok
+}
+
 // IntGetter is a Getter that must return an int64.
 type IntGetter[K any] interface {
 	// Get retrieves an int64 value.
@@ -873,6 +903,9 @@ func (g StandardBoolLikeGetter[K]) Get(ctx context.Context, tCtx K) (*bool, erro
 }
 
 func (p *Parser[K]) newGetter(val value) (Getter[K], error) {
+	if val.Lambda != nil {
+		return nil, errors.New("lambdas can only be passed to the Lambda parameters of functions")
+	}
 	if val.IsNil != nil && *val.IsNil {
 		return &literal[K]{value: nil}, nil
 	}
@@ -903,6 +936,13 @@ func (p *Parser[K]) newGetter(val value) (Getter[K], error) {
 			return &literal[K]{value: *i}, nil
 		}
 		if eL.Path != nil {
+			v, err := p.variables.reference(eL.Path)
+			if err != nil {
+				return nil, err
+			}
+			if v != nil {
+				return p.newVariableGetter(v, eL.Path.Fields[0].Keys)
+			}
 			np, err := p.newPath(eL.Path)
 			if err != nil {
 				return nil, err
@@ -950,10 +990,14 @@ func (p *Parser[K]) newGetterFromConverter(c converter) (Getter[K], error) {
 	if err != nil {
 		return nil, err
 	}
-	return &exprGetter[K]{
+	getter := &exprGetter[K]{
 		expr: call,
 		keys: c.Keys,
-	}, nil
+	}
+	if folded, ok := p.foldConverter(c, getter); ok {
+		return folded, nil
+	}
+	return getter, nil
 }
 
 // TimeGetter is a Getter that must return a time.Time.
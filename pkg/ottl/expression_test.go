@@ -1073,6 +1073,22 @@ func Test_StandardStringGetter_WrappedError(t *testing.T) {
 	assert.False(t, ok)
 }
 
+func Test_GetLiteralString(t *testing.T) {
+	value, ok := GetLiteralString[any](literalStringGetter[any]{value: "literal"})
+	assert.True(t, ok)
+	assert.Equal(t, "literal", value)
+
+	_, ok = GetLiteralString[any](literalStringGetter[any]{value: int64(1)})
+	assert.False(t, ok)
+
+	_, ok = GetLiteralString[any](StandardStringGetter[any]{
+		Getter: func(context.Context, any) (any, error) {
+			return "not literal", nil
+		},
+	})
+	assert.False(t, ok)
+}
+
 func Test_StandardStringLikeGetter(t *testing.T) {
 	tests := []struct {
 		name             string
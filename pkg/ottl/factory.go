@@ -40,6 +40,7 @@ type factory[K any] struct {
 	name               string
 	args               Arguments
 	createFunctionFunc CreateFunctionFunc[K]
+	pure               bool
 }
 
 //nolint:unused
@@ -60,6 +61,24 @@ func (f *factory[K]) CreateFunction(fCtx FunctionContext, args Arguments) (ExprF
 // FactoryOption is an option for a Factory
 type FactoryOption[K any] func(factory *factory[K])
 
+// WithPureFunction declares that the function has no side effects, and that its result only
+// depends on its arguments. The calls of pure converters whose arguments are all literals
+// are evaluated once when they're parsed, if they return a string, a number, a boolean, a
+// time or an ID.
+//
+// Experimental: *NOTE* this option is subject to change or removal in the future.
+func WithPureFunction[K any]() FactoryOption[K] {
+	return func(factory *factory[K]) {
+		factory.pure = true
+	}
+}
+
+// isPure returns true if the function of the factory was declared pure with WithPureFunction.
+func isPure[K any](f Factory[K]) bool {
+	pf, ok := f.(*factory[K])
+	return ok && pf.pure
+}
+
 // NewFactory creates a new Factory
 func NewFactory[K any](name string, args Arguments, createFunctionFunc CreateFunctionFunc[K], options ...FactoryOption[K]) Factory[K] {
 	f := &factory[K]{
@@ -0,0 +1,81 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottl // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/internal/ottlcommon"
+)
+
+// newForEachFunction returns the function of a for each statement, which runs its statements
+// for each entry of a map or element of a slice. The entries are collected before running the
+// statements, so the statements can modify the target.
+func (p *Parser[K]) newForEachFunction(f *forEach) (Expr[K], error) {
+	target, err := p.newGetter(f.Target)
+	if err != nil {
+		return Expr[K]{}, err
+	}
+	inner, parameters, err := p.withParametersScope(f.Parameters)
+	if err != nil {
+		return Expr[K]{}, err
+	}
+
+	type statement struct {
+		function  Expr[K]
+		condition BoolExpr[K]
+	}
+	statements := make([]statement, len(f.Statements))
+	for i, ps := range f.Statements {
+		var function Expr[K]
+		if ps.ForEach != nil {
+			function, err = inner.newForEachFunction(ps.ForEach)
+		} else {
+			function, err = inner.newFunctionCall(ps.Editor)
+		}
+		if err != nil {
+			return Expr[K]{}, err
+		}
+		condition, err := inner.newBoolExpr(ps.WhereClause)
+		if err != nil {
+			return Expr[K]{}, err
+		}
+		inner.checkStatementCondition(ps.WhereClause)
+		statements[i] = statement{function: function, condition: condition}
+	}
+
+	return Expr[K]{exprFunc: func(ctx context.Context, tCtx K) (any, error) {
+		val, err := target.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+		ctx, values := withParameters(ctx)
+		err = ottlcommon.Range(val, func(key, value any) error {
+			if len(parameters) == 1 {
+				values[parameters[0]] = value
+			} else {
+				values[parameters[0]] = key
+				values[parameters[1]] = value
+			}
+			for _, s := range statements {
+				condition, err := s.condition.Eval(ctx, tCtx)
+				if err != nil {
+					return err
+				}
+				if !condition {
+					continue
+				}
+				if _, err = s.function.Eval(ctx, tCtx); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to execute for each: %w", err)
+		}
+		return nil, nil
+	}}, nil
+}
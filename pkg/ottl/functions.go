@@ -231,7 +231,7 @@ func (p *Parser[K]) newKeys(keys []key) ([]Key[K], error) {
 		var getter Getter[K]
 		if keys[i].Expression != nil {
 			if keys[i].Expression.Path != nil {
-				g, err := p.buildGetSetterFromPath(keys[i].Expression.Path)
+				g, err := p.newGetter(value{Literal: keys[i].Expression})
 				if err != nil {
 					return nil, err
 				}
@@ -315,6 +315,9 @@ func (p *Parser[K]) parsePath(ip *basePath[K]) (GetSetter[K], error) {
 }
 
 func (p *Parser[K]) newFunctionCall(ed editor) (Expr[K], error) {
+	if def, ok := p.definitions[ed.Function]; ok {
+		return p.newDefinitionCall(def, ed.Arguments)
+	}
 	f, ok := p.functions[ed.Function]
 	if !ok {
 		return Expr[K]{}, fmt.Errorf("undefined function %q", ed.Function)
@@ -423,6 +426,7 @@ func (p *Parser[K]) buildArgs(ed editor, argsVal reflect.Value) error {
 		case fieldType.Kind() == reflect.Slice:
 			val, err = p.buildSliceArg(arg.Value, fieldType)
 		default:
+			p.checkArgumentType(ed.Function, i, arg.Value, fieldType.Name())
 			val, err = p.buildArg(arg.Value, fieldType)
 		}
 		if err != nil {
@@ -536,6 +540,13 @@ func (p *Parser[K]) buildSliceArg(argVal value, argType reflect.Type) (any, erro
 }
 
 func (p *Parser[K]) buildGetSetterFromPath(path *path) (GetSetter[K], error) {
+	v, err := p.variables.reference(path)
+	if err != nil {
+		return nil, err
+	}
+	if v != nil {
+		return nil, fmt.Errorf("variable %q can't be set, variables are only bound by let statements", v.name)
+	}
 	np, err := p.newPath(path)
 	if err != nil {
 		return nil, err
@@ -550,6 +561,9 @@ func (p *Parser[K]) buildGetSetterFromPath(path *path) (GetSetter[K], error) {
 // Handle interfaces that can be passed as arguments to OTTL functions.
 func (p *Parser[K]) buildArg(argVal value, argType reflect.Type) (any, error) {
 	name := argType.Name()
+	if err := p.variables.checkArgument(argVal, name); err != nil {
+		return nil, err
+	}
 	switch {
 	case strings.HasPrefix(name, "Setter"),
 		strings.HasPrefix(name, "GetSetter"):
@@ -563,11 +577,16 @@ func (p *Parser[K]) buildArg(argVal value, argType reflect.Type) (any, error) {
 			return nil, err
 		}
 		return arg, nil
+	case strings.HasPrefix(name, "Lambda"):
+		return p.newLambda(argVal)
 	case strings.HasPrefix(name, "StringGetter"):
 		arg, err := p.newGetter(argVal)
 		if err != nil {
 			return nil, err
 		}
+		if l, ok := arg.(*literal[K]); ok {
+			return literalStringGetter[K]{StandardStringGetter: StandardStringGetter[K]{Getter: arg.Get}, value: l.value}, nil
+		}
 		return StandardStringGetter[K]{Getter: arg.Get}, nil
 	case strings.HasPrefix(name, "StringLikeGetter"):
 		arg, err := p.newGetter(argVal)
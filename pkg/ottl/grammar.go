@@ -6,33 +6,154 @@ package ottl // import "github.com/open-telemetry/opentelemetry-collector-contri
 import (
 	"encoding/hex"
 	"fmt"
+	"slices"
 	"strings"
+	"unicode"
 
 	"github.com/alecthomas/participle/v2/lexer"
 )
 
 // parsedStatement represents a parsed statement. It is the entry point into the statement DSL.
 type parsedStatement struct {
-	Editor editor `parser:"(@@"`
+	Let     *letBinding `parser:"( @@"`
+	ForEach *forEach    `parser:"| @@"`
+	Editor  editor      `parser:"| @@"`
 	// If converter is matched then return error
-	Converter   *converter         `parser:"|@@)"`
+	Converter   *converter         `parser:"| @@ )"`
 	WhereClause *booleanExpression `parser:"( 'where' @@ )?"`
 }
 
 func (p *parsedStatement) checkForCustomError() error {
 	validator := &grammarCustomErrorsVisitor{}
+	p.validate(validator)
+	return validator.join()
+}
+
+func (p *parsedStatement) validate(validator *grammarCustomErrorsVisitor) {
 	if p.Converter != nil {
 		validator.add(fmt.Errorf("editor names must start with a lowercase letter but got '%v'", p.Converter.Function))
 	}
 
-	p.Editor.accept(validator)
+	switch {
+	case p.Let != nil:
+		p.Let.Value.accept(validator)
+	case p.ForEach != nil:
+		p.ForEach.validate(validator)
+	default:
+		p.Editor.accept(validator)
+	}
 	if p.WhereClause != nil {
 		p.WhereClause.accept(validator)
 	}
+}
+
+func (p *parsedStatement) accept(v grammarVisitor) {
+	switch {
+	case p.Let != nil:
+		p.Let.Value.accept(v)
+	case p.ForEach != nil:
+		p.ForEach.accept(v)
+	default:
+		p.Editor.accept(v)
+	}
+	if p.WhereClause != nil {
+		p.WhereClause.accept(v)
+	}
+}
+
+// forEach runs a block of statements for each entry of a map or element of a slice, bound to
+// its parameters: the value, or the key or index and the value.
+type forEach struct {
+	Parameters []string           `parser:"'for' 'each' '(' @Lowercase ( ',' @Lowercase )? ')' 'in'"`
+	Target     value              `parser:"@@"`
+	Statements []*parsedStatement `parser:"'{' @@ ( ';' @@ )* ';'? '}'"`
+}
+
+func (f *forEach) validate(validator *grammarCustomErrorsVisitor) {
+	if len(f.Parameters) == 2 && f.Parameters[0] == f.Parameters[1] {
+		validator.add(fmt.Errorf("duplicate parameter '%v'", f.Parameters[0]))
+	}
+	f.Target.accept(validator)
+	for _, statement := range f.Statements {
+		if statement.Let != nil {
+			validator.add(fmt.Errorf("let statements are not supported in for each blocks, but '%v' is bound", statement.Let.Name))
+			continue
+		}
+		statement.validate(validator)
+	}
+}
+
+func (f *forEach) accept(v grammarVisitor) {
+	f.Target.accept(v)
+	scoped := newScopedVisitor(v, f.Parameters)
+	for _, statement := range f.Statements {
+		statement.accept(scoped)
+	}
+}
+
+// letBinding binds the value of an expression to a variable, which can be referenced by the
+// following statements of the same group.
+type letBinding struct {
+	Name  string `parser:"'let' @Lowercase Equal"`
+	Value value  `parser:"@@"`
+}
+
+// parsedDefinition represents a parsed function definition. It is the entry point into the definition DSL.
+// Converters are defined by a value expression, and editors by a block of statements separated by semicolons.
+type parsedDefinition struct {
+	Name       string             `parser:"@((Uppercase | Lowercase)(Uppercase | Lowercase)*)"`
+	Parameters []string           `parser:"'(' ( @Lowercase ( ',' @Lowercase )* )? ')'"`
+	Value      *value             `parser:"( Equal @@"`
+	Statements []*parsedStatement `parser:"| '{' @@ ( ';' @@ )* ';'? '}' )"`
+}
+
+func (d *parsedDefinition) isConverter() bool {
+	return unicode.IsUpper(rune(d.Name[0]))
+}
+
+func (d *parsedDefinition) checkForCustomError() error {
+	validator := &grammarCustomErrorsVisitor{}
+	if d.isConverter() && d.Value == nil {
+		validator.add(fmt.Errorf("converter definitions must be an expression, but '%v' is a block of statements; blocks names must start with a lowercase letter", d.Name))
+	}
+	if !d.isConverter() && d.Value != nil {
+		validator.add(fmt.Errorf("editor definitions must be a block of statements, but '%v' is an expression; expressions names must start with an uppercase letter", d.Name))
+	}
+	for i, param := range d.Parameters {
+		if slices.Contains(d.Parameters[:i], param) {
+			validator.add(fmt.Errorf("duplicate parameter '%v'", param))
+		}
+	}
+
+	if d.Value != nil {
+		d.Value.accept(validator)
+	}
+	for _, statement := range d.Statements {
+		if statement.Let != nil {
+			validator.add(fmt.Errorf("let statements are not supported in function definitions, but '%v' binds '%v'", d.Name, statement.Let.Name))
+			continue
+		}
+		if statement.ForEach != nil {
+			validator.add(fmt.Errorf("for each statements are not supported in function definitions, but '%v' has one", d.Name))
+			continue
+		}
+		if err := statement.checkForCustomError(); err != nil {
+			validator.add(err)
+		}
+	}
 
 	return validator.join()
 }
 
+func (d *parsedDefinition) accept(v grammarVisitor) {
+	if d.Value != nil {
+		d.Value.accept(v)
+	}
+	for _, statement := range d.Statements {
+		statement.accept(v)
+	}
+}
+
 type constExpr struct {
 	Boolean   *boolean   `parser:"( @Boolean"`
 	Converter *converter `parser:"| @@ )"`
@@ -234,7 +355,8 @@ func (a *argument) accept(v grammarVisitor) {
 // value represents a part of a parsed statement which is resolved to a value of some sort. This can be a telemetry path
 // mathExpression, function call, or literal.
 type value struct {
-	IsNil          *isNil           `parser:"( @'nil'"`
+	Lambda         *lambda          `parser:"( @@"`
+	IsNil          *isNil           `parser:"| @'nil'"`
 	Literal        *mathExprLiteral `parser:"| @@ (?! OpAddSub | OpMultDiv)"`
 	MathExpression *mathExpression  `parser:"| @@"`
 	Bytes          *byteSlice       `parser:"| @Bytes"`
@@ -253,6 +375,9 @@ func (v *value) checkForCustomError() error {
 
 func (v *value) accept(vis grammarVisitor) {
 	vis.visitValue(v)
+	if v.Lambda != nil {
+		v.Lambda.accept(vis)
+	}
 	if v.Literal != nil {
 		v.Literal.accept(vis)
 	}
@@ -269,6 +394,46 @@ func (v *value) accept(vis grammarVisitor) {
 	}
 }
 
+// lambda is an expression of its parameters, whose body is either a value or a condition, such as
+// `(k, v) => IsMatch(k, "^http")` or `(v) => v > 1024`, which functions can evaluate for the
+// arguments of their choice.
+type lambda struct {
+	Parameters []string           `parser:"'(' @Lowercase ( ',' @Lowercase )* ')' Arrow"`
+	Body       *value             `parser:"( @@ (?! OpComparison | OpAnd | OpOr)"`
+	Condition  *booleanExpression `parser:"| @@ )"`
+}
+
+func (l *lambda) accept(v grammarVisitor) {
+	scoped := newScopedVisitor(v, l.Parameters)
+	if l.Body != nil {
+		l.Body.accept(scoped)
+	}
+	if l.Condition != nil {
+		l.Condition.accept(scoped)
+	}
+}
+
+// scopedVisitor hides the references to the parameters of a lambda or of a for each statement
+// from the visitor it wraps, as they aren't paths.
+type scopedVisitor struct {
+	grammarVisitor
+	parameters map[string]struct{}
+}
+
+func newScopedVisitor(v grammarVisitor, parameters []string) *scopedVisitor {
+	scoped := &scopedVisitor{grammarVisitor: v, parameters: make(map[string]struct{}, len(parameters))}
+	for _, parameter := range parameters {
+		scoped.parameters[parameter] = struct{}{}
+	}
+	return scoped
+}
+
+func (v *scopedVisitor) visitPath(p *path) {
+	if !isVariableReference(p, v.parameters) {
+		v.grammarVisitor.visitPath(p)
+	}
+}
+
 // path represents a telemetry path mathExpression.
 type path struct {
 	Pos     lexer.Position
@@ -504,6 +669,7 @@ func buildLexer() *lexer.StatefulDefinition {
 		{Name: `Float`, Pattern: `[-+]?\d*\.\d+([eE][-+]?\d+)?`},
 		{Name: `Int`, Pattern: `[-+]?\d+`},
 		{Name: `String`, Pattern: `"(\\.|[^\\"])*"`},
+		{Name: `Arrow`, Pattern: `=>`},
 		{Name: `OpNot`, Pattern: `\b(not)\b`},
 		{Name: `OpOr`, Pattern: `\b(or)\b`},
 		{Name: `OpAnd`, Pattern: `\b(and)\b`},
@@ -517,7 +683,7 @@ func buildLexer() *lexer.StatefulDefinition {
 		{Name: `LBrace`, Pattern: `\{`},
 		{Name: `RBrace`, Pattern: `\}`},
 		{Name: `Colon`, Pattern: `\:`},
-		{Name: `Punct`, Pattern: `[,.\[\]]`},
+		{Name: `Punct`, Pattern: `[,.;\[\]]`},
 		{Name: `Uppercase`, Pattern: `[A-Z][A-Z0-9_]*`},
 		{Name: `Lowercase`, Pattern: `[a-z][a-z0-9_]*`},
 		{Name: "whitespace", Pattern: `\s+`},
@@ -579,7 +745,16 @@ func (g *grammarCustomErrorsVisitor) join() error {
 
 func (*grammarCustomErrorsVisitor) visitPath(*path) {}
 
-func (*grammarCustomErrorsVisitor) visitValue(*value) {}
+func (g *grammarCustomErrorsVisitor) visitValue(v *value) {
+	if v.Lambda == nil {
+		return
+	}
+	for i, parameter := range v.Lambda.Parameters {
+		if slices.Contains(v.Lambda.Parameters[:i], parameter) {
+			g.add(fmt.Errorf("duplicate parameter '%v'", parameter))
+		}
+	}
+}
 
 func (*grammarCustomErrorsVisitor) visitConverter(*converter) {}
 
@@ -0,0 +1,71 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlcommon // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/internal/ottlcommon"
+
+import (
+	"fmt"
+	"slices"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+type element struct {
+	key   any
+	value any
+}
+
+// Range calls fn with the string key and the value of each entry of a map, or the int64 index
+// and the value of each element of a slice. The entries are collected before fn is first
+// called, so fn can modify val. The entries of a map[string]any are sorted by key.
+func Range(val any, fn func(key, value any) error) error {
+	var elements []element
+	switch v := val.(type) {
+	case pcommon.Map:
+		elements = make([]element, 0, v.Len())
+		for k, mv := range v.All() {
+			elements = append(elements, element{key: k, value: GetValue(mv)})
+		}
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		slices.Sort(keys)
+		elements = make([]element, 0, len(v))
+		for _, k := range keys {
+			elements = append(elements, element{key: k, value: v[k]})
+		}
+	case pcommon.Slice:
+		elements = make([]element, 0, v.Len())
+		for i, sv := range v.All() {
+			elements = append(elements, element{key: int64(i), value: GetValue(sv)})
+		}
+	case []any:
+		elements = sliceElements(v)
+	case []string:
+		elements = sliceElements(v)
+	case []int64:
+		elements = sliceElements(v)
+	case []float64:
+		elements = sliceElements(v)
+	case []bool:
+		elements = sliceElements(v)
+	default:
+		return fmt.Errorf("expected a map or a slice but got %T", val)
+	}
+	for _, e := range elements {
+		if err := fn(e.key, e.value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func sliceElements[T any](s []T) []element {
+	elements := make([]element, len(s))
+	for i, v := range s {
+		elements[i] = element{key: int64(i), value: v}
+	}
+	return elements
+}
@@ -0,0 +1,72 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottl // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// Lambda is a function parameter holding an expression of its own parameters, such as
+// `(k, v) => IsMatch(k, "^http")`, that the function evaluates for the arguments of its choice.
+// The expression can also refer to the paths of the context, and to the variables in scope.
+type Lambda[K any] interface {
+	// Arity returns the number of parameters of the lambda.
+	Arity() int
+	// Call evaluates the expression with the arguments bound to the parameters, in order.
+	// If the number of arguments isn't the arity of the lambda, an error is returned.
+	Call(ctx context.Context, tCtx K, args ...any) (any, error)
+}
+
+type lambdaFunction[K any] struct {
+	parameters []*variable
+	body       Getter[K]
+}
+
+func (l *lambdaFunction[K]) Arity() int {
+	return len(l.parameters)
+}
+
+func (l *lambdaFunction[K]) Call(ctx context.Context, tCtx K, args ...any) (any, error) {
+	if len(args) != len(l.parameters) {
+		return nil, fmt.Errorf("lambda expects %d arguments but got %d", len(l.parameters), len(args))
+	}
+	ctx, values := withParameters(ctx)
+	for i, parameter := range l.parameters {
+		values[parameter] = args[i]
+	}
+	return l.body.Get(ctx, tCtx)
+}
+
+func (p *Parser[K]) newLambda(argVal value) (Lambda[K], error) {
+	if argVal.Lambda == nil {
+		return nil, errors.New("must be a lambda, such as `(value) => value`")
+	}
+	inner, parameters, err := p.withParametersScope(argVal.Lambda.Parameters)
+	if err != nil {
+		return nil, err
+	}
+	if argVal.Lambda.Condition != nil {
+		condition, err := inner.newBoolExpr(argVal.Lambda.Condition)
+		if err != nil {
+			return nil, err
+		}
+		return &lambdaFunction[K]{parameters: parameters, body: conditionGetter[K]{condition: condition}}, nil
+	}
+	body, err := inner.newGetter(*argVal.Lambda.Body)
+	if err != nil {
+		return nil, err
+	}
+	return &lambdaFunction[K]{parameters: parameters, body: body}, nil
+}
+
+// conditionGetter is the body of a lambda returning the result of a condition.
+type conditionGetter[K any] struct {
+	condition BoolExpr[K]
+}
+
+func (g conditionGetter[K]) Get(ctx context.Context, tCtx K) (any, error) {
+	return g.condition.Eval(ctx, tCtx)
+}
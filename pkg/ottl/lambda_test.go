@@ -0,0 +1,198 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottl
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+)
+
+type applyArguments[K any] struct {
+	Value  Getter[K]
+	Lambda Lambda[K]
+}
+
+// newApplyFactory returns the factory of an `Apply` converter calling its lambda with its value.
+func newApplyFactory() Factory[any] {
+	return NewFactory("Apply", &applyArguments[any]{}, func(_ FunctionContext, args Arguments) (ExprFunc[any], error) {
+		applyArgs := args.(*applyArguments[any])
+		return func(ctx context.Context, tCtx any) (any, error) {
+			v, err := applyArgs.Value.Get(ctx, tCtx)
+			if err != nil {
+				return nil, err
+			}
+			return applyArgs.Lambda.Call(ctx, tCtx, v)
+		}, nil
+	})
+}
+
+func newLambdaTestParser(t *testing.T, recorded *[]any, definitions ...string) Parser[any] {
+	p, err := NewParser(
+		CreateFactoryMap(newRecordFactory(recorded), newApplyFactory()),
+		testParsePath[any],
+		componenttest.NewNopTelemetrySettings(),
+		WithFunctionDefinitions[any](definitions),
+	)
+	require.NoError(t, err)
+	return p
+}
+
+func Test_Lambda(t *testing.T) {
+	definitions := []string{
+		`Scale(value, factor) = Apply(value, (x) => x * factor)`,
+		`Identity(x) = Apply(1, (x) => x)`,
+	}
+	tests := []struct {
+		statement string
+		expected  []any
+	}{
+		{
+			statement: `record(Apply(2, (x) => x * 3))`,
+			expected:  []any{int64(6)},
+		},
+		{
+			statement: `record(Apply(2, (x) => x > 1 and x < 3))`,
+			expected:  []any{true},
+		},
+		{
+			statement: `record(Apply(2, (x) => not (x > 1)))`,
+			expected:  []any{false},
+		},
+		{
+			statement: `record(Apply("a", (x) => [x, name]))`,
+			expected:  []any{[]any{"a", "tCtx"}},
+		},
+		{
+			statement: `record(Apply("a", (name) => name))`,
+			expected:  []any{"a"},
+		},
+		{
+			statement: `record(Apply(1, (x) => Apply(2, (y) => x + y)))`,
+			expected:  []any{int64(3)},
+		},
+		{
+			statement: `record(Scale(2, 5))`,
+			expected:  []any{int64(10)},
+		},
+		{
+			statement: `record(Identity(7))`,
+			expected:  []any{int64(1)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.statement, func(t *testing.T) {
+			var recorded []any
+			p := newLambdaTestParser(t, &recorded, definitions...)
+			statement, err := p.ParseStatement(tt.statement)
+			require.NoError(t, err)
+			_, _, err = statement.Execute(context.Background(), "tCtx")
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, recorded)
+		})
+	}
+}
+
+func Test_ForEach(t *testing.T) {
+	tests := []struct {
+		name       string
+		statements []string
+		expected   []any
+	}{
+		{
+			name:       "values",
+			statements: []string{`for each (v) in [1, 2, 3] { record(v * 10) where v > 1 }`},
+			expected:   []any{int64(20), int64(30)},
+		},
+		{
+			name:       "keys and values",
+			statements: []string{`for each (k, v) in {"a": 1} { record([k, v]); }`},
+			expected:   []any{[]any{"a", int64(1)}},
+		},
+		{
+			name:       "indexes",
+			statements: []string{`for each (i, v) in ["a", "b"] { record(i); record(v) }`},
+			expected:   []any{int64(0), "a", int64(1), "b"},
+		},
+		{
+			name:       "nested",
+			statements: []string{`let m = [1, 2]`, `for each (v) in m { for each (w) in m { record(v * w) } }`},
+			expected:   []any{int64(1), int64(2), int64(2), int64(4)},
+		},
+		{
+			name:       "condition",
+			statements: []string{`for each (v) in [1] { record(v) } where name == "other"`},
+			expected:   nil,
+		},
+		{
+			name:       "lambda",
+			statements: []string{`for each (v) in [1, 2] { record(Apply(v, (x) => x + v)) }`},
+			expected:   []any{int64(2), int64(4)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var recorded []any
+			p := newLambdaTestParser(t, &recorded)
+			statements, err := p.ParseStatements(tt.statements)
+			require.NoError(t, err)
+			sequence := NewStatementSequence(statements, componenttest.NewNopTelemetrySettings())
+			require.NoError(t, sequence.Execute(context.Background(), "tCtx"))
+			assert.Equal(t, tt.expected, recorded)
+		})
+	}
+}
+
+func Test_Lambda_Error(t *testing.T) {
+	tests := []struct {
+		name      string
+		statement string
+		err       string
+	}{
+		{
+			name:      "lambda as a getter",
+			statement: `record((x) => x)`,
+			err:       "lambdas can only be passed to the Lambda parameters of functions",
+		},
+		{
+			name:      "value as a lambda",
+			statement: `record(Apply(1, 2))`,
+			err:       "must be a lambda",
+		},
+		{
+			name:      "duplicate lambda parameter",
+			statement: `record(Apply(1, (x, x) => x))`,
+			err:       "duplicate parameter 'x'",
+		},
+		{
+			name:      "duplicate for each parameter",
+			statement: `for each (v, v) in [1] { record(v) }`,
+			err:       "duplicate parameter 'v'",
+		},
+		{
+			name:      "let in for each",
+			statement: `for each (v) in [1] { let x = v }`,
+			err:       "let statements are not supported in for each blocks",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var recorded []any
+			p := newLambdaTestParser(t, &recorded)
+			_, err := p.ParseStatement(tt.statement)
+			require.ErrorContains(t, err, tt.err)
+		})
+	}
+
+	// the lambda is called with a single argument by Apply
+	var recorded []any
+	p := newLambdaTestParser(t, &recorded)
+	statement, err := p.ParseStatement(`record(Apply(1, (x, y) => x))`)
+	require.NoError(t, err)
+	_, _, err = statement.Execute(context.Background(), "tCtx")
+	require.ErrorContains(t, err, "lambda expects 2 arguments but got 1")
+}
@@ -46,6 +46,15 @@ func Test_lexer(t *testing.T) {
 			{"OpComparison", "!="},
 			{"Float", "4.9"},
 		}},
+		{"lambda_arrow", "(v) => v>=1", false, []result{
+			{"LParen", "("},
+			{"Lowercase", "v"},
+			{"RParen", ")"},
+			{"Arrow", "=>"},
+			{"Lowercase", "v"},
+			{"OpComparison", ">="},
+			{"Int", "1"},
+		}},
 		{"unambiguous_names", "foo bar BAZZ", false, []result{
 			{"Lowercase", "foo"},
 			{"Lowercase", "bar"},
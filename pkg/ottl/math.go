@@ -45,6 +45,9 @@ func (p *Parser[K]) evaluateAddSubTerm(term *addSubTerm) (Getter[K], error) {
 func (p *Parser[K]) evaluateMathValue(val *mathValue) (Getter[K], error) {
 	switch {
 	case val.Literal != nil:
+		if err := p.variables.checkMathOperand(val.Literal); err != nil {
+			return nil, err
+		}
 		return p.newGetter(value{Literal: val.Literal})
 	case val.SubExpression != nil:
 		return p.evaluateMathExpression(val.SubExpression)
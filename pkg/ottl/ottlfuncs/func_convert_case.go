@@ -20,7 +20,7 @@ type ConvertCaseArguments[K any] struct {
 }
 
 func NewConvertCaseFactory[K any]() ottl.Factory[K] {
-	return ottl.NewFactory("ConvertCase", &ConvertCaseArguments[K]{}, createConvertCaseFunction[K])
+	return ottl.NewFactory("ConvertCase", &ConvertCaseArguments[K]{}, createConvertCaseFunction[K], ottl.WithPureFunction[K]())
 }
 
 func createConvertCaseFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
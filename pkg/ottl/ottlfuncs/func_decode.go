@@ -21,7 +21,7 @@ type DecodeArguments[K any] struct {
 }
 
 func NewDecodeFactory[K any]() ottl.Factory[K] {
-	return ottl.NewFactory("Decode", &DecodeArguments[K]{}, createDecodeFunction[K])
+	return ottl.NewFactory("Decode", &DecodeArguments[K]{}, createDecodeFunction[K], ottl.WithPureFunction[K]())
 }
 
 func createDecodeFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
@@ -15,7 +15,7 @@ type DoubleArguments[K any] struct {
 }
 
 func NewDoubleFactory[K any]() ottl.Factory[K] {
-	return ottl.NewFactory("Double", &DoubleArguments[K]{}, createDoubleFunction[K])
+	return ottl.NewFactory("Double", &DoubleArguments[K]{}, createDoubleFunction[K], ottl.WithPureFunction[K]())
 }
 
 func createDoubleFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
@@ -16,7 +16,7 @@ type DurationArguments[K any] struct {
 }
 
 func NewDurationFactory[K any]() ottl.Factory[K] {
-	return ottl.NewFactory("Duration", &DurationArguments[K]{}, createDurationFunction[K])
+	return ottl.NewFactory("Duration", &DurationArguments[K]{}, createDurationFunction[K], ottl.WithPureFunction[K]())
 }
 
 func createDurationFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
@@ -0,0 +1,53 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+type FilterArguments[K any] struct {
+	Target ottl.Getter[K]
+	Lambda ottl.Lambda[K]
+}
+
+func NewFilterFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("Filter", &FilterArguments[K]{}, createFilterFunction[K])
+}
+
+func createFilterFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*FilterArguments[K])
+	if !ok {
+		return nil, errors.New("FilterFactory args must be of type *FilterArguments[K]")
+	}
+	if arity := args.Lambda.Arity(); arity != 1 && arity != 2 {
+		return nil, fmt.Errorf("the Filter lambda must have 1 or 2 parameters, the value or the key and the value, but has %d", arity)
+	}
+
+	return filter(args.Target, args.Lambda), nil
+}
+
+func filter[K any](target ottl.Getter[K], lambda ottl.Lambda[K]) ottl.ExprFunc[K] {
+	return func(ctx context.Context, tCtx K) (any, error) {
+		val, err := target.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+		return collectElements(val, func(key, value any) (any, bool, error) {
+			result, err := callLambda(ctx, tCtx, lambda, nil, key, value)
+			if err != nil {
+				return nil, false, err
+			}
+			keep, ok := result.(bool)
+			if !ok {
+				return nil, false, fmt.Errorf("the Filter lambda must return a bool but returned %T", result)
+			}
+			return value, keep, nil
+		})
+	}
+}
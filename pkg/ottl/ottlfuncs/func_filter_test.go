@@ -0,0 +1,88 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_Filter(t *testing.T) {
+	attributes := pcommon.NewMap()
+	attributes.PutStr("k8s.pod.name", "pod")
+	attributes.PutStr("host.name", "host")
+	ports := pcommon.NewSlice()
+	ports.AppendEmpty().SetInt(80)
+	ports.AppendEmpty().SetInt(8080)
+
+	tests := []struct {
+		name     string
+		target   any
+		lambda   testLambda
+		expected any
+	}{
+		{
+			name:   "map keys",
+			target: attributes,
+			lambda: testLambda{arity: 2, fn: func(args ...any) (any, error) {
+				return strings.HasPrefix(args[0].(string), "k8s."), nil
+			}},
+			expected: map[string]any{"k8s.pod.name": "pod"},
+		},
+		{
+			name:   "slice values",
+			target: ports,
+			lambda: testLambda{arity: 1, fn: func(args ...any) (any, error) {
+				return args[0].(int64) > 1024, nil
+			}},
+			expected: []any{int64(8080)},
+		},
+		{
+			name:   "none kept",
+			target: []any{"a"},
+			lambda: testLambda{arity: 1, fn: func(...any) (any, error) {
+				return false, nil
+			}},
+			expected: []any{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc, err := createFilterFunction[any](ottl.FunctionContext{}, &FilterArguments[any]{
+				Target: literalGetter(tt.target),
+				Lambda: tt.lambda,
+			})
+			require.NoError(t, err)
+			result, err := exprFunc(context.Background(), nil)
+			require.NoError(t, err)
+			switch r := result.(type) {
+			case pcommon.Map:
+				assert.Equal(t, tt.expected, r.AsRaw())
+			case pcommon.Slice:
+				assert.Equal(t, tt.expected, r.AsRaw())
+			default:
+				t.Fatalf("unexpected result type %T", result)
+			}
+		})
+	}
+}
+
+func Test_Filter_Error(t *testing.T) {
+	exprFunc, err := createFilterFunction[any](ottl.FunctionContext{}, &FilterArguments[any]{
+		Target: literalGetter([]any{"a"}),
+		Lambda: testLambda{arity: 1, fn: func(args ...any) (any, error) {
+			return args[0], nil
+		}},
+	})
+	require.NoError(t, err)
+	_, err = exprFunc(context.Background(), nil)
+	assert.ErrorContains(t, err, "the Filter lambda must return a bool but returned string")
+}
@@ -16,7 +16,7 @@ type FnvArguments[K any] struct {
 }
 
 func NewFnvFactory[K any]() ottl.Factory[K] {
-	return ottl.NewFactory("FNV", &FnvArguments[K]{}, createFnvFunction[K])
+	return ottl.NewFactory("FNV", &FnvArguments[K]{}, createFnvFunction[K], ottl.WithPureFunction[K]())
 }
 
 func createFnvFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
@@ -17,7 +17,7 @@ type FormatTimeArguments[K any] struct {
 }
 
 func NewFormatTimeFactory[K any]() ottl.Factory[K] {
-	return ottl.NewFactory("FormatTime", &FormatTimeArguments[K]{}, createFormatTimeFunction[K])
+	return ottl.NewFactory("FormatTime", &FormatTimeArguments[K]{}, createFormatTimeFunction[K], ottl.WithPureFunction[K]())
 }
 
 func createFormatTimeFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
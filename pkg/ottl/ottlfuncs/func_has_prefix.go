@@ -17,7 +17,7 @@ type HasPrefixArguments[K any] struct {
 }
 
 func NewHasPrefixFactory[K any]() ottl.Factory[K] {
-	return ottl.NewFactory("HasPrefix", &HasPrefixArguments[K]{}, createHasPrefixFunction[K])
+	return ottl.NewFactory("HasPrefix", &HasPrefixArguments[K]{}, createHasPrefixFunction[K], ottl.WithPureFunction[K]())
 }
 
 func createHasPrefixFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
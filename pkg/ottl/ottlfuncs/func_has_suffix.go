@@ -17,7 +17,7 @@ type HasSuffixArguments[K any] struct {
 }
 
 func NewHasSuffixFactory[K any]() ottl.Factory[K] {
-	return ottl.NewFactory("HasSuffix", &HasSuffixArguments[K]{}, createHasSuffixFunction[K])
+	return ottl.NewFactory("HasSuffix", &HasSuffixArguments[K]{}, createHasSuffixFunction[K], ottl.WithPureFunction[K]())
 }
 
 func createHasSuffixFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
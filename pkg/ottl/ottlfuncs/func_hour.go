@@ -15,7 +15,7 @@ type HourArguments[K any] struct {
 }
 
 func NewHourFactory[K any]() ottl.Factory[K] {
-	return ottl.NewFactory("Hour", &HourArguments[K]{}, createHourFunction[K])
+	return ottl.NewFactory("Hour", &HourArguments[K]{}, createHourFunction[K], ottl.WithPureFunction[K]())
 }
 
 func createHourFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
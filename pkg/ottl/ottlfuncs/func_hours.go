@@ -15,7 +15,7 @@ type HoursArguments[K any] struct {
 }
 
 func NewHoursFactory[K any]() ottl.Factory[K] {
-	return ottl.NewFactory("Hours", &HoursArguments[K]{}, createHoursFunction[K])
+	return ottl.NewFactory("Hours", &HoursArguments[K]{}, createHoursFunction[K], ottl.WithPureFunction[K]())
 }
 
 func createHoursFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
@@ -15,7 +15,7 @@ type IsBoolArguments[K any] struct {
 }
 
 func NewIsBoolFactory[K any]() ottl.Factory[K] {
-	return ottl.NewFactory("IsBool", &IsBoolArguments[K]{}, createIsBoolFunction[K])
+	return ottl.NewFactory("IsBool", &IsBoolArguments[K]{}, createIsBoolFunction[K], ottl.WithPureFunction[K]())
 }
 
 func createIsBoolFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
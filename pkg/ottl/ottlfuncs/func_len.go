@@ -25,7 +25,7 @@ type LenArguments[K any] struct {
 }
 
 func NewLenFactory[K any]() ottl.Factory[K] {
-	return ottl.NewFactory("Len", &LenArguments[K]{}, createLenFunction[K])
+	return ottl.NewFactory("Len", &LenArguments[K]{}, createLenFunction[K], ottl.WithPureFunction[K]())
 }
 
 func createLenFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
@@ -0,0 +1,139 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/internal/ottlcommon"
+)
+
+type MapArguments[K any] struct {
+	Target ottl.Getter[K]
+	Lambda ottl.Lambda[K]
+}
+
+func NewMapFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("Map", &MapArguments[K]{}, createMapFunction[K])
+}
+
+func createMapFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*MapArguments[K])
+	if !ok {
+		return nil, errors.New("MapFactory args must be of type *MapArguments[K]")
+	}
+	if arity := args.Lambda.Arity(); arity != 1 && arity != 2 {
+		return nil, fmt.Errorf("the Map lambda must have 1 or 2 parameters, the value or the key and the value, but has %d", arity)
+	}
+
+	return mapFunc(args.Target, args.Lambda), nil
+}
+
+func mapFunc[K any](target ottl.Getter[K], lambda ottl.Lambda[K]) ottl.ExprFunc[K] {
+	return func(ctx context.Context, tCtx K) (any, error) {
+		val, err := target.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+		return collectElements(val, func(key, value any) (any, bool, error) {
+			mapped, err := callLambda(ctx, tCtx, lambda, nil, key, value)
+			return mapped, true, err
+		})
+	}
+}
+
+// callLambda calls a lambda with the value of an element, preceded by its key or index if the
+// lambda has one more parameter, and preceded by the leading arguments.
+func callLambda[K any](ctx context.Context, tCtx K, lambda ottl.Lambda[K], leading []any, key, value any) (any, error) {
+	args := leading
+	if lambda.Arity() == len(leading)+2 {
+		args = append(args, key)
+	}
+	return lambda.Call(ctx, tCtx, append(args, value)...)
+}
+
+// collectElements returns the elements of a map or a slice for which fn returns true, replaced
+// by the value returned by fn. Maps are returned as a pcommon.Map, and slices as a pcommon.Slice.
+func collectElements(val any, fn func(key, value any) (any, bool, error)) (any, error) {
+	var m map[string]any
+	var s []any
+	switch val.(type) {
+	case pcommon.Map, map[string]any:
+		m = map[string]any{}
+	}
+	err := ottlcommon.Range(val, func(key, value any) error {
+		result, keep, err := fn(key, value)
+		if err != nil || !keep {
+			return err
+		}
+		if m != nil {
+			m[key.(string)] = toRaw(result)
+		} else {
+			s = append(s, toRaw(result))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if m != nil {
+		result := pcommon.NewMap()
+		if err := result.FromRaw(m); err != nil {
+			return nil, err
+		}
+		return result, nil
+	}
+	result := pcommon.NewSlice()
+	if err := result.FromRaw(s); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// toRaw converts a value to a type supported by the FromRaw functions of pcommon.
+func toRaw(val any) any {
+	switch v := val.(type) {
+	case pcommon.Map:
+		return v.AsRaw()
+	case pcommon.Slice:
+		return v.AsRaw()
+	case pcommon.Value:
+		return v.AsRaw()
+	case []string:
+		return sliceToRaw(v)
+	case []int64:
+		return sliceToRaw(v)
+	case []float64:
+		return sliceToRaw(v)
+	case []bool:
+		return sliceToRaw(v)
+	case []any:
+		raw := make([]any, len(v))
+		for i, e := range v {
+			raw[i] = toRaw(e)
+		}
+		return raw
+	case map[string]any:
+		raw := make(map[string]any, len(v))
+		for k, e := range v {
+			raw[k] = toRaw(e)
+		}
+		return raw
+	}
+	return val
+}
+
+func sliceToRaw[T any](s []T) []any {
+	raw := make([]any, len(s))
+	for i, e := range s {
+		raw[i] = e
+	}
+	return raw
+}
@@ -0,0 +1,127 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+type testLambda struct {
+	arity int
+	fn    func(args ...any) (any, error)
+}
+
+func (l testLambda) Arity() int {
+	return l.arity
+}
+
+func (l testLambda) Call(_ context.Context, _ any, args ...any) (any, error) {
+	return l.fn(args...)
+}
+
+func literalGetter(val any) ottl.Getter[any] {
+	return ottl.StandardGetSetter[any]{
+		Getter: func(context.Context, any) (any, error) {
+			return val, nil
+		},
+	}
+}
+
+func Test_Map(t *testing.T) {
+	attributes := pcommon.NewMap()
+	attributes.PutStr("a", "x")
+	attributes.PutInt("b", 1)
+
+	tests := []struct {
+		name     string
+		target   any
+		lambda   testLambda
+		expected any
+	}{
+		{
+			name:   "map values",
+			target: attributes,
+			lambda: testLambda{arity: 1, fn: func(args ...any) (any, error) {
+				return []any{args[0]}, nil
+			}},
+			expected: map[string]any{"a": []any{"x"}, "b": []any{int64(1)}},
+		},
+		{
+			name:   "map keys and values",
+			target: map[string]any{"a": "x", "b": "y"},
+			lambda: testLambda{arity: 2, fn: func(args ...any) (any, error) {
+				return args[0].(string) + args[1].(string), nil
+			}},
+			expected: map[string]any{"a": "ax", "b": "by"},
+		},
+		{
+			name:   "slice values",
+			target: []int64{1, 2},
+			lambda: testLambda{arity: 1, fn: func(args ...any) (any, error) {
+				return args[0].(int64) * 2, nil
+			}},
+			expected: []any{int64(2), int64(4)},
+		},
+		{
+			name:   "slice indexes",
+			target: []string{"a", "b"},
+			lambda: testLambda{arity: 2, fn: func(args ...any) (any, error) {
+				return args[0], nil
+			}},
+			expected: []any{int64(0), int64(1)},
+		},
+		{
+			name:   "nested maps",
+			target: []any{"a"},
+			lambda: testLambda{arity: 1, fn: func(args ...any) (any, error) {
+				m := pcommon.NewMap()
+				m.PutStr("name", args[0].(string))
+				return m, nil
+			}},
+			expected: []any{map[string]any{"name": "a"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc, err := createMapFunction[any](ottl.FunctionContext{}, &MapArguments[any]{
+				Target: literalGetter(tt.target),
+				Lambda: tt.lambda,
+			})
+			require.NoError(t, err)
+			result, err := exprFunc(context.Background(), nil)
+			require.NoError(t, err)
+			switch r := result.(type) {
+			case pcommon.Map:
+				assert.Equal(t, tt.expected, r.AsRaw())
+			case pcommon.Slice:
+				assert.Equal(t, tt.expected, r.AsRaw())
+			default:
+				t.Fatalf("unexpected result type %T", result)
+			}
+		})
+	}
+}
+
+func Test_Map_Error(t *testing.T) {
+	_, err := createMapFunction[any](ottl.FunctionContext{}, &MapArguments[any]{
+		Target: literalGetter([]any{}),
+		Lambda: testLambda{arity: 3},
+	})
+	assert.ErrorContains(t, err, "the Map lambda must have 1 or 2 parameters")
+
+	exprFunc, err := createMapFunction[any](ottl.FunctionContext{}, &MapArguments[any]{
+		Target: literalGetter("a"),
+		Lambda: testLambda{arity: 1},
+	})
+	require.NoError(t, err)
+	_, err = exprFunc(context.Background(), nil)
+	assert.ErrorContains(t, err, "expected a map or a slice but got string")
+}
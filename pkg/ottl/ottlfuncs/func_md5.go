@@ -17,7 +17,7 @@ type MD5Arguments[K any] struct {
 }
 
 func NewMD5Factory[K any]() ottl.Factory[K] {
-	return ottl.NewFactory("MD5", &MD5Arguments[K]{}, createMD5Function[K])
+	return ottl.NewFactory("MD5", &MD5Arguments[K]{}, createMD5Function[K], ottl.WithPureFunction[K]())
 }
 
 func createMD5Function[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
@@ -15,7 +15,7 @@ type MinuteArguments[K any] struct {
 }
 
 func NewMinuteFactory[K any]() ottl.Factory[K] {
-	return ottl.NewFactory("Minute", &MinuteArguments[K]{}, createMinuteFunction[K])
+	return ottl.NewFactory("Minute", &MinuteArguments[K]{}, createMinuteFunction[K], ottl.WithPureFunction[K]())
 }
 
 func createMinuteFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
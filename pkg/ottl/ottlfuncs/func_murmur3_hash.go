@@ -19,7 +19,7 @@ type Murmur3HashArguments[K any] struct {
 }
 
 func NewMurmur3HashFactory[K any]() ottl.Factory[K] {
-	return ottl.NewFactory("Murmur3Hash", &Murmur3HashArguments[K]{}, createMurmur3HashFunction[K])
+	return ottl.NewFactory("Murmur3Hash", &Murmur3HashArguments[K]{}, createMurmur3HashFunction[K], ottl.WithPureFunction[K]())
 }
 
 func createMurmur3HashFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
@@ -15,7 +15,7 @@ type NanosecondsArguments[K any] struct {
 }
 
 func NewNanosecondsFactory[K any]() ottl.Factory[K] {
-	return ottl.NewFactory("Nanoseconds", &NanosecondsArguments[K]{}, createNanosecondsFunction[K])
+	return ottl.NewFactory("Nanoseconds", &NanosecondsArguments[K]{}, createNanosecondsFunction[K], ottl.WithPureFunction[K]())
 }
 
 func createNanosecondsFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
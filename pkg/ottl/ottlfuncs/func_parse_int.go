@@ -18,7 +18,7 @@ type ParseIntArguments[K any] struct {
 }
 
 func NewParseIntFactory[K any]() ottl.Factory[K] {
-	return ottl.NewFactory("ParseInt", &ParseIntArguments[K]{}, createParseIntFunction[K])
+	return ottl.NewFactory("ParseInt", &ParseIntArguments[K]{}, createParseIntFunction[K], ottl.WithPureFunction[K]())
 }
 
 func createParseIntFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
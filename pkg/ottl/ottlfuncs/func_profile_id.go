@@ -18,7 +18,7 @@ type ProfileIDArguments[K any] struct {
 }
 
 func NewProfileIDFactory[K any]() ottl.Factory[K] {
-	return ottl.NewFactory("ProfileID", &ProfileIDArguments[K]{}, createProfileIDFunction[K])
+	return ottl.NewFactory("ProfileID", &ProfileIDArguments[K]{}, createProfileIDFunction[K], ottl.WithPureFunction[K]())
 }
 
 func createProfileIDFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
@@ -0,0 +1,56 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/internal/ottlcommon"
+)
+
+type ReduceArguments[K any] struct {
+	Target  ottl.Getter[K]
+	Initial ottl.Getter[K]
+	Lambda  ottl.Lambda[K]
+}
+
+func NewReduceFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("Reduce", &ReduceArguments[K]{}, createReduceFunction[K])
+}
+
+func createReduceFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*ReduceArguments[K])
+	if !ok {
+		return nil, errors.New("ReduceFactory args must be of type *ReduceArguments[K]")
+	}
+	if arity := args.Lambda.Arity(); arity != 2 && arity != 3 {
+		return nil, fmt.Errorf("the Reduce lambda must have 2 or 3 parameters, the accumulator and the value or the key and the value, but has %d", arity)
+	}
+
+	return reduce(args.Target, args.Initial, args.Lambda), nil
+}
+
+func reduce[K any](target, initial ottl.Getter[K], lambda ottl.Lambda[K]) ottl.ExprFunc[K] {
+	return func(ctx context.Context, tCtx K) (any, error) {
+		val, err := target.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+		acc, err := initial.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+		err = ottlcommon.Range(val, func(key, value any) error {
+			acc, err = callLambda(ctx, tCtx, lambda, []any{acc}, key, value)
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+		return acc, nil
+	}
+}
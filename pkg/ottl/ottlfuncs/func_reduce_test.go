@@ -0,0 +1,84 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_Reduce(t *testing.T) {
+	tests := []struct {
+		name     string
+		target   any
+		initial  any
+		lambda   testLambda
+		expected any
+	}{
+		{
+			name:    "sum",
+			target:  []int64{1, 2, 3},
+			initial: int64(0),
+			lambda: testLambda{arity: 2, fn: func(args ...any) (any, error) {
+				return args[0].(int64) + args[1].(int64), nil
+			}},
+			expected: int64(6),
+		},
+		{
+			name:    "keys",
+			target:  map[string]any{"b": 1, "a": 2},
+			initial: "",
+			lambda: testLambda{arity: 3, fn: func(args ...any) (any, error) {
+				return args[0].(string) + args[1].(string), nil
+			}},
+			expected: "ab",
+		},
+		{
+			name:     "empty",
+			target:   []any{},
+			initial:  "initial",
+			lambda:   testLambda{arity: 2},
+			expected: "initial",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc, err := createReduceFunction[any](ottl.FunctionContext{}, &ReduceArguments[any]{
+				Target:  literalGetter(tt.target),
+				Initial: literalGetter(tt.initial),
+				Lambda:  tt.lambda,
+			})
+			require.NoError(t, err)
+			result, err := exprFunc(context.Background(), nil)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func Test_Reduce_Error(t *testing.T) {
+	_, err := createReduceFunction[any](ottl.FunctionContext{}, &ReduceArguments[any]{
+		Target:  literalGetter([]any{}),
+		Initial: literalGetter(nil),
+		Lambda:  testLambda{arity: 1},
+	})
+	assert.ErrorContains(t, err, "the Reduce lambda must have 2 or 3 parameters")
+
+	exprFunc, err := createReduceFunction[any](ottl.FunctionContext{}, &ReduceArguments[any]{
+		Target:  literalGetter([]any{1}),
+		Initial: literalGetter(nil),
+		Lambda: testLambda{arity: 2, fn: func(...any) (any, error) {
+			return nil, errors.New("failed")
+		}},
+	})
+	require.NoError(t, err)
+	_, err = exprFunc(context.Background(), nil)
+	assert.ErrorContains(t, err, "failed")
+}
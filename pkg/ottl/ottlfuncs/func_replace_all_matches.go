@@ -45,6 +45,7 @@ func replaceAllMatches[K any](target ottl.PMapGetSetter[K], pattern string, repl
 	if err != nil {
 		return nil, fmt.Errorf("the pattern supplied to replace_match is not a valid pattern: %w", err)
 	}
+	validFormat := isValidLiteralReplaceFormat(replacementFormat)
 	return func(ctx context.Context, tCtx K) (any, error) {
 		val, err := target.Get(ctx, tCtx)
 		if err != nil {
@@ -71,7 +72,7 @@ func replaceAllMatches[K any](target ottl.PMapGetSetter[K], pattern string, repl
 			if !ok {
 				return nil, errors.New("replacement value is not a string")
 			}
-			replacementVal, err = applyReplaceFormat(ctx, tCtx, replacementFormat, replacementValStr)
+			replacementVal, err = applyReplaceFormat(ctx, tCtx, replacementFormat, validFormat, replacementValStr)
 			if err != nil {
 				return nil, err
 			}
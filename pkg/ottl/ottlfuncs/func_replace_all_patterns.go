@@ -50,6 +50,7 @@ func replaceAllPatterns[K any](target ottl.PMapGetSetter[K], mode, regexPattern
 	if mode != modeValue && mode != modeKey {
 		return nil, fmt.Errorf("invalid mode %v, must be either 'key' or 'value'", mode)
 	}
+	validFormat := isValidLiteralReplaceFormat(replacementFormat)
 
 	return func(ctx context.Context, tCtx K) (any, error) {
 		val, err := target.Get(ctx, tCtx)
@@ -71,7 +72,7 @@ func replaceAllPatterns[K any](target ottl.PMapGetSetter[K], mode, regexPattern
 			case modeValue:
 				if originalValue.Type() == pcommon.ValueTypeStr && compiledPattern.MatchString(originalValue.Str()) {
 					if !fn.IsEmpty() {
-						updatedString, err := applyOptReplaceFunction(ctx, tCtx, compiledPattern, fn, originalValue.Str(), replacementVal, replacementFormat)
+						updatedString, err := applyOptReplaceFunction(ctx, tCtx, compiledPattern, fn, originalValue.Str(), replacementVal, replacementFormat, validFormat)
 						if err != nil {
 							break AttributeLoop
 						}
@@ -86,7 +87,7 @@ func replaceAllPatterns[K any](target ottl.PMapGetSetter[K], mode, regexPattern
 			case modeKey:
 				if compiledPattern.MatchString(key) {
 					if !fn.IsEmpty() {
-						updatedKey, err := applyOptReplaceFunction(ctx, tCtx, compiledPattern, fn, key, replacementVal, replacementFormat)
+						updatedKey, err := applyOptReplaceFunction(ctx, tCtx, compiledPattern, fn, key, replacementVal, replacementFormat, validFormat)
 						if err != nil {
 							break AttributeLoop
 						}
@@ -44,6 +44,7 @@ func replaceMatch[K any](target ottl.GetSetter[K], pattern string, replacement o
 	if err != nil {
 		return nil, fmt.Errorf("the pattern supplied to replace_match is not a valid pattern: %w", err)
 	}
+	validFormat := isValidLiteralReplaceFormat(replacementFormat)
 	return func(ctx context.Context, tCtx K) (any, error) {
 		val, err := target.Get(ctx, tCtx)
 		var replacementVal string
@@ -69,7 +70,7 @@ func replaceMatch[K any](target ottl.GetSetter[K], pattern string, replacement o
 			if !ok {
 				return nil, errors.New("replacement value is not a string")
 			}
-			replacementVal, err = applyReplaceFormat(ctx, tCtx, replacementFormat, replacementValStr)
+			replacementVal, err = applyReplaceFormat(ctx, tCtx, replacementFormat, validFormat, replacementValStr)
 			if err != nil {
 				return nil, err
 			}
@@ -39,32 +39,45 @@ func createReplacePatternFunction[K any](_ ottl.FunctionContext, oArgs ottl.Argu
 	return replacePattern(args.Target, args.RegexPattern, args.Replacement, args.Function, args.ReplacementFormat)
 }
 
+var (
+	validFormatRegex   = regexp.MustCompile(`^(.*?%s.*?)$`)
+	invalidFormatRegex = regexp.MustCompile(`%[^s]`)
+
+	errInvalidReplacementFormat = errors.New("replacementFormat must be format string containing a single %s and no other format specifiers")
+)
+
 func validFormatString(formatString string) bool {
 	// Check for exactly one %s and no other invalid format specifiers
-	validPattern := `^(.*?%s.*?)$`
-	validRegex := regexp.MustCompile(validPattern)
-	invalidPattern := `%[^s]`
-	invalidRegex := regexp.MustCompile(invalidPattern)
+	return validFormatRegex.MatchString(formatString) && !invalidFormatRegex.MatchString(formatString)
+}
 
-	return validRegex.MatchString(formatString) && !invalidRegex.MatchString(formatString)
+// isValidLiteralReplaceFormat returns true if the replacement format is a valid literal, which then
+// doesn't need to be validated again for each replacement. Any other format is validated when it is
+// applied.
+func isValidLiteralReplaceFormat[K any](replacementFormat ottl.Optional[ottl.StringGetter[K]]) bool {
+	if replacementFormat.IsEmpty() {
+		return false
+	}
+	formatString, ok := ottl.GetLiteralString(replacementFormat.Get())
+	return ok && validFormatString(formatString)
 }
 
-func applyReplaceFormat[K any](ctx context.Context, tCtx K, replacementFormat ottl.Optional[ottl.StringGetter[K]], replacementVal string) (string, error) {
+func applyReplaceFormat[K any](ctx context.Context, tCtx K, replacementFormat ottl.Optional[ottl.StringGetter[K]], validFormat bool, replacementVal string) (string, error) {
 	if !replacementFormat.IsEmpty() { // If replacementFormat is not empty, add it to the replacement value
 		formatString := replacementFormat.Get()
 		formatStringVal, errFmt := formatString.Get(ctx, tCtx)
 		if errFmt != nil {
 			return "", errFmt
 		}
-		if !validFormatString(formatStringVal) {
-			return "", errors.New("replacementFormat must be format string containing a single %s and no other format specifiers")
+		if !validFormat && !validFormatString(formatStringVal) {
+			return "", errInvalidReplacementFormat
 		}
 		replacementVal = fmt.Sprintf(formatStringVal, replacementVal)
 	}
 	return replacementVal, nil
 }
 
-func applyOptReplaceFunction[K any](ctx context.Context, tCtx K, compiledPattern *regexp.Regexp, fn ottl.Optional[ottl.FunctionGetter[K]], originalValStr, replacementVal string, replacementFormat ottl.Optional[ottl.StringGetter[K]]) (string, error) {
+func applyOptReplaceFunction[K any](ctx context.Context, tCtx K, compiledPattern *regexp.Regexp, fn ottl.Optional[ottl.FunctionGetter[K]], originalValStr, replacementVal string, replacementFormat ottl.Optional[ottl.StringGetter[K]], validFormat bool) (string, error) {
 	var updatedString string
 	updatedString = originalValStr
 	submatches := compiledPattern.FindAllStringSubmatchIndex(updatedString, -1)
@@ -89,7 +102,7 @@ func applyOptReplaceFunction[K any](ctx context.Context, tCtx K, compiledPattern
 		if !ok {
 			return "", errors.New("the replacement value must be a string")
 		}
-		replacementValStr, errNew = applyReplaceFormat(ctx, tCtx, replacementFormat, replacementValStr)
+		replacementValStr, errNew = applyReplaceFormat(ctx, tCtx, replacementFormat, validFormat, replacementValStr)
 		if errNew != nil {
 			return "", errNew
 		}
@@ -103,6 +116,7 @@ func replacePattern[K any](target ottl.GetSetter[K], regexPattern string, replac
 	if err != nil {
 		return nil, fmt.Errorf("the regex pattern supplied to replace_pattern is not a valid pattern: %w", err)
 	}
+	validFormat := isValidLiteralReplaceFormat(replacementFormat)
 	return func(ctx context.Context, tCtx K) (any, error) {
 		originalVal, err := target.Get(ctx, tCtx)
 		var replacementVal string
@@ -120,7 +134,7 @@ func replacePattern[K any](target ottl.GetSetter[K], regexPattern string, replac
 			if compiledPattern.MatchString(originalValStr) {
 				if !fn.IsEmpty() {
 					var updatedString string
-					updatedString, err = applyOptReplaceFunction[K](ctx, tCtx, compiledPattern, fn, originalValStr, replacementVal, replacementFormat)
+					updatedString, err = applyOptReplaceFunction[K](ctx, tCtx, compiledPattern, fn, originalValStr, replacementVal, replacementFormat, validFormat)
 					if err != nil {
 						return nil, err
 					}
@@ -17,7 +17,7 @@ type SHA512Arguments[K any] struct {
 }
 
 func NewSHA512Factory[K any]() ottl.Factory[K] {
-	return ottl.NewFactory("SHA512", &SHA512Arguments[K]{}, createSHA512Function[K])
+	return ottl.NewFactory("SHA512", &SHA512Arguments[K]{}, createSHA512Function[K], ottl.WithPureFunction[K]())
 }
 
 func createSHA512Function[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
@@ -17,7 +17,7 @@ type SpanIDArguments[K any] struct {
 }
 
 func NewSpanIDFactory[K any]() ottl.Factory[K] {
-	return ottl.NewFactory("SpanID", &SpanIDArguments[K]{}, createSpanIDFunction[K])
+	return ottl.NewFactory("SpanID", &SpanIDArguments[K]{}, createSpanIDFunction[K], ottl.WithPureFunction[K]())
 }
 
 func createSpanIDFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
@@ -20,7 +20,7 @@ type TimeArguments[K any] struct {
 }
 
 func NewTimeFactory[K any]() ottl.Factory[K] {
-	return ottl.NewFactory("Time", &TimeArguments[K]{}, createTimeFunction[K])
+	return ottl.NewFactory("Time", &TimeArguments[K]{}, createTimeFunction[K], ottl.WithPureFunction[K]())
 }
 
 func createTimeFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
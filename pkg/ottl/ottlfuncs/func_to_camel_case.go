@@ -17,7 +17,7 @@ type ToCamelCaseArguments[K any] struct {
 }
 
 func NewToCamelCaseFactory[K any]() ottl.Factory[K] {
-	return ottl.NewFactory("ToCamelCase", &ToCamelCaseArguments[K]{}, createToCamelCaseFunction[K])
+	return ottl.NewFactory("ToCamelCase", &ToCamelCaseArguments[K]{}, createToCamelCaseFunction[K], ottl.WithPureFunction[K]())
 }
 
 func createToCamelCaseFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
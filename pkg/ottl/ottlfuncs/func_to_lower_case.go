@@ -16,7 +16,7 @@ type ToLowerCaseArguments[K any] struct {
 }
 
 func NewToLowerCaseFactory[K any]() ottl.Factory[K] {
-	return ottl.NewFactory("ToLowerCase", &ToLowerCaseArguments[K]{}, createToLowerCaseFunction[K])
+	return ottl.NewFactory("ToLowerCase", &ToLowerCaseArguments[K]{}, createToLowerCaseFunction[K], ottl.WithPureFunction[K]())
 }
 
 func createToLowerCaseFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
@@ -16,7 +16,7 @@ type ToUpperCaseArguments[K any] struct {
 }
 
 func NewToUpperCaseFactory[K any]() ottl.Factory[K] {
-	return ottl.NewFactory("ToUpperCase", &ToUpperCaseArguments[K]{}, createToUpperCaseFunction[K])
+	return ottl.NewFactory("ToUpperCase", &ToUpperCaseArguments[K]{}, createToUpperCaseFunction[K], ottl.WithPureFunction[K]())
 }
 
 func createToUpperCaseFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
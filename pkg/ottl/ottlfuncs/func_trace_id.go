@@ -17,7 +17,7 @@ type TraceIDArguments[K any] struct {
 }
 
 func NewTraceIDFactory[K any]() ottl.Factory[K] {
-	return ottl.NewFactory("TraceID", &TraceIDArguments[K]{}, createTraceIDFunction[K])
+	return ottl.NewFactory("TraceID", &TraceIDArguments[K]{}, createTraceIDFunction[K], ottl.WithPureFunction[K]())
 }
 
 func createTraceIDFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
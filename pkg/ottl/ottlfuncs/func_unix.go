@@ -17,7 +17,7 @@ type UnixArguments[K any] struct {
 }
 
 func NewUnixFactory[K any]() ottl.Factory[K] {
-	return ottl.NewFactory("Unix", &UnixArguments[K]{}, createUnixFunction[K])
+	return ottl.NewFactory("Unix", &UnixArguments[K]{}, createUnixFunction[K], ottl.WithPureFunction[K]())
 }
 
 func createUnixFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
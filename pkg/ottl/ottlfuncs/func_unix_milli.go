@@ -15,7 +15,7 @@ type UnixMilliArguments[K any] struct {
 }
 
 func NewUnixMilliFactory[K any]() ottl.Factory[K] {
-	return ottl.NewFactory("UnixMilli", &UnixMilliArguments[K]{}, createUnixMilliFunction[K])
+	return ottl.NewFactory("UnixMilli", &UnixMilliArguments[K]{}, createUnixMilliFunction[K], ottl.WithPureFunction[K]())
 }
 
 func createUnixMilliFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
@@ -15,7 +15,7 @@ type UnixSecondsArguments[K any] struct {
 }
 
 func NewUnixSecondsFactory[K any]() ottl.Factory[K] {
-	return ottl.NewFactory("UnixSeconds", &UnixSecondsArguments[K]{}, createUnixSecondsFunction[K])
+	return ottl.NewFactory("UnixSeconds", &UnixSecondsArguments[K]{}, createUnixSecondsFunction[K], ottl.WithPureFunction[K]())
 }
 
 func createUnixSecondsFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
@@ -7,6 +7,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"sort"
 	"strings"
 	"sync"
@@ -24,6 +25,8 @@ type Statement[K any] struct {
 	condition         BoolExpr[K]
 	origText          string
 	telemetrySettings component.TelemetrySettings
+	// variable is the variable bound by the statement, if it is a let statement.
+	variable *variable
 }
 
 // Execute is a function that will execute the statement's function if the statement's condition is met.
@@ -69,6 +72,19 @@ type Parser[K any] struct {
 	enumParser        EnumParser
 	telemetrySettings component.TelemetrySettings
 	pathContextNames  map[string]struct{}
+	// definitions are the user-defined functions, called like the functions of the parser.
+	definitions map[string]*functionDefinition
+	// definitionsContext is the context of the context-less paths of the definitions, if any.
+	definitionsContext string
+	definitionsErr     error
+	// expanding are the definitions being expanded, used to detect recursive calls.
+	expanding []string
+	// variables are the variables bound by the statements parsed so far, if parsing statements.
+	variables *variableScope
+	// warningHandler is called with the warnings found in the statements and conditions.
+	warningHandler func(Warning)
+	// warnings are the warnings found so far in the statement or condition being parsed, if any.
+	warnings *[]string
 }
 
 // NewParser creates a new Parser
@@ -92,6 +108,14 @@ func NewParser[K any](
 	for _, opt := range options {
 		opt(&p)
 	}
+	if p.definitionsErr != nil {
+		return Parser[K]{}, p.definitionsErr
+	}
+	for name := range p.definitions {
+		if _, ok := p.functions[name]; ok {
+			return Parser[K]{}, fmt.Errorf("function definition %q conflicts with an existing function", name)
+		}
+	}
 	return p, nil
 }
 
@@ -121,15 +145,30 @@ func WithPathContextNames[K any](contexts []string) Option[K] {
 	}
 }
 
+// WithFunctionDefinitions sets the user-defined functions the parsed statements, conditions and
+// value expressions can call. Converters are defined by a value expression, such as
+// `IsError(code) = code >= 500`, and editors by a block of statements separated by semicolons,
+// such as `rename(target, from, to) { set(target[to], target[from]); delete_key(target, from) }`.
+// The parameters are substituted by the arguments of each call when it is parsed.
+// Invalid definitions make NewParser return an error.
+func WithFunctionDefinitions[K any](definitions []string) Option[K] {
+	return func(p *Parser[K]) {
+		p.definitions, p.definitionsErr = parseFunctionDefinitions(definitions)
+	}
+}
+
 // ParseStatements parses string statements into ottl.Statement objects ready for execution.
+// The variables bound by let statements can be referenced by the following statements.
 // Returns a slice of statements and a nil error on successful parsing.
 // If parsing fails, returns nil and a joined error containing each error per failed statement.
 func (p *Parser[K]) ParseStatements(statements []string) ([]*Statement[K], error) {
 	parsedStatements := make([]*Statement[K], 0, len(statements))
 	var parseErrs []error
 
+	scoped := *p
+	scoped.variables = newVariableScope()
 	for _, statement := range statements {
-		ps, err := p.ParseStatement(statement)
+		ps, err := scoped.newStatement(statement)
 		if err != nil {
 			parseErrs = append(parseErrs, fmt.Errorf("unable to parse OTTL statement %q: %w", statement, err))
 			continue
@@ -148,23 +187,44 @@ func (p *Parser[K]) ParseStatements(statements []string) ([]*Statement[K], error
 // Returns a Statement and a nil error on successful parsing.
 // If parsing fails, returns nil and an error.
 func (p *Parser[K]) ParseStatement(statement string) (*Statement[K], error) {
+	scoped := *p
+	scoped.variables = newVariableScope()
+	return scoped.newStatement(statement)
+}
+
+// newStatement parses a statement in the variable scope of the parser.
+func (p *Parser[K]) newStatement(statement string) (*Statement[K], error) {
 	parsed, err := parseStatement(statement)
 	if err != nil {
 		return nil, err
 	}
-	function, err := p.newFunctionCall(parsed.Editor)
+	p, reportWarnings := p.withWarnings(statement)
+	expression, err := p.newBoolExpr(parsed.WhereClause)
 	if err != nil {
 		return nil, err
 	}
-	expression, err := p.newBoolExpr(parsed.WhereClause)
+	p.checkStatementCondition(parsed.WhereClause)
+	var function Expr[K]
+	var v *variable
+	switch {
+	case parsed.Let != nil:
+		// the variable is declared last, so that it's not visible to its own statement
+		function, v, err = p.newLetFunction(parsed.Let)
+	case parsed.ForEach != nil:
+		function, err = p.newForEachFunction(parsed.ForEach)
+	default:
+		function, err = p.newFunctionCall(parsed.Editor)
+	}
 	if err != nil {
 		return nil, err
 	}
+	reportWarnings()
 	return &Statement[K]{
 		function:          function,
 		condition:         expression,
 		origText:          statement,
 		telemetrySettings: p.telemetrySettings,
+		variable:          v,
 	}, nil
 }
 
@@ -199,10 +259,13 @@ func (p *Parser[K]) ParseCondition(condition string) (*Condition[K], error) {
 	if err != nil {
 		return nil, err
 	}
+	p, reportWarnings := p.withWarnings(condition)
 	expression, err := p.newBoolExpr(parsed)
 	if err != nil {
 		return nil, err
 	}
+	p.checkCondition(parsed)
+	reportWarnings()
 	return &Condition[K]{
 		condition: expression,
 		origText:  condition,
@@ -236,13 +299,38 @@ func (p *Parser[K]) prependContextToPaths(context, ottl string, ottlPathsGetter
 // value matches any WithPathContextNames value.
 // The context argument must be valid WithPathContextNames value, otherwise an error is returned.
 func (p *Parser[K]) prependContextToStatementPaths(context, statement string) (string, error) {
-	return p.prependContextToPaths(context, statement, func(ottl string) ([]path, error) {
-		parsed, err := parseStatement(ottl)
+	prepended, err := p.prependContextToStatementsPaths(context, []string{statement})
+	if err != nil {
+		return "", err
+	}
+	return prepended[0], nil
+}
+
+// prependContextToStatementsPaths is like prependContextToStatementPaths for a group of statements,
+// leaving the references to the variables bound by the previous statements unchanged.
+func (p *Parser[K]) prependContextToStatementsPaths(context string, statements []string) ([]string, error) {
+	variables := map[string]struct{}{}
+	prepended := make([]string, 0, len(statements))
+	for _, statement := range statements {
+		result, err := p.prependContextToPaths(context, statement, func(ottl string) ([]path, error) {
+			parsed, err := parseStatement(ottl)
+			if err != nil {
+				return nil, err
+			}
+			paths := slices.DeleteFunc(getParsedStatementPaths(parsed), func(it path) bool {
+				return isVariableReference(&it, variables)
+			})
+			if parsed.Let != nil {
+				variables[parsed.Let.Name] = struct{}{}
+			}
+			return paths, nil
+		})
 		if err != nil {
 			return nil, err
 		}
-		return getParsedStatementPaths(parsed), nil
-	})
+		prepended = append(prepended, result)
+	}
+	return prepended, nil
 }
 
 // prependContextToConditionPaths changes the given OTTL condition adding the context name prefix
@@ -277,6 +365,7 @@ var (
 	parser                = sync.OnceValue(newParser[parsedStatement])
 	conditionParser       = sync.OnceValue(newParser[booleanExpression])
 	valueExpressionParser = sync.OnceValue(newParser[value])
+	definitionParser      = sync.OnceValue(newParser[parsedDefinition])
 )
 
 func parseStatement(raw string) (*parsedStatement, error) {
@@ -318,6 +407,19 @@ func parseValueExpression(raw string) (*value, error) {
 	return parsed, nil
 }
 
+func parseDefinition(raw string) (*parsedDefinition, error) {
+	parsed, err := definitionParser().ParseString("", raw)
+	if err != nil {
+		return nil, fmt.Errorf("definition has invalid syntax: %w", err)
+	}
+	err = parsed.checkForCustomError()
+	if err != nil {
+		return nil, err
+	}
+
+	return parsed, nil
+}
+
 func insertContextIntoPathsOffsets(context, statement string, offsets []int) (string, error) {
 	if len(offsets) == 0 {
 		return statement, nil
@@ -364,6 +466,8 @@ type StatementSequence[K any] struct {
 	statements        []*Statement[K]
 	errorMode         ErrorMode
 	telemetrySettings component.TelemetrySettings
+	// hasVariables is true if any statement is a let statement.
+	hasVariables bool
 }
 
 // StatementSequenceOption is an option for a StatementSequence
@@ -388,6 +492,11 @@ func NewStatementSequence[K any](statements []*Statement[K], telemetrySettings c
 	for _, op := range options {
 		op(&s)
 	}
+	for _, statement := range statements {
+		if statement.variable != nil {
+			s.hasVariables = true
+		}
+	}
 	return s
 }
 
@@ -395,10 +504,14 @@ func NewStatementSequence[K any](statements []*Statement[K], telemetrySettings c
 // When the ErrorMode of the StatementSequence is `propagate`, errors cause the execution to halt and the error is returned.
 // When the ErrorMode of the StatementSequence is `ignore`, errors are logged and execution continues to the next statement.
 // When the ErrorMode of the StatementSequence is `silent`, errors are not logged and execution continues to the next statement.
+// The variables bound by let statements only live for the execution of the StatementSequence.
 func (s *StatementSequence[K]) Execute(ctx context.Context, tCtx K) error {
 	if s.telemetrySettings.Logger.Core().Enabled(zap.DebugLevel) {
 		s.telemetrySettings.Logger.Debug("initial TransformContext before executing StatementSequence", zap.Any("TransformContext", tCtx))
 	}
+	if s.hasVariables {
+		ctx = withVariables(ctx)
+	}
 	for _, statement := range s.statements {
 		_, _, err := statement.Execute(ctx, tCtx)
 		if err != nil {
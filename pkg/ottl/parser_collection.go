@@ -5,6 +5,7 @@ package ottl // import "github.com/open-telemetry/opentelemetry-collector-contri
 
 import (
 	"fmt"
+	"maps"
 
 	"go.opentelemetry.io/collector/component"
 	"go.uber.org/zap"
@@ -79,6 +80,7 @@ type ParserCollection[R any] struct {
 	contextInferrer           contextInferrer
 	contextInferrerCandidates map[string]*priorityContextInferrerCandidate
 	candidatesLowerContexts   map[string][]string
+	functionDefinitions       map[string]*functionDefinition
 	modifiedLogging           bool
 	Settings                  component.TelemetrySettings
 	ErrorMode                 ErrorMode
@@ -97,12 +99,14 @@ func NewParserCollection[R any](
 	options ...ParserCollectionOption[R],
 ) (*ParserCollection[R], error) {
 	contextInferrerCandidates := map[string]*priorityContextInferrerCandidate{}
+	functionDefinitions := map[string]*functionDefinition{}
 	pc := &ParserCollection[R]{
 		Settings:                  settings,
 		contextParsers:            map[string]*ParserCollectionContextParser[R]{},
-		contextInferrer:           newPriorityContextInferrer(settings, contextInferrerCandidates),
+		contextInferrer:           newPriorityContextInferrer(settings, contextInferrerCandidates, withContextInferrerFunctionDefinitions(functionDefinitions)),
 		contextInferrerCandidates: contextInferrerCandidates,
 		candidatesLowerContexts:   map[string][]string{},
+		functionDefinitions:       functionDefinitions,
 	}
 
 	for _, op := range options {
@@ -220,7 +224,11 @@ func createConditionsParserWithConverter[K, R any](converter ParsedConditionsCon
 		} else {
 			parsingConditions = conditions.GetConditions()
 		}
-		parsedConditions, err := parser.ParseConditions(parsingConditions)
+		contextParser, err := withFunctionDefinitions(pc, parser, context)
+		if err != nil {
+			return *new(R), err
+		}
+		parsedConditions, err := contextParser.ParseConditions(parsingConditions)
 		if err != nil {
 			return *new(R), err
 		}
@@ -257,7 +265,11 @@ func createValueExpressionsParserWithConverter[K, R any](converter ParsedValueEx
 		} else {
 			parsingValueExpressions = expressions.GetValueExpressions()
 		}
-		parsedValueExpressions, err := parser.ParseValueExpressions(parsingValueExpressions)
+		contextParser, err := withFunctionDefinitions(pc, parser, context)
+		if err != nil {
+			return *new(R), err
+		}
+		parsedValueExpressions, err := contextParser.ParseValueExpressions(parsingValueExpressions)
 		if err != nil {
 			return *new(R), err
 		}
@@ -276,15 +288,7 @@ func createStatementsParserWithConverter[K, R any](converter ParsedStatementsCon
 		var parsingStatements []string
 		if prependPathsContext {
 			originalStatements := statements.GetStatements()
-			parsingStatements = make([]string, 0, len(originalStatements))
-			for _, cond := range originalStatements {
-				prependedStatement, prependErr := parser.prependContextToStatementPaths(context, cond)
-				if prependErr != nil {
-					err = prependErr
-					break
-				}
-				parsingStatements = append(parsingStatements, prependedStatement)
-			}
+			parsingStatements, err = parser.prependContextToStatementsPaths(context, originalStatements)
 			if err != nil {
 				return *new(R), err
 			}
@@ -294,7 +298,11 @@ func createStatementsParserWithConverter[K, R any](converter ParsedStatementsCon
 		} else {
 			parsingStatements = statements.GetStatements()
 		}
-		parsedStatements, err := parser.ParseStatements(parsingStatements)
+		contextParser, err := withFunctionDefinitions(pc, parser, context)
+		if err != nil {
+			return *new(R), err
+		}
+		parsedStatements, err := contextParser.ParseStatements(parsingStatements)
 		if err != nil {
 			return *new(R), err
 		}
@@ -380,6 +388,28 @@ func WithParserCollectionContext[K, R any](
 	}
 }
 
+// withFunctionDefinitions returns a copy of the parser that can call the ParserCollection
+// function definitions, with their context-less paths bound to the given context.
+func withFunctionDefinitions[K, R any](pc *ParserCollection[R], parser *Parser[K], context string) (*Parser[K], error) {
+	if len(pc.functionDefinitions) == 0 {
+		return parser, nil
+	}
+	definitions := maps.Clone(parser.definitions)
+	if definitions == nil {
+		definitions = make(map[string]*functionDefinition, len(pc.functionDefinitions))
+	}
+	for name, definition := range pc.functionDefinitions {
+		if _, ok := parser.functions[name]; ok {
+			return nil, fmt.Errorf(`function definition %q conflicts with an existing function of the "%s" context`, name, context)
+		}
+		definitions[name] = definition
+	}
+	contextParser := *parser
+	contextParser.definitions = definitions
+	contextParser.definitionsContext = context
+	return &contextParser, nil
+}
+
 func (pc *ParserCollection[R]) getLowerContexts(context string) []string {
 	return pc.candidatesLowerContexts[context]
 }
@@ -407,6 +437,25 @@ func EnableParserCollectionModifiedPathsLogging[R any](enabled bool) ParserColle
 	}
 }
 
+// WithParserCollectionFunctionDefinitions sets user-defined functions that can be called from
+// all the contexts of the ParserCollection. See WithFunctionDefinitions for their syntax.
+// Their context-less paths are bound to the context the calling OTTL is parsed with, so they
+// don't take part in the context inference, unlike the functions they call and their paths
+// with an explicit context.
+//
+// Experimental: *NOTE* this API is subject to change or removal in the future.
+func WithParserCollectionFunctionDefinitions[R any](definitions []string) ParserCollectionOption[R] {
+	return func(pc *ParserCollection[R]) error {
+		parsed, err := parseFunctionDefinitions(definitions)
+		if err != nil {
+			return err
+		}
+		// the map is shared with the context inferrer
+		maps.Copy(pc.functionDefinitions, parsed)
+		return nil
+	}
+}
+
 type parseCollectionContextInferenceOptions struct {
 	conditions []string
 }
@@ -484,6 +484,72 @@ func Test_ParseStatementsWithContext_PrependPathContext(t *testing.T) {
 	assert.Equal(t, `set(dummy.attributes["bar"], "bar")`, parsedStatements[1].origText)
 }
 
+func Test_ParseStatements_Variables(t *testing.T) {
+	ps := mockParser(t, WithPathContextNames[any]([]string{"dummy"}))
+	pc, err := NewParserCollection(
+		componenttest.NewNopTelemetrySettings(),
+		WithParserCollectionContext("dummy", ps, WithStatementConverter(newNopParsedStatementsConverter[any]())),
+	)
+	require.NoError(t, err)
+
+	// the references to variables are not prepended with the context
+	result, err := pc.ParseStatementsWithContext(
+		"dummy",
+		mockGetter{[]string{
+			`let foo = attributes["foo"]`,
+			`set(attributes["bar"], foo["baz"]) where foo != nil`,
+		}},
+		true,
+	)
+	require.NoError(t, err)
+	parsedStatements := result.([]*Statement[any])
+	assert.Equal(t, `let foo = dummy.attributes["foo"]`, parsedStatements[0].origText)
+	assert.Equal(t, `set(dummy.attributes["bar"], foo["baz"]) where foo != nil`, parsedStatements[1].origText)
+
+	// nor used to infer the context
+	_, err = pc.ParseStatements(mockGetter{[]string{
+		`let foo = dummy.attributes["foo"]`,
+		`set(dummy.attributes["bar"], foo)`,
+	}})
+	require.NoError(t, err)
+
+	_, err = pc.ParseStatements(mockGetter{[]string{`let dummy = dummy.attributes["foo"]`}})
+	require.ErrorContains(t, err, `variable "dummy" conflicts with the "dummy" context`)
+}
+
+func Test_ParseStatements_FunctionDefinitions(t *testing.T) {
+	ps := mockParser(t, WithPathContextNames[any]([]string{"dummy"}))
+	pc, err := NewParserCollection(
+		componenttest.NewNopTelemetrySettings(),
+		WithParserCollectionContext("dummy", ps, WithStatementConverter(newNopParsedStatementsConverter[any]())),
+		WithParserCollectionFunctionDefinitions[any]([]string{`tag(value) { set(attributes["tag"], value) }`}),
+	)
+	require.NoError(t, err)
+
+	// the context-less paths of the definition are bound to the context of the statements
+	result, err := pc.ParseStatementsWithContext("dummy", mockGetter{[]string{`tag("foo")`}}, true)
+	require.NoError(t, err)
+	require.Len(t, result, 1)
+
+	_, err = pc.ParseStatements(mockGetter{[]string{`tag(dummy.name)`}})
+	require.NoError(t, err)
+
+	pc, err = NewParserCollection(
+		componenttest.NewNopTelemetrySettings(),
+		WithParserCollectionContext("dummy", ps, WithStatementConverter(newNopParsedStatementsConverter[any]())),
+		WithParserCollectionFunctionDefinitions[any]([]string{`set(target, value) { set(target, value) }`}),
+	)
+	require.NoError(t, err)
+	_, err = pc.ParseStatementsWithContext("dummy", mockGetter{[]string{`set(name, "foo")`}}, true)
+	require.ErrorContains(t, err, `function definition "set" conflicts with an existing function of the "dummy" context`)
+
+	_, err = NewParserCollection(
+		componenttest.NewNopTelemetrySettings(),
+		WithParserCollectionFunctionDefinitions[any]([]string{`Tag(value) { set(attributes["tag"], value) }`}),
+	)
+	require.ErrorContains(t, err, "converter definitions must be an expression")
+}
+
 func Test_NewStatementsGetter(t *testing.T) {
 	statements := []string{`set(foo, "bar")`, `set(bar, "foo")`}
 	statementsGetter := NewStatementsGetter(statements)
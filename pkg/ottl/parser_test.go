@@ -49,6 +49,19 @@ func Test_parse(t *testing.T) {
 				WhereClause: nil,
 			},
 		},
+		{
+			name:      "let statement",
+			statement: `let parsed = "foo"`,
+			expected: &parsedStatement{
+				Let: &letBinding{
+					Name: "parsed",
+					Value: value{
+						String: ottltest.Strp("foo"),
+					},
+				},
+				WhereClause: nil,
+			},
+		},
 		{
 			name:      "editor with float",
 			statement: `met(1.2)`,
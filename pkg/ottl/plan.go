@@ -0,0 +1,122 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottl // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+
+import (
+	"context"
+	"reflect"
+	"time"
+)
+
+// foldConverter returns the result of a call to a pure converter whose arguments are literals,
+// if it can be evaluated when parsing and is immutable.
+func (p *Parser[K]) foldConverter(c converter, getter Getter[K]) (Getter[K], bool) {
+	if !p.isConstantConverter(c) {
+		return nil, false
+	}
+	val, err := getter.Get(context.Background(), *new(K))
+	if err != nil || !isImmutable(val) {
+		// the error is returned when the statement is executed
+		return nil, false
+	}
+	return &literal[K]{value: val}, true
+}
+
+// isConstantConverter returns true if the converter is pure, and its arguments and keys are
+// literals or constant expressions.
+func (p *Parser[K]) isConstantConverter(c converter) bool {
+	if _, ok := p.definitions[c.Function]; ok {
+		return false
+	}
+	f, ok := p.functions[c.Function]
+	if !ok || !isPure(f) {
+		return false
+	}
+	for _, arg := range c.Arguments {
+		if arg.FunctionName == nil && !p.isConstantValue(arg.Value) {
+			return false
+		}
+	}
+	return p.isConstantKeys(c.Keys)
+}
+
+func (p *Parser[K]) isConstantValue(v value) bool {
+	switch {
+	case v.IsNil != nil, v.String != nil, v.Bool != nil, v.Bytes != nil, v.Enum != nil:
+		return true
+	case v.Literal != nil:
+		return p.isConstantLiteral(v.Literal)
+	case v.MathExpression != nil:
+		return p.isConstantMathExpression(v.MathExpression)
+	case v.List != nil:
+		for _, item := range v.List.Values {
+			if !p.isConstantValue(item) {
+				return false
+			}
+		}
+		return true
+	case v.Map != nil:
+		for _, item := range v.Map.Values {
+			if !p.isConstantValue(*item.Value) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+func (p *Parser[K]) isConstantLiteral(l *mathExprLiteral) bool {
+	switch {
+	case l.Int != nil, l.Float != nil:
+		return true
+	case l.Converter != nil:
+		return p.isConstantConverter(*l.Converter)
+	}
+	return false
+}
+
+func (p *Parser[K]) isConstantMathExpression(m *mathExpression) bool {
+	terms := []*addSubTerm{m.Left}
+	for _, rhs := range m.Right {
+		terms = append(terms, rhs.Term)
+	}
+	for _, t := range terms {
+		values := []*mathValue{t.Left}
+		for _, rhs := range t.Right {
+			values = append(values, rhs.Value)
+		}
+		for _, v := range values {
+			if v.Literal != nil && !p.isConstantLiteral(v.Literal) {
+				return false
+			}
+			if v.SubExpression != nil && !p.isConstantMathExpression(v.SubExpression) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (p *Parser[K]) isConstantKeys(keys []key) bool {
+	for _, k := range keys {
+		if k.MathExpression != nil && !p.isConstantMathExpression(k.MathExpression) {
+			return false
+		}
+		if k.Expression != nil && !p.isConstantLiteral(k.Expression) {
+			return false
+		}
+	}
+	return true
+}
+
+// isImmutable returns true if the value can be shared by all the executions of a statement.
+func isImmutable(val any) bool {
+	switch val.(type) {
+	case nil, string, bool, int64, float64, time.Time, time.Duration:
+		return true
+	}
+	// IDs are byte arrays
+	return reflect.TypeOf(val).Kind() == reflect.Array
+}
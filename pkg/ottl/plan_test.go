@@ -0,0 +1,148 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottl
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+)
+
+// planTestContext is a transform context holding the values of its paths.
+type planTestContext struct {
+	values map[string]any
+}
+
+func newPlanTestContext() *planTestContext {
+	return &planTestContext{
+		values: map[string]any{"name": "a", "attributes.x": "1", "attributes.y": "2"},
+	}
+}
+
+func planTestParsePath(p Path[any]) (GetSetter[any], error) {
+	if p.Name() != "name" && p.Name() != "attributes" {
+		return nil, errors.New("unknown path")
+	}
+	keys := p.Keys()
+	name := func(ctx context.Context, tCtx any) (string, error) {
+		if len(keys) == 0 {
+			return p.Name(), nil
+		}
+		k, err := keys[0].String(ctx, tCtx)
+		if err != nil || k == nil {
+			return "", errors.New("invalid key")
+		}
+		return p.Name() + "." + *k, nil
+	}
+	return &StandardGetSetter[any]{
+		Getter: func(ctx context.Context, tCtx any) (any, error) {
+			n, err := name(ctx, tCtx)
+			if err != nil {
+				return nil, err
+			}
+			return tCtx.(*planTestContext).values[n], nil
+		},
+		Setter: func(ctx context.Context, tCtx, val any) error {
+			n, err := name(ctx, tCtx)
+			if err != nil {
+				return err
+			}
+			tCtx.(*planTestContext).values[n] = val
+			return nil
+		},
+	}, nil
+}
+
+type upperArguments[K any] struct {
+	Value StringGetter[K]
+}
+
+func newPlanTestParser(t *testing.T, recorded *[]any, upperCalls *int) Parser[any] {
+	newUpper := func(name string, options ...FactoryOption[any]) Factory[any] {
+		return NewFactory(name, &upperArguments[any]{}, func(_ FunctionContext, args Arguments) (ExprFunc[any], error) {
+			value := args.(*upperArguments[any]).Value
+			return func(ctx context.Context, tCtx any) (any, error) {
+				*upperCalls++
+				s, err := value.Get(ctx, tCtx)
+				if err != nil {
+					return nil, err
+				}
+				return strings.ToUpper(s), nil
+			}, nil
+		}, options...)
+	}
+	p, err := NewParser(
+		CreateFactoryMap(newRecordFactory(recorded), newUpper("Upper", WithPureFunction[any]()), newUpper("ImpureUpper")),
+		planTestParsePath,
+		componenttest.NewNopTelemetrySettings(),
+	)
+	require.NoError(t, err)
+	return p
+}
+
+func Test_ConstantFolding(t *testing.T) {
+	tests := []struct {
+		name      string
+		statement string
+		recorded  any
+		// calls is the number of calls of the converter when parsing and executing the statement twice.
+		calls int
+	}{
+		{
+			name:      "pure converter",
+			statement: `record(Upper("a"))`,
+			recorded:  "A",
+			calls:     1,
+		},
+		{
+			name:      "nested pure converters",
+			statement: `record(Upper(Upper("a")))`,
+			recorded:  "A",
+			calls:     2,
+		},
+		{
+			name:      "impure converter",
+			statement: `record(ImpureUpper("a"))`,
+			recorded:  "A",
+			calls:     2,
+		},
+		{
+			name:      "path argument",
+			statement: `record(Upper(name))`,
+			recorded:  "A",
+			calls:     2,
+		},
+		{
+			name:      "error",
+			statement: `record(Upper(1))`,
+			calls:     3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var recorded []any
+			var upperCalls int
+			p := newPlanTestParser(t, &recorded, &upperCalls)
+			statement, err := p.ParseStatement(tt.statement)
+			require.NoError(t, err)
+
+			for range 2 {
+				recorded = nil
+				_, _, err = statement.Execute(context.Background(), newPlanTestContext())
+				if tt.recorded == nil {
+					require.Error(t, err)
+					continue
+				}
+				require.NoError(t, err)
+				assert.Equal(t, []any{tt.recorded}, recorded)
+			}
+			assert.Equal(t, tt.calls, upperCalls)
+		})
+	}
+}
@@ -0,0 +1,421 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottl // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// variableType is the type of the value of a variable, as far as it is known when parsing.
+type variableType int
+
+const (
+	unknownType variableType = iota
+	stringType
+	intType
+	floatType
+	boolType
+	bytesType
+	mapType
+	listType
+)
+
+func (t variableType) String() string {
+	switch t {
+	case stringType:
+		return "string"
+	case intType:
+		return "int"
+	case floatType:
+		return "float"
+	case boolType:
+		return "bool"
+	case bytesType:
+		return "bytes"
+	case mapType:
+		return "map"
+	case listType:
+		return "list"
+	default:
+		return "unknown"
+	}
+}
+
+// getterVariableTypes are the variable types accepted by the typed getters arguments. The
+// other getters either accept any value, or convert it.
+var getterVariableTypes = map[string]variableType{
+	"StringGetter": stringType,
+	"IntGetter":    intType,
+	"FloatGetter":  floatType,
+	"BoolGetter":   boolType,
+	"PMapGetter":   mapType,
+}
+
+// variable is bound by a let statement to the value of an expression, and can be referenced
+// by the following statements of the same group.
+type variable struct {
+	name string
+	typ  variableType
+}
+
+// variableScope holds the variables bound by the statements of a group, or the parameters of
+// a lambda or a for each statement, which hide the variables of the parent scope with the same
+// name.
+type variableScope struct {
+	variables map[string]*variable
+	parent    *variableScope
+}
+
+func newVariableScope() *variableScope {
+	return &variableScope{variables: map[string]*variable{}}
+}
+
+func (s *variableScope) newChildScope() *variableScope {
+	return &variableScope{variables: map[string]*variable{}, parent: s}
+}
+
+func (s *variableScope) lookup(name string) (*variable, bool) {
+	for ; s != nil; s = s.parent {
+		if v, ok := s.variables[name]; ok {
+			return v, true
+		}
+	}
+	return nil, false
+}
+
+// isVariableReference returns true if the path refers to one of the given variables.
+func isVariableReference(p *path, variables map[string]struct{}) bool {
+	name := p.Context
+	if name == "" {
+		name = p.Fields[0].Name
+	}
+	_, ok := variables[name]
+	return ok
+}
+
+// reference returns the variable the path refers to, or nil if it isn't a reference to a variable.
+func (s *variableScope) reference(p *path) (*variable, error) {
+	if p.Context != "" {
+		if v, ok := s.lookup(p.Context); ok {
+			return nil, fmt.Errorf("variable %q has no fields, use keys to index it", v.name)
+		}
+		return nil, nil
+	}
+	v, ok := s.lookup(p.Fields[0].Name)
+	if !ok {
+		return nil, nil
+	}
+	if len(p.Fields) > 1 {
+		return nil, fmt.Errorf("variable %q has no fields, use keys to index it", v.name)
+	}
+	return v, nil
+}
+
+// typeOf returns the type of a value, if it can be known when parsing.
+func (s *variableScope) typeOf(v value) variableType {
+	switch {
+	case v.String != nil:
+		return stringType
+	case v.Bool != nil:
+		return boolType
+	case v.Bytes != nil:
+		return bytesType
+	case v.Enum != nil:
+		return intType
+	case v.Map != nil:
+		return mapType
+	case v.List != nil:
+		return listType
+	case v.Literal != nil:
+		return s.literalType(v.Literal)
+	case v.MathExpression != nil:
+		return s.mathExpressionType(v.MathExpression)
+	}
+	return unknownType
+}
+
+func (s *variableScope) literalType(l *mathExprLiteral) variableType {
+	switch {
+	case l.Int != nil:
+		return intType
+	case l.Float != nil:
+		return floatType
+	case l.Path != nil && len(l.Path.Fields[0].Keys) == 0:
+		if v, err := s.reference(l.Path); err == nil && v != nil {
+			return v.typ
+		}
+	}
+	return unknownType
+}
+
+// mathExpressionType returns the type of a math expression whose operands are all ints or
+// floats, with ints being promoted to floats like the math operations do.
+func (s *variableScope) mathExpressionType(m *mathExpression) variableType {
+	result := intType
+	terms := []*addSubTerm{m.Left}
+	for _, r := range m.Right {
+		terms = append(terms, r.Term)
+	}
+	for _, t := range terms {
+		values := []*mathValue{t.Left}
+		for _, r := range t.Right {
+			values = append(values, r.Value)
+		}
+		for _, mv := range values {
+			var typ variableType
+			if mv.SubExpression != nil {
+				typ = s.mathExpressionType(mv.SubExpression)
+			} else {
+				typ = s.literalType(mv.Literal)
+			}
+			switch typ {
+			case intType:
+			case floatType:
+				result = floatType
+			default:
+				return unknownType
+			}
+		}
+	}
+	return result
+}
+
+// declare adds the variable bound by a let statement to the scope.
+func (s *variableScope) declare(name string, typ variableType, contextNames map[string]struct{}) (*variable, error) {
+	if _, ok := s.variables[name]; ok {
+		return nil, fmt.Errorf("variable %q is already bound by a previous statement", name)
+	}
+	if _, ok := contextNames[name]; ok {
+		return nil, fmt.Errorf("variable %q conflicts with the %q context", name, name)
+	}
+	v := &variable{name: name, typ: typ}
+	s.variables[name] = v
+	return v, nil
+}
+
+// checkArgument returns an error if the argument is a variable whose type isn't accepted by
+// the getter it is passed to.
+func (s *variableScope) checkArgument(argVal value, getterName string) error {
+	if argVal.Literal == nil || argVal.Literal.Path == nil {
+		return nil
+	}
+	expected, ok := getterVariableTypes[strings.SplitN(getterName, "[", 2)[0]]
+	if !ok {
+		return nil
+	}
+	typ := s.literalType(argVal.Literal)
+	if typ == unknownType || typ == expected {
+		return nil
+	}
+	return fmt.Errorf("variable %q is of type %v but the argument must be of type %v", argVal.Literal.Path.Fields[0].Name, typ, expected)
+}
+
+// checkMathOperand returns an error if the operand is a variable that can't be used in a math
+// expression.
+func (s *variableScope) checkMathOperand(l *mathExprLiteral) error {
+	if l.Path == nil {
+		return nil
+	}
+	switch typ := s.literalType(l); typ {
+	case stringType, boolType, bytesType, mapType, listType:
+		return fmt.Errorf("variable %q is of type %v and can't be used in a math expression", l.Path.Fields[0].Name, typ)
+	}
+	return nil
+}
+
+// checkKeys returns an error if the variable can't be indexed by the first of the keys.
+func (v *variable) checkKeys(keys []key) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	switch v.typ {
+	case unknownType:
+	case mapType:
+		if keys[0].Int != nil {
+			return fmt.Errorf("variable %q is of type map and can't be indexed by an int", v.name)
+		}
+	case listType, bytesType:
+		if keys[0].String != nil {
+			return fmt.Errorf("variable %q is of type %v and can't be indexed by a string", v.name, v.typ)
+		}
+	default:
+		return fmt.Errorf("variable %q is of type %v and can't be indexed", v.name, v.typ)
+	}
+	return nil
+}
+
+type variablesContextKey struct{}
+
+// variableValues are the values of the variables bound while executing a statement sequence.
+type variableValues map[*variable]any
+
+func withVariables(ctx context.Context) context.Context {
+	return context.WithValue(ctx, variablesContextKey{}, variableValues{})
+}
+
+func variablesFromContext(ctx context.Context) (variableValues, error) {
+	values, ok := ctx.Value(variablesContextKey{}).(variableValues)
+	if !ok {
+		return nil, errors.New("variables are only available to the statements executed by a StatementSequence")
+	}
+	return values, nil
+}
+
+// withParameters returns a context holding the values of the variables, so that parameters can
+// be bound outside a statement sequence too.
+func withParameters(ctx context.Context) (context.Context, variableValues) {
+	if values, ok := ctx.Value(variablesContextKey{}).(variableValues); ok {
+		return ctx, values
+	}
+	values := variableValues{}
+	return context.WithValue(ctx, variablesContextKey{}, values), values
+}
+
+// declareVariable adds a variable to the scope of the parser.
+func (p *Parser[K]) declareVariable(name string, typ variableType) (*variable, error) {
+	if len(p.pathContextNames) == 0 {
+		// without context names, the paths of the context can't be told apart from the variables
+		if np, err := p.newPath(&path{Fields: []field{{Name: name}}}); err == nil {
+			if _, err = p.pathParser(np); err == nil {
+				return nil, fmt.Errorf("variable %q conflicts with a path of the context", name)
+			}
+		}
+	}
+	return p.variables.declare(name, typ, p.pathContextNames)
+}
+
+// withParametersScope returns a copy of the parser with a child scope holding the parameters.
+func (p *Parser[K]) withParametersScope(parameters []string) (*Parser[K], []*variable, error) {
+	inner := *p
+	if p.variables == nil {
+		inner.variables = newVariableScope()
+	} else {
+		inner.variables = p.variables.newChildScope()
+	}
+	variables := make([]*variable, len(parameters))
+	for i, parameter := range parameters {
+		// parameters hide the paths without a context with the same name
+		v, err := inner.variables.declare(parameter, unknownType, p.pathContextNames)
+		if err != nil {
+			return nil, nil, err
+		}
+		variables[i] = v
+	}
+	return &inner, variables, nil
+}
+
+// newLetFunction returns the function of a let statement, which binds the value of the
+// expression to the variable.
+func (p *Parser[K]) newLetFunction(let *letBinding) (Expr[K], *variable, error) {
+	getter, err := p.newGetter(let.Value)
+	if err != nil {
+		return Expr[K]{}, nil, err
+	}
+	v, err := p.declareVariable(let.Name, p.variables.typeOf(let.Value))
+	if err != nil {
+		return Expr[K]{}, nil, err
+	}
+	return Expr[K]{exprFunc: func(ctx context.Context, tCtx K) (any, error) {
+		values, err := variablesFromContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+		val, err := getter.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+		values[v] = val
+		return nil, nil
+	}}, v, nil
+}
+
+// variableGetter returns the value of a variable, indexed by the keys if any. Variables not
+// bound yet, because the condition of their let statement wasn't met, are nil. Like for paths,
+// indexing nil or a map without the key is nil.
+type variableGetter[K any] struct {
+	variable *variable
+	keys     []Key[K]
+}
+
+func (p *Parser[K]) newVariableGetter(v *variable, keys []key) (Getter[K], error) {
+	if err := v.checkKeys(keys); err != nil {
+		return nil, err
+	}
+	ks, err := p.newKeys(keys)
+	if err != nil {
+		return nil, err
+	}
+	return &variableGetter[K]{variable: v, keys: ks}, nil
+}
+
+func (g *variableGetter[K]) Get(ctx context.Context, tCtx K) (any, error) {
+	values, err := variablesFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result := values[g.variable]
+	for _, k := range g.keys {
+		var resolved key
+		if resolved.String, err = k.String(ctx, tCtx); err != nil {
+			return nil, err
+		}
+		if resolved.Int, err = k.Int(ctx, tCtx); err != nil {
+			return nil, err
+		}
+		if resolved.String == nil && resolved.Int == nil {
+			if resolved, err = resolveExpressionKey(ctx, tCtx, k); err != nil {
+				return nil, err
+			}
+		}
+		if result == nil || isMissingKey(result, resolved) {
+			return nil, nil
+		}
+		if result, err = getIndexedValue(result, resolved); err != nil {
+			return nil, fmt.Errorf("unable to index variable %q: %w", g.variable.name, err)
+		}
+	}
+	return result, nil
+}
+
+func isMissingKey(val any, k key) bool {
+	if k.String == nil {
+		return false
+	}
+	switch m := val.(type) {
+	case pcommon.Map:
+		_, ok := m.Get(*k.String)
+		return !ok
+	case map[string]any:
+		_, ok := m[*k.String]
+		return !ok
+	}
+	return false
+}
+
+func resolveExpressionKey[K any](ctx context.Context, tCtx K, k Key[K]) (key, error) {
+	getter, err := k.ExpressionGetter(ctx, tCtx)
+	if err != nil {
+		return key{}, err
+	}
+	if getter == nil {
+		return key{}, errors.New("neither map nor slice index were set; this is an error in OTTL")
+	}
+	val, err := getter.Get(ctx, tCtx)
+	if err != nil {
+		return key{}, err
+	}
+	switch v := val.(type) {
+	case string:
+		return key{String: &v}, nil
+	case int64:
+		return key{Int: &v}, nil
+	default:
+		return key{}, fmt.Errorf("key must be a string or an int64, but got %T", val)
+	}
+}
@@ -0,0 +1,139 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottl
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+)
+
+func Test_Variables(t *testing.T) {
+	var recorded []any
+	p := newDefinitionsTestParser(t, &recorded)
+	statements, err := p.ParseStatements([]string{
+		`let m = {"a": 1, "b": [2, 3]}`,
+		`let n = m["b"][1] * 2`,
+		`record(m["a"]) where n == 6`,
+		`record(n)`,
+		`let s = "x" where 1 > 2`,
+		`record(s)`,
+		`record(m[attributes["key"]])`,
+		`record(m["missing"])`,
+		`let u = {"a": 1} where 1 > 2`,
+		`record(u["a"])`,
+	})
+	require.NoError(t, err)
+	sequence := NewStatementSequence(statements, componenttest.NewNopTelemetrySettings())
+
+	require.NoError(t, sequence.Execute(context.Background(), "a"))
+	assert.Equal(t, []any{int64(1), int64(6), nil, int64(1), nil, nil}, recorded)
+}
+
+func Test_Variables_Scope(t *testing.T) {
+	var recorded []any
+	p := newDefinitionsTestParser(t, &recorded)
+	statements, err := p.ParseStatements([]string{
+		`let first = name where name == "first"`,
+		`record(first)`,
+	})
+	require.NoError(t, err)
+	sequence := NewStatementSequence(statements, componenttest.NewNopTelemetrySettings())
+
+	// the variables are bound again by every execution of the sequence
+	require.NoError(t, sequence.Execute(context.Background(), "first"))
+	require.NoError(t, sequence.Execute(context.Background(), "second"))
+	assert.Equal(t, []any{"first", nil}, recorded)
+
+	// variables can't be used outside of a sequence
+	_, _, err = statements[0].Execute(context.Background(), "first")
+	require.ErrorContains(t, err, "variables are only available to the statements executed by a StatementSequence")
+}
+
+func Test_Variables_Types(t *testing.T) {
+	p, err := NewParser(
+		defaultFunctionsForTests(),
+		testParsePath[any],
+		componenttest.NewNopTelemetrySettings(),
+		WithEnumParser[any](testParseEnum),
+	)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		statements []string
+		err        string
+	}{
+		{
+			name:       "typed getter",
+			statements: []string{`let x = 1 + 2.5`, `testing_floatgetter(x)`, `testing_getter(x * 2)`},
+		},
+		{
+			name:       "unknown type",
+			statements: []string{`let x = name`, `testing_intgetter(x)`, `testing_stringgetter(x)`, `testing_getter(x["a"])`},
+		},
+		{
+			name:       "indexed",
+			statements: []string{`let x = {"a": "b"}`, `testing_stringgetter(x["a"])`},
+		},
+		{
+			name:       "mismatched typed getter",
+			statements: []string{`let x = "a"`, `testing_intgetter(x)`},
+			err:        `variable "x" is of type string but the argument must be of type int`,
+		},
+		{
+			name:       "mismatched map getter",
+			statements: []string{`let x = [1, 2]`, `testing_pmapgetter(x)`},
+			err:        `variable "x" is of type list but the argument must be of type map`,
+		},
+		{
+			name:       "math expression",
+			statements: []string{`let x = {"a": 1}`, `testing_getter(x + 1)`},
+			err:        `variable "x" is of type map and can't be used in a math expression`,
+		},
+		{
+			name:       "indexed scalar",
+			statements: []string{`let x = true`, `testing_getter(x["a"])`},
+			err:        `variable "x" is of type bool and can't be indexed`,
+		},
+		{
+			name:       "list indexed by string",
+			statements: []string{`let x = [1]`, `testing_getter(x["a"])`},
+			err:        `variable "x" is of type list and can't be indexed by a string`,
+		},
+		{
+			name:       "fields",
+			statements: []string{`let x = {"a": 1}`, `testing_getter(x.a)`},
+			err:        `variable "x" has no fields, use keys to index it`,
+		},
+		{
+			name:       "set",
+			statements: []string{`let x = 1`, `testing_setter(x)`},
+			err:        `variable "x" can't be set, variables are only bound by let statements`,
+		},
+		{
+			name:       "bound twice",
+			statements: []string{`let x = 1`, `let x = 2`},
+			err:        `variable "x" is already bound by a previous statement`,
+		},
+		{
+			name:       "path",
+			statements: []string{`let name = 1`},
+			err:        `variable "name" conflicts with a path of the context`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := p.ParseStatements(tt.statements)
+			if tt.err == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tt.err)
+		})
+	}
+}
@@ -0,0 +1,47 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package agent // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/agent"
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/collector/extension/xextension/storage"
+	"go.uber.org/multierr"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/pipeline"
+)
+
+// Agent runs a stanza pipeline outside of the collector.
+type Agent struct {
+	pipeline  pipeline.Pipeline
+	persister storage.Client
+}
+
+// Start will start the pipeline of the agent.
+func (a *Agent) Start() error {
+	if err := a.pipeline.Start(a.persister); err != nil {
+		return fmt.Errorf("start pipeline: %w", err)
+	}
+	return nil
+}
+
+// Stop will stop the pipeline of the agent. Entries in flight are written to the output, and
+// checkpoints are saved, before it returns.
+func (a *Agent) Stop() error {
+	err := a.pipeline.Stop()
+	if closeErr := a.persister.Close(context.Background()); closeErr != nil {
+		err = multierr.Append(err, fmt.Errorf("save checkpoints: %w", closeErr))
+	}
+	return err
+}
+
+// Run will start the agent, and stop it once the context is done.
+func (a *Agent) Run(ctx context.Context) error {
+	if err := a.Start(); err != nil {
+		return err
+	}
+	<-ctx.Done()
+	return a.Stop()
+}
@@ -0,0 +1,107 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package agent
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/confmap"
+)
+
+func TestAgentCheckpoints(t *testing.T) {
+	dir := t.TempDir()
+	logFile := filepath.Join(dir, "app.log")
+	outputFile := filepath.Join(dir, "out.json")
+	require.NoError(t, os.WriteFile(logFile, []byte("INFO starting\nWARN slow\n"), 0o600))
+
+	cfg := newTestConfig(t, logFile)
+	cfg.CheckpointFile = filepath.Join(dir, "checkpoints.json")
+	cfg.OutputFile = outputFile
+
+	runUntil(t, cfg, func() bool { return len(readOutput(t, outputFile)) == 2 })
+	assert.FileExists(t, cfg.CheckpointFile)
+
+	f, err := os.OpenFile(logFile, os.O_APPEND|os.O_WRONLY, 0o600)
+	require.NoError(t, err)
+	_, err = f.WriteString("ERROR failed\n")
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	// Only the new line is read again, thanks to the checkpoints
+	cfg = newTestConfig(t, logFile)
+	cfg.CheckpointFile = filepath.Join(dir, "checkpoints.json")
+	cfg.OutputFile = outputFile
+	runUntil(t, cfg, func() bool { return len(readOutput(t, outputFile)) >= 3 })
+
+	entries := readOutput(t, outputFile)
+	require.Len(t, entries, 3)
+	for i, expected := range []string{"starting", "slow", "failed"} {
+		attributes, ok := entries[i]["attributes"].(map[string]any)
+		require.True(t, ok)
+		assert.Equal(t, expected, attributes["message"])
+		assert.Equal(t, "app.log", attributes["log.file.name"])
+	}
+}
+
+func newTestConfig(t *testing.T, logFile string) *Config {
+	cfg := NewConfig()
+	err := confmap.NewFromStringMap(map[string]any{
+		"operators": []any{
+			map[string]any{
+				"type":          "file_input",
+				"include":       []any{logFile},
+				"start_at":      "beginning",
+				"poll_interval": "10ms",
+			},
+			map[string]any{
+				"type":     "regex_parser",
+				"regex":    `^(?P<level>\w+) (?P<message>.*)$`,
+				"parse_to": "attributes",
+			},
+		},
+	}).Unmarshal(cfg)
+	require.NoError(t, err)
+	return cfg
+}
+
+func runUntil(t *testing.T, cfg *Config, condition func() bool) {
+	agent, err := cfg.Build(componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- agent.Run(ctx) }()
+
+	assert.Eventually(t, condition, 5*time.Second, 10*time.Millisecond)
+	cancel()
+	require.NoError(t, <-done)
+}
+
+func readOutput(t *testing.T, path string) []map[string]any {
+	content, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	require.NoError(t, err)
+
+	var entries []map[string]any
+	for _, line := range bytes.Split(bytes.TrimSpace(content), []byte("\n")) {
+		if len(line) == 0 {
+			continue
+		}
+		var e map[string]any
+		require.NoError(t, json.Unmarshal(line, &e))
+		entries = append(entries, e)
+	}
+	return entries
+}
@@ -0,0 +1,111 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package agent // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/agent"
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/extension/xextension/storage"
+	"gopkg.in/yaml.v3"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/output/file"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/output/stdout"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/pipeline"
+)
+
+// outputID is the ID of the operator which writes the entries of the pipeline.
+const outputID = "agent_output"
+
+// Config is the configuration of an agent.
+type Config struct {
+	Operators []operator.Config `mapstructure:"operators"`
+	// CheckpointFile is the file in which operators, like file_input, keep their checkpoints.
+	// Checkpoints are not kept if it is empty.
+	CheckpointFile string `mapstructure:"checkpoint_file"`
+	// OutputFile is the file to which entries are written. Entries are written to stdout if it is empty.
+	OutputFile string `mapstructure:"output_file"`
+}
+
+// NewConfig creates a new agent config with default values.
+func NewConfig() *Config {
+	return &Config{}
+}
+
+// LoadConfig reads the config of an agent from a YAML file. The file contains either a list of
+// operators, or a map with the fields of the config.
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var raw any
+	if err = yaml.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+
+	var conf map[string]any
+	switch r := raw.(type) {
+	case []any:
+		conf = map[string]any{"operators": r}
+	case map[string]any:
+		conf = r
+	default:
+		return nil, fmt.Errorf("%s must contain a list of operators or an agent config", path)
+	}
+
+	cfg := NewConfig()
+	if err = confmap.NewFromStringMap(conf).Unmarshal(cfg); err != nil {
+		return nil, fmt.Errorf("unmarshal %s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// Build will build an agent from the config. Operators without an output send their entries to
+// the output of the agent.
+func (c Config) Build(set component.TelemetrySettings) (*Agent, error) {
+	if len(c.Operators) == 0 {
+		return nil, errors.New("at least one operator must be specified")
+	}
+
+	output, err := c.buildOutput(set)
+	if err != nil {
+		return nil, fmt.Errorf("build output: %w", err)
+	}
+
+	pipe, err := pipeline.Config{
+		Operators:     c.Operators,
+		DefaultOutput: output,
+	}.Build(set)
+	if err != nil {
+		return nil, fmt.Errorf("build pipeline: %w", err)
+	}
+
+	var persister storage.Client = storage.NewNopClient()
+	if c.CheckpointFile != "" {
+		persister, err = newFilePersister(c.CheckpointFile)
+		if err != nil {
+			return nil, fmt.Errorf("load checkpoints: %w", err)
+		}
+	}
+
+	return &Agent{
+		pipeline:  pipe,
+		persister: persister,
+	}, nil
+}
+
+func (c Config) buildOutput(set component.TelemetrySettings) (operator.Operator, error) {
+	if c.OutputFile == "" {
+		return stdout.NewConfig(outputID).Build(set)
+	}
+	outputCfg := file.NewConfig(outputID)
+	outputCfg.Path = c.OutputFile
+	return outputCfg.Build(set)
+}
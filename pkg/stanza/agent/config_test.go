@@ -0,0 +1,64 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package agent
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	t.Run("Operators", func(t *testing.T) {
+		cfg, err := LoadConfig(filepath.Join("testdata", "operators.yaml"))
+		require.NoError(t, err)
+		require.Len(t, cfg.Operators, 2)
+		assert.Equal(t, "file_input", cfg.Operators[0].Type())
+		assert.Equal(t, "regex_parser", cfg.Operators[1].Type())
+		assert.Empty(t, cfg.CheckpointFile)
+		assert.Empty(t, cfg.OutputFile)
+	})
+
+	t.Run("Agent", func(t *testing.T) {
+		cfg, err := LoadConfig(filepath.Join("testdata", "agent.yaml"))
+		require.NoError(t, err)
+		require.Len(t, cfg.Operators, 1)
+		assert.Equal(t, "file_input", cfg.Operators[0].Type())
+		assert.Equal(t, "checkpoints.json", cfg.CheckpointFile)
+		assert.Equal(t, "out.json", cfg.OutputFile)
+	})
+
+	t.Run("UnknownField", func(t *testing.T) {
+		_, err := LoadConfig(filepath.Join("testdata", "unknown_field.yaml"))
+		assert.ErrorContains(t, err, "has invalid keys: output")
+	})
+
+	t.Run("Scalar", func(t *testing.T) {
+		_, err := LoadConfig(filepath.Join("testdata", "scalar.yaml"))
+		assert.EqualError(t, err, filepath.Join("testdata", "scalar.yaml")+" must contain a list of operators or an agent config")
+	})
+
+	t.Run("Missing", func(t *testing.T) {
+		_, err := LoadConfig(filepath.Join("testdata", "missing.yaml"))
+		assert.Error(t, err)
+	})
+}
+
+func TestBuild(t *testing.T) {
+	t.Run("NoOperators", func(t *testing.T) {
+		_, err := NewConfig().Build(componenttest.NewNopTelemetrySettings())
+		assert.EqualError(t, err, "at least one operator must be specified")
+	})
+
+	t.Run("InvalidCheckpointFile", func(t *testing.T) {
+		cfg, err := LoadConfig(filepath.Join("testdata", "operators.yaml"))
+		require.NoError(t, err)
+		cfg.CheckpointFile = filepath.Join("testdata", "operators.yaml")
+		_, err = cfg.Build(componenttest.NewNopTelemetrySettings())
+		assert.ErrorContains(t, err, "load checkpoints: parse "+cfg.CheckpointFile)
+	})
+}
@@ -0,0 +1,158 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package agent // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/agent"
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/extension/xextension/storage"
+)
+
+// checkpointWriteInterval is the longest time a change of the checkpoints waits before being
+// written to the file.
+const checkpointWriteInterval = time.Second
+
+// filePersister keeps its data in memory, and writes all of it to a JSON file once the changes
+// made during checkpointWriteInterval are collected, and when it's closed. The file is replaced
+// atomically, so that it always contains a consistent set of checkpoints.
+type filePersister struct {
+	path     string
+	interval time.Duration
+	data     map[string][]byte
+	// dirty is true if the data has changed since it was last written, and timer is set while
+	// a write is scheduled.
+	dirty bool
+	timer *time.Timer
+	// err is the error of the last scheduled write, returned by the next call.
+	err error
+	mux sync.Mutex
+}
+
+func newFilePersister(path string) (*filePersister, error) {
+	p := &filePersister{
+		path:     path,
+		interval: checkpointWriteInterval,
+		data:     map[string][]byte{},
+	}
+
+	content, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return p, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(content, &p.data); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	return p, nil
+}
+
+func (p *filePersister) Get(_ context.Context, key string) ([]byte, error) {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+	return p.data[key], nil
+}
+
+func (p *filePersister) Set(ctx context.Context, key string, value []byte) error {
+	return p.Batch(ctx, storage.SetOperation(key, value))
+}
+
+func (p *filePersister) Delete(ctx context.Context, key string) error {
+	return p.Batch(ctx, storage.DeleteOperation(key))
+}
+
+func (p *filePersister) Batch(_ context.Context, ops ...*storage.Operation) error {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+
+	for _, op := range ops {
+		switch op.Type {
+		case storage.Get:
+			op.Value = p.data[op.Key]
+		case storage.Set:
+			p.data[op.Key] = op.Value
+			p.dirty = true
+		case storage.Delete:
+			delete(p.data, op.Key)
+			p.dirty = true
+		}
+	}
+	if p.dirty && p.timer == nil {
+		p.timer = time.AfterFunc(p.interval, p.scheduledWrite)
+	}
+
+	err := p.err
+	p.err = nil
+	return err
+}
+
+// Close writes the changes that are not written yet.
+func (p *filePersister) Close(_ context.Context) error {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+
+	if p.timer != nil {
+		p.timer.Stop()
+		p.timer = nil
+	}
+	err := p.err
+	p.err = nil
+	if p.dirty {
+		err = p.write()
+	}
+	return err
+}
+
+func (p *filePersister) scheduledWrite() {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+
+	if p.timer == nil {
+		// The persister was closed in the meantime
+		return
+	}
+	p.timer = nil
+	p.err = p.write()
+}
+
+func (p *filePersister) write() error {
+	content, err := json.Marshal(p.data)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(p.path), filepath.Base(p.path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	if _, err = tmp.Write(content); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	// The content must be on disk before the file replaces the previous one
+	if err = tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	if err = os.Rename(tmp.Name(), p.path); err != nil {
+		return err
+	}
+	p.dirty = false
+	return nil
+}
@@ -0,0 +1,85 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package agent
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/extension/xextension/storage"
+)
+
+func TestFilePersister(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "checkpoints.json")
+
+	p, err := newFilePersister(path)
+	require.NoError(t, err)
+	require.NoError(t, p.Set(ctx, "a", []byte("1")))
+	require.NoError(t, p.Batch(ctx, storage.SetOperation("b", []byte("2")), storage.SetOperation("c", []byte("3"))))
+	require.NoError(t, p.Delete(ctx, "c"))
+	require.NoError(t, p.Close(ctx))
+
+	// The checkpoints are read back from the file
+	p, err = newFilePersister(path)
+	require.NoError(t, err)
+	value, err := p.Get(ctx, "a")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("1"), value)
+
+	get := storage.GetOperation("b")
+	require.NoError(t, p.Batch(ctx, get))
+	assert.Equal(t, []byte("2"), get.Value)
+
+	value, err = p.Get(ctx, "c")
+	require.NoError(t, err)
+	assert.Nil(t, value)
+
+	// No temporary file is left behind
+	entries, err := os.ReadDir(filepath.Dir(path))
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
+
+func TestFilePersisterWriteInterval(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "checkpoints.json")
+
+	p, err := newFilePersister(path)
+	require.NoError(t, err)
+	p.interval = time.Hour
+
+	// The changes are written together once the interval has elapsed
+	require.NoError(t, p.Set(ctx, "a", []byte("1")))
+	require.NoError(t, p.Set(ctx, "b", []byte("2")))
+	assert.NoFileExists(t, path)
+
+	p.mux.Lock()
+	p.timer.Reset(0)
+	p.mux.Unlock()
+	assert.Eventually(t, func() bool {
+		reloaded, err := newFilePersister(path)
+		return err == nil && len(reloaded.data) == 2
+	}, 10*time.Second, 10*time.Millisecond)
+
+	// The changes not written yet are written when closing
+	require.NoError(t, p.Delete(ctx, "a"))
+	require.NoError(t, p.Close(ctx))
+	reloaded, err := newFilePersister(path)
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]byte{"b": []byte("2")}, reloaded.data)
+}
+
+func TestFilePersisterInvalidFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "checkpoints.json")
+	require.NoError(t, os.WriteFile(path, []byte("{"), 0o600))
+
+	_, err := newFilePersister(path)
+	assert.ErrorContains(t, err, "parse "+path)
+}
@@ -117,11 +117,17 @@ func (c Config) Build(set component.TelemetrySettings, emit emit.Callback, opts
 	}
 
 	splitFunc := o.splitFunc
+	var flushPeriodFunc func([]byte) time.Duration
 	if splitFunc == nil {
 		splitFunc, err = c.SplitConfig.Func(enc, false, int(c.MaxLogSize))
 		if err != nil {
 			return nil, err
 		}
+		// The states of a state machine may have their own flush period
+		flushPeriodFunc, err = c.SplitConfig.FlushPeriodFunc(enc, c.FlushPeriod)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	trimFunc := trim.Nop
@@ -163,6 +169,7 @@ func (c Config) Build(set component.TelemetrySettings, emit emit.Callback, opts
 		SplitFunc:               splitFunc,
 		TrimFunc:                trimFunc,
 		FlushTimeout:            c.FlushPeriod,
+		FlushPeriodFunc:         flushPeriodFunc,
 		EmitFunc:                emit,
 		Attributes:              c.Resolver,
 		HeaderConfig:            hCfg,
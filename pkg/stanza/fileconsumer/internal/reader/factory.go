@@ -43,6 +43,7 @@ type Factory struct {
 	SplitFunc               bufio.SplitFunc
 	TrimFunc                trim.Func
 	FlushTimeout            time.Duration
+	FlushPeriodFunc         func([]byte) time.Duration
 	EmitFunc                emit.Callback
 	Attributes              attrs.Resolver
 	DeleteAtEOF             bool
@@ -119,6 +120,9 @@ func (f *Factory) NewReaderFromMetadata(file *os.File, m *Metadata) (r *Reader,
 
 	tokenLenFunc := m.TokenLenState.Func(f.SplitFunc)
 	flushFunc := m.FlushState.Func(tokenLenFunc, f.FlushTimeout)
+	if f.FlushPeriodFunc != nil {
+		flushFunc = m.FlushState.FuncWithPeriod(tokenLenFunc, f.FlushPeriodFunc)
+	}
 	r.contentSplitFunc = trim.WithFunc(trim.ToLength(flushFunc, f.MaxLogSize), f.TrimFunc)
 
 	if f.HeaderConfig != nil && !m.HeaderFinalized {
@@ -22,6 +22,14 @@ func (s *State) Func(splitFunc bufio.SplitFunc, period time.Duration) bufio.Spli
 	if s == nil || period <= 0 {
 		return splitFunc
 	}
+	return s.FuncWithPeriod(splitFunc, func([]byte) time.Duration { return period })
+}
+
+// FuncWithPeriod is like Func, except that the period of the timer depends on the incomplete data.
+func (s *State) FuncWithPeriod(splitFunc bufio.SplitFunc, periodFunc func(data []byte) time.Duration) bufio.SplitFunc {
+	if s == nil || periodFunc == nil {
+		return splitFunc
+	}
 
 	return func(data []byte, atEOF bool) (int, []byte, error) {
 		advance, token, err := splitFunc(data, atEOF)
@@ -51,7 +59,7 @@ func (s *State) Func(splitFunc bufio.SplitFunc, period time.Duration) bufio.Spli
 		}
 
 		// Flush timed out
-		if internaltime.Since(s.LastDataChange) > period {
+		if period := periodFunc(data); period > 0 && internaltime.Since(s.LastDataChange) > period {
 			s.LastDataChange = internaltime.Now()
 			s.LastDataLength = 0
 			return len(data), data, nil
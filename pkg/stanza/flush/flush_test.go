@@ -45,3 +45,26 @@ func TestNewlineSplitFunc(t *testing.T) {
 		t.Run(tc.name+"/Func", splittest.New(previousState.Func(tc.baseFunc, tc.flushPeriod), tc.input, tc.steps...))
 	}
 }
+
+func TestFuncWithPeriod(t *testing.T) {
+	periodFunc := func(data []byte) time.Duration {
+		if string(data) == "wait" {
+			return time.Hour
+		}
+		return 100 * time.Millisecond
+	}
+
+	previousState := &State{LastDataChange: time.Now()}
+	t.Run("ShortPeriod", splittest.New(previousState.FuncWithPeriod(splittest.ScanLinesStrict, periodFunc),
+		[]byte("complete line\nincomplete"),
+		splittest.ExpectAdvanceToken(len("complete line\n"), "complete line"),
+		splittest.ExpectReadMore(),
+		splittest.Eventually(splittest.ExpectToken("incomplete"), 150*time.Millisecond, 10*time.Millisecond),
+	))
+
+	previousState = &State{LastDataChange: time.Now()}
+	t.Run("LongPeriod", splittest.New(previousState.FuncWithPeriod(splittest.ScanLinesStrict, periodFunc),
+		[]byte("wait"),
+		splittest.ExpectReadMore(),
+	))
+}
@@ -5,6 +5,7 @@ go 1.23.0
 require (
 	github.com/bmatcuk/doublestar/v4 v4.9.1
 	github.com/cespare/xxhash/v2 v2.3.0
+	github.com/elastic/go-grok v0.3.1
 	github.com/expr-lang/expr v1.17.5
 	github.com/goccy/go-json v0.10.5
 	github.com/jonboulle/clockwork v0.5.0
@@ -0,0 +1,102 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package helper // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+
+import (
+	"fmt"
+
+	"github.com/expr-lang/expr/vm"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+)
+
+// Route is a route on an operator sending entries to the outputs of the routes
+// whose expression matches.
+type Route struct {
+	Attributer
+	Expression      *vm.Program
+	OutputIDs       []string
+	OutputOperators []operator.Operator
+}
+
+// RouterOperator is an operator that sends entries to the outputs of its routes.
+type RouterOperator struct {
+	BasicOperator
+	Routes []*Route
+}
+
+// CanOutput always returns true for a router operator.
+func (*RouterOperator) CanOutput() bool {
+	return true
+}
+
+// Outputs will return all connected operators.
+func (r *RouterOperator) Outputs() []operator.Operator {
+	outputs := make([]operator.Operator, 0, len(r.Routes))
+	for _, route := range r.Routes {
+		outputs = append(outputs, route.OutputOperators...)
+	}
+	return outputs
+}
+
+// GetOutputIDs will return all connected operators.
+func (r *RouterOperator) GetOutputIDs() []string {
+	outputs := make([]string, 0, len(r.Routes))
+	for _, route := range r.Routes {
+		outputs = append(outputs, route.OutputIDs...)
+	}
+	return outputs
+}
+
+// SetOutputs will set the outputs of the routes.
+func (r *RouterOperator) SetOutputs(operators []operator.Operator) error {
+	for _, route := range r.Routes {
+		outputOperators, err := r.findOperators(operators, route.OutputIDs)
+		if err != nil {
+			return fmt.Errorf("failed to set outputs on route: %w", err)
+		}
+		route.OutputOperators = outputOperators
+	}
+
+	return nil
+}
+
+// SetOutputIDs will do nothing.
+func (*RouterOperator) SetOutputIDs(_ []string) {}
+
+// findOperators will find a subset of operators from a collection.
+func (r *RouterOperator) findOperators(operators []operator.Operator, operatorIDs []string) ([]operator.Operator, error) {
+	result := make([]operator.Operator, len(operatorIDs))
+	for i, operatorID := range operatorIDs {
+		operator, err := r.findOperator(operators, operatorID)
+		if err != nil {
+			return nil, err
+		}
+		result[i] = operator
+	}
+	return result, nil
+}
+
+// findOperator will find an operator from a collection.
+func (*RouterOperator) findOperator(operators []operator.Operator, operatorID string) (operator.Operator, error) {
+	for _, operator := range operators {
+		if operator.ID() == operatorID {
+			return operator, nil
+		}
+	}
+	return nil, fmt.Errorf("operator %s does not exist", operatorID)
+}
+
+// RouteLogFields returns the fields logged along with an error routing an entry.
+func RouteLogFields(entry *entry.Entry, err error) []zap.Field {
+	logFields := make([]zap.Field, 0, 2+len(entry.Attributes))
+	logFields = append(logFields, zap.Time("entry.timestamp", entry.Timestamp))
+	for attrName, attrValue := range entry.Attributes {
+		logFields = append(logFields, zap.Any(attrName, attrValue))
+	}
+	logFields = append(logFields, zap.Error(err))
+	return logFields
+}
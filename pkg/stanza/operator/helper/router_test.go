@@ -0,0 +1,64 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package helper
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/testutil"
+)
+
+func TestRouterOperatorCanOutput(t *testing.T) {
+	router := RouterOperator{}
+	require.True(t, router.CanOutput())
+}
+
+func TestRouterSetOutputsMissing(t *testing.T) {
+	output1 := testutil.NewMockOperator("output1")
+	router := RouterOperator{
+		Routes: []*Route{{OutputIDs: []string{"output1"}}, {OutputIDs: []string{"output2"}}},
+	}
+
+	err := router.SetOutputs([]operator.Operator{output1})
+	require.ErrorContains(t, err, "operator output2 does not exist")
+}
+
+func TestRouterSetOutputsValid(t *testing.T) {
+	output1 := testutil.NewMockOperator("output1")
+	output2 := testutil.NewMockOperator("output2")
+	output3 := testutil.NewMockOperator("output3")
+	router := RouterOperator{
+		Routes: []*Route{
+			{OutputIDs: []string{"output1", "output2"}},
+			{OutputIDs: []string{"output3"}},
+			{OutputIDs: []string{"output1"}},
+		},
+	}
+
+	err := router.SetOutputs([]operator.Operator{output1, output2, output3})
+	require.NoError(t, err)
+	require.Equal(t, []operator.Operator{output1, output2}, router.Routes[0].OutputOperators)
+	require.Equal(t, []operator.Operator{output3}, router.Routes[1].OutputOperators)
+	require.Equal(t, []operator.Operator{output1}, router.Routes[2].OutputOperators)
+	require.Equal(t, []operator.Operator{output1, output2, output3, output1}, router.Outputs())
+	require.Equal(t, []string{"output1", "output2", "output3", "output1"}, router.GetOutputIDs())
+}
+
+func TestRouteLogFields(t *testing.T) {
+	e := entry.New()
+	e.Timestamp = time.Unix(1, 0)
+	e.Attributes = map[string]any{"key": "value"}
+
+	fields := RouteLogFields(e, errors.New("failure"))
+	require.Len(t, fields, 3)
+	require.Equal(t, "entry.timestamp", fields[0].Key)
+	require.Equal(t, "key", fields[1].Key)
+	require.Equal(t, "error", fields[2].Key)
+}
@@ -0,0 +1,164 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cef // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/parser/cef"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+)
+
+const prefix = "CEF:"
+
+// headerFields are the names of the fields of the CEF header, following the version.
+var headerFields = []string{"device_vendor", "device_product", "device_version", "device_event_class_id", "name", "severity"}
+
+// Parser is an operator that parses Common Event Format (CEF) events.
+type Parser struct {
+	helper.ParserOperator
+}
+
+func (p *Parser) ProcessBatch(ctx context.Context, entries []*entry.Entry) error {
+	return p.ProcessBatchWith(ctx, entries, p.parse)
+}
+
+// Process will parse an entry for a CEF event.
+func (p *Parser) Process(ctx context.Context, entry *entry.Entry) error {
+	return p.ProcessWith(ctx, entry, p.parse)
+}
+
+// parse will parse a value as a CEF event.
+func (*Parser) parse(value any) (any, error) {
+	switch m := value.(type) {
+	case string:
+		return parseCEF(m)
+	case []byte:
+		return parseCEF(string(m))
+	default:
+		return nil, fmt.Errorf("type %T cannot be parsed as CEF", value)
+	}
+}
+
+// parseCEF parses a CEF event. Any text preceding the CEF prefix, like a syslog header, is ignored.
+func parseCEF(input string) (map[string]any, error) {
+	start := strings.Index(input, prefix)
+	if start < 0 {
+		return nil, errors.New("missing CEF prefix")
+	}
+	input = input[start+len(prefix):]
+
+	parsed := make(map[string]any, len(headerFields)+2)
+	for i := 0; i <= len(headerFields); i++ {
+		field, rest, ok := cutHeaderField(input)
+		if !ok {
+			return nil, fmt.Errorf("expected %d header fields, found %d", len(headerFields)+1, i)
+		}
+		if i == 0 {
+			parsed["version"] = strings.TrimSpace(field)
+		} else {
+			parsed[headerFields[i-1]] = field
+		}
+		input = rest
+	}
+
+	extensions, err := parseExtensions(strings.TrimSpace(input))
+	if err != nil {
+		return nil, err
+	}
+	if len(extensions) > 0 {
+		parsed["extensions"] = extensions
+	}
+	return parsed, nil
+}
+
+// cutHeaderField returns the unescaped header field preceding the first unescaped pipe, and the
+// text following the pipe.
+func cutHeaderField(input string) (string, string, bool) {
+	var sb strings.Builder
+	for i := 0; i < len(input); i++ {
+		switch c := input[i]; {
+		case c == '\\' && i+1 < len(input) && (input[i+1] == '|' || input[i+1] == '\\'):
+			i++
+			sb.WriteByte(input[i])
+		case c == '|':
+			return sb.String(), input[i+1:], true
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	return "", "", false
+}
+
+// parseExtensions parses the space separated key value pairs of the extension. Values may contain
+// spaces, so a value ends where the key of the next pair starts.
+func parseExtensions(input string) (map[string]any, error) {
+	if input == "" {
+		return nil, nil
+	}
+
+	extensions := map[string]any{}
+	key := ""
+	var value strings.Builder
+	for i := 0; i < len(input); i++ {
+		c := input[i]
+		if c == '\\' && i+1 < len(input) {
+			i++
+			switch input[i] {
+			case 'n':
+				value.WriteByte('\n')
+			case 'r':
+				value.WriteByte('\r')
+			default:
+				value.WriteByte(input[i])
+			}
+			continue
+		}
+		if c != '=' {
+			value.WriteByte(c)
+			continue
+		}
+
+		// the key of the pair is the last word preceding the equal sign
+		text := value.String()
+		keyStart := strings.LastIndexByte(text, ' ') + 1
+		nextKey := text[keyStart:]
+		if key != "" && (keyStart == 0 || !isKey(nextKey)) {
+			// an unescaped equal sign in a value
+			value.WriteByte(c)
+			continue
+		}
+		if !isKey(nextKey) {
+			return nil, fmt.Errorf("invalid extension key %q", nextKey)
+		}
+		if key != "" {
+			extensions[key] = strings.TrimRight(text[:keyStart], " ")
+		} else if keyStart != 0 {
+			return nil, fmt.Errorf("invalid extension %q", text)
+		}
+		key = nextKey
+		value.Reset()
+	}
+	if key == "" {
+		return nil, fmt.Errorf("invalid extension %q", input)
+	}
+	extensions[key] = strings.TrimRight(value.String(), " ")
+	return extensions, nil
+}
+
+// isKey returns true if the text is a valid extension key.
+func isKey(text string) bool {
+	if text == "" {
+		return false
+	}
+	for _, c := range text {
+		if (c < 'a' || c > 'z') && (c < 'A' || c > 'Z') && (c < '0' || c > '9') && c != '_' && c != '.' && c != '-' && c != '[' && c != ']' {
+			return false
+		}
+	}
+	return true
+}
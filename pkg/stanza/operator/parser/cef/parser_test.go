@@ -0,0 +1,233 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cef
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+)
+
+func newTestParser(t *testing.T) *Parser {
+	cfg := NewConfigWithID("test")
+	set := componenttest.NewNopTelemetrySettings()
+	op, err := cfg.Build(set)
+	require.NoError(t, err)
+	return op.(*Parser)
+}
+
+func TestInit(t *testing.T) {
+	builder, ok := operator.DefaultRegistry.Lookup("cef_parser")
+	require.True(t, ok, "expected cef_parser to be registered")
+	require.Equal(t, "cef_parser", builder().Type())
+}
+
+func TestParserBuildFailure(t *testing.T) {
+	cfg := NewConfigWithID("test")
+	cfg.OnError = "invalid_on_error"
+	set := componenttest.NewNopTelemetrySettings()
+	_, err := cfg.Build(set)
+	require.ErrorContains(t, err, "invalid `on_error` field")
+}
+
+func TestParserInvalidType(t *testing.T) {
+	parser := newTestParser(t)
+	_, err := parser.parse([]int{})
+	require.ErrorContains(t, err, "type []int cannot be parsed as CEF")
+}
+
+func TestProcess(t *testing.T) {
+	cases := []struct {
+		name   string
+		op     func() (operator.Operator, error)
+		input  *entry.Entry
+		expect *entry.Entry
+	}{
+		{
+			"default",
+			func() (operator.Operator, error) {
+				cfg := NewConfigWithID("test_id")
+				set := componenttest.NewNopTelemetrySettings()
+				return cfg.Build(set)
+			},
+			&entry.Entry{
+				Body: "CEF:0|Security|threatmanager|1.0|100|worm successfully stopped|10|src=10.0.0.1 dst=2.1.2.2",
+			},
+			&entry.Entry{
+				Attributes: map[string]any{
+					"version":               "0",
+					"device_vendor":         "Security",
+					"device_product":        "threatmanager",
+					"device_version":        "1.0",
+					"device_event_class_id": "100",
+					"name":                  "worm successfully stopped",
+					"severity":              "10",
+					"extensions": map[string]any{
+						"src": "10.0.0.1",
+						"dst": "2.1.2.2",
+					},
+				},
+				Body: "CEF:0|Security|threatmanager|1.0|100|worm successfully stopped|10|src=10.0.0.1 dst=2.1.2.2",
+			},
+		},
+		{
+			"parse-from-syslog-message",
+			func() (operator.Operator, error) {
+				cfg := NewConfigWithID("test_id")
+				cfg.ParseFrom = entry.NewAttributeField("message")
+				cfg.ParseTo = entry.RootableField{Field: entry.NewAttributeField("cef")}
+				set := componenttest.NewNopTelemetrySettings()
+				return cfg.Build(set)
+			},
+			&entry.Entry{
+				Attributes: map[string]any{
+					"hostname": "firewall",
+					"message":  "CEF:1|Vendor|Product|2.0|deny|Connection denied|High|",
+				},
+			},
+			&entry.Entry{
+				Attributes: map[string]any{
+					"hostname": "firewall",
+					"message":  "CEF:1|Vendor|Product|2.0|deny|Connection denied|High|",
+					"cef": map[string]any{
+						"version":               "1",
+						"device_vendor":         "Vendor",
+						"device_product":        "Product",
+						"device_version":        "2.0",
+						"device_event_class_id": "deny",
+						"name":                  "Connection denied",
+						"severity":              "High",
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			op, err := tc.op()
+			require.NoError(t, err, "did not expect operator function to return an error, this is a bug with the test case")
+
+			err = op.Process(context.Background(), tc.input)
+			require.NoError(t, err)
+			require.Equal(t, tc.expect, tc.input)
+		})
+	}
+}
+
+func TestParserParse(t *testing.T) {
+	header := map[string]any{
+		"version":               "0",
+		"device_vendor":         "Vendor",
+		"device_product":        "Product",
+		"device_version":        "1.0",
+		"device_event_class_id": "100",
+		"name":                  "Name",
+		"severity":              "5",
+	}
+	withFields := func(fields map[string]any) map[string]any {
+		m := map[string]any{}
+		for k, v := range header {
+			m[k] = v
+		}
+		for k, v := range fields {
+			m[k] = v
+		}
+		return m
+	}
+
+	cases := []struct {
+		name       string
+		inputBody  any
+		outputBody map[string]any
+		expectErr  bool
+	}{
+		{
+			"no extension",
+			"CEF:0|Vendor|Product|1.0|100|Name|5|",
+			header,
+			false,
+		},
+		{
+			"bytes",
+			[]byte("CEF:0|Vendor|Product|1.0|100|Name|5|"),
+			header,
+			false,
+		},
+		{
+			"syslog header",
+			"<134>Sep 19 08:26:10 host CEF:0|Vendor|Product|1.0|100|Name|5|",
+			header,
+			false,
+		},
+		{
+			"escaped header",
+			`CEF:0|Vendor\|Corp|Product\\|1.0|100|Name|5|`,
+			withFields(map[string]any{
+				"device_vendor":  "Vendor|Corp",
+				"device_product": `Product\`,
+			}),
+			false,
+		},
+		{
+			"values with spaces",
+			"CEF:0|Vendor|Product|1.0|100|Name|5|msg=Detected a threat. No action needed  src=10.0.0.1",
+			withFields(map[string]any{
+				"extensions": map[string]any{
+					"msg": "Detected a threat. No action needed",
+					"src": "10.0.0.1",
+				},
+			}),
+			false,
+		},
+		{
+			"escaped values",
+			`CEF:0|Vendor|Product|1.0|100|Name|5|cs1=a\=b\\c msg=line\nbreak request=http://example.com/?a=b`,
+			withFields(map[string]any{
+				"extensions": map[string]any{
+					"cs1":     `a=b\c`,
+					"msg":     "line\nbreak",
+					"request": "http://example.com/?a=b",
+				},
+			}),
+			false,
+		},
+		{
+			"missing prefix",
+			"0|Vendor|Product|1.0|100|Name|5|",
+			nil,
+			true,
+		},
+		{
+			"missing header fields",
+			"CEF:0|Vendor|Product|1.0",
+			nil,
+			true,
+		},
+		{
+			"invalid extension",
+			"CEF:0|Vendor|Product|1.0|100|Name|5|not an extension",
+			nil,
+			true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			parser := Parser{}
+			x, err := parser.parse(tc.inputBody)
+			if tc.expectErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.outputBody, x)
+		})
+	}
+}
@@ -0,0 +1,139 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package grok // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/parser/grok"
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/elastic/go-grok"
+	"go.opentelemetry.io/collector/component"
+	"go.uber.org/zap"
+
+	stanza_errors "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/errors"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/parser/internal/cache"
+)
+
+const operatorType = "grok_parser"
+
+func init() {
+	operator.Register(operatorType, func() operator.Builder { return NewConfig() })
+}
+
+// NewConfig creates a new grok parser config with default values
+func NewConfig() *Config {
+	return NewConfigWithID(operatorType)
+}
+
+// NewConfigWithID creates a new grok parser config with default values
+func NewConfigWithID(operatorID string) *Config {
+	return &Config{
+		ParserConfig:      helper.NewParserConfig(operatorID, operatorType),
+		NamedCapturesOnly: true,
+	}
+}
+
+// Config is the configuration of a grok parser operator.
+type Config struct {
+	helper.ParserConfig `mapstructure:",squash"`
+
+	Pattern string `mapstructure:"pattern"`
+	// NamedCapturesOnly ignores the patterns without a semantic, like %{IP}.
+	NamedCapturesOnly bool `mapstructure:"named_captures_only"`
+	// PatternDefinitions are custom patterns, by name.
+	PatternDefinitions map[string]string `mapstructure:"pattern_definitions"`
+	// PatternFiles are files of custom patterns, with one "NAME pattern" definition per line.
+	PatternFiles []string `mapstructure:"pattern_files"`
+	Cache        struct {
+		Size uint16 `mapstructure:"size"`
+	} `mapstructure:"cache"`
+}
+
+// Build will build a grok parser operator.
+func (c Config) Build(set component.TelemetrySettings) (operator.Operator, error) {
+	parserOperator, err := c.ParserConfig.Build(set)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.Pattern == "" {
+		return nil, errors.New("missing required field 'pattern'")
+	}
+
+	// the patterns of the ExtractGrokPatterns OTTL converter
+	g, err := grok.NewComplete()
+	if err != nil {
+		return nil, fmt.Errorf("initializing grok patterns: %w", err)
+	}
+	// the definitions of the files are overridden by the inline definitions
+	for _, path := range c.PatternFiles {
+		definitions, err := readPatternFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if err := g.AddPatterns(definitions); err != nil {
+			return nil, fmt.Errorf("adding patterns of %s: %w", path, err)
+		}
+	}
+	if err := g.AddPatterns(c.PatternDefinitions); err != nil {
+		return nil, fmt.Errorf("adding pattern definitions: %w", err)
+	}
+	if err := g.Compile(c.Pattern, c.NamedCapturesOnly); err != nil {
+		return nil, fmt.Errorf("compiling pattern: %w", err)
+	}
+	if !g.HasCaptureGroups() {
+		return nil, stanza_errors.NewError(
+			"no named captures in grok pattern",
+			"use patterns with a semantic like '%{IP:client}' to specify the key name for the parsed field",
+		)
+	}
+
+	op := &Parser{
+		ParserOperator: parserOperator,
+		grok:           g,
+	}
+
+	if c.Cache.Size > 0 {
+		op.cache = cache.New(c.Cache.Size)
+		set.Logger.Debug(
+			"configured memory cache",
+			zap.String("operator_id", op.ID()),
+			zap.Uint16("size", op.cache.MaxSize()),
+		)
+	}
+
+	return op, nil
+}
+
+// readPatternFile reads a file of pattern definitions in the Logstash format, with one "NAME pattern"
+// definition per line. Empty lines and lines starting with # are ignored.
+func readPatternFile(path string) (map[string]string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading pattern file: %w", err)
+	}
+	definitions := map[string]string{}
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		i := strings.IndexAny(text, " \t")
+		if i < 0 {
+			return nil, fmt.Errorf("invalid pattern definition at %s:%d, expecting 'NAME pattern'", path, line)
+		}
+		definitions[text[:i]] = strings.TrimSpace(text[i+1:])
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading pattern file: %w", err)
+	}
+	return definitions, nil
+}
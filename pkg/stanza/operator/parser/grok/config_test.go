@@ -0,0 +1,125 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package grok
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/operatortest"
+)
+
+func TestParserGoldenConfig(t *testing.T) {
+	operatortest.ConfigUnmarshalTests{
+		DefaultConfig: NewConfig(),
+		TestsFile:     filepath.Join(".", "testdata", "config.yaml"),
+		Tests: []operatortest.ConfigUnmarshalTest{
+			{
+				Name:   "default",
+				Expect: NewConfig(),
+			},
+			{
+				Name: "cache",
+				Expect: func() *Config {
+					cfg := NewConfig()
+					cfg.Cache.Size = 50
+					return cfg
+				}(),
+			},
+			{
+				Name: "pattern",
+				Expect: func() *Config {
+					cfg := NewConfig()
+					cfg.Pattern = "%{IP:client.ip} %{WORD:method} %{NUMBER:bytes:int}"
+					return cfg
+				}(),
+			},
+			{
+				Name: "named_captures_only",
+				Expect: func() *Config {
+					cfg := NewConfig()
+					cfg.Pattern = "%{IP} %{WORD:method}"
+					cfg.NamedCapturesOnly = false
+					return cfg
+				}(),
+			},
+			{
+				Name: "pattern_definitions",
+				Expect: func() *Config {
+					cfg := NewConfig()
+					cfg.Pattern = "%{REQUEST_ID:request_id} %{GREEDYDATA:message}"
+					cfg.PatternDefinitions = map[string]string{"REQUEST_ID": "req-[0-9a-f]{8}"}
+					return cfg
+				}(),
+			},
+			{
+				Name: "pattern_files",
+				Expect: func() *Config {
+					cfg := NewConfig()
+					cfg.Pattern = "%{REQUEST_ID:request_id} %{GREEDYDATA:message}"
+					cfg.PatternFiles = []string{"./testdata/patterns"}
+					return cfg
+				}(),
+			},
+			{
+				Name: "parse_from_simple",
+				Expect: func() *Config {
+					cfg := NewConfig()
+					cfg.ParseFrom = entry.NewBodyField("from")
+					return cfg
+				}(),
+			},
+			{
+				Name: "parse_to_simple",
+				Expect: func() *Config {
+					cfg := NewConfig()
+					cfg.ParseTo = entry.RootableField{Field: entry.NewBodyField("log")}
+					return cfg
+				}(),
+			},
+			{
+				Name: "on_error_drop",
+				Expect: func() *Config {
+					cfg := NewConfig()
+					cfg.OnError = "drop"
+					return cfg
+				}(),
+			},
+			{
+				Name: "timestamp",
+				Expect: func() *Config {
+					cfg := NewConfig()
+					parseField := entry.NewBodyField("timestamp_field")
+					newTime := helper.TimeParser{
+						LayoutType: "strptime",
+						Layout:     "%Y-%m-%d",
+						ParseFrom:  &parseField,
+					}
+					cfg.TimeParser = &newTime
+					return cfg
+				}(),
+			},
+			{
+				Name: "severity",
+				Expect: func() *Config {
+					cfg := NewConfig()
+					parseField := entry.NewBodyField("severity_field")
+					severityField := helper.NewSeverityConfig()
+					severityField.ParseFrom = &parseField
+					mapping := map[string]any{
+						"critical": "5xx",
+						"error":    "4xx",
+						"info":     "3xx",
+						"debug":    "2xx",
+					}
+					severityField.Mapping = mapping
+					cfg.SeverityConfig = &severityField
+					return cfg
+				}(),
+			},
+		},
+	}.Run(t)
+}
@@ -0,0 +1,80 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package grok // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/parser/grok"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/elastic/go-grok"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/parser/internal/cache"
+)
+
+// Parser is an operator that parses an entry with a grok pattern.
+type Parser struct {
+	helper.ParserOperator
+	grok  *grok.Grok
+	cache cache.Cache
+}
+
+func (p *Parser) Stop() error {
+	if p.cache != nil {
+		p.cache.Stop()
+	}
+	return nil
+}
+
+func (p *Parser) ProcessBatch(ctx context.Context, entries []*entry.Entry) error {
+	return p.ProcessBatchWith(ctx, entries, p.parse)
+}
+
+// Process will parse an entry with the grok pattern.
+func (p *Parser) Process(ctx context.Context, entry *entry.Entry) error {
+	return p.ProcessWith(ctx, entry, p.parse)
+}
+
+// parse will parse a value using the grok pattern.
+func (p *Parser) parse(value any) (any, error) {
+	var raw string
+	switch m := value.(type) {
+	case string:
+		raw = m
+	default:
+		return nil, fmt.Errorf("type '%T' cannot be parsed with grok", value)
+	}
+	return p.match(raw)
+}
+
+func (p *Parser) match(value string) (any, error) {
+	if p.cache != nil {
+		if x := p.cache.Get(value); x != nil {
+			return x, nil
+		}
+	}
+
+	matches, err := p.grok.ParseTypedString(value)
+	if err != nil {
+		return nil, err
+	}
+	if len(matches) == 0 && !p.grok.MatchString(value) {
+		return nil, errors.New("grok pattern does not match")
+	}
+	parsedValues := make(map[string]any, len(matches))
+	for k, v := range matches {
+		// the int and long types are parsed as int
+		if i, ok := v.(int); ok {
+			v = int64(i)
+		}
+		parsedValues[k] = v
+	}
+
+	if p.cache != nil {
+		p.cache.Add(value, parsedValues)
+	}
+	return parsedValues, nil
+}
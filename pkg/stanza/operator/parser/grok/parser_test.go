@@ -0,0 +1,273 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package grok
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/testutil"
+)
+
+func newTestParser(t *testing.T, pattern string, cacheSize uint16) *Parser {
+	cfg := NewConfigWithID("test")
+	cfg.Pattern = pattern
+	cfg.Cache.Size = cacheSize
+	set := componenttest.NewNopTelemetrySettings()
+	op, err := cfg.Build(set)
+	require.NoError(t, err)
+	return op.(*Parser)
+}
+
+func TestInit(t *testing.T) {
+	builder, ok := operator.DefaultRegistry.Lookup("grok_parser")
+	require.True(t, ok, "expected grok_parser to be registered")
+	require.Equal(t, "grok_parser", builder().Type())
+}
+
+func TestParserBuildFailure(t *testing.T) {
+	cfg := NewConfigWithID("test")
+	cfg.OnError = "invalid_on_error"
+	set := componenttest.NewNopTelemetrySettings()
+	_, err := cfg.Build(set)
+	require.ErrorContains(t, err, "invalid `on_error` field")
+}
+
+func TestParserByteFailure(t *testing.T) {
+	parser := newTestParser(t, "%{WORD:key}", 0)
+	_, err := parser.parse([]byte("invalid"))
+	require.ErrorContains(t, err, "type '[]uint8' cannot be parsed with grok")
+}
+
+func TestParserStringFailure(t *testing.T) {
+	parser := newTestParser(t, "^%{IP:ip}$", 0)
+	_, err := parser.parse("invalid")
+	require.ErrorContains(t, err, "grok pattern does not match")
+}
+
+func TestParserCache(t *testing.T) {
+	parser := newTestParser(t, "^%{WORD:key}", 200)
+	defer func() {
+		require.NoError(t, parser.Stop())
+	}()
+	_, err := parser.parse("cache")
+	require.NoError(t, err)
+	require.NotNil(t, parser.cache, "expected cache to be configured")
+	require.Equal(t, uint16(200), parser.cache.MaxSize())
+	require.Equal(t, map[string]any{"key": "cache"}, parser.cache.Get("cache"))
+}
+
+func TestParserGrok(t *testing.T) {
+	cases := []struct {
+		name      string
+		configure func(*Config)
+		input     *entry.Entry
+		expected  *entry.Entry
+	}{
+		{
+			"TypedCaptures",
+			func(p *Config) {
+				p.Pattern = "%{IP:client.ip} %{WORD:method} %{URIPATHPARAM:path} %{NUMBER:bytes:int} %{NUMBER:duration:float}"
+			},
+			&entry.Entry{
+				Body: "55.3.244.1 GET /index.html 15824 0.043",
+			},
+			&entry.Entry{
+				Body: "55.3.244.1 GET /index.html 15824 0.043",
+				Attributes: map[string]any{
+					"client.ip": "55.3.244.1",
+					"method":    "GET",
+					"path":      "/index.html",
+					"bytes":     int64(15824),
+					"duration":  0.043,
+				},
+			},
+		},
+		{
+			"NamedCapturesOnly",
+			func(p *Config) {
+				p.Pattern = "%{IP} %{WORD:method}"
+			},
+			&entry.Entry{
+				Body: "55.3.244.1 GET",
+			},
+			&entry.Entry{
+				Body: "55.3.244.1 GET",
+				Attributes: map[string]any{
+					"method": "GET",
+				},
+			},
+		},
+		{
+			"AllCaptures",
+			func(p *Config) {
+				p.Pattern = "%{IP} %{WORD:method}"
+				p.NamedCapturesOnly = false
+			},
+			&entry.Entry{
+				Body: "55.3.244.1 GET",
+			},
+			&entry.Entry{
+				Body: "55.3.244.1 GET",
+				Attributes: map[string]any{
+					"IP":     "55.3.244.1",
+					"IPV4":   "55.3.244.1",
+					"method": "GET",
+				},
+			},
+		},
+		{
+			"PatternDefinitions",
+			func(p *Config) {
+				p.Pattern = "%{REQUEST_ID:request_id} %{GREEDYDATA:message}"
+				p.PatternDefinitions = map[string]string{"REQUEST_ID": "req-[0-9a-f]{8}"}
+			},
+			&entry.Entry{
+				Body: "req-0a1b2c3d user logged in",
+			},
+			&entry.Entry{
+				Body: "req-0a1b2c3d user logged in",
+				Attributes: map[string]any{
+					"request_id": "req-0a1b2c3d",
+					"message":    "user logged in",
+				},
+			},
+		},
+		{
+			"PatternFiles",
+			func(p *Config) {
+				p.Pattern = "%{APP_LINE}"
+				p.PatternFiles = []string{filepath.Join("testdata", "patterns")}
+			},
+			&entry.Entry{
+				Body: "2024-01-02T03:04:05Z\tINFO req-0a1b2c3d user logged in",
+			},
+			&entry.Entry{
+				Body: "2024-01-02T03:04:05Z\tINFO req-0a1b2c3d user logged in",
+				Attributes: map[string]any{
+					"timestamp":  "2024-01-02T03:04:05Z",
+					"level":      "INFO",
+					"request_id": "req-0a1b2c3d",
+					"message":    "user logged in",
+				},
+			},
+		},
+		{
+			"PatternDefinitionsOverridePatternFiles",
+			func(p *Config) {
+				p.Pattern = "%{REQUEST_ID:request_id}"
+				p.PatternFiles = []string{filepath.Join("testdata", "patterns")}
+				p.PatternDefinitions = map[string]string{"REQUEST_ID": "[0-9]+"}
+			},
+			&entry.Entry{
+				Body: "request 42",
+			},
+			&entry.Entry{
+				Body: "request 42",
+				Attributes: map[string]any{
+					"request_id": "42",
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := NewConfigWithID("test")
+			cfg.OutputIDs = []string{"fake"}
+			tc.configure(cfg)
+
+			set := componenttest.NewNopTelemetrySettings()
+			op, err := cfg.Build(set)
+			require.NoError(t, err)
+
+			defer func() {
+				require.NoError(t, op.Stop())
+			}()
+
+			fake := testutil.NewFakeOutput(t)
+			require.NoError(t, op.SetOutputs([]operator.Operator{fake}))
+
+			ots := time.Now()
+			tc.input.ObservedTimestamp = ots
+			tc.expected.ObservedTimestamp = ots
+
+			err = op.Process(context.Background(), tc.input)
+			require.NoError(t, err)
+
+			fake.ExpectEntry(t, tc.expected)
+		})
+	}
+}
+
+func TestBuildParserGrok(t *testing.T) {
+	newBasicParser := func() *Config {
+		cfg := NewConfigWithID("test")
+		cfg.OutputIDs = []string{"test"}
+		cfg.Pattern = "%{GREEDYDATA:all}"
+		return cfg
+	}
+
+	t.Run("BasicConfig", func(t *testing.T) {
+		c := newBasicParser()
+		set := componenttest.NewNopTelemetrySettings()
+		_, err := c.Build(set)
+		require.NoError(t, err)
+	})
+
+	t.Run("MissingPatternField", func(t *testing.T) {
+		c := newBasicParser()
+		c.Pattern = ""
+		set := componenttest.NewNopTelemetrySettings()
+		_, err := c.Build(set)
+		require.ErrorContains(t, err, "missing required field 'pattern'")
+	})
+
+	t.Run("UnknownPattern", func(t *testing.T) {
+		c := newBasicParser()
+		c.Pattern = "%{UNKNOWN:all}"
+		set := componenttest.NewNopTelemetrySettings()
+		_, err := c.Build(set)
+		require.ErrorContains(t, err, "compiling pattern")
+	})
+
+	t.Run("NoNamedCaptures", func(t *testing.T) {
+		c := newBasicParser()
+		c.Pattern = "%{IP}"
+		set := componenttest.NewNopTelemetrySettings()
+		_, err := c.Build(set)
+		require.ErrorContains(t, err, "no named captures")
+	})
+
+	t.Run("InvalidPatternDefinitionName", func(t *testing.T) {
+		c := newBasicParser()
+		c.PatternDefinitions = map[string]string{"IN:VALID": ".*"}
+		set := componenttest.NewNopTelemetrySettings()
+		_, err := c.Build(set)
+		require.ErrorContains(t, err, "adding pattern definitions")
+	})
+
+	t.Run("MissingPatternFile", func(t *testing.T) {
+		c := newBasicParser()
+		c.PatternFiles = []string{filepath.Join("testdata", "missing")}
+		set := componenttest.NewNopTelemetrySettings()
+		_, err := c.Build(set)
+		require.ErrorContains(t, err, "reading pattern file")
+	})
+
+	t.Run("InvalidPatternFile", func(t *testing.T) {
+		c := newBasicParser()
+		c.PatternFiles = []string{filepath.Join("testdata", "invalid_patterns")}
+		set := componenttest.NewNopTelemetrySettings()
+		_, err := c.Build(set)
+		require.ErrorContains(t, err, "invalid pattern definition")
+	})
+}
@@ -1,7 +1,8 @@
 // Copyright The OpenTelemetry Authors
 // SPDX-License-Identifier: Apache-2.0
 
-package regex // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/parser/regex"
+// Package cache provides the cache of the parsed values of parser operators.
+package cache // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/parser/internal/cache"
 
 import (
 	"math"
@@ -10,13 +11,18 @@ import (
 	"time"
 )
 
-// cache allows operators to cache a value and look it up later
-type cache interface {
-	get(key string) any
-	add(key string, data any) bool
-	copy() map[string]any
-	maxSize() uint16
-	stop()
+// Cache allows operators to cache a value and look it up later
+type Cache interface {
+	Get(key string) any
+	Add(key string, data any) bool
+	Copy() map[string]any
+	MaxSize() uint16
+	Stop()
+}
+
+// New returns a new memory backed cache of the given size
+func New(maxSize uint16) Cache {
+	return newMemoryCache(maxSize, 0)
 }
 
 // newMemoryCache takes a cache size and a limiter interval and
@@ -55,10 +61,10 @@ type memoryCache struct {
 	limiter limiter
 }
 
-var _ cache = (&memoryCache{})
+var _ Cache = (&memoryCache{})
 
-// get returns a cached entry, nil if it does not exist
-func (m *memoryCache) get(key string) any {
+// Get returns a cached entry, nil if it does not exist
+func (m *memoryCache) Get(key string) any {
 	// Read and unlock as fast as possible
 	m.mutex.RLock()
 	data := m.cache[key]
@@ -67,9 +73,9 @@ func (m *memoryCache) get(key string) any {
 	return data
 }
 
-// add inserts an item into the cache, if the cache is full, the
+// Add inserts an item into the cache, if the cache is full, the
 // oldest item is removed
-func (m *memoryCache) add(key string, data any) bool {
+func (m *memoryCache) Add(key string, data any) bool {
 	if m.limiter.throttled() {
 		return false
 	}
@@ -94,8 +100,8 @@ func (m *memoryCache) add(key string, data any) bool {
 	return true
 }
 
-// copy returns a deep copy of the cache
-func (m *memoryCache) copy() map[string]any {
+// Copy returns a deep copy of the cache
+func (m *memoryCache) Copy() map[string]any {
 	cp := make(map[string]any, cap(m.keys))
 
 	m.mutex.Lock()
@@ -107,12 +113,13 @@ func (m *memoryCache) copy() map[string]any {
 	return cp
 }
 
-// maxSize returns the max size of the cache
-func (m *memoryCache) maxSize() uint16 {
+// MaxSize returns the max size of the cache
+func (m *memoryCache) MaxSize() uint16 {
 	return uint16(cap(m.keys))
 }
 
-func (m *memoryCache) stop() {
+// Stop stops the rate limiter of the cache
+func (m *memoryCache) Stop() {
 	m.limiter.stop()
 }
 
@@ -1,7 +1,7 @@
 // Copyright The OpenTelemetry Authors
 // SPDX-License-Identifier: Apache-2.0
 
-package regex
+package cache
 
 import (
 	"strconv"
@@ -32,7 +32,7 @@ func TestNewMemoryCache(t *testing.T) {
 
 	for _, tc := range cases {
 		output := newMemoryCache(tc.maxSize, 0)
-		defer output.stop()
+		defer output.Stop()
 		require.Equal(t, tc.expect.cache, output.cache)
 		require.Empty(t, output.cache, "new memory should always be empty")
 		require.Empty(t, output.keys, "new memory should always be empty")
@@ -73,10 +73,10 @@ func TestMemory(t *testing.T) {
 
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
-			defer tc.cache.stop()
+			defer tc.cache.Stop()
 			for key, value := range tc.input {
-				tc.cache.add(key, value)
-				out := tc.cache.get(key)
+				tc.cache.Add(key, value)
+				out := tc.cache.Get(key)
 				require.NotNil(t, out, "expected to get value from cache immediately after adding it")
 				require.Equal(t, value, out, "expected value to equal the value that was added to the cache")
 			}
@@ -84,7 +84,7 @@ func TestMemory(t *testing.T) {
 			require.Len(t, tc.cache.cache, len(tc.expect.cache))
 
 			for expectKey, expectItem := range tc.expect.cache {
-				actual := tc.cache.get(expectKey)
+				actual := tc.cache.Get(expectKey)
 				require.NotNil(t, actual)
 				require.Equal(t, expectItem, actual)
 			}
@@ -97,12 +97,12 @@ func TestCleanupLast(t *testing.T) {
 	maxSize := 10
 
 	m := newMemoryCache(uint16(maxSize), 0)
-	defer m.stop()
+	defer m.Stop()
 
 	// Add to cache until it is full
 	for i := 0; i <= cap(m.keys); i++ {
 		str := strconv.Itoa(i)
-		m.add(str, i)
+		m.Add(str, i)
 	}
 
 	// make sure the cache looks the way we expect
@@ -126,10 +126,10 @@ func TestCleanupLast(t *testing.T) {
 	// 1, 2, 3 and so on.
 	for i := 11; i <= 20; i++ {
 		str := strconv.Itoa(i)
-		m.add(str, i)
+		m.Add(str, i)
 
 		removedKey := strconv.Itoa(i - 10)
-		x := m.get(removedKey)
+		x := m.Get(removedKey)
 		require.Nil(t, x, "expected key %s to have been removed", removedKey)
 		require.Len(t, m.cache, maxSize)
 	}
@@ -242,7 +242,7 @@ func TestThrottledLimiter(t *testing.T) {
 
 func TestThrottledCache(t *testing.T) {
 	c := newMemoryCache(3, 120)
-	defer c.stop()
+	defer c.Stop()
 	require.False(t, c.limiter.throttled())
 	require.Equal(t, 4, int(c.limiter.limit()), "expected limit be cache size + 1")
 	require.Equal(t, float64(120), c.limiter.resetInterval().Seconds(), "expected reset interval to be 120 seconds")
@@ -251,7 +251,7 @@ func TestThrottledCache(t *testing.T) {
 	for i := 1; i <= 6; i++ {
 		key := strconv.Itoa(i)
 		value := i
-		c.add(key, value)
+		c.Add(key, value)
 		require.False(t, c.limiter.throttled())
 	}
 
@@ -261,12 +261,12 @@ func TestThrottledCache(t *testing.T) {
 
 	// 7th addition will be throttled because the cache
 	// has already reached 100% eviction rate
-	c.add("7", "should be limited")
+	c.Add("7", "should be limited")
 	require.True(t, c.limiter.throttled())
 
 	// 8th addition will skip adding to the cache
 	// because the 7th addition enabled the limiter
-	result := c.add("8", "add miss")
+	result := c.Add("8", "add miss")
 	require.True(t, c.limiter.throttled())
 	require.False(t, result, "expected add to return false when cache writes are throttled")
 }
@@ -0,0 +1,131 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package leef // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/parser/leef"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+)
+
+const prefix = "LEEF:"
+
+// headerFields are the names of the fields of the LEEF header, following the version.
+var headerFields = []string{"vendor", "product", "product_version", "event_id"}
+
+// Parser is an operator that parses Log Event Extended Format (LEEF) events.
+type Parser struct {
+	helper.ParserOperator
+	delimiter string
+}
+
+func (p *Parser) ProcessBatch(ctx context.Context, entries []*entry.Entry) error {
+	return p.ProcessBatchWith(ctx, entries, p.parse)
+}
+
+// Process will parse an entry for a LEEF event.
+func (p *Parser) Process(ctx context.Context, entry *entry.Entry) error {
+	return p.ProcessWith(ctx, entry, p.parse)
+}
+
+// parse will parse a value as a LEEF event.
+func (p *Parser) parse(value any) (any, error) {
+	switch m := value.(type) {
+	case string:
+		return p.parseLEEF(m)
+	case []byte:
+		return p.parseLEEF(string(m))
+	default:
+		return nil, fmt.Errorf("type %T cannot be parsed as LEEF", value)
+	}
+}
+
+// parseLEEF parses a LEEF event. Any text preceding the LEEF prefix, like a syslog header, is
+// ignored.
+func (p *Parser) parseLEEF(input string) (map[string]any, error) {
+	start := strings.Index(input, prefix)
+	if start < 0 {
+		return nil, errors.New("missing LEEF prefix")
+	}
+	input = input[start+len(prefix):]
+
+	parsed := make(map[string]any, len(headerFields)+2)
+	version, input, ok := strings.Cut(input, "|")
+	if !ok {
+		return nil, fmt.Errorf("expected %d header fields, found 0", len(headerFields)+1)
+	}
+	version = strings.TrimSpace(version)
+	parsed["version"] = version
+	for i, name := range headerFields {
+		var field string
+		if field, input, ok = strings.Cut(input, "|"); !ok {
+			return nil, fmt.Errorf("expected %d header fields, found %d", len(headerFields)+1, i+1)
+		}
+		parsed[name] = field
+	}
+
+	delimiter := p.delimiter
+	// LEEF 2.0 events declare the delimiter of their attributes
+	if version != "1.0" {
+		if field, rest, ok := strings.Cut(input, "|"); ok && !strings.Contains(field, "=") {
+			input = rest
+			if field != "" {
+				var err error
+				if delimiter, err = parseDelimiter(field); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	attributes := parseAttributes(input, delimiter)
+	if len(attributes) > 0 {
+		parsed["event_attributes"] = attributes
+	}
+	return parsed, nil
+}
+
+// parseDelimiter parses the delimiter of a LEEF 2.0 event, which is a character or its hexadecimal
+// code prefixed with x or 0x.
+func parseDelimiter(field string) (string, error) {
+	if len(field) == 1 {
+		return field, nil
+	}
+	code, ok := strings.CutPrefix(strings.ToLower(field), "0x")
+	if !ok {
+		code, ok = strings.CutPrefix(strings.ToLower(field), "x")
+	}
+	if !ok {
+		return "", fmt.Errorf("invalid delimiter %q", field)
+	}
+	c, err := strconv.ParseUint(code, 16, 32)
+	if err != nil {
+		return "", fmt.Errorf("invalid delimiter %q: %w", field, err)
+	}
+	return string(rune(c)), nil
+}
+
+// parseAttributes parses the delimited key value pairs of the event attributes. The pairs without
+// an equal sign are ignored.
+func parseAttributes(input, delimiter string) map[string]any {
+	input = strings.TrimRight(input, "\r\n")
+	if input == "" {
+		return nil
+	}
+	attributes := map[string]any{}
+	for _, pair := range strings.Split(input, delimiter) {
+		key, value, ok := strings.Cut(pair, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			continue
+		}
+		attributes[key] = value
+	}
+	return attributes
+}
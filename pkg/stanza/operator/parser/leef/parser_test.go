@@ -0,0 +1,232 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package leef
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+)
+
+func newTestParser(t *testing.T) *Parser {
+	cfg := NewConfigWithID("test")
+	set := componenttest.NewNopTelemetrySettings()
+	op, err := cfg.Build(set)
+	require.NoError(t, err)
+	return op.(*Parser)
+}
+
+func TestInit(t *testing.T) {
+	builder, ok := operator.DefaultRegistry.Lookup("leef_parser")
+	require.True(t, ok, "expected leef_parser to be registered")
+	require.Equal(t, "leef_parser", builder().Type())
+}
+
+func TestParserBuildFailure(t *testing.T) {
+	cfg := NewConfigWithID("test")
+	cfg.OnError = "invalid_on_error"
+	set := componenttest.NewNopTelemetrySettings()
+	_, err := cfg.Build(set)
+	require.ErrorContains(t, err, "invalid `on_error` field")
+}
+
+func TestParserBuildMissingDelimiter(t *testing.T) {
+	cfg := NewConfigWithID("test")
+	cfg.Delimiter = ""
+	set := componenttest.NewNopTelemetrySettings()
+	_, err := cfg.Build(set)
+	require.ErrorContains(t, err, "delimiter is a required parameter")
+}
+
+func TestParserInvalidType(t *testing.T) {
+	parser := newTestParser(t)
+	_, err := parser.parse([]int{})
+	require.ErrorContains(t, err, "type []int cannot be parsed as LEEF")
+}
+
+func TestProcess(t *testing.T) {
+	cases := []struct {
+		name   string
+		op     func() (operator.Operator, error)
+		input  *entry.Entry
+		expect *entry.Entry
+	}{
+		{
+			"default",
+			func() (operator.Operator, error) {
+				cfg := NewConfigWithID("test_id")
+				set := componenttest.NewNopTelemetrySettings()
+				return cfg.Build(set)
+			},
+			&entry.Entry{
+				Body: "LEEF:1.0|Microsoft|MSExchange|4.0 SP1|15345|src=192.0.2.0\tdst=172.50.123.1",
+			},
+			&entry.Entry{
+				Attributes: map[string]any{
+					"version":         "1.0",
+					"vendor":          "Microsoft",
+					"product":         "MSExchange",
+					"product_version": "4.0 SP1",
+					"event_id":        "15345",
+					"event_attributes": map[string]any{
+						"src": "192.0.2.0",
+						"dst": "172.50.123.1",
+					},
+				},
+				Body: "LEEF:1.0|Microsoft|MSExchange|4.0 SP1|15345|src=192.0.2.0\tdst=172.50.123.1",
+			},
+		},
+		{
+			"delimiter",
+			func() (operator.Operator, error) {
+				cfg := NewConfigWithID("test_id")
+				cfg.Delimiter = "|"
+				cfg.ParseTo = entry.RootableField{Field: entry.NewBodyField()}
+				set := componenttest.NewNopTelemetrySettings()
+				return cfg.Build(set)
+			},
+			&entry.Entry{
+				Body: "LEEF:1.0|Vendor|Product|1.0|login|usrName=admin|devTime=1700000000000",
+			},
+			&entry.Entry{
+				Body: map[string]any{
+					"version":         "1.0",
+					"vendor":          "Vendor",
+					"product":         "Product",
+					"product_version": "1.0",
+					"event_id":        "login",
+					"event_attributes": map[string]any{
+						"usrName": "admin",
+						"devTime": "1700000000000",
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			op, err := tc.op()
+			require.NoError(t, err, "did not expect operator function to return an error, this is a bug with the test case")
+
+			err = op.Process(context.Background(), tc.input)
+			require.NoError(t, err)
+			require.Equal(t, tc.expect, tc.input)
+		})
+	}
+}
+
+func TestParserParse(t *testing.T) {
+	header := func(version string, attributes map[string]any) map[string]any {
+		m := map[string]any{
+			"version":         version,
+			"vendor":          "Lancope",
+			"product":         "StealthWatch",
+			"product_version": "1.0",
+			"event_id":        "41",
+		}
+		if attributes != nil {
+			m["event_attributes"] = attributes
+		}
+		return m
+	}
+
+	cases := []struct {
+		name       string
+		inputBody  any
+		outputBody map[string]any
+		expectErr  bool
+	}{
+		{
+			"leef 1.0",
+			"LEEF:1.0|Lancope|StealthWatch|1.0|41|src=10.0.1.8\tdst=10.0.0.5\tmsg=a=b c",
+			header("1.0", map[string]any{"src": "10.0.1.8", "dst": "10.0.0.5", "msg": "a=b c"}),
+			false,
+		},
+		{
+			"bytes",
+			[]byte("LEEF:1.0|Lancope|StealthWatch|1.0|41|src=10.0.1.8"),
+			header("1.0", map[string]any{"src": "10.0.1.8"}),
+			false,
+		},
+		{
+			"syslog header",
+			"<13>Jan 18 11:07:53 host LEEF:1.0|Lancope|StealthWatch|1.0|41|src=10.0.1.8",
+			header("1.0", map[string]any{"src": "10.0.1.8"}),
+			false,
+		},
+		{
+			"no attributes",
+			"LEEF:1.0|Lancope|StealthWatch|1.0|41|",
+			header("1.0", nil),
+			false,
+		},
+		{
+			"leef 2.0 character delimiter",
+			"LEEF:2.0|Lancope|StealthWatch|1.0|41|^|src=10.0.1.8^dst=10.0.0.5",
+			header("2.0", map[string]any{"src": "10.0.1.8", "dst": "10.0.0.5"}),
+			false,
+		},
+		{
+			"leef 2.0 hexadecimal delimiter",
+			"LEEF:2.0|Lancope|StealthWatch|1.0|41|0x5E|src=10.0.1.8^dst=10.0.0.5",
+			header("2.0", map[string]any{"src": "10.0.1.8", "dst": "10.0.0.5"}),
+			false,
+		},
+		{
+			"leef 2.0 short hexadecimal delimiter",
+			"LEEF:2.0|Lancope|StealthWatch|1.0|41|x5e|src=10.0.1.8^dst=10.0.0.5",
+			header("2.0", map[string]any{"src": "10.0.1.8", "dst": "10.0.0.5"}),
+			false,
+		},
+		{
+			"leef 2.0 empty delimiter",
+			"LEEF:2.0|Lancope|StealthWatch|1.0|41||src=10.0.1.8\tdst=10.0.0.5",
+			header("2.0", map[string]any{"src": "10.0.1.8", "dst": "10.0.0.5"}),
+			false,
+		},
+		{
+			"leef 2.0 missing delimiter",
+			"LEEF:2.0|Lancope|StealthWatch|1.0|41|src=10.0.1.8\tdst=10.0.0.5",
+			header("2.0", map[string]any{"src": "10.0.1.8", "dst": "10.0.0.5"}),
+			false,
+		},
+		{
+			"leef 2.0 invalid delimiter",
+			"LEEF:2.0|Lancope|StealthWatch|1.0|41|ab|src=10.0.1.8",
+			nil,
+			true,
+		},
+		{
+			"missing prefix",
+			"1.0|Lancope|StealthWatch|1.0|41|src=10.0.1.8",
+			nil,
+			true,
+		},
+		{
+			"missing header fields",
+			"LEEF:1.0|Lancope|StealthWatch",
+			nil,
+			true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			parser := newTestParser(t)
+			x, err := parser.parse(tc.inputBody)
+			if tc.expectErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.outputBody, x)
+		})
+	}
+}
@@ -14,6 +14,7 @@ import (
 	stanza_errors "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/errors"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/parser/internal/cache"
 )
 
 const operatorType = "regex_parser"
@@ -80,11 +81,11 @@ func (c Config) Build(set component.TelemetrySettings) (operator.Operator, error
 	}
 
 	if c.Cache.Size > 0 {
-		op.cache = newMemoryCache(c.Cache.Size, 0)
+		op.cache = cache.New(c.Cache.Size)
 		set.Logger.Debug(
 			"configured memory cache",
 			zap.String("operator_id", op.ID()),
-			zap.Uint16("size", op.cache.maxSize()),
+			zap.Uint16("size", op.cache.MaxSize()),
 		)
 	}
 
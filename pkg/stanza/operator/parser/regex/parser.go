@@ -10,18 +10,19 @@ import (
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/parser/internal/cache"
 )
 
 // Parser is an operator that parses regex in an entry.
 type Parser struct {
 	helper.ParserOperator
 	regexp *regexp.Regexp
-	cache  cache
+	cache  cache.Cache
 }
 
 func (p *Parser) Stop() error {
 	if p.cache != nil {
-		p.cache.stop()
+		p.cache.Stop()
 	}
 	return nil
 }
@@ -49,7 +50,7 @@ func (p *Parser) parse(value any) (any, error) {
 
 func (p *Parser) match(value string) (any, error) {
 	if p.cache != nil {
-		if x := p.cache.get(value); x != nil {
+		if x := p.cache.Get(value); x != nil {
 			return x, nil
 		}
 	}
@@ -60,7 +61,7 @@ func (p *Parser) match(value string) (any, error) {
 	}
 
 	if p.cache != nil {
-		p.cache.add(value, parsedValues)
+		p.cache.Add(value, parsedValues)
 	}
 
 	return parsedValues, nil
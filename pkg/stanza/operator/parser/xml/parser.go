@@ -0,0 +1,53 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package xml // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/parser/xml"
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/parseutils"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+)
+
+// Parser is an operator that parses XML.
+type Parser struct {
+	helper.ParserOperator
+}
+
+func (p *Parser) ProcessBatch(ctx context.Context, entries []*entry.Entry) error {
+	return p.ProcessBatchWith(ctx, entries, p.parse)
+}
+
+// Process will parse an entry for XML.
+func (p *Parser) Process(ctx context.Context, entry *entry.Entry) error {
+	return p.ProcessWith(ctx, entry, p.parse)
+}
+
+// parse will parse a value as XML.
+func (*Parser) parse(value any) (any, error) {
+	var input string
+	switch m := value.(type) {
+	case string:
+		input = m
+	case []byte:
+		input = string(m)
+	default:
+		return nil, fmt.Errorf("type %T cannot be parsed as XML", value)
+	}
+
+	element := parseutils.XMLElement{}
+	decoder := xml.NewDecoder(strings.NewReader(input))
+	if err := decoder.Decode(&element); err != nil {
+		return nil, fmt.Errorf("unmarshal xml: %w", err)
+	}
+	if strings.TrimSpace(input[decoder.InputOffset():]) != "" {
+		return nil, errors.New("trailing bytes after parsing xml")
+	}
+	return element.AsMap(), nil
+}
@@ -0,0 +1,204 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package xml
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+)
+
+func newTestParser(t *testing.T) *Parser {
+	cfg := NewConfigWithID("test")
+	set := componenttest.NewNopTelemetrySettings()
+	op, err := cfg.Build(set)
+	require.NoError(t, err)
+	return op.(*Parser)
+}
+
+func TestInit(t *testing.T) {
+	builder, ok := operator.DefaultRegistry.Lookup("xml_parser")
+	require.True(t, ok, "expected xml_parser to be registered")
+	require.Equal(t, "xml_parser", builder().Type())
+}
+
+func TestParserBuildFailure(t *testing.T) {
+	cfg := NewConfigWithID("test")
+	cfg.OnError = "invalid_on_error"
+	set := componenttest.NewNopTelemetrySettings()
+	_, err := cfg.Build(set)
+	require.ErrorContains(t, err, "invalid `on_error` field")
+}
+
+func TestParserInvalidType(t *testing.T) {
+	parser := newTestParser(t)
+	_, err := parser.parse([]int{})
+	require.ErrorContains(t, err, "type []int cannot be parsed as XML")
+}
+
+func TestProcess(t *testing.T) {
+	cases := []struct {
+		name   string
+		op     func() (operator.Operator, error)
+		input  *entry.Entry
+		expect *entry.Entry
+	}{
+		{
+			"default",
+			func() (operator.Operator, error) {
+				cfg := NewConfigWithID("test_id")
+				set := componenttest.NewNopTelemetrySettings()
+				return cfg.Build(set)
+			},
+			&entry.Entry{
+				Body: `<Event id="4625"><Level>error</Level></Event>`,
+			},
+			&entry.Entry{
+				Attributes: map[string]any{
+					"tag": "Event",
+					"attributes": map[string]any{
+						"id": "4625",
+					},
+					"children": []any{
+						map[string]any{
+							"tag":     "Level",
+							"content": "error",
+						},
+					},
+				},
+				Body: `<Event id="4625"><Level>error</Level></Event>`,
+			},
+		},
+		{
+			"parse-to",
+			func() (operator.Operator, error) {
+				cfg := NewConfigWithID("test_id")
+				cfg.ParseFrom = entry.NewBodyField("message")
+				cfg.ParseTo = entry.RootableField{Field: entry.NewBodyField("parsed")}
+				set := componenttest.NewNopTelemetrySettings()
+				return cfg.Build(set)
+			},
+			&entry.Entry{
+				Body: map[string]any{
+					"message": `<Level>error</Level>`,
+				},
+			},
+			&entry.Entry{
+				Body: map[string]any{
+					"message": `<Level>error</Level>`,
+					"parsed": map[string]any{
+						"tag":     "Level",
+						"content": "error",
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			op, err := tc.op()
+			require.NoError(t, err, "did not expect operator function to return an error, this is a bug with the test case")
+
+			err = op.Process(context.Background(), tc.input)
+			require.NoError(t, err)
+			require.Equal(t, tc.expect, tc.input)
+		})
+	}
+}
+
+func TestParserParse(t *testing.T) {
+	cases := []struct {
+		name       string
+		inputBody  any
+		outputBody map[string]any
+		expectErr  bool
+	}{
+		{
+			"nested",
+			`<?xml version="1.0"?>
+<!-- audit event -->
+<Event xmlns="http://schemas.microsoft.com/win/2004/08/events/event">
+  <System>
+    <Provider Name="Security"/>
+    <EventID>4625</EventID>
+  </System>
+  <Data Name="TargetUserName">admin</Data>
+</Event>
+`,
+			map[string]any{
+				"tag": "Event",
+				"attributes": map[string]any{
+					"xmlns": "http://schemas.microsoft.com/win/2004/08/events/event",
+				},
+				"children": []any{
+					map[string]any{
+						"tag": "System",
+						"children": []any{
+							map[string]any{
+								"tag":        "Provider",
+								"attributes": map[string]any{"Name": "Security"},
+							},
+							map[string]any{
+								"tag":     "EventID",
+								"content": "4625",
+							},
+						},
+					},
+					map[string]any{
+						"tag":        "Data",
+						"attributes": map[string]any{"Name": "TargetUserName"},
+						"content":    "admin",
+					},
+				},
+			},
+			false,
+		},
+		{
+			"bytes",
+			[]byte(`<a>b</a>`),
+			map[string]any{
+				"tag":     "a",
+				"content": "b",
+			},
+			false,
+		},
+		{
+			"empty",
+			"",
+			nil,
+			true,
+		},
+		{
+			"unclosed",
+			`<a><b></a>`,
+			nil,
+			true,
+		},
+		{
+			"trailing",
+			`<a></a><b></b>`,
+			nil,
+			true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			parser := Parser{}
+			x, err := parser.parse(tc.inputBody)
+			if tc.expectErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.outputBody, x)
+		})
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package fanout // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/transformer/fanout"
+
+import (
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+)
+
+const operatorType = "fanout"
+
+func init() {
+	operator.Register(operatorType, func() operator.Builder { return NewConfig() })
+}
+
+// NewConfig creates a new fanout operator config with default values
+func NewConfig() *Config {
+	return NewConfigWithID(operatorType)
+}
+
+// NewConfigWithID creates a new fanout operator config with default values
+func NewConfigWithID(operatorID string) *Config {
+	return &Config{
+		BasicConfig: helper.NewBasicConfig(operatorID, operatorType),
+	}
+}
+
+// Config is the configuration of a fanout operator
+type Config struct {
+	helper.BasicConfig `mapstructure:",squash"`
+	Routes             []*RouteConfig `mapstructure:"routes"`
+	Default            []string       `mapstructure:"default"`
+}
+
+// RouteConfig is the configuration of a route on a fanout operator
+type RouteConfig struct {
+	helper.AttributerConfig `mapstructure:",squash"`
+	Expression              string   `mapstructure:"expr"`
+	OutputIDs               []string `mapstructure:"output"`
+}
+
+// Build will build a fanout operator from the supplied configuration
+func (c Config) Build(set component.TelemetrySettings) (operator.Operator, error) {
+	basicOperator, err := c.BasicConfig.Build(set)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(c.Routes) == 0 {
+		return nil, errors.New("at least one route must be specified")
+	}
+
+	routes := make([]*Route, 0, len(c.Routes)+1)
+	for i, routeConfig := range c.Routes {
+		if len(routeConfig.OutputIDs) == 0 {
+			return nil, fmt.Errorf("route %d must have an output", i)
+		}
+
+		// A route without an expression receives every entry
+		expression := routeConfig.Expression
+		if expression == "" {
+			expression = "true"
+		}
+		compiled, err := helper.ExprCompileBool(expression)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compile expression '%s': %w", expression, err)
+		}
+
+		attributer, err := routeConfig.Build()
+		if err != nil {
+			return nil, fmt.Errorf("failed to build attributer for route '%s': %w", expression, err)
+		}
+
+		routes = append(routes, &Route{
+			Attributer: attributer,
+			Expression: compiled,
+			OutputIDs:  routeConfig.OutputIDs,
+		})
+	}
+
+	return &Transformer{
+		RouterOperator: helper.RouterOperator{
+			BasicOperator: basicOperator,
+			Routes:        append(routes, &Route{OutputIDs: c.Default}),
+		},
+	}, nil
+}
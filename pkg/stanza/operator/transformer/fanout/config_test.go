@@ -0,0 +1,75 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package fanout
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/operatortest"
+)
+
+func TestFanoutGoldenConfig(t *testing.T) {
+	operatortest.ConfigUnmarshalTests{
+		DefaultConfig: NewConfig(),
+		TestsFile:     filepath.Join(".", "testdata", "config.yaml"),
+		Tests: []operatortest.ConfigUnmarshalTest{
+			{
+				Name:   "default",
+				Expect: NewConfig(),
+			},
+			{
+				Name: "routes_attributes",
+				Expect: func() *Config {
+					cfg := NewConfig()
+					attVal := helper.NewAttributerConfig()
+					attVal.Attributes = map[string]helper.ExprStringConfig{
+						"key1": "val1",
+					}
+					cfg.Routes = []*RouteConfig{
+						{
+							Expression:       `attributes.tenant == "a"`,
+							OutputIDs:        []string{"tenant_a"},
+							AttributerConfig: attVal,
+						},
+						{
+							OutputIDs: []string{"archive"},
+						},
+					}
+					return cfg
+				}(),
+			},
+			{
+				Name: "routes_default",
+				Expect: func() *Config {
+					cfg := NewConfig()
+					cfg.Routes = []*RouteConfig{
+						{
+							Expression: `attributes.tenant == "a"`,
+							OutputIDs:  []string{"tenant_a"},
+						},
+					}
+					cfg.Default = []string{"catchall"}
+					return cfg
+				}(),
+			},
+			{
+				Name: "routes_multi_output",
+				Expect: func() *Config {
+					cfg := NewConfig()
+					cfg.Routes = []*RouteConfig{
+						{
+							OutputIDs: []string{"tenant_a", "tenant_b"},
+						},
+						{
+							OutputIDs: []string{"archive"},
+						},
+					}
+					return cfg
+				}(),
+			},
+		},
+	}.Run(t)
+}
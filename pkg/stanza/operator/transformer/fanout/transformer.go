@@ -0,0 +1,96 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package fanout // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/transformer/fanout"
+
+import (
+	"context"
+	"errors"
+
+	"github.com/expr-lang/expr/vm"
+	"go.uber.org/multierr"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+)
+
+// Transformer is an operator that sends a copy of each entry to every route with a matching expression
+type Transformer struct {
+	// Routes holds the default route last, which receives the entries matched by no other route
+	helper.RouterOperator
+}
+
+// Route is a route on a fanout operator
+type Route = helper.Route
+
+// CanProcess will always return true for a fanout operator
+func (*Transformer) CanProcess() bool {
+	return true
+}
+
+func (t *Transformer) ProcessBatch(ctx context.Context, entries []*entry.Entry) error {
+	var errs error
+	for i := range entries {
+		errs = multierr.Append(errs, t.Process(ctx, entries[i]))
+	}
+	return errs
+}
+
+// Process will send a copy of the entry to the outputs of every matching route
+func (t *Transformer) Process(ctx context.Context, entry *entry.Entry) error {
+	if entry == nil {
+		return errors.New("got a nil entry, this should not happen and is potentially a bug")
+	}
+
+	// All expressions are evaluated before any route labels the entry
+	matched := t.match(entry)
+	if len(matched) == 0 {
+		matched = append(matched, t.Routes[len(t.Routes)-1])
+	}
+
+	for i, route := range matched {
+		// The last route receives the original entry, the others a copy
+		routeEntry := entry
+		if i < len(matched)-1 {
+			routeEntry = entry.Copy()
+		}
+
+		if err := route.Attribute(routeEntry); err != nil {
+			t.Logger().Error("Failed to label entry", helper.RouteLogFields(entry, err)...)
+			return err
+		}
+
+		for j, output := range route.OutputOperators {
+			outputEntry := routeEntry
+			if j < len(route.OutputOperators)-1 {
+				outputEntry = routeEntry.Copy()
+			}
+			if err := output.Process(ctx, outputEntry); err != nil {
+				t.Logger().Error("Failed to process entry", helper.RouteLogFields(entry, err)...)
+			}
+		}
+	}
+
+	return nil
+}
+
+// match returns the routes whose expression matches the entry.
+func (t *Transformer) match(entry *entry.Entry) []*Route {
+	env := helper.GetExprEnv(entry)
+	defer helper.PutExprEnv(env)
+
+	var matched []*Route
+	for _, route := range t.Routes[:len(t.Routes)-1] {
+		matches, err := vm.Run(route.Expression, env)
+		if err != nil {
+			t.Logger().Warn("Running expression returned an error", helper.RouteLogFields(entry, err)...)
+			continue
+		}
+
+		// we compile the expression with "AsBool", so this should be safe
+		if matches.(bool) {
+			matched = append(matched, route)
+		}
+	}
+	return matched
+}
@@ -0,0 +1,183 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package fanout
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/testutil"
+)
+
+func TestBuildInvalid(t *testing.T) {
+	t.Run("NoRoutes", func(t *testing.T) {
+		cfg := NewConfigWithID("test")
+		_, err := cfg.Build(componenttest.NewNopTelemetrySettings())
+		require.ErrorContains(t, err, "at least one route")
+	})
+
+	t.Run("NoOutput", func(t *testing.T) {
+		cfg := NewConfigWithID("test")
+		cfg.Routes = []*RouteConfig{{Expression: "true"}}
+		_, err := cfg.Build(componenttest.NewNopTelemetrySettings())
+		require.ErrorContains(t, err, "must have an output")
+	})
+
+	t.Run("InvalidExpression", func(t *testing.T) {
+		cfg := NewConfigWithID("test")
+		cfg.Routes = []*RouteConfig{{Expression: "body ==", OutputIDs: []string{"output1"}}}
+		_, err := cfg.Build(componenttest.NewNopTelemetrySettings())
+		require.ErrorContains(t, err, "failed to compile expression")
+	})
+}
+
+func TestTransformer(t *testing.T) {
+	withAttributes := func(attributes map[string]helper.ExprStringConfig) helper.AttributerConfig {
+		cfg := helper.NewAttributerConfig()
+		cfg.Attributes = attributes
+		return cfg
+	}
+
+	cases := []struct {
+		name          string
+		routes        []*RouteConfig
+		defaultOutput []string
+		// expected are the attributes of the entries received by each output
+		expected map[string][]map[string]any
+	}{
+		{
+			name: "AllRoutes",
+			routes: []*RouteConfig{
+				{OutputIDs: []string{"output1"}},
+				{OutputIDs: []string{"output2", "output3"}},
+			},
+			expected: map[string][]map[string]any{
+				"output1": {{"tenant": "a"}},
+				"output2": {{"tenant": "a"}},
+				"output3": {{"tenant": "a"}},
+			},
+		},
+		{
+			name: "MatchingRoutes",
+			routes: []*RouteConfig{
+				{Expression: `attributes.tenant == "a"`, OutputIDs: []string{"output1"}},
+				{Expression: `attributes.tenant == "b"`, OutputIDs: []string{"output2"}},
+				{Expression: `attributes.tenant != nil`, OutputIDs: []string{"output3"}},
+			},
+			expected: map[string][]map[string]any{
+				"output1": {{"tenant": "a"}},
+				"output3": {{"tenant": "a"}},
+			},
+		},
+		{
+			name: "RouteAttributes",
+			routes: []*RouteConfig{
+				{
+					AttributerConfig: withAttributes(map[string]helper.ExprStringConfig{"route": "first"}),
+					Expression:       `attributes.route == nil`,
+					OutputIDs:        []string{"output1"},
+				},
+				{
+					AttributerConfig: withAttributes(map[string]helper.ExprStringConfig{"route": "second"}),
+					Expression:       `attributes.route == nil`,
+					OutputIDs:        []string{"output2"},
+				},
+			},
+			expected: map[string][]map[string]any{
+				"output1": {{"tenant": "a", "route": "first"}},
+				"output2": {{"tenant": "a", "route": "second"}},
+			},
+		},
+		{
+			name: "NoMatch",
+			routes: []*RouteConfig{
+				{Expression: `false`, OutputIDs: []string{"output1"}},
+			},
+			expected: map[string][]map[string]any{},
+		},
+		{
+			name: "UseDefault",
+			routes: []*RouteConfig{
+				{Expression: `false`, OutputIDs: []string{"output1"}},
+			},
+			defaultOutput: []string{"output2"},
+			expected: map[string][]map[string]any{
+				"output2": {{"tenant": "a"}},
+			},
+		},
+		{
+			name: "MatchBeforeDefault",
+			routes: []*RouteConfig{
+				{OutputIDs: []string{"output1"}},
+			},
+			defaultOutput: []string{"output2"},
+			expected: map[string][]map[string]any{
+				"output1": {{"tenant": "a"}},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := NewConfigWithID("test")
+			cfg.Routes = tc.routes
+			cfg.Default = tc.defaultOutput
+
+			set := componenttest.NewNopTelemetrySettings()
+			op, err := cfg.Build(set)
+			require.NoError(t, err)
+
+			received := map[string][]map[string]any{}
+			seen := map[*entry.Entry]bool{}
+			outputs := make([]operator.Operator, 0, 3)
+			for _, id := range []string{"output1", "output2", "output3"} {
+				output := testutil.NewMockOperator(id)
+				output.On("Process", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+					e := args[1].(*entry.Entry)
+					require.False(t, seen[e], "outputs must receive distinct entries")
+					seen[e] = true
+					received[id] = append(received[id], e.Attributes)
+				})
+				outputs = append(outputs, output)
+			}
+			require.NoError(t, op.SetOutputs(outputs))
+
+			input := entry.New()
+			input.AddAttribute("tenant", "a")
+			require.NoError(t, op.ProcessBatch(context.Background(), []*entry.Entry{input}))
+			require.Equal(t, tc.expected, received)
+		})
+	}
+}
+
+func TestOutputs(t *testing.T) {
+	cfg := NewConfigWithID("test")
+	cfg.Routes = []*RouteConfig{
+		{OutputIDs: []string{"output1", "output2"}},
+		{Expression: "false", OutputIDs: []string{"output3"}},
+	}
+	cfg.Default = []string{"output4"}
+
+	op, err := cfg.Build(componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	require.Equal(t, []string{"output1", "output2", "output3", "output4"}, op.GetOutputIDs())
+
+	outputs := []operator.Operator{
+		testutil.NewMockOperator("output1"),
+		testutil.NewMockOperator("output2"),
+		testutil.NewMockOperator("output3"),
+	}
+	require.ErrorContains(t, op.SetOutputs(outputs), "operator output4 does not exist")
+
+	outputs = append(outputs, testutil.NewMockOperator("output4"))
+	require.NoError(t, op.SetOutputs(outputs))
+	require.Equal(t, outputs, op.Outputs())
+}
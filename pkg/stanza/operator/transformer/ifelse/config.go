@@ -0,0 +1,91 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ifelse // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/transformer/ifelse"
+
+import (
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+)
+
+const operatorType = "if"
+
+func init() {
+	operator.Register(operatorType, func() operator.Builder { return NewConfig() })
+}
+
+// NewConfig creates a new if operator config with default values
+func NewConfig() *Config {
+	return NewConfigWithID(operatorType)
+}
+
+// NewConfigWithID creates a new if operator config with default values
+func NewConfigWithID(operatorID string) *Config {
+	return &Config{
+		BasicConfig: helper.NewBasicConfig(operatorID, operatorType),
+	}
+}
+
+// Config is the configuration of an if operator. The operators of the then and else branches are
+// part of the pipeline, and the branches rejoin the pipeline at the outputs of the if operator.
+type Config struct {
+	helper.BasicConfig `mapstructure:",squash"`
+	Expression         string            `mapstructure:"expr"`
+	Then               []operator.Config `mapstructure:"then"`
+	Else               []operator.Config `mapstructure:"else"`
+	OutputIDs          []string          `mapstructure:"output"`
+
+	// branchOutputIDs are the ids of the operators receiving the entries of each branch,
+	// set by the pipeline.
+	branchOutputIDs [][]string
+}
+
+// Branches returns the operators of the then and else branches.
+func (c *Config) Branches() [][]operator.Config {
+	return [][]operator.Config{c.Then, c.Else}
+}
+
+// JoinIDs returns the ids of the operators where the branches rejoin the pipeline.
+func (c *Config) JoinIDs() []string {
+	return c.OutputIDs
+}
+
+// SetBranchOutputIDs sets the ids of the operators receiving the entries of the then and else branches.
+func (c *Config) SetBranchOutputIDs(outputIDs [][]string) {
+	c.branchOutputIDs = outputIDs
+}
+
+// Build will build an if operator from the supplied configuration
+func (c Config) Build(set component.TelemetrySettings) (operator.Operator, error) {
+	basicOperator, err := c.BasicConfig.Build(set)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.Expression == "" {
+		return nil, errors.New("missing required field 'expr'")
+	}
+
+	compiled, err := helper.ExprCompileBool(c.Expression)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile expression '%s': %w", c.Expression, err)
+	}
+
+	// Outside of a pipeline, both branches send entries straight to the outputs
+	thenIDs, elseIDs := c.OutputIDs, c.OutputIDs
+	if len(c.branchOutputIDs) == 2 {
+		thenIDs, elseIDs = c.branchOutputIDs[0], c.branchOutputIDs[1]
+	}
+
+	return &Transformer{
+		BasicOperator: basicOperator,
+		expression:    compiled,
+		thenBranch:    &branch{outputIDs: thenIDs},
+		elseBranch:    &branch{outputIDs: elseIDs},
+	}, nil
+}
@@ -0,0 +1,42 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ifelse
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/operatortest"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/transformer/noop"
+)
+
+func TestIfGoldenConfig(t *testing.T) {
+	operatortest.ConfigUnmarshalTests{
+		DefaultConfig: NewConfig(),
+		TestsFile:     filepath.Join(".", "testdata", "config.yaml"),
+		Tests: []operatortest.ConfigUnmarshalTest{
+			{
+				Name:   "default",
+				Expect: NewConfig(),
+			},
+			{
+				Name: "branches",
+				Expect: func() *Config {
+					cfg := NewConfig()
+					cfg.Expression = `body.format == "json"`
+					cfg.Then = []operator.Config{
+						{Builder: noop.NewConfigWithID("json")},
+					}
+					cfg.Else = []operator.Config{
+						{Builder: noop.NewConfigWithID("text")},
+						{Builder: noop.NewConfigWithID("text2")},
+					}
+					cfg.OutputIDs = []string{"rejoin"}
+					return cfg
+				}(),
+			},
+		},
+	}.Run(t)
+}
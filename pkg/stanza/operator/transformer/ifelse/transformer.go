@@ -0,0 +1,129 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ifelse // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/transformer/ifelse"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/expr-lang/expr/vm"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+)
+
+// Transformer is an operator that sends entries to the then or else branch based on an expression
+type Transformer struct {
+	helper.BasicOperator
+	expression *vm.Program
+	thenBranch *branch
+	elseBranch *branch
+}
+
+// branch holds the operators receiving the entries of a branch
+type branch struct {
+	outputIDs       []string
+	outputOperators []operator.Operator
+}
+
+// CanProcess will always return true for an if operator
+func (*Transformer) CanProcess() bool {
+	return true
+}
+
+func (t *Transformer) ProcessBatch(ctx context.Context, entries []*entry.Entry) error {
+	var errs error
+	for i := range entries {
+		errs = multierr.Append(errs, t.Process(ctx, entries[i]))
+	}
+	return errs
+}
+
+// Process will send the entry to the then branch if the expression matches, or else to the else branch
+func (t *Transformer) Process(ctx context.Context, entry *entry.Entry) error {
+	if entry == nil {
+		return errors.New("got a nil entry, this should not happen and is potentially a bug")
+	}
+
+	env := helper.GetExprEnv(entry)
+	matches, err := vm.Run(t.expression, env)
+	helper.PutExprEnv(env)
+	if err != nil {
+		t.Logger().Warn("Running expression returned an error", zap.Error(err))
+		matches = false
+	}
+
+	target := t.elseBranch
+	// we compile the expression with "AsBool", so this should be safe
+	if matches.(bool) {
+		target = t.thenBranch
+	}
+
+	for i, output := range target.outputOperators {
+		outputEntry := entry
+		if i < len(target.outputOperators)-1 {
+			outputEntry = entry.Copy()
+		}
+		if err := output.Process(ctx, outputEntry); err != nil {
+			t.Logger().Error("Failed to process entry", zap.Error(err))
+		}
+	}
+	return nil
+}
+
+// CanOutput will always return true for an if operator
+func (*Transformer) CanOutput() bool {
+	return true
+}
+
+// Outputs will return the operators receiving the entries of either branch.
+func (t *Transformer) Outputs() []operator.Operator {
+	outputs := slices.Clone(t.thenBranch.outputOperators)
+	for _, output := range t.elseBranch.outputOperators {
+		if !slices.Contains(outputs, output) {
+			outputs = append(outputs, output)
+		}
+	}
+	return outputs
+}
+
+// GetOutputIDs will return the ids of the operators receiving the entries of either branch.
+func (t *Transformer) GetOutputIDs() []string {
+	outputIDs := slices.Clone(t.thenBranch.outputIDs)
+	for _, outputID := range t.elseBranch.outputIDs {
+		if !slices.Contains(outputIDs, outputID) {
+			outputIDs = append(outputIDs, outputID)
+		}
+	}
+	return outputIDs
+}
+
+// SetOutputs will set the outputs of both branches.
+func (t *Transformer) SetOutputs(operators []operator.Operator) error {
+	for _, b := range []*branch{t.thenBranch, t.elseBranch} {
+		outputOperators := make([]operator.Operator, 0, len(b.outputIDs))
+		for _, outputID := range b.outputIDs {
+			i := slices.IndexFunc(operators, func(op operator.Operator) bool { return op.ID() == outputID })
+			if i < 0 {
+				return fmt.Errorf("operator %s does not exist", outputID)
+			}
+			outputOperators = append(outputOperators, operators[i])
+		}
+		b.outputOperators = outputOperators
+	}
+	return nil
+}
+
+// SetOutputIDs will set the outputs of both branches, if neither has outputs.
+func (t *Transformer) SetOutputIDs(outputIDs []string) {
+	if len(t.thenBranch.outputIDs) == 0 && len(t.elseBranch.outputIDs) == 0 {
+		t.thenBranch.outputIDs = outputIDs
+		t.elseBranch.outputIDs = outputIDs
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ifelse
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/testutil"
+)
+
+func TestBuildInvalid(t *testing.T) {
+	t.Run("MissingExpression", func(t *testing.T) {
+		cfg := NewConfigWithID("test")
+		_, err := cfg.Build(componenttest.NewNopTelemetrySettings())
+		require.ErrorContains(t, err, "missing required field 'expr'")
+	})
+
+	t.Run("InvalidExpression", func(t *testing.T) {
+		cfg := NewConfigWithID("test")
+		cfg.Expression = "body =="
+		_, err := cfg.Build(componenttest.NewNopTelemetrySettings())
+		require.ErrorContains(t, err, "failed to compile expression")
+	})
+}
+
+func TestTransformer(t *testing.T) {
+	cases := []struct {
+		name     string
+		body     any
+		expected map[string]int
+	}{
+		{
+			name:     "Then",
+			body:     map[string]any{"format": "json"},
+			expected: map[string]int{"then1": 1, "then2": 1},
+		},
+		{
+			name:     "Else",
+			body:     map[string]any{"format": "text"},
+			expected: map[string]int{"else": 1},
+		},
+		{
+			name:     "ExpressionError",
+			body:     "not a map",
+			expected: map[string]int{"else": 1},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := NewConfigWithID("test")
+			cfg.Expression = `body.format == "json"`
+			cfg.SetBranchOutputIDs([][]string{{"then1", "then2"}, {"else"}})
+
+			op, err := cfg.Build(componenttest.NewNopTelemetrySettings())
+			require.NoError(t, err)
+			require.Equal(t, []string{"then1", "then2", "else"}, op.GetOutputIDs())
+
+			received := map[string]int{}
+			seen := map[*entry.Entry]bool{}
+			outputs := make([]operator.Operator, 0, 3)
+			for _, id := range []string{"then1", "then2", "else"} {
+				output := testutil.NewMockOperator(id)
+				output.On("Process", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+					e := args[1].(*entry.Entry)
+					require.False(t, seen[e], "outputs must receive distinct entries")
+					seen[e] = true
+					received[id]++
+				})
+				outputs = append(outputs, output)
+			}
+			require.NoError(t, op.SetOutputs(outputs))
+			require.Equal(t, outputs, op.Outputs())
+
+			input := entry.New()
+			input.Body = tc.body
+			require.NoError(t, op.ProcessBatch(context.Background(), []*entry.Entry{input}))
+			require.Equal(t, tc.expected, received)
+		})
+	}
+}
+
+func TestSharedOutputs(t *testing.T) {
+	cfg := NewConfigWithID("test")
+	cfg.Expression = "true"
+	cfg.OutputIDs = []string{"output"}
+
+	op, err := cfg.Build(componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	require.Equal(t, []string{"output"}, op.GetOutputIDs())
+
+	output := testutil.NewMockOperator("output")
+	require.ErrorContains(t, op.SetOutputs(nil), "operator output does not exist")
+	require.NoError(t, op.SetOutputs([]operator.Operator{output}))
+	require.Equal(t, []operator.Operator{output}, op.Outputs())
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/attrs"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/split"
 )
 
 const (
@@ -50,17 +51,18 @@ func NewConfigWithID(operatorID string) *Config {
 // Config is the configuration of a recombine operator
 type Config struct {
 	helper.TransformerConfig `mapstructure:",squash"`
-	IsFirstEntry             string          `mapstructure:"is_first_entry"`
-	IsLastEntry              string          `mapstructure:"is_last_entry"`
-	MaxBatchSize             int             `mapstructure:"max_batch_size"`
-	MaxUnmatchedBatchSize    int             `mapstructure:"max_unmatched_batch_size"`
-	CombineField             entry.Field     `mapstructure:"combine_field"`
-	CombineWith              string          `mapstructure:"combine_with"`
-	SourceIdentifier         entry.Field     `mapstructure:"source_identifier"`
-	OverwriteWith            string          `mapstructure:"overwrite_with"`
-	ForceFlushTimeout        time.Duration   `mapstructure:"force_flush_period"`
-	MaxSources               int             `mapstructure:"max_sources"`
-	MaxLogSize               helper.ByteSize `mapstructure:"max_log_size,omitempty"`
+	IsFirstEntry             string                    `mapstructure:"is_first_entry"`
+	IsLastEntry              string                    `mapstructure:"is_last_entry"`
+	MaxBatchSize             int                       `mapstructure:"max_batch_size"`
+	MaxUnmatchedBatchSize    int                       `mapstructure:"max_unmatched_batch_size"`
+	CombineField             entry.Field               `mapstructure:"combine_field"`
+	CombineWith              string                    `mapstructure:"combine_with"`
+	SourceIdentifier         entry.Field               `mapstructure:"source_identifier"`
+	OverwriteWith            string                    `mapstructure:"overwrite_with"`
+	ForceFlushTimeout        time.Duration             `mapstructure:"force_flush_period"`
+	MaxSources               int                       `mapstructure:"max_sources"`
+	MaxLogSize               helper.ByteSize           `mapstructure:"max_log_size,omitempty"`
+	StateMachine             *split.StateMachineConfig `mapstructure:"state_machine"`
 }
 
 // Build creates a new Transformer from a config
@@ -70,28 +72,40 @@ func (c *Config) Build(set component.TelemetrySettings) (operator.Operator, erro
 		return nil, fmt.Errorf("failed to build transformer config: %w", err)
 	}
 
-	if c.IsLastEntry != "" && c.IsFirstEntry != "" {
-		return nil, errors.New("only one of is_first_entry and is_last_entry can be set")
+	numModes := 0
+	for _, set := range []bool{c.IsFirstEntry != "", c.IsLastEntry != "", c.StateMachine != nil} {
+		if set {
+			numModes++
+		}
 	}
-
-	if c.IsLastEntry == "" && c.IsFirstEntry == "" {
-		return nil, errors.New("one of is_first_entry and is_last_entry must be set")
+	if numModes > 1 {
+		return nil, errors.New("only one of is_first_entry, is_last_entry and state_machine can be set")
+	}
+	if numModes == 0 {
+		return nil, errors.New("one of is_first_entry, is_last_entry and state_machine must be set")
 	}
 
 	var matchesFirst bool
 	var prog *vm.Program
-	if c.IsFirstEntry != "" {
+	var stateMachine *split.StateMachine
+	switch {
+	case c.IsFirstEntry != "":
 		matchesFirst = true
 		prog, err = helper.ExprCompileBool(c.IsFirstEntry)
 		if err != nil {
 			return nil, fmt.Errorf("failed to compile is_first_entry: %w", err)
 		}
-	} else {
+	case c.IsLastEntry != "":
 		matchesFirst = false
 		prog, err = helper.ExprCompileBool(c.IsLastEntry)
 		if err != nil {
 			return nil, fmt.Errorf("failed to compile is_last_entry: %w", err)
 		}
+	default:
+		stateMachine, err = c.StateMachine.Build()
+		if err != nil {
+			return nil, fmt.Errorf("failed to build state_machine: %w", err)
+		}
 	}
 
 	if c.CombineField.FieldInterface == nil {
@@ -108,6 +122,14 @@ func (c *Config) Build(set component.TelemetrySettings) (operator.Operator, erro
 		return nil, fmt.Errorf("invalid value '%s' for parameter 'overwrite_with'", c.OverwriteWith)
 	}
 
+	// Batches must be checked often enough to honor the shortest timeout
+	flushCheckPeriod := c.ForceFlushTimeout
+	if stateMachine != nil {
+		if minTimeout := stateMachine.MinTimeout(); minTimeout > 0 && minTimeout < flushCheckPeriod {
+			flushCheckPeriod = minTimeout
+		}
+	}
+
 	return &Transformer{
 		TransformerOperator:   transformer,
 		matchFirstLine:        matchesFirst,
@@ -127,9 +149,11 @@ func (c *Config) Build(set component.TelemetrySettings) (operator.Operator, erro
 		combineField:      c.CombineField,
 		combineWith:       c.CombineWith,
 		forceFlushTimeout: c.ForceFlushTimeout,
-		ticker:            time.NewTicker(c.ForceFlushTimeout),
+		flushCheckPeriod:  flushCheckPeriod / 5,
+		ticker:            time.NewTicker(flushCheckPeriod),
 		chClose:           make(chan struct{}),
 		sourceIdentifier:  c.SourceIdentifier,
 		maxLogSize:        int64(c.MaxLogSize),
+		stateMachine:      stateMachine,
 	}, nil
 }
@@ -6,9 +6,12 @@ package recombine
 import (
 	"path/filepath"
 	"testing"
+	"time"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/operatortest"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/split"
 )
 
 func TestUnmarshal(t *testing.T) {
@@ -93,6 +96,32 @@ func TestUnmarshal(t *testing.T) {
 					return cfg
 				}(),
 			},
+			{
+				Name: "state_machine",
+				Expect: func() *Config {
+					cfg := NewConfig()
+					cfg.CombineField = entry.NewBodyField()
+					cfg.StateMachine = &split.StateMachineConfig{
+						Presets: []string{"java"},
+						States: []split.StateConfig{
+							{
+								Name: split.StartState,
+								Transitions: []split.TransitionConfig{
+									{Regex: `^\d{4}-\d{2}-\d{2} `, NextState: "cont"},
+								},
+							},
+							{
+								Name:    "cont",
+								Timeout: 2 * time.Second,
+								Transitions: []split.TransitionConfig{
+									{Regex: `^\s`, NextState: "cont"},
+								},
+							},
+						},
+					}
+					return cfg
+				}(),
+			},
 		},
 	}.Run(t)
 }
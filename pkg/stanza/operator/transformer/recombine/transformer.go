@@ -17,6 +17,7 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/split"
 )
 
 const DefaultSourceIdentifier = "DefaultSourceIdentifier"
@@ -34,8 +35,10 @@ type Transformer struct {
 	combineWith           string
 	ticker                *time.Ticker
 	forceFlushTimeout     time.Duration
+	flushCheckPeriod      time.Duration
 	chClose               chan struct{}
 	sourceIdentifier      entry.Field
+	stateMachine          *split.StateMachine
 
 	sync.Mutex
 	batchPool  sync.Pool
@@ -49,7 +52,10 @@ type sourceBatch struct {
 	numEntries             int
 	recombined             *bytes.Buffer
 	firstEntryObservedTime time.Time
+	lastEntryObservedTime  time.Time
 	matchDetected          bool
+	// state is the state of the state machine after the last entry of the batch
+	state *split.State
 }
 
 func (t *Transformer) Start(_ operator.Persister) error {
@@ -64,16 +70,20 @@ func (t *Transformer) flushLoop() {
 			t.Lock()
 			timeNow := time.Now()
 			for source, batch := range t.batchMap {
-				timeSinceFirstEntry := timeNow.Sub(batch.firstEntryObservedTime)
-				if timeSinceFirstEntry < t.forceFlushTimeout {
+				if batch.state != nil && batch.state.Timeout() > 0 {
+					// The state of the batch sets how long to wait for its next entry
+					if timeNow.Sub(batch.lastEntryObservedTime) < batch.state.Timeout() {
+						continue
+					}
+				} else if timeNow.Sub(batch.firstEntryObservedTime) < t.forceFlushTimeout {
 					continue
 				}
 				if err := t.flushSource(context.Background(), source); err != nil {
 					t.Logger().Error("there was error flushing combined logs", zap.Error(err))
 				}
 			}
-			// check every 1/5 forceFlushTimeout
-			t.ticker.Reset(t.forceFlushTimeout / 5)
+			// check every 1/5 of the shortest timeout
+			t.ticker.Reset(t.flushCheckPeriod)
 			t.Unlock()
 		case <-t.chClose:
 			t.ticker.Stop()
@@ -103,6 +113,11 @@ func (t *Transformer) Process(ctx context.Context, e *entry.Entry) error {
 	t.Lock()
 	defer t.Unlock()
 
+	s := t.source(e)
+	if t.stateMachine != nil {
+		return t.processWithStateMachine(ctx, e, s)
+	}
+
 	// Get the environment for executing the expression.
 	// In the future, we may want to provide access to the currently
 	// batched entries so users can do comparisons to other entries
@@ -117,16 +132,6 @@ func (t *Transformer) Process(ctx context.Context, e *entry.Entry) error {
 
 	// this is guaranteed to be a boolean because of expr.AsBool
 	matches := m.(bool)
-	var s string
-	err = e.Read(t.sourceIdentifier, &s)
-	if err != nil {
-		t.Logger().Warn("entry does not contain the source_identifier, so it may be pooled with other sources")
-		s = DefaultSourceIdentifier
-	}
-
-	if s == "" {
-		s = DefaultSourceIdentifier
-	}
 
 	switch {
 	// This is the first entry in the next batch
@@ -151,6 +156,57 @@ func (t *Transformer) Process(ctx context.Context, e *entry.Entry) error {
 	return nil
 }
 
+// source returns the source identifier of the entry
+func (t *Transformer) source(e *entry.Entry) string {
+	var s string
+	err := e.Read(t.sourceIdentifier, &s)
+	if err != nil {
+		t.Logger().Warn("entry does not contain the source_identifier, so it may be pooled with other sources")
+		s = DefaultSourceIdentifier
+	}
+
+	if s == "" {
+		s = DefaultSourceIdentifier
+	}
+	return s
+}
+
+// processWithStateMachine adds the entry to the batch of its source, or to a new batch if the
+// entry starts a new log according to the state machine
+func (t *Transformer) processWithStateMachine(ctx context.Context, e *entry.Entry, source string) error {
+	var line string
+	if err := e.Read(t.combineField, &line); err != nil {
+		return t.HandleEntryError(ctx, e, err)
+	}
+
+	var current *split.State
+	if batch, ok := t.batchMap[source]; ok {
+		current = batch.state
+	}
+
+	next, starts := t.stateMachine.Step(current, []byte(line))
+	if starts {
+		// Flush the existing batch
+		if err := t.flushSource(ctx, source); err != nil {
+			return err
+		}
+	}
+	t.addToBatch(ctx, e, source, true)
+
+	// The batch may have been flushed while adding the entry
+	batch, ok := t.batchMap[source]
+	if !ok {
+		return nil
+	}
+	batch.state = next
+
+	// This entry is a log of its own, or the last entry of a log
+	if next == nil || next.Final() {
+		return t.flushSource(ctx, source)
+	}
+	return nil
+}
+
 // addToBatch adds the current entry to the current batch of entries that will be combined
 func (t *Transformer) addToBatch(ctx context.Context, e *entry.Entry, source string, matches bool) {
 	batch, ok := t.batchMap[source]
@@ -162,6 +218,7 @@ func (t *Transformer) addToBatch(ctx context.Context, e *entry.Entry, source str
 		batch = t.addNewBatch(source, e)
 	} else {
 		batch.numEntries++
+		batch.lastEntryObservedTime = e.ObservedTimestamp
 		if t.overwriteWithNewest {
 			batch.baseEntry = e
 		}
@@ -237,7 +294,9 @@ func (t *Transformer) addNewBatch(source string, e *entry.Entry) *sourceBatch {
 	batch.numEntries = 1
 	batch.recombined.Reset()
 	batch.firstEntryObservedTime = e.ObservedTimestamp
+	batch.lastEntryObservedTime = e.ObservedTimestamp
 	batch.matchDetected = false
+	batch.state = nil
 	t.batchMap[source] = batch
 	return batch
 }
@@ -18,6 +18,7 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/attrs"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/split"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/testutil"
 )
 
@@ -714,6 +715,65 @@ func TestTransformer(t *testing.T) {
 				entryWithBody(t1, "test6\ntest7\ntest1"),
 			},
 		},
+		{
+			"StateMachineJava",
+			func() *Config {
+				cfg := NewConfig()
+				cfg.CombineField = entry.NewBodyField()
+				cfg.StateMachine = &split.StateMachineConfig{Presets: []string{"java"}}
+				cfg.OutputIDs = []string{"fake"}
+				return cfg
+			}(),
+			[]*entry.Entry{
+				entryWithBody(t1, "starting"),
+				entryWithBody(t1, "java.lang.IllegalStateException: outer"),
+				entryWithBody(t1, "\tat com.example.App.run(App.java:10)"),
+				entryWithBody(t1, "Caused by: java.lang.NullPointerException: inner"),
+				entryWithBody(t1, "\tat com.example.App.load(App.java:20)"),
+				entryWithBody(t1, "\t... 1 more"),
+				entryWithBody(t2, "stopping"),
+			},
+			[]*entry.Entry{
+				entryWithBody(t1, "starting"),
+				entryWithBody(t1, "java.lang.IllegalStateException: outer\n\tat com.example.App.run(App.java:10)\nCaused by: java.lang.NullPointerException: inner\n\tat com.example.App.load(App.java:20)\n\t... 1 more"),
+				entryWithBody(t2, "stopping"),
+			},
+		},
+		{
+			"StateMachineFinalState",
+			func() *Config {
+				cfg := NewConfig()
+				cfg.CombineField = entry.NewBodyField()
+				cfg.StateMachine = &split.StateMachineConfig{
+					States: []split.StateConfig{
+						{
+							Name:        split.StartState,
+							Transitions: []split.TransitionConfig{{Regex: "^begin$", NextState: "body"}},
+						},
+						{
+							Name:        "body",
+							Transitions: []split.TransitionConfig{{Regex: "^end$", NextState: "end"}, {Regex: "^middle$", NextState: "body"}},
+						},
+						{
+							Name: "end",
+						},
+					},
+				}
+				cfg.OutputIDs = []string{"fake"}
+				return cfg
+			}(),
+			[]*entry.Entry{
+				entryWithBody(t1, "begin"),
+				entryWithBody(t1, "middle"),
+				entryWithBody(t1, "end"),
+				entryWithBody(t2, "begin"),
+				entryWithBody(t2, "begin"),
+			},
+			[]*entry.Entry{
+				entryWithBody(t1, "begin\nmiddle\nend"),
+				entryWithBody(t2, "begin"),
+			},
+		},
 	}
 
 	for _, tc := range cases {
@@ -899,6 +959,56 @@ func TestTimeout(t *testing.T) {
 	require.NoError(t, recombine.Stop())
 }
 
+func TestStateTimeout(t *testing.T) {
+	t.Parallel()
+
+	cfg := NewConfig()
+	cfg.CombineField = entry.NewBodyField()
+	cfg.StateMachine = &split.StateMachineConfig{
+		States: []split.StateConfig{
+			{
+				Name:        split.StartState,
+				Transitions: []split.TransitionConfig{{Regex: "^start$", NextState: "cont"}},
+			},
+			{
+				Name:        "cont",
+				Timeout:     100 * time.Millisecond,
+				Transitions: []split.TransitionConfig{{Regex: "^next$", NextState: "cont"}},
+			},
+		},
+	}
+	cfg.OutputIDs = []string{"fake"}
+	cfg.ForceFlushTimeout = time.Hour
+	set := componenttest.NewNopTelemetrySettings()
+	op, err := cfg.Build(set)
+	require.NoError(t, err)
+
+	fake := testutil.NewFakeOutput(t)
+	require.NoError(t, op.SetOutputs([]operator.Operator{fake}))
+
+	e := entry.New()
+	e.Timestamp = time.Now()
+	e.Body = "start"
+
+	require.NoError(t, op.Start(nil))
+	require.NoError(t, op.ProcessBatch(context.Background(), []*entry.Entry{e}))
+	select {
+	case <-fake.Received:
+		t.Logf("We shouldn't receive an entry before timeout")
+		t.FailNow()
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	select {
+	case <-fake.Received:
+	case <-time.After(5 * time.Second):
+		t.Logf("The entry should be flushed by now")
+		t.FailNow()
+	}
+
+	require.NoError(t, op.Stop())
+}
+
 // This test is to make sure the timeout would take effect when there
 // are constantly logs that meet the aggregation criteria
 func TestTimeoutWhenAggregationKeepHappen(t *testing.T) {
@@ -80,7 +80,9 @@ func (c Config) Build(set component.TelemetrySettings) (operator.Operator, error
 	}
 
 	return &Transformer{
-		BasicOperator: basicOperator,
-		routes:        routes,
+		RouterOperator: helper.RouterOperator{
+			BasicOperator: basicOperator,
+			Routes:        routes,
+		},
 	}, nil
 }
@@ -5,6 +5,7 @@ package pipeline // import "github.com/open-telemetry/opentelemetry-collector-co
 
 import (
 	"fmt"
+	"slices"
 
 	"go.opentelemetry.io/collector/component"
 
@@ -31,37 +32,114 @@ func (c Config) Build(set component.TelemetrySettings) (*DirectedPipeline, error
 		return nil, errors.NewError("empty pipeline not allowed", "")
 	}
 
-	dedeplucateIDs(c.Operators)
+	dedeplucateIDs(flattenConfigs(c.Operators))
+
+	var defaultOutputIDs []string
+	if c.DefaultOutput != nil {
+		defaultOutputIDs = []string{c.DefaultOutput.ID()}
+	}
+
+	ops, err := buildOperators(set, c.Operators, defaultOutputIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	// The default output is only part of the pipeline if an operator outputs to it
+	if c.DefaultOutput != nil && outputsTo(ops, c.DefaultOutput.ID()) {
+		ops = append(ops, c.DefaultOutput)
+	}
+
+	return NewDirectedPipeline(ops)
+}
+
+// Brancher is implemented by the configurations of operators, like the if operator, that send
+// entries to branches of operators. The operators of the branches are added to the pipeline, and
+// the last operator of each branch outputs to where the branches rejoin the pipeline.
+type Brancher interface {
+	// Branches returns the configurations of the operators of each branch.
+	Branches() [][]operator.Config
+	// JoinIDs returns the ids of the operators where the branches rejoin the pipeline. If empty,
+	// the branches rejoin the pipeline at the operator following the brancher.
+	JoinIDs() []string
+	// SetBranchOutputIDs sets the ids of the operators receiving the entries sent to each branch.
+	SetBranchOutputIDs([][]string)
+}
+
+// buildOperators will build the operators of a list of configs and of their branches. Any operator
+// without outputs will output to the next operator of the list, and the last one to nextIDs.
+func buildOperators(set component.TelemetrySettings, configs []operator.Config, nextIDs []string) ([]operator.Operator, error) {
+	ops := make([]operator.Operator, 0, len(configs))
+	for i, opCfg := range configs {
+		followingIDs := nextIDs
+		if i+1 < len(configs) {
+			followingIDs = []string{configs[i+1].ID()}
+		}
+
+		if brancher, ok := opCfg.Builder.(Brancher); ok {
+			joinIDs := brancher.JoinIDs()
+			if len(joinIDs) == 0 {
+				joinIDs = followingIDs
+			}
+
+			branches := brancher.Branches()
+			branchOutputIDs := make([][]string, len(branches))
+			for j, branch := range branches {
+				// An empty branch sends entries straight to where the branches rejoin
+				if len(branch) == 0 {
+					branchOutputIDs[j] = joinIDs
+					continue
+				}
+
+				branchOps, err := buildOperators(set, branch, joinIDs)
+				if err != nil {
+					return nil, err
+				}
+				ops = append(ops, branchOps...)
+				branchOutputIDs[j] = []string{branch[0].ID()}
+			}
+			brancher.SetBranchOutputIDs(branchOutputIDs)
+		}
 
-	ops := make([]operator.Operator, 0, len(c.Operators))
-	for _, opCfg := range c.Operators {
 		op, err := opCfg.Build(set)
 		if err != nil {
 			return nil, err
 		}
 		ops = append(ops, op)
-	}
 
-	for i, op := range ops {
 		// Any operator that already has an output will not be changed
-		if len(op.GetOutputIDs()) > 0 {
+		if len(op.GetOutputIDs()) > 0 || !op.CanOutput() {
 			continue
 		}
+		if len(followingIDs) > 0 {
+			op.SetOutputIDs(followingIDs)
+		}
+	}
+	return ops, nil
+}
 
-		// Any operator (except the last) will just output to the next
-		if i+1 < len(ops) {
-			op.SetOutputIDs([]string{ops[i+1].ID()})
+// flattenConfigs returns the configs, followed by the configs of the operators of their branches.
+func flattenConfigs(configs []operator.Config) []operator.Config {
+	flattened := slices.Clone(configs)
+	for _, opCfg := range configs {
+		brancher, ok := opCfg.Builder.(Brancher)
+		if !ok {
 			continue
 		}
-
-		// The last operator may output to the default output
-		if op.CanOutput() && c.DefaultOutput != nil {
-			ops = append(ops, c.DefaultOutput)
-			op.SetOutputIDs([]string{ops[i+1].ID()})
+		for _, branch := range brancher.Branches() {
+			flattened = append(flattened, flattenConfigs(branch)...)
 		}
 	}
+	return flattened
+}
 
-	return NewDirectedPipeline(ops)
+// outputsTo returns true if any of the operators outputs to the operator with the id.
+func outputsTo(ops []operator.Operator, id string) bool {
+	for _, op := range ops {
+		if slices.Contains(op.GetOutputIDs(), id) {
+			return true
+		}
+	}
+	return false
 }
 
 func dedeplucateIDs(ops []operator.Config) {
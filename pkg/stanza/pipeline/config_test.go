@@ -14,6 +14,8 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/parser/json"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/transformer/copy"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/transformer/fanout"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/transformer/ifelse"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/transformer/noop"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/testutil"
 )
@@ -111,6 +113,93 @@ func TestBuildAPipelineDefaultOperator(t *testing.T) {
 	require.True(t, exists["fake"])
 }
 
+func TestBuildPipelineBranches(t *testing.T) {
+	ifConfig := ifelse.NewConfigWithID("if")
+	ifConfig.Expression = "true"
+	ifConfig.Then = []operator.Config{
+		{Builder: noop.NewConfigWithID("noop")},
+		{Builder: noop.NewConfigWithID("noop")},
+	}
+
+	cfg := Config{
+		Operators: []operator.Config{
+			{Builder: ifConfig},
+			{Builder: noop.NewConfigWithID("noop")},
+		},
+		DefaultOutput: testutil.NewFakeOutput(t),
+	}
+
+	set := componenttest.NewNopTelemetrySettings()
+	pipe, err := cfg.Build(set)
+	require.NoError(t, err)
+
+	outputs := make(map[string][]string)
+	for _, op := range pipe.Operators() {
+		outputs[op.ID()] = op.GetOutputIDs()
+	}
+	require.Equal(t, map[string][]string{
+		"if":    {"noop1", "noop"},
+		"noop1": {"noop2"},
+		"noop2": {"noop"},
+		"noop":  {"fake"},
+		"fake":  nil,
+	}, outputs)
+}
+
+func TestBuildPipelineBranchesJoinIDs(t *testing.T) {
+	ifConfig := ifelse.NewConfigWithID("if")
+	ifConfig.Expression = "true"
+	ifConfig.Else = []operator.Config{
+		{Builder: noop.NewConfigWithID("else")},
+	}
+	ifConfig.OutputIDs = []string{"join"}
+
+	cfg := Config{
+		Operators: []operator.Config{
+			{Builder: ifConfig},
+			{Builder: noop.NewConfigWithID("skipped")},
+			{Builder: noop.NewConfigWithID("join")},
+		},
+	}
+
+	set := componenttest.NewNopTelemetrySettings()
+	pipe, err := cfg.Build(set)
+	require.NoError(t, err)
+
+	outputs := make(map[string][]string)
+	for _, op := range pipe.Operators() {
+		outputs[op.ID()] = op.GetOutputIDs()
+	}
+	require.Equal(t, map[string][]string{
+		"if":      {"join", "else"},
+		"else":    {"join"},
+		"skipped": {"join"},
+		"join":    nil,
+	}, outputs)
+}
+
+func TestBuildPipelineCircularDependency(t *testing.T) {
+	fanoutConfig := fanout.NewConfigWithID("fanout")
+	fanoutConfig.Routes = []*fanout.RouteConfig{
+		{OutputIDs: []string{"noop"}},
+		{OutputIDs: []string{"noop1"}},
+	}
+	loopConfig := noop.NewConfigWithID("noop1")
+	loopConfig.OutputIDs = []string{"fanout"}
+
+	cfg := Config{
+		Operators: []operator.Config{
+			{Builder: fanoutConfig},
+			{Builder: noop.NewConfigWithID("noop")},
+			{Builder: loopConfig},
+		},
+	}
+
+	set := componenttest.NewNopTelemetrySettings()
+	_, err := cfg.Build(set)
+	require.ErrorContains(t, err, "circular dependency")
+}
+
 func TestDeduplicateIDs(t *testing.T) {
 	cases := []struct {
 		name        string
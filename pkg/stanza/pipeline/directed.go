@@ -135,7 +135,7 @@ func connectNodes(graph *simple.DirectedGraph) error {
 		errors.As(err, &topoErr)
 		return stanzaerrors.NewError(
 			"pipeline has a circular dependency",
-			"ensure that all operators are connected in a straight, acyclic line",
+			"ensure that no operator outputs, directly or through other operators, to itself",
 			"cycles", unorderableToCycles(topoErr),
 		)
 	}
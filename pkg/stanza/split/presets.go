@@ -0,0 +1,142 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package split // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/split"
+
+// presets are the built-in states of state machines, by preset name. Apart from the start state,
+// the names of the states are prefixed with the name of the preset, so that presets can be combined.
+var presets = map[string][]StateConfig{
+	// Java stack traces, including "Caused by" and "Suppressed" sections
+	"java": {
+		{
+			Name: StartState,
+			Transitions: []TransitionConfig{
+				{Regex: `(?:Exception|Error|Throwable)(?::|$)`, NextState: "java_after_exception"},
+			},
+		},
+		{
+			Name: "java_after_exception",
+			Transitions: []TransitionConfig{
+				{Regex: `^[\t ]*nested exception is:`, NextState: "java_after_exception"},
+				{Regex: `^[\t ]+(?:eval )?at `, NextState: "java_frame"},
+			},
+		},
+		{
+			Name: "java_frame",
+			Transitions: []TransitionConfig{
+				{Regex: `^[\t ]+(?:eval )?at `, NextState: "java_frame"},
+				{Regex: `^[\t ]*(?:Caused by|Suppressed):`, NextState: "java_after_exception"},
+				{Regex: `^[\t ]*\.\.\. \d+ (?:more|common frames omitted)`, NextState: "java_frame"},
+			},
+		},
+	},
+	// Python tracebacks, including chained exceptions
+	"python": {
+		{
+			Name: StartState,
+			Transitions: []TransitionConfig{
+				{Regex: `^Traceback \(most recent call last\):$`, NextState: "python_traceback"},
+			},
+		},
+		{
+			Name: "python_traceback",
+			Transitions: []TransitionConfig{
+				{Regex: `^[\t ]+File `, NextState: "python_code"},
+				{Regex: `^[\t ]+[~^]+$`, NextState: "python_traceback"},
+				{Regex: `^(?:[^\s.():]+\.)*[^\s.():]+(?::.*)?$`, NextState: "python_exception"},
+			},
+		},
+		{
+			Name: "python_code",
+			Transitions: []TransitionConfig{
+				{Regex: `^[\t ]+File `, NextState: "python_code"},
+				{Regex: `^[\t ]+\S`, NextState: "python_traceback"},
+				{Regex: `^(?:[^\s.():]+\.)*[^\s.():]+(?::.*)?$`, NextState: "python_exception"},
+			},
+		},
+		{
+			Name: "python_exception",
+			Transitions: []TransitionConfig{
+				{Regex: `^$`, NextState: "python_chain"},
+			},
+		},
+		{
+			Name: "python_chain",
+			Transitions: []TransitionConfig{
+				{Regex: `^(?:During handling of the above exception, another exception occurred|The above exception was the direct cause of the following exception):$`, NextState: "python_chain"},
+				{Regex: `^$`, NextState: "python_chain"},
+				{Regex: `^Traceback \(most recent call last\):$`, NextState: "python_traceback"},
+			},
+		},
+	},
+	// Go panics, including the traces of all goroutines
+	"go": {
+		{
+			Name: StartState,
+			Transitions: []TransitionConfig{
+				{Regex: `\bpanic: `, NextState: "go_after_panic"},
+				{Regex: `^fatal error: `, NextState: "go_after_panic"},
+				{Regex: `http: panic serving`, NextState: "go_goroutine"},
+			},
+		},
+		{
+			Name: "go_after_panic",
+			Transitions: []TransitionConfig{
+				{Regex: `^$`, NextState: "go_goroutine"},
+				{Regex: `^\[signal `, NextState: "go_after_panic"},
+				{Regex: `^goroutine \d+ \[[^\]]+\]:$`, NextState: "go_function"},
+				{Regex: `^[\t ]`, NextState: "go_after_panic"},
+			},
+		},
+		{
+			Name: "go_goroutine",
+			Transitions: []TransitionConfig{
+				{Regex: `^goroutine \d+ \[[^\]]+\]:$`, NextState: "go_function"},
+			},
+		},
+		{
+			Name: "go_function",
+			Transitions: []TransitionConfig{
+				{Regex: `^$`, NextState: "go_goroutine"},
+				{Regex: `^\.\.\.\d* additional frames elided\.\.\.$`, NextState: "go_function"},
+				{Regex: `^(?:created by )?\S+(?:\(.*\))?(?: in goroutine \d+)?$`, NextState: "go_file"},
+			},
+		},
+		{
+			Name: "go_file",
+			Transitions: []TransitionConfig{
+				{Regex: `^[\t ]+\S+:\d+(?: \+0x[0-9a-f]+)?$`, NextState: "go_function"},
+			},
+		},
+	},
+	// Ruby backtraces, and Rails error pages
+	"ruby": {
+		{
+			Name: StartState,
+			Transitions: []TransitionConfig{
+				{Regex: `:\d+:in [` + "`" + `'].*\([A-Z]\w*(?:::[A-Z]\w*)*\)$`, NextState: "ruby_backtrace"},
+				{Regex: `Error \(.*\):$`, NextState: "ruby_before_rails_trace"},
+			},
+		},
+		{
+			Name: "ruby_backtrace",
+			Transitions: []TransitionConfig{
+				{Regex: `^[\t ]+from \S+:\d+:in `, NextState: "ruby_backtrace"},
+				{Regex: `^[\t ]+\S+\.rb:\d+:in `, NextState: "ruby_backtrace"},
+			},
+		},
+		{
+			Name: "ruby_before_rails_trace",
+			Transitions: []TransitionConfig{
+				{Regex: `^[\t ]*$`, NextState: "ruby_before_rails_trace"},
+				{Regex: `^[\t ]*\S+\.rb:\d+:in `, NextState: "ruby_rails_trace"},
+			},
+		},
+		{
+			Name: "ruby_rails_trace",
+			Transitions: []TransitionConfig{
+				{Regex: `^[\t ]*\S+\.rb:\d+:in `, NextState: "ruby_rails_trace"},
+			},
+		},
+	},
+}
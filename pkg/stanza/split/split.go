@@ -9,15 +9,17 @@ import (
 	"errors"
 	"fmt"
 	"regexp"
+	"time"
 
 	"golang.org/x/text/encoding"
 )
 
 // Config is the configuration for a split func
 type Config struct {
-	LineStartPattern string `mapstructure:"line_start_pattern"`
-	LineEndPattern   string `mapstructure:"line_end_pattern"`
-	OmitPattern      bool   `mapstructure:"omit_pattern"`
+	LineStartPattern string              `mapstructure:"line_start_pattern"`
+	LineEndPattern   string              `mapstructure:"line_end_pattern"`
+	OmitPattern      bool                `mapstructure:"omit_pattern"`
+	StateMachine     *StateMachineConfig `mapstructure:"state_machine"`
 }
 
 // Func will return a bufio.SplitFunc based on the config
@@ -29,9 +31,23 @@ func (c Config) Func(enc encoding.Encoding, flushAtEOF bool, maxLogSize int) (bu
 		if c.LineStartPattern != "" {
 			return nil, errors.New("line_start_pattern should not be set when using nop encoding")
 		}
+		if c.StateMachine != nil {
+			return nil, errors.New("state_machine should not be set when using nop encoding")
+		}
 		return NoSplitFunc(maxLogSize), nil
 	}
 
+	if c.StateMachine != nil {
+		if c.LineEndPattern != "" || c.LineStartPattern != "" {
+			return nil, errors.New("state_machine cannot be set with line_start_pattern or line_end_pattern")
+		}
+		m, err := c.StateMachine.Build()
+		if err != nil {
+			return nil, fmt.Errorf("build state machine: %w", err)
+		}
+		return m.SplitFunc(enc, flushAtEOF)
+	}
+
 	if c.LineEndPattern == "" && c.LineStartPattern == "" {
 		return NewlineSplitFunc(enc, flushAtEOF)
 	}
@@ -55,6 +71,19 @@ func (c Config) Func(enc encoding.Encoding, flushAtEOF bool, maxLogSize int) (bu
 	return nil, errors.New("only one of line_start_pattern or line_end_pattern can be set")
 }
 
+// FlushPeriodFunc will return a function giving the time to wait for more data before flushing
+// data which could not be split, or nil if the period is always the default one.
+func (c Config) FlushPeriodFunc(enc encoding.Encoding, defaultPeriod time.Duration) (func([]byte) time.Duration, error) {
+	if c.StateMachine == nil || enc == encoding.Nop {
+		return nil, nil
+	}
+	m, err := c.StateMachine.Build()
+	if err != nil {
+		return nil, fmt.Errorf("build state machine: %w", err)
+	}
+	return m.FlushPeriodFunc(enc, defaultPeriod)
+}
+
 // LineStartSplitFunc creates a bufio.SplitFunc that splits an incoming stream into
 // tokens that start with a match to the regex pattern provided
 func LineStartSplitFunc(re *regexp.Regexp, omitPattern, flushAtEOF bool) bufio.SplitFunc {
@@ -58,6 +58,35 @@ func TestConfigFunc(t *testing.T) {
 		assert.Equal(t, []byte("foo"), token)
 	})
 
+	t.Run("StateMachine", func(t *testing.T) {
+		cfg := Config{StateMachine: &StateMachineConfig{Presets: []string{"java"}}}
+		f, err := cfg.Func(unicode.UTF8, false, maxLogSize)
+		assert.NoError(t, err)
+
+		advance, token, err := f([]byte("java.lang.Error\n\tat App.main(App.java:1)\nfoo\n"), false)
+		assert.NoError(t, err)
+		assert.Equal(t, len("java.lang.Error\n\tat App.main(App.java:1)\n"), advance)
+		assert.Equal(t, []byte("java.lang.Error\n\tat App.main(App.java:1)"), token)
+	})
+
+	t.Run("StateMachineWithPattern", func(t *testing.T) {
+		cfg := Config{LineStartPattern: "foo", StateMachine: &StateMachineConfig{Presets: []string{"java"}}}
+		_, err := cfg.Func(unicode.UTF8, false, maxLogSize)
+		assert.EqualError(t, err, "state_machine cannot be set with line_start_pattern or line_end_pattern")
+	})
+
+	t.Run("StateMachineNopEncoding", func(t *testing.T) {
+		cfg := Config{StateMachine: &StateMachineConfig{Presets: []string{"java"}}}
+		_, err := cfg.Func(encoding.Nop, false, maxLogSize)
+		assert.EqualError(t, err, "state_machine should not be set when using nop encoding")
+	})
+
+	t.Run("InvalidStateMachine", func(t *testing.T) {
+		cfg := Config{StateMachine: &StateMachineConfig{Presets: []string{"cobol"}}}
+		_, err := cfg.Func(unicode.UTF8, false, maxLogSize)
+		assert.EqualError(t, err, "build state machine: unknown state machine preset 'cobol'")
+	})
+
 	t.Run("InvalidStartRegex", func(t *testing.T) {
 		cfg := Config{LineStartPattern: "["}
 		_, err := cfg.Func(unicode.UTF8, false, maxLogSize)
@@ -0,0 +1,244 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package split // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/split"
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+
+	"golang.org/x/text/encoding"
+)
+
+// StartState is the name of the state in which a state machine looks for the first line of a log.
+const StartState = "start_state"
+
+// StateMachineConfig is the configuration of a state machine which combines lines into logs.
+type StateMachineConfig struct {
+	Presets []string      `mapstructure:"presets"`
+	States  []StateConfig `mapstructure:"states"`
+}
+
+// StateConfig is the configuration of a named state of a state machine.
+type StateConfig struct {
+	Name        string             `mapstructure:"name"`
+	Timeout     time.Duration      `mapstructure:"timeout"`
+	Transitions []TransitionConfig `mapstructure:"transitions"`
+}
+
+// TransitionConfig is the configuration of a transition from a state to the next one, taken when a
+// line matches the regex.
+type TransitionConfig struct {
+	Regex     string `mapstructure:"regex"`
+	NextState string `mapstructure:"next_state"`
+}
+
+// Build will build a state machine from the config. The states of the presets come first, and
+// states sharing a name are merged.
+func (c StateMachineConfig) Build() (*StateMachine, error) {
+	var configs []StateConfig
+	for _, name := range c.Presets {
+		preset, ok := presets[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown state machine preset '%s'", name)
+		}
+		configs = append(configs, preset...)
+	}
+	configs = append(configs, c.States...)
+
+	states := make(map[string]*State, len(configs))
+	for _, config := range configs {
+		if config.Name == "" {
+			return nil, errors.New("state name must be specified")
+		}
+		if config.Timeout < 0 {
+			return nil, fmt.Errorf("timeout of state '%s' must not be negative", config.Name)
+		}
+		state, ok := states[config.Name]
+		if !ok {
+			state = &State{name: config.Name}
+			states[config.Name] = state
+		}
+		if config.Timeout > 0 {
+			state.timeout = config.Timeout
+		}
+	}
+
+	for _, config := range configs {
+		state := states[config.Name]
+		for _, transitionConfig := range config.Transitions {
+			re, err := regexp.Compile(transitionConfig.Regex)
+			if err != nil {
+				return nil, fmt.Errorf("compile regex of state '%s': %w", config.Name, err)
+			}
+			next, ok := states[transitionConfig.NextState]
+			if !ok {
+				return nil, fmt.Errorf("state '%s' has a transition to unknown state '%s'", config.Name, transitionConfig.NextState)
+			}
+			state.transitions = append(state.transitions, transition{regex: re, next: next})
+		}
+	}
+
+	start, ok := states[StartState]
+	if !ok || len(start.transitions) == 0 {
+		return nil, fmt.Errorf("state machine must have a '%s' with transitions", StartState)
+	}
+	return &StateMachine{start: start, states: states}, nil
+}
+
+// StateMachine combines lines into logs. A line matching a transition of the start state starts a
+// log, which continues with each line matching a transition of the current state. A log ends with
+// the line before a line matching no transition, or with a line leading to a state without
+// transitions. Lines that are not part of a log are logs of their own.
+type StateMachine struct {
+	start  *State
+	states map[string]*State
+}
+
+// State is a named state of a state machine.
+type State struct {
+	name        string
+	timeout     time.Duration
+	transitions []transition
+}
+
+type transition struct {
+	regex *regexp.Regexp
+	next  *State
+}
+
+// Name returns the name of the state.
+func (s *State) Name() string {
+	return s.name
+}
+
+// Timeout returns the time to wait for the next line of a log in the state, or zero if not set.
+func (s *State) Timeout() time.Duration {
+	return s.timeout
+}
+
+// Final returns true if no line can continue a log in the state.
+func (s *State) Final() bool {
+	return len(s.transitions) == 0
+}
+
+func (s *State) next(line []byte) *State {
+	for _, t := range s.transitions {
+		if t.regex.Match(line) {
+			return t.next
+		}
+	}
+	return nil
+}
+
+// Step returns the state of the machine after the line, and true if the line starts a new log.
+// The current state is nil if no log is in progress, and the returned state is nil if the line is
+// a log of its own.
+func (m *StateMachine) Step(current *State, line []byte) (*State, bool) {
+	if current != nil {
+		if next := current.next(line); next != nil {
+			return next, false
+		}
+	}
+	return m.start.next(line), true
+}
+
+// MinTimeout returns the shortest timeout of the states, or zero if no state has a timeout.
+func (m *StateMachine) MinTimeout() time.Duration {
+	var minTimeout time.Duration
+	for _, state := range m.states {
+		if state.timeout > 0 && (minTimeout == 0 || state.timeout < minTimeout) {
+			minTimeout = state.timeout
+		}
+	}
+	return minTimeout
+}
+
+// SplitFunc creates a bufio.SplitFunc that splits an incoming stream into the logs of the state machine.
+func (m *StateMachine) SplitFunc(enc encoding.Encoding, flushAtEOF bool) (bufio.SplitFunc, error) {
+	newline, err := encodedNewline(enc)
+	if err != nil {
+		return nil, err
+	}
+
+	carriageReturn, err := encodedCarriageReturn(enc)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(data []byte, atEOF bool) (int, []byte, error) {
+		if atEOF && len(data) == 0 {
+			return 0, nil, nil
+		}
+
+		var current *State
+		for start := 0; start < len(data); {
+			i := bytes.Index(data[start:], newline)
+			if i < 0 {
+				break
+			}
+			end := start + i
+			line := bytes.TrimSuffix(data[start:end], carriageReturn)
+
+			next, starts := m.Step(current, line)
+			if starts && start > 0 {
+				// The line starts a new log, so the previous one is complete
+				return start, bytes.TrimSuffix(data[:start-len(newline)], carriageReturn), nil
+			}
+			if next == nil || next.Final() {
+				return end + len(newline), bytes.TrimSuffix(data[:end], carriageReturn), nil
+			}
+			current = next
+			start = end + len(newline)
+		}
+
+		// Flush if no more data is expected
+		if atEOF && flushAtEOF {
+			return len(data), bytes.TrimSuffix(bytes.TrimSuffix(data, newline), carriageReturn), nil
+		}
+
+		// Request more data.
+		return 0, nil, nil
+	}, nil
+}
+
+// FlushPeriodFunc returns a function giving the time to wait for more data before flushing data
+// which could not be split. It is the timeout of the state of the machine after the data, or else
+// the default period.
+func (m *StateMachine) FlushPeriodFunc(enc encoding.Encoding, defaultPeriod time.Duration) (func([]byte) time.Duration, error) {
+	newline, err := encodedNewline(enc)
+	if err != nil {
+		return nil, err
+	}
+
+	carriageReturn, err := encodedCarriageReturn(enc)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(data []byte) time.Duration {
+		lines := bytes.Split(data, newline)
+		if len(lines[len(lines)-1]) == 0 {
+			// The data ends with a newline, so the next line has not started yet
+			lines = lines[:len(lines)-1]
+		}
+
+		// The lines are walked like SplitFunc does: a log ends with a line matching no transition
+		// or leading to a state without transitions, and the next line restarts from the start state
+		var current *State
+		for _, line := range lines {
+			current, _ = m.Step(current, bytes.TrimSuffix(line, carriageReturn))
+			if current != nil && current.Final() {
+				current = nil
+			}
+		}
+		if current == nil || current.timeout == 0 {
+			return defaultPeriod
+		}
+		return current.timeout
+	}, nil
+}
@@ -0,0 +1,319 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package split
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/encoding/unicode"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/split/splittest"
+)
+
+func TestStateMachineConfigBuild(t *testing.T) {
+	testCases := []struct {
+		name        string
+		config      StateMachineConfig
+		expectedErr string
+	}{
+		{
+			name:   "Presets",
+			config: StateMachineConfig{Presets: []string{"java", "python", "go", "ruby"}},
+		},
+		{
+			name:        "UnknownPreset",
+			config:      StateMachineConfig{Presets: []string{"cobol"}},
+			expectedErr: "unknown state machine preset 'cobol'",
+		},
+		{
+			name:        "NoStartState",
+			config:      StateMachineConfig{States: []StateConfig{{Name: "cont"}}},
+			expectedErr: "state machine must have a 'start_state' with transitions",
+		},
+		{
+			name:        "MissingName",
+			config:      StateMachineConfig{States: []StateConfig{{}}},
+			expectedErr: "state name must be specified",
+		},
+		{
+			name: "NegativeTimeout",
+			config: StateMachineConfig{States: []StateConfig{
+				{Name: StartState, Timeout: -time.Second},
+			}},
+			expectedErr: "timeout of state 'start_state' must not be negative",
+		},
+		{
+			name: "UnknownNextState",
+			config: StateMachineConfig{States: []StateConfig{
+				{Name: StartState, Transitions: []TransitionConfig{{Regex: "a", NextState: "cont"}}},
+			}},
+			expectedErr: "state 'start_state' has a transition to unknown state 'cont'",
+		},
+		{
+			name: "InvalidRegex",
+			config: StateMachineConfig{States: []StateConfig{
+				{Name: StartState, Transitions: []TransitionConfig{{Regex: "[", NextState: StartState}}},
+			}},
+			expectedErr: "compile regex of state 'start_state': error parsing regexp: missing closing ]: `[`",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			m, err := tc.config.Build()
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.NotNil(t, m)
+		})
+	}
+}
+
+func TestStateMachineMergesStates(t *testing.T) {
+	m, err := StateMachineConfig{
+		Presets: []string{"java"},
+		States: []StateConfig{
+			{Name: "java_frame", Timeout: time.Second},
+			{Name: "java_frame", Timeout: 2 * time.Second},
+			{Name: "custom", Timeout: 3 * time.Second},
+		},
+	}.Build()
+	require.NoError(t, err)
+	assert.Equal(t, 2*time.Second, m.states["java_frame"].Timeout())
+	assert.Len(t, m.states["java_frame"].transitions, 3)
+	assert.Equal(t, 2*time.Second, m.MinTimeout())
+}
+
+func TestStateMachineSplitFunc(t *testing.T) {
+	testCases := []struct {
+		name       string
+		config     StateMachineConfig
+		flushAtEOF bool
+		input      []byte
+		steps      []splittest.Step
+	}{
+		{
+			name:   "Java",
+			config: StateMachineConfig{Presets: []string{"java"}},
+			input: []byte("starting\n" +
+				"Exception in thread \"main\" java.lang.IllegalStateException: outer\n" +
+				"\tat com.example.App.run(App.java:10)\n" +
+				"\tat com.example.App.main(App.java:5)\n" +
+				"Caused by: java.lang.NullPointerException\n" +
+				"\tat com.example.App.load(App.java:20)\n" +
+				"\t... 2 more\n" +
+				"stopping\n"),
+			steps: []splittest.Step{
+				splittest.ExpectAdvanceToken(len("starting\n"), "starting"),
+				splittest.ExpectAdvanceToken(len("Exception in thread \"main\" java.lang.IllegalStateException: outer\n"+
+					"\tat com.example.App.run(App.java:10)\n"+
+					"\tat com.example.App.main(App.java:5)\n"+
+					"Caused by: java.lang.NullPointerException\n"+
+					"\tat com.example.App.load(App.java:20)\n"+
+					"\t... 2 more\n"),
+					"Exception in thread \"main\" java.lang.IllegalStateException: outer\n"+
+						"\tat com.example.App.run(App.java:10)\n"+
+						"\tat com.example.App.main(App.java:5)\n"+
+						"Caused by: java.lang.NullPointerException\n"+
+						"\tat com.example.App.load(App.java:20)\n"+
+						"\t... 2 more"),
+				splittest.ExpectAdvanceToken(len("stopping\n"), "stopping"),
+			},
+		},
+		{
+			name:   "Python",
+			config: StateMachineConfig{Presets: []string{"python"}},
+			input: []byte("Traceback (most recent call last):\n" +
+				"  File \"app.py\", line 3, in <module>\n" +
+				"    main()\n" +
+				"  File \"app.py\", line 2, in main\n" +
+				"    1 / 0\n" +
+				"    ~~^~~\n" +
+				"ZeroDivisionError: division by zero\n" +
+				"done\n"),
+			steps: []splittest.Step{
+				splittest.ExpectAdvanceToken(len("Traceback (most recent call last):\n"+
+					"  File \"app.py\", line 3, in <module>\n"+
+					"    main()\n"+
+					"  File \"app.py\", line 2, in main\n"+
+					"    1 / 0\n"+
+					"    ~~^~~\n"+
+					"ZeroDivisionError: division by zero\n"),
+					"Traceback (most recent call last):\n"+
+						"  File \"app.py\", line 3, in <module>\n"+
+						"    main()\n"+
+						"  File \"app.py\", line 2, in main\n"+
+						"    1 / 0\n"+
+						"    ~~^~~\n"+
+						"ZeroDivisionError: division by zero"),
+				splittest.ExpectAdvanceToken(len("done\n"), "done"),
+			},
+		},
+		{
+			name:   "PythonChainedExceptions",
+			config: StateMachineConfig{Presets: []string{"python"}},
+			input: []byte("Traceback (most recent call last):\n" +
+				"  File \"app.py\", line 2, in <module>\n" +
+				"KeyError: 'a'\n" +
+				"\n" +
+				"During handling of the above exception, another exception occurred:\n" +
+				"\n" +
+				"Traceback (most recent call last):\n" +
+				"  File \"app.py\", line 4, in <module>\n" +
+				"ValueError\n" +
+				"done\n"),
+			steps: []splittest.Step{
+				splittest.ExpectAdvanceToken(len("Traceback (most recent call last):\n"+
+					"  File \"app.py\", line 2, in <module>\n"+
+					"KeyError: 'a'\n"+
+					"\n"+
+					"During handling of the above exception, another exception occurred:\n"+
+					"\n"+
+					"Traceback (most recent call last):\n"+
+					"  File \"app.py\", line 4, in <module>\n"+
+					"ValueError\n"),
+					"Traceback (most recent call last):\n"+
+						"  File \"app.py\", line 2, in <module>\n"+
+						"KeyError: 'a'\n"+
+						"\n"+
+						"During handling of the above exception, another exception occurred:\n"+
+						"\n"+
+						"Traceback (most recent call last):\n"+
+						"  File \"app.py\", line 4, in <module>\n"+
+						"ValueError"),
+				splittest.ExpectAdvanceToken(len("done\n"), "done"),
+			},
+		},
+		{
+			name:   "Go",
+			config: StateMachineConfig{Presets: []string{"go"}},
+			input: []byte("panic: boom\n" +
+				"\n" +
+				"goroutine 1 [running]:\n" +
+				"main.main()\n" +
+				"\t/app/main.go:5 +0x1d\n" +
+				"\n" +
+				"goroutine 6 [chan receive]:\n" +
+				"main.worker(0xc000010000)\n" +
+				"\t/app/worker.go:12 +0x45\n" +
+				"created by main.main in goroutine 1\n" +
+				"\t/app/main.go:4 +0x18\n" +
+				"exit status 2\n"),
+			steps: []splittest.Step{
+				splittest.ExpectAdvanceToken(len("panic: boom\n"+
+					"\n"+
+					"goroutine 1 [running]:\n"+
+					"main.main()\n"+
+					"\t/app/main.go:5 +0x1d\n"+
+					"\n"+
+					"goroutine 6 [chan receive]:\n"+
+					"main.worker(0xc000010000)\n"+
+					"\t/app/worker.go:12 +0x45\n"+
+					"created by main.main in goroutine 1\n"+
+					"\t/app/main.go:4 +0x18\n"),
+					"panic: boom\n"+
+						"\n"+
+						"goroutine 1 [running]:\n"+
+						"main.main()\n"+
+						"\t/app/main.go:5 +0x1d\n"+
+						"\n"+
+						"goroutine 6 [chan receive]:\n"+
+						"main.worker(0xc000010000)\n"+
+						"\t/app/worker.go:12 +0x45\n"+
+						"created by main.main in goroutine 1\n"+
+						"\t/app/main.go:4 +0x18"),
+				splittest.ExpectAdvanceToken(len("exit status 2\n"), "exit status 2"),
+			},
+		},
+		{
+			name:   "Ruby",
+			config: StateMachineConfig{Presets: []string{"ruby"}},
+			input: []byte("app.rb:2:in `fail': boom (RuntimeError)\n" +
+				"\tfrom app.rb:6:in `<main>'\n" +
+				"done\n"),
+			steps: []splittest.Step{
+				splittest.ExpectAdvanceToken(len("app.rb:2:in `fail': boom (RuntimeError)\n"+
+					"\tfrom app.rb:6:in `<main>'\n"),
+					"app.rb:2:in `fail': boom (RuntimeError)\n"+
+						"\tfrom app.rb:6:in `<main>'"),
+				splittest.ExpectAdvanceToken(len("done\n"), "done"),
+			},
+		},
+		{
+			name: "FinalState",
+			config: StateMachineConfig{States: []StateConfig{
+				{Name: StartState, Transitions: []TransitionConfig{{Regex: "^begin$", NextState: "body"}}},
+				{Name: "body", Transitions: []TransitionConfig{{Regex: "^end$", NextState: "end"}, {Regex: "^ ", NextState: "body"}}},
+				{Name: "end"},
+			}},
+			input: []byte("begin\n line\nend\nbegin\n"),
+			steps: []splittest.Step{
+				splittest.ExpectAdvanceToken(len("begin\n line\nend\n"), "begin\n line\nend"),
+			},
+		},
+		{
+			name:       "FlushAtEOF",
+			config:     StateMachineConfig{Presets: []string{"java"}},
+			flushAtEOF: true,
+			input:      []byte("java.lang.IllegalStateException\n\tat com.example.App.run(App.java:10)\n"),
+			steps: []splittest.Step{
+				splittest.ExpectAdvanceToken(len("java.lang.IllegalStateException\n\tat com.example.App.run(App.java:10)\n"),
+					"java.lang.IllegalStateException\n\tat com.example.App.run(App.java:10)"),
+			},
+		},
+		{
+			name:   "IncompleteLog",
+			config: StateMachineConfig{Presets: []string{"java"}},
+			input:  []byte("java.lang.IllegalStateException\n\tat com.example.App.run(App.java:10)\n"),
+		},
+		{
+			name:   "CarriageReturn",
+			config: StateMachineConfig{Presets: []string{"java"}},
+			input:  []byte("java.lang.IllegalStateException\r\n\tat com.example.App.run(App.java:10)\r\ndone\r\n"),
+			steps: []splittest.Step{
+				splittest.ExpectAdvanceToken(len("java.lang.IllegalStateException\r\n\tat com.example.App.run(App.java:10)\r\n"),
+					"java.lang.IllegalStateException\r\n\tat com.example.App.run(App.java:10)"),
+				splittest.ExpectAdvanceToken(len("done\r\n"), "done"),
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		m, err := tc.config.Build()
+		require.NoError(t, err)
+		splitFunc, err := m.SplitFunc(unicode.UTF8, tc.flushAtEOF)
+		require.NoError(t, err)
+		t.Run(tc.name, splittest.New(splitFunc, tc.input, tc.steps...))
+	}
+}
+
+func TestStateMachineFlushPeriodFunc(t *testing.T) {
+	m, err := StateMachineConfig{States: []StateConfig{
+		{Name: StartState, Transitions: []TransitionConfig{{Regex: "^begin$", NextState: "body"}}},
+		{Name: "body", Timeout: time.Second, Transitions: []TransitionConfig{
+			{Regex: "^ ", NextState: "body"},
+			{Regex: "^end$", NextState: "end"},
+		}},
+		{Name: "end", Timeout: time.Hour},
+	}}.Build()
+	require.NoError(t, err)
+
+	periodFunc, err := m.FlushPeriodFunc(unicode.UTF8, time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, time.Second, periodFunc([]byte("begin\n line\n")))
+	assert.Equal(t, time.Second, periodFunc([]byte("begin\n line\n lin")))
+	assert.Equal(t, time.Minute, periodFunc([]byte("other")))
+	// lines matching no transition are logs of their own, and the next line restarts from the start state
+	assert.Equal(t, time.Second, periodFunc([]byte("other\nbegin\n line\n")))
+	assert.Equal(t, time.Second, periodFunc([]byte("begin\nother\nbegin\n")))
+	assert.Equal(t, time.Minute, periodFunc([]byte("begin\n line\nother\n")))
+	// a log ends in a state without transitions
+	assert.Equal(t, time.Minute, periodFunc([]byte("begin\nend\n")))
+	assert.Equal(t, time.Second, periodFunc([]byte("begin\nend\nbegin\n")))
+}
@@ -5,6 +5,8 @@ package groupbytraceprocessor // import "github.com/open-telemetry/opentelemetry
 
 import (
 	"time"
+
+	"go.opentelemetry.io/collector/component"
 )
 
 // Config is the configuration for the processor.
@@ -30,6 +32,12 @@ type Config struct {
 	// StoreOnDisk tells the processor to keep only the trace ID in memory, serializing the trace spans to disk.
 	// Useful when the duration to wait for traces to complete is high.
 	// Default: false.
-	// Not yet implemented, and an error will be returned when this option is used.
+	// Not yet implemented, and an error will be returned when this option is used. Use Storage instead.
 	StoreOnDisk bool `mapstructure:"store_on_disk"`
+
+	// Storage is the ID of a storage extension. When set, the processor keeps only the trace IDs in memory,
+	// storing the trace spans through the extension, which allows for waiting longer than the available
+	// memory would permit.
+	// Default: nil, spans are kept in memory.
+	Storage *component.ID `mapstructure:"storage"`
 }
@@ -24,7 +24,7 @@ const (
 )
 
 var (
-	errDiskStorageNotSupported    = errors.New("option 'disk storage' not supported in this release")
+	errDiskStorageNotSupported    = errors.New("option 'disk storage' not supported in this release, use 'storage' instead")
 	errDiscardOrphansNotSupported = errors.New("option 'discard orphans' not supported in this release")
 )
 
@@ -58,7 +58,6 @@ func createTracesProcessor(
 ) (processor.Traces, error) {
 	oCfg := cfg.(*Config)
 
-	var st storage
 	if oCfg.StoreOnDisk {
 		return nil, errDiskStorageNotSupported
 	}
@@ -67,8 +66,9 @@ func createTracesProcessor(
 	}
 
 	processor := newGroupByTraceProcessor(params, nextConsumer, *oCfg)
-	// the only supported storage for now
-	st = newMemoryStorage(processor.telemetryBuilder)
-	processor.st = st
+	if oCfg.Storage == nil {
+		processor.st = newMemoryStorage(processor.telemetryBuilder)
+	}
+	// otherwise, the storage is created on start, once the storage extension is available
 	return processor, nil
 }
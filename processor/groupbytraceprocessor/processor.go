@@ -33,6 +33,7 @@ import (
 // Each worker in the eventMachine also uses a ring buffer to hold the in-flight trace IDs, so that we don't hold more than the given maximum number
 // of traces in memory/storage. Items that are evicted from the buffer are discarded without warning.
 type groupByTraceProcessor struct {
+	id               component.ID
 	nextConsumer     consumer.Traces
 	config           Config
 	logger           *zap.Logger
@@ -59,6 +60,7 @@ func newGroupByTraceProcessor(set processor.Settings, nextConsumer consumer.Trac
 	eventMachine := newEventMachine(set.Logger, 10000, config.NumWorkers, config.NumTraces, telemetryBuilder)
 
 	sp := &groupByTraceProcessor{
+		id:               set.ID,
 		logger:           set.Logger,
 		nextConsumer:     nextConsumer,
 		config:           config,
@@ -88,18 +90,33 @@ func (*groupByTraceProcessor) Capabilities() consumer.Capabilities {
 }
 
 // Start is invoked during service startup.
-func (sp *groupByTraceProcessor) Start(context.Context, component.Host) error {
+func (sp *groupByTraceProcessor) Start(ctx context.Context, host component.Host) error {
+	if sp.config.Storage != nil {
+		client, err := getStorageClient(ctx, host, *sp.config.Storage, sp.id)
+		if err != nil {
+			return err
+		}
+		sp.st = newExtensionStorage(client, sp.telemetryBuilder)
+	}
+
 	// start these metrics, as it might take a while for them to receive their first event
 	sp.telemetryBuilder.ProcessorGroupbytraceTracesEvicted.Add(context.Background(), 0)
 	sp.telemetryBuilder.ProcessorGroupbytraceIncompleteReleases.Add(context.Background(), 0)
 	sp.telemetryBuilder.ProcessorGroupbytraceConfNumTraces.Record(context.Background(), (int64(sp.config.NumTraces)))
+	if err := sp.st.start(); err != nil {
+		return err
+	}
 	sp.eventMachine.startInBackground()
-	return sp.st.start()
+	return nil
 }
 
 // Shutdown is invoked during service shutdown.
 func (sp *groupByTraceProcessor) Shutdown(_ context.Context) error {
 	sp.eventMachine.shutdown()
+	if sp.st == nil {
+		// the storage extension couldn't be found on start
+		return nil
+	}
 	return sp.st.shutdown()
 }
 
@@ -0,0 +1,365 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package groupbytraceprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/groupbytraceprocessor"
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	xstorage "go.opentelemetry.io/collector/extension/xextension/storage"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/groupbytraceprocessor/internal/metadata"
+)
+
+const (
+	batchKeyFormat = "%s/%d"
+	slotKeyFormat  = "slots/%d"
+	slotsKey       = "slots"
+
+	// slotsReserved is the number of slots added to the index at once when it is full,
+	// so that the number of slots only needs to be written once in a while.
+	slotsReserved = 64
+	// sweepBatchSize is the maximum number of operations in a batch when removing the
+	// traces left over by a previous run.
+	sweepBatchSize = 1024
+)
+
+// extensionStorage keeps only the trace IDs in memory, writing the spans through a
+// storage extension client. Each call to createOrAppend is stored under its own key,
+// so appending spans to a trace doesn't require reading back what was stored before.
+//
+// Each pending trace also takes a slot in an index of trace IDs kept in the storage,
+// so that the traces left over by a collector that didn't shut down cleanly can be
+// removed on start.
+type extensionStorage struct {
+	// the lock guards the traces and the slots, but is never held while calling the client
+	sync.RWMutex
+	client      xstorage.Client
+	traces      map[pcommon.TraceID]*storedTrace
+	freeSlots   []int
+	numSlots    int
+	marshaler   ptrace.ProtoMarshaler
+	unmarshaler ptrace.ProtoUnmarshaler
+	telemetry   *metadata.TelemetryBuilder
+
+	// indexLock serializes the writes of the number of slots of the index
+	indexLock   sync.Mutex
+	storedSlots int
+
+	stopped                   bool
+	stoppedLock               sync.RWMutex
+	metricsCollectionInterval time.Duration
+}
+
+// storedTrace is a trace held in the storage. Its lock is held while calling the
+// client, so that the batches of a trace are written, read and removed in order.
+type storedTrace struct {
+	sync.Mutex
+	batches int
+	slot    int
+	// removed is set once the trace is removed from the storage, its batches
+	// must not be written anymore.
+	removed bool
+}
+
+var _ storage = (*extensionStorage)(nil)
+
+func newExtensionStorage(client xstorage.Client, telemetry *metadata.TelemetryBuilder) *extensionStorage {
+	return &extensionStorage{
+		client:                    client,
+		traces:                    make(map[pcommon.TraceID]*storedTrace),
+		metricsCollectionInterval: time.Second,
+		telemetry:                 telemetry,
+	}
+}
+
+// getStorageClient returns the client of the storage extension with the given ID.
+func getStorageClient(ctx context.Context, host component.Host, storageID, componentID component.ID) (xstorage.Client, error) {
+	ext, ok := host.GetExtensions()[storageID]
+	if !ok {
+		return nil, fmt.Errorf("storage extension '%s' not found", storageID)
+	}
+
+	storageExt, ok := ext.(xstorage.Extension)
+	if !ok {
+		return nil, fmt.Errorf("non-storage extension '%s' found", storageID)
+	}
+
+	return storageExt.GetClient(ctx, component.KindProcessor, componentID, "")
+}
+
+func (st *extensionStorage) createOrAppend(traceID pcommon.TraceID, td ptrace.Traces) error {
+	// the marshaled bytes are a copy, further changes to td are not reflected in the storage
+	data, err := st.marshaler.MarshalTraces(td)
+	if err != nil {
+		return err
+	}
+
+	for {
+		trace := st.getOrCreate(traceID)
+		trace.Lock()
+		if trace.removed {
+			// the trace was removed in the meantime, start a new one
+			trace.Unlock()
+			continue
+		}
+		err = st.appendBatch(traceID, trace, data)
+		trace.Unlock()
+		return err
+	}
+}
+
+func (st *extensionStorage) getOrCreate(traceID pcommon.TraceID) *storedTrace {
+	st.Lock()
+	defer st.Unlock()
+
+	trace, ok := st.traces[traceID]
+	if !ok {
+		trace = &storedTrace{}
+		if n := len(st.freeSlots); n > 0 {
+			trace.slot = st.freeSlots[n-1]
+			st.freeSlots = st.freeSlots[:n-1]
+		} else {
+			trace.slot = st.numSlots
+			st.numSlots++
+		}
+		st.traces[traceID] = trace
+	}
+	return trace
+}
+
+// appendBatch writes the next batch of the trace, which must be locked.
+func (st *extensionStorage) appendBatch(traceID pcommon.TraceID, trace *storedTrace, data []byte) error {
+	ops := []*xstorage.Operation{xstorage.SetOperation(batchKey(traceID, trace.batches), data)}
+	if trace.batches == 0 {
+		// the trace ID is written to its slot along with the first batch
+		if err := st.reserveSlot(trace.slot); err != nil {
+			return err
+		}
+		ops = append(ops, xstorage.SetOperation(slotKey(trace.slot), traceID[:]))
+	}
+
+	if err := st.client.Batch(context.Background(), ops...); err != nil {
+		return err
+	}
+	trace.batches++
+	return nil
+}
+
+// reserveSlot makes sure the number of slots stored in the index includes the given slot.
+func (st *extensionStorage) reserveSlot(slot int) error {
+	st.indexLock.Lock()
+	defer st.indexLock.Unlock()
+
+	if slot < st.storedSlots {
+		return nil
+	}
+	n := slot + slotsReserved
+	if err := st.client.Set(context.Background(), slotsKey, binary.LittleEndian.AppendUint64(nil, uint64(n))); err != nil {
+		return fmt.Errorf("couldn't write the index of pending traces: %w", err)
+	}
+	st.storedSlots = n
+	return nil
+}
+
+func (st *extensionStorage) get(traceID pcommon.TraceID) ([]ptrace.ResourceSpans, error) {
+	st.RLock()
+	trace, ok := st.traces[traceID]
+	st.RUnlock()
+	if !ok {
+		return nil, nil
+	}
+
+	trace.Lock()
+	if trace.removed {
+		trace.Unlock()
+		return nil, nil
+	}
+	ops := make([]*xstorage.Operation, 0, trace.batches)
+	for i := 0; i < trace.batches; i++ {
+		ops = append(ops, xstorage.GetOperation(batchKey(traceID, i)))
+	}
+	err := st.client.Batch(context.Background(), ops...)
+	trace.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	return st.unmarshal(ops)
+}
+
+// delete will return the ResourceSpans that were stored for the trace, removing them from the storage.
+func (st *extensionStorage) delete(traceID pcommon.TraceID) ([]ptrace.ResourceSpans, error) {
+	st.Lock()
+	trace, ok := st.traces[traceID]
+	delete(st.traces, traceID)
+	st.Unlock()
+	if !ok {
+		return nil, nil
+	}
+
+	trace.Lock()
+	defer trace.Unlock()
+	trace.removed = true
+
+	ops := make([]*xstorage.Operation, 0, 2*trace.batches+1)
+	gets := make([]*xstorage.Operation, 0, trace.batches)
+	for i := 0; i < trace.batches; i++ {
+		key := batchKey(traceID, i)
+		get := xstorage.GetOperation(key)
+		gets = append(gets, get)
+		ops = append(ops, get, xstorage.DeleteOperation(key))
+	}
+	ops = append(ops, xstorage.DeleteOperation(slotKey(trace.slot)))
+	if err := st.client.Batch(context.Background(), ops...); err != nil {
+		// the slot isn't released, so the spans left in the storage are removed on the next start
+		return nil, err
+	}
+
+	st.Lock()
+	st.freeSlots = append(st.freeSlots, trace.slot)
+	st.Unlock()
+
+	return st.unmarshal(gets)
+}
+
+func (st *extensionStorage) unmarshal(ops []*xstorage.Operation) ([]ptrace.ResourceSpans, error) {
+	var result []ptrace.ResourceSpans
+	for _, op := range ops {
+		if op.Value == nil {
+			continue
+		}
+		td, err := st.unmarshaler.UnmarshalTraces(op.Value)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't unmarshal spans stored at %q: %w", op.Key, err)
+		}
+		rss := td.ResourceSpans()
+		for i := 0; i < rss.Len(); i++ {
+			result = append(result, rss.At(i))
+		}
+	}
+	return result, nil
+}
+
+// start removes the traces left in the storage by a previous run that didn't shut down
+// cleanly, as they are not known to the processor anymore.
+func (st *extensionStorage) start() error {
+	if err := st.sweep(context.Background()); err != nil {
+		return fmt.Errorf("couldn't remove the traces left over in the storage: %w", err)
+	}
+	go st.periodicMetrics()
+	return nil
+}
+
+func (st *extensionStorage) sweep(ctx context.Context) error {
+	data, err := st.client.Get(ctx, slotsKey)
+	if err != nil || len(data) != 8 {
+		return err
+	}
+	numSlots := int(binary.LittleEndian.Uint64(data))
+
+	for first := 0; first < numSlots; first += sweepBatchSize {
+		slots := make([]*xstorage.Operation, 0, sweepBatchSize)
+		for i := first; i < min(first+sweepBatchSize, numSlots); i++ {
+			slots = append(slots, xstorage.GetOperation(slotKey(i)))
+		}
+		if err = st.client.Batch(ctx, slots...); err != nil {
+			return err
+		}
+
+		var ops []*xstorage.Operation
+		for _, slot := range slots {
+			if len(slot.Value) != len(pcommon.TraceID{}) {
+				continue
+			}
+			traceID := pcommon.TraceID(slot.Value)
+			// the number of batches of the trace isn't stored, look for them until one is missing
+			for i := 0; ; i++ {
+				batch, err := st.client.Get(ctx, batchKey(traceID, i))
+				if err != nil {
+					return err
+				}
+				if batch == nil {
+					break
+				}
+				ops = append(ops, xstorage.DeleteOperation(batchKey(traceID, i)))
+			}
+			ops = append(ops, xstorage.DeleteOperation(slot.Key))
+		}
+		if len(ops) > 0 {
+			if err = st.client.Batch(ctx, ops...); err != nil {
+				return err
+			}
+		}
+	}
+
+	return st.client.Delete(ctx, slotsKey)
+}
+
+// shutdown removes the traces that weren't released yet, as they are not known
+// to the processor anymore once it is restarted, and closes the client.
+func (st *extensionStorage) shutdown() error {
+	st.stoppedLock.Lock()
+	st.stopped = true
+	st.stoppedLock.Unlock()
+
+	st.Lock()
+	defer st.Unlock()
+
+	var ops []*xstorage.Operation
+	for traceID, trace := range st.traces {
+		for i := 0; i < trace.batches; i++ {
+			ops = append(ops, xstorage.DeleteOperation(batchKey(traceID, i)))
+		}
+		ops = append(ops, xstorage.DeleteOperation(slotKey(trace.slot)))
+	}
+	st.traces = make(map[pcommon.TraceID]*storedTrace)
+
+	ctx := context.Background()
+	if len(ops) > 0 {
+		if err := st.client.Batch(ctx, ops...); err != nil {
+			return fmt.Errorf("couldn't remove pending traces from the storage: %w", err)
+		}
+	}
+	if err := st.client.Delete(ctx, slotsKey); err != nil {
+		return fmt.Errorf("couldn't remove pending traces from the storage: %w", err)
+	}
+	return st.client.Close(ctx)
+}
+
+func (st *extensionStorage) periodicMetrics() {
+	numTraces := st.count()
+	st.telemetry.ProcessorGroupbytraceNumTracesInMemory.Record(context.Background(), int64(numTraces))
+
+	st.stoppedLock.RLock()
+	stopped := st.stopped
+	st.stoppedLock.RUnlock()
+	if stopped {
+		return
+	}
+
+	time.AfterFunc(st.metricsCollectionInterval, func() {
+		st.periodicMetrics()
+	})
+}
+
+func (st *extensionStorage) count() int {
+	st.RLock()
+	defer st.RUnlock()
+	return len(st.traces)
+}
+
+func batchKey(traceID pcommon.TraceID, n int) string {
+	return fmt.Sprintf(batchKeyFormat, traceID, n)
+}
+
+func slotKey(slot int) string {
+	return fmt.Sprintf(slotKeyFormat, slot)
+}
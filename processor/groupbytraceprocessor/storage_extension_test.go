@@ -0,0 +1,208 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package groupbytraceprocessor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/processor/processortest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/groupbytraceprocessor/internal/metadata"
+)
+
+func newTestExtensionStorage(t *testing.T) (*extensionStorage, *storagetest.TestClient) {
+	set := processortest.NewNopSettings(metadata.Type)
+	tel, _ := metadata.NewTelemetryBuilder(set.TelemetrySettings)
+	client := storagetest.NewInMemoryClient(component.KindProcessor, set.ID, "")
+	return newExtensionStorage(client, tel), client
+}
+
+func TestExtensionCreateAndGetTrace(t *testing.T) {
+	st, _ := newTestExtensionStorage(t)
+
+	traceIDs := []pcommon.TraceID{
+		pcommon.TraceID([16]byte{1, 2, 3, 4}),
+		pcommon.TraceID([16]byte{2, 3, 4, 5}),
+	}
+
+	baseTrace := ptrace.NewTraces()
+	span := baseTrace.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+
+	// test
+	for _, traceID := range traceIDs {
+		span.SetTraceID(traceID)
+		assert.NoError(t, st.createOrAppend(traceID, baseTrace))
+	}
+
+	// verify
+	assert.Equal(t, 2, st.count())
+	for _, traceID := range traceIDs {
+		expected := ptrace.NewResourceSpans()
+		baseTrace.ResourceSpans().At(0).CopyTo(expected)
+		expected.ScopeSpans().At(0).Spans().At(0).SetTraceID(traceID)
+
+		retrieved, err := st.get(traceID)
+		require.NoError(t, err)
+		assert.Equal(t, []ptrace.ResourceSpans{expected}, retrieved)
+	}
+
+	retrieved, err := st.get(pcommon.TraceID([16]byte{9}))
+	require.NoError(t, err)
+	assert.Nil(t, retrieved)
+}
+
+func TestExtensionAppendAndDeleteTrace(t *testing.T) {
+	st, client := newTestExtensionStorage(t)
+	traceID := pcommon.TraceID([16]byte{1, 2, 3, 4})
+
+	trace := ptrace.NewTraces()
+	span := trace.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	span.SetTraceID(traceID)
+	span.SetName("first-name")
+	require.NoError(t, st.createOrAppend(traceID, trace))
+
+	// the stored spans are a copy
+	span.SetName("second-name")
+	require.NoError(t, st.createOrAppend(traceID, trace))
+
+	// test
+	deleted, err := st.delete(traceID)
+
+	// verify
+	require.NoError(t, err)
+	require.Len(t, deleted, 2)
+	assert.Equal(t, "first-name", deleted[0].ScopeSpans().At(0).Spans().At(0).Name())
+	assert.Equal(t, "second-name", deleted[1].ScopeSpans().At(0).Spans().At(0).Name())
+	assert.Zero(t, st.count())
+
+	for i := 0; i < 2; i++ {
+		data, err := client.Get(context.Background(), batchKey(traceID, i))
+		require.NoError(t, err)
+		assert.Nil(t, data)
+	}
+
+	deleted, err = st.delete(traceID)
+	require.NoError(t, err)
+	assert.Nil(t, deleted)
+}
+
+func TestExtensionShutdownRemovesPendingTraces(t *testing.T) {
+	st, client := newTestExtensionStorage(t)
+	traceID := pcommon.TraceID([16]byte{1, 2, 3, 4})
+
+	trace := ptrace.NewTraces()
+	trace.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetTraceID(traceID)
+	require.NoError(t, st.start())
+	require.NoError(t, st.createOrAppend(traceID, trace))
+	require.NoError(t, st.shutdown())
+
+	assert.Zero(t, st.count())
+	_, err := client.Get(context.Background(), batchKey(traceID, 0))
+	assert.Error(t, err, "the client should be closed")
+}
+
+func TestExtensionStartRemovesLeftoverTraces(t *testing.T) {
+	st, client := newTestExtensionStorage(t)
+	require.NoError(t, st.start())
+	defer func() {
+		assert.NoError(t, st.shutdown())
+	}()
+
+	traceIDs := []pcommon.TraceID{
+		pcommon.TraceID([16]byte{1, 2, 3, 4}),
+		pcommon.TraceID([16]byte{2, 3, 4, 5}),
+		pcommon.TraceID([16]byte{3, 4, 5, 6}),
+	}
+	trace := ptrace.NewTraces()
+	trace.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	for _, traceID := range traceIDs {
+		require.NoError(t, st.createOrAppend(traceID, trace))
+		require.NoError(t, st.createOrAppend(traceID, trace))
+	}
+	_, err := st.delete(traceIDs[1])
+	require.NoError(t, err)
+
+	// test: a new run starts on the same storage without the previous one shutting down
+	restarted := newExtensionStorage(client, st.telemetry)
+	require.NoError(t, restarted.sweep(context.Background()))
+
+	// verify
+	for _, traceID := range traceIDs {
+		for i := 0; i < 2; i++ {
+			data, err := client.Get(context.Background(), batchKey(traceID, i))
+			require.NoError(t, err)
+			assert.Nil(t, data)
+		}
+	}
+	for _, key := range []string{slotsKey, slotKey(0), slotKey(1), slotKey(2)} {
+		data, err := client.Get(context.Background(), key)
+		require.NoError(t, err)
+		assert.Nil(t, data, key)
+	}
+}
+
+func TestExtensionReusesSlots(t *testing.T) {
+	st, client := newTestExtensionStorage(t)
+	trace := ptrace.NewTraces()
+	trace.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+
+	first := pcommon.TraceID([16]byte{1, 2, 3, 4})
+	second := pcommon.TraceID([16]byte{2, 3, 4, 5})
+	require.NoError(t, st.createOrAppend(first, trace))
+	_, err := st.delete(first)
+	require.NoError(t, err)
+	require.NoError(t, st.createOrAppend(second, trace))
+
+	data, err := client.Get(context.Background(), slotKey(0))
+	require.NoError(t, err)
+	assert.Equal(t, second[:], data)
+	assert.Equal(t, 1, st.numSlots)
+	assert.Equal(t, slotsReserved, st.storedSlots)
+}
+
+func TestProcessorWithStorageExtension(t *testing.T) {
+	ext := storagetest.NewInMemoryStorageExtension("test")
+	host := storagetest.NewStorageHost().WithExtension(ext.ID, ext)
+
+	config := createDefaultConfig().(*Config)
+	config.WaitDuration = 0
+	config.Storage = &ext.ID
+
+	next := &consumertest.TracesSink{}
+	p, err := createTracesProcessor(context.Background(), processortest.NewNopSettings(metadata.Type), config, next)
+	require.NoError(t, err)
+	require.NoError(t, p.Start(context.Background(), host))
+	defer func() {
+		assert.NoError(t, p.Shutdown(context.Background()))
+	}()
+
+	trace := ptrace.NewTraces()
+	trace.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetTraceID(pcommon.TraceID([16]byte{1, 2, 3, 4}))
+	require.NoError(t, p.ConsumeTraces(context.Background(), trace))
+
+	assert.Eventually(t, func() bool {
+		return next.SpanCount() == 1
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestProcessorWithMissingStorageExtension(t *testing.T) {
+	id := storagetest.NewStorageID("missing")
+	config := createDefaultConfig().(*Config)
+	config.Storage = &id
+
+	p, err := createTracesProcessor(context.Background(), processortest.NewNopSettings(metadata.Type), config, consumertest.NewNop())
+	require.NoError(t, err)
+	assert.ErrorContains(t, p.Start(context.Background(), componenttest.NewNopHost()), "not found")
+	assert.NoError(t, p.Shutdown(context.Background()))
+}
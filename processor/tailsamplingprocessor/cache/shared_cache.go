@@ -0,0 +1,91 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cache // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/cache"
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/golang-lru/v2/expirable"
+	"go.opentelemetry.io/collector/extension/xextension/storage"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.uber.org/zap"
+)
+
+// sharedDecisionCache stores sampling decisions in a storage.Client that can be shared
+// by several collectors, such as one provided by the redis storage extension.
+// The value is true for sampled traces and false for non-sampled ones.
+//
+// The decisions read from or written to the storage are also kept in a local LRU cache,
+// and the trace IDs without a decision in a second one whose entries expire after missTTL,
+// so that looking up the same trace IDs again doesn't require a round trip to the storage.
+type sharedDecisionCache struct {
+	client storage.Client
+	logger *zap.Logger
+	hits   *expirable.LRU[uint64, bool]
+	misses *expirable.LRU[uint64, struct{}]
+}
+
+var _ Cache[bool] = (*sharedDecisionCache)(nil)
+
+// NewSharedDecisionCache returns a cache of sampling decisions backed by the given client.
+// Up to size decisions and size misses are kept locally, the misses for at most missTTL.
+// The expiration of the decisions is left to the storage. Errors from the storage are
+// logged and reported as cache misses.
+func NewSharedDecisionCache(client storage.Client, logger *zap.Logger, size int, missTTL time.Duration) (Cache[bool], error) {
+	if size <= 0 {
+		return nil, fmt.Errorf("invalid local cache size %d, it must be positive", size)
+	}
+	return &sharedDecisionCache{
+		client: client,
+		logger: logger,
+		hits:   expirable.NewLRU[uint64, bool](size, nil, 0),
+		misses: expirable.NewLRU[uint64, struct{}](size, nil, missTTL),
+	}, nil
+}
+
+func (c *sharedDecisionCache) Get(id pcommon.TraceID) (bool, bool) {
+	k := rightHalfTraceID(id)
+	if sampled, ok := c.hits.Get(k); ok {
+		return sampled, true
+	}
+	if _, ok := c.misses.Get(k); ok {
+		return false, false
+	}
+
+	data, err := c.client.Get(context.Background(), id.String())
+	if err != nil {
+		c.logger.Debug("Failed to read shared sampling decision", zap.Stringer("id", id), zap.Error(err))
+		return false, false
+	}
+	if len(data) == 0 {
+		c.misses.Add(k, struct{}{})
+		return false, false
+	}
+	sampled := data[0] == 1
+	c.hits.Add(k, sampled)
+	return sampled, true
+}
+
+func (c *sharedDecisionCache) Put(id pcommon.TraceID, sampled bool) {
+	k := rightHalfTraceID(id)
+	c.misses.Remove(k)
+	c.hits.Add(k, sampled)
+
+	v := []byte{0}
+	if sampled {
+		v[0] = 1
+	}
+	if err := c.client.Set(context.Background(), id.String(), v); err != nil {
+		c.logger.Warn("Failed to write shared sampling decision", zap.Stringer("id", id), zap.Error(err))
+	}
+}
+
+func (c *sharedDecisionCache) Delete(id pcommon.TraceID) {
+	c.hits.Remove(rightHalfTraceID(id))
+	if err := c.client.Delete(context.Background(), id.String()); err != nil {
+		c.logger.Warn("Failed to delete shared sampling decision", zap.Stringer("id", id), zap.Error(err))
+	}
+}
@@ -0,0 +1,115 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension/xextension/storage"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
+)
+
+func newTestSharedCache(t *testing.T, client storage.Client) Cache[bool] {
+	c, err := NewSharedDecisionCache(client, zap.NewNop(), 10, time.Minute)
+	require.NoError(t, err)
+	return c
+}
+
+func TestSharedCacheGetPut(t *testing.T) {
+	client := storagetest.NewInMemoryClient(component.KindProcessor, component.MustNewID("tail_sampling"), "decisions")
+	c := newTestSharedCache(t, client)
+	other := newTestSharedCache(t, client)
+
+	sampledID, err := traceIDFromHex("12341234123412341234123412341231")
+	require.NoError(t, err)
+	notSampledID, err := traceIDFromHex("12341234123412341234123412341232")
+	require.NoError(t, err)
+
+	_, ok := c.Get(sampledID)
+	assert.False(t, ok)
+
+	c.Put(sampledID, true)
+	c.Put(notSampledID, false)
+
+	v, ok := other.Get(sampledID)
+	assert.True(t, ok)
+	assert.True(t, v)
+	v, ok = other.Get(notSampledID)
+	assert.True(t, ok)
+	assert.False(t, v)
+
+	other.Delete(sampledID)
+	_, ok = newTestSharedCache(t, client).Get(sampledID)
+	assert.False(t, ok)
+}
+
+func TestSharedCacheLocalCache(t *testing.T) {
+	client := storagetest.NewInMemoryClient(component.KindProcessor, component.MustNewID("tail_sampling"), "decisions")
+	c, err := NewSharedDecisionCache(client, zap.NewNop(), 10, 50*time.Millisecond)
+	require.NoError(t, err)
+	other := newTestSharedCache(t, client)
+
+	sampledID, err := traceIDFromHex("12341234123412341234123412341231")
+	require.NoError(t, err)
+	missingID, err := traceIDFromHex("12341234123412341234123412341232")
+	require.NoError(t, err)
+
+	other.Put(sampledID, true)
+	v, ok := c.Get(sampledID)
+	assert.True(t, ok)
+	assert.True(t, v)
+	_, ok = c.Get(missingID)
+	assert.False(t, ok)
+
+	// Known decisions and misses are answered locally
+	require.NoError(t, client.Delete(context.Background(), sampledID.String()))
+	other.Put(missingID, false)
+	v, ok = c.Get(sampledID)
+	assert.True(t, ok)
+	assert.True(t, v)
+	_, ok = c.Get(missingID)
+	assert.False(t, ok)
+
+	// Misses are looked up again once expired
+	assert.Eventually(t, func() bool {
+		v, ok := c.Get(missingID)
+		return ok && !v
+	}, time.Second, 10*time.Millisecond)
+
+	// Local decisions replace misses
+	newID, err := traceIDFromHex("12341234123412341234123412341233")
+	require.NoError(t, err)
+	_, ok = c.Get(newID)
+	assert.False(t, ok)
+	c.Put(newID, true)
+	v, ok = c.Get(newID)
+	assert.True(t, ok)
+	assert.True(t, v)
+}
+
+func TestSharedCacheInvalidSize(t *testing.T) {
+	client := storagetest.NewInMemoryClient(component.KindProcessor, component.MustNewID("tail_sampling"), "decisions")
+	_, err := NewSharedDecisionCache(client, zap.NewNop(), 0, time.Minute)
+	assert.EqualError(t, err, "invalid local cache size 0, it must be positive")
+}
+
+func TestSharedCacheClosedClient(t *testing.T) {
+	client := storagetest.NewInMemoryClient(component.KindProcessor, component.MustNewID("tail_sampling"), "decisions")
+	c := newTestSharedCache(t, client)
+	id, err := traceIDFromHex("12341234123412341234123412341231")
+	require.NoError(t, err)
+
+	newTestSharedCache(t, client).Put(id, true)
+	require.NoError(t, client.Close(context.Background()))
+
+	_, ok := c.Get(id)
+	assert.False(t, ok, "storage errors are reported as misses")
+}
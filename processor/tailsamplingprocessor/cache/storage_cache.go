@@ -0,0 +1,237 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cache // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/cache"
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+	"go.opentelemetry.io/collector/extension/xextension/storage"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// PersistentCache is a decision cache whose entries can be saved to and restored from
+// a storage.Client, so decisions survive a restart of the collector.
+type PersistentCache interface {
+	Cache[bool]
+	// Attach binds the cache to the given client and restores the entries previously
+	// flushed under key. Entries already in the cache are kept.
+	Attach(ctx context.Context, client storage.Client, key string) error
+	// Flush saves the entries changed since the last flush to the attached client.
+	// It is a no-op if the cache is not attached.
+	Flush(ctx context.Context) error
+}
+
+// The entries are stored as a snapshot of the whole cache under the key, followed by a
+// journal of the entries changed since the snapshot. Each flush only appends a journal
+// segment with the changed entries. Once the journal holds as many entries as the cache,
+// it is compacted into a new snapshot, so writing an entry costs at most twice its size.
+const (
+	// entrySize is the encoded size of a single entry: the right half of the
+	// trace ID followed by a single byte for the value.
+	entrySize = 9
+
+	entryNotSampled byte = 0
+	entrySampled    byte = 1
+	entryDeleted    byte = 2
+)
+
+type storageDecisionCache struct {
+	cache *lru.Cache[uint64, bool]
+	size  int
+
+	mu sync.Mutex
+	// changes are the encoded entries changed since the last flush, in order.
+	changes []byte
+
+	client storage.Client
+	key    string
+	// journalSegments and journalEntries count the segments and entries of the journal.
+	journalSegments uint64
+	journalEntries  int
+}
+
+var _ PersistentCache = (*storageDecisionCache)(nil)
+
+// NewStorageDecisionCache returns an LRU decision cache of the given size that can be
+// persisted to a storage.Client once attached.
+func NewStorageDecisionCache(size int) (PersistentCache, error) {
+	c, err := lru.New[uint64, bool](size)
+	if err != nil {
+		return nil, err
+	}
+	return &storageDecisionCache{cache: c, size: size}, nil
+}
+
+func (c *storageDecisionCache) Get(id pcommon.TraceID) (bool, bool) {
+	return c.cache.Get(rightHalfTraceID(id))
+}
+
+func (c *storageDecisionCache) Put(id pcommon.TraceID, v bool) {
+	k := rightHalfTraceID(id)
+	_ = c.cache.Add(k, v)
+	if v {
+		c.recordChange(k, entrySampled)
+	} else {
+		c.recordChange(k, entryNotSampled)
+	}
+}
+
+func (c *storageDecisionCache) Delete(id pcommon.TraceID) {
+	k := rightHalfTraceID(id)
+	if c.cache.Remove(k) {
+		c.recordChange(k, entryDeleted)
+	}
+}
+
+func (c *storageDecisionCache) recordChange(k uint64, value byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.changes = appendEntry(c.changes, k, value)
+}
+
+func (c *storageDecisionCache) Attach(ctx context.Context, client storage.Client, key string) error {
+	if client == nil {
+		return errors.New("storage client is nil")
+	}
+	c.client = client
+	c.key = key
+
+	// Entries are stored from the oldest to the newest,
+	// so adding them in order preserves their recency.
+	snapshot, err := client.Get(ctx, key)
+	if err != nil {
+		return fmt.Errorf("failed to read decision cache %q: %w", key, err)
+	}
+	if err = c.restore(key, snapshot); err != nil {
+		return err
+	}
+
+	meta, err := client.Get(ctx, c.journalKey())
+	if err != nil {
+		return fmt.Errorf("failed to read decision cache %q: %w", key, err)
+	}
+	if len(meta) == 8 {
+		c.journalSegments = binary.LittleEndian.Uint64(meta)
+	}
+	for i := uint64(0); i < c.journalSegments; i++ {
+		segment, err := client.Get(ctx, c.segmentKey(i))
+		if err != nil {
+			return fmt.Errorf("failed to read decision cache %q: %w", key, err)
+		}
+		if err = c.restore(key, segment); err != nil {
+			return err
+		}
+		c.journalEntries += len(segment) / entrySize
+	}
+	return nil
+}
+
+func (c *storageDecisionCache) restore(key string, data []byte) error {
+	if len(data)%entrySize != 0 {
+		return fmt.Errorf("decision cache %q is corrupted: unexpected length %d", key, len(data))
+	}
+	for i := 0; i < len(data); i += entrySize {
+		k := binary.LittleEndian.Uint64(data[i:])
+		switch data[i+8] {
+		case entryDeleted:
+			c.cache.Remove(k)
+		default:
+			_ = c.cache.Add(k, data[i+8] == entrySampled)
+		}
+	}
+	return nil
+}
+
+func (c *storageDecisionCache) Flush(ctx context.Context) error {
+	if c.client == nil {
+		return nil
+	}
+
+	c.mu.Lock()
+	changes := c.changes
+	c.changes = nil
+	c.mu.Unlock()
+	if len(changes) == 0 {
+		return nil
+	}
+
+	var err error
+	if c.journalEntries+len(changes)/entrySize > c.size {
+		err = c.compact(ctx)
+	} else {
+		err = c.appendSegment(ctx, changes)
+	}
+	if err != nil {
+		// Keep the changes for the next flush, before the ones recorded in the meantime
+		c.mu.Lock()
+		c.changes = append(changes, c.changes...)
+		c.mu.Unlock()
+		return fmt.Errorf("failed to write decision cache %q: %w", c.key, err)
+	}
+	return nil
+}
+
+// appendSegment writes the changed entries as a new journal segment.
+func (c *storageDecisionCache) appendSegment(ctx context.Context, changes []byte) error {
+	err := c.client.Batch(ctx,
+		storage.SetOperation(c.segmentKey(c.journalSegments), changes),
+		storage.SetOperation(c.journalKey(), binary.LittleEndian.AppendUint64(nil, c.journalSegments+1)),
+	)
+	if err != nil {
+		return err
+	}
+	c.journalSegments++
+	c.journalEntries += len(changes) / entrySize
+	return nil
+}
+
+// compact writes a snapshot of the whole cache and deletes the journal.
+func (c *storageDecisionCache) compact(ctx context.Context) error {
+	keys := c.cache.Keys()
+	snapshot := make([]byte, 0, len(keys)*entrySize)
+	for _, k := range keys {
+		v, ok := c.cache.Peek(k)
+		if !ok {
+			continue
+		}
+		if v {
+			snapshot = appendEntry(snapshot, k, entrySampled)
+		} else {
+			snapshot = appendEntry(snapshot, k, entryNotSampled)
+		}
+	}
+
+	ops := []*storage.Operation{
+		storage.SetOperation(c.key, snapshot),
+		storage.DeleteOperation(c.journalKey()),
+	}
+	for i := uint64(0); i < c.journalSegments; i++ {
+		ops = append(ops, storage.DeleteOperation(c.segmentKey(i)))
+	}
+	if err := c.client.Batch(ctx, ops...); err != nil {
+		return err
+	}
+	c.journalSegments = 0
+	c.journalEntries = 0
+	return nil
+}
+
+func (c *storageDecisionCache) journalKey() string {
+	return c.key + ".journal"
+}
+
+func (c *storageDecisionCache) segmentKey(i uint64) string {
+	return c.key + ".journal." + strconv.FormatUint(i, 10)
+}
+
+func appendEntry(data []byte, k uint64, value byte) []byte {
+	data = binary.LittleEndian.AppendUint64(data, k)
+	return append(data, value)
+}
@@ -0,0 +1,136 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cache
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
+)
+
+func TestStorageCacheFlushAndAttach(t *testing.T) {
+	ctx := context.Background()
+	client := storagetest.NewInMemoryClient(component.KindProcessor, component.MustNewID("tail_sampling"), "")
+
+	c, err := NewStorageDecisionCache(2)
+	require.NoError(t, err)
+	require.NoError(t, c.Attach(ctx, client, "sampled"))
+
+	id1, err := traceIDFromHex("12341234123412341234123412341231")
+	require.NoError(t, err)
+	id2, err := traceIDFromHex("12341234123412341234123412341232")
+	require.NoError(t, err)
+	id3, err := traceIDFromHex("12341234123412341234123412341233")
+	require.NoError(t, err)
+
+	c.Put(id1, true)
+	c.Put(id2, false)
+	require.NoError(t, c.Flush(ctx))
+
+	restored, err := NewStorageDecisionCache(2)
+	require.NoError(t, err)
+	require.NoError(t, restored.Attach(ctx, client, "sampled"))
+
+	v, ok := restored.Get(id2)
+	assert.True(t, ok)
+	assert.False(t, v)
+	v, ok = restored.Get(id1)
+	assert.True(t, ok)
+	assert.True(t, v)
+
+	// id1 was used last, so id2 is evicted.
+	restored.Put(id3, true)
+	_, ok = restored.Get(id2)
+	assert.False(t, ok)
+}
+
+func TestStorageCacheJournal(t *testing.T) {
+	ctx := context.Background()
+	client := storagetest.NewInMemoryClient(component.KindProcessor, component.MustNewID("tail_sampling"), "")
+
+	c, err := NewStorageDecisionCache(4)
+	require.NoError(t, err)
+	require.NoError(t, c.Attach(ctx, client, "sampled"))
+
+	ids := make([]pcommon.TraceID, 5)
+	for i := range ids {
+		ids[i], err = traceIDFromHex(fmt.Sprintf("1234123412341234123412341234123%d", i))
+		require.NoError(t, err)
+	}
+
+	// Each flush only writes the entries changed since the previous one
+	c.Put(ids[0], true)
+	require.NoError(t, c.Flush(ctx))
+	c.Put(ids[1], false)
+	c.Delete(ids[0])
+	require.NoError(t, c.Flush(ctx))
+	require.NoError(t, c.Flush(ctx))
+
+	snapshot, err := client.Get(ctx, "sampled")
+	require.NoError(t, err)
+	assert.Empty(t, snapshot)
+	for i, expected := range []int{1, 2} {
+		segment, err := client.Get(ctx, fmt.Sprintf("sampled.journal.%d", i))
+		require.NoError(t, err)
+		assert.Len(t, segment, expected*entrySize)
+	}
+
+	restored, err := NewStorageDecisionCache(4)
+	require.NoError(t, err)
+	require.NoError(t, restored.Attach(ctx, client, "sampled"))
+	_, ok := restored.Get(ids[0])
+	assert.False(t, ok)
+	v, ok := restored.Get(ids[1])
+	assert.True(t, ok)
+	assert.False(t, v)
+
+	// The journal is compacted into a snapshot once it holds as many entries as the cache
+	for _, id := range ids[2:] {
+		restored.Put(id, true)
+	}
+	require.NoError(t, restored.Flush(ctx))
+	snapshot, err = client.Get(ctx, "sampled")
+	require.NoError(t, err)
+	assert.Len(t, snapshot, 4*entrySize)
+	for _, key := range []string{"sampled.journal", "sampled.journal.0", "sampled.journal.1"} {
+		data, err := client.Get(ctx, key)
+		require.NoError(t, err)
+		assert.Nil(t, data, key)
+	}
+
+	compacted, err := NewStorageDecisionCache(4)
+	require.NoError(t, err)
+	require.NoError(t, compacted.Attach(ctx, client, "sampled"))
+	for _, id := range ids[1:] {
+		_, ok = compacted.Get(id)
+		assert.True(t, ok)
+	}
+}
+
+func TestStorageCacheFlushWithoutClient(t *testing.T) {
+	c, err := NewStorageDecisionCache(2)
+	require.NoError(t, err)
+	id, err := traceIDFromHex("12341234123412341234123412341234")
+	require.NoError(t, err)
+
+	c.Put(id, true)
+	assert.NoError(t, c.Flush(context.Background()))
+}
+
+func TestStorageCacheAttachCorrupted(t *testing.T) {
+	ctx := context.Background()
+	client := storagetest.NewInMemoryClient(component.KindProcessor, component.MustNewID("tail_sampling"), "")
+	require.NoError(t, client.Set(ctx, "sampled", []byte{1, 2, 3}))
+
+	c, err := NewStorageDecisionCache(2)
+	require.NoError(t, err)
+	assert.ErrorContains(t, c.Attach(ctx, client, "sampled"), "corrupted")
+}
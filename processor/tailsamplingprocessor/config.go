@@ -6,6 +6,8 @@ package tailsamplingprocessor // import "github.com/open-telemetry/opentelemetry
 import (
 	"time"
 
+	"go.opentelemetry.io/collector/component"
+
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
 )
 
@@ -45,6 +47,9 @@ const (
 	// OTTLCondition sample traces which match user provided OpenTelemetry Transformation Language
 	// conditions.
 	OTTLCondition PolicyType = "ottl_condition"
+	// Adaptive samples traces probabilistically, adjusting the probability to reach a target
+	// number of traces per second for each key.
+	Adaptive PolicyType = "adaptive"
 )
 
 // sharedPolicyCfg holds the common configuration to all policies that are used in derivative policy configurations
@@ -74,6 +79,8 @@ type sharedPolicyCfg struct {
 	BooleanAttributeCfg BooleanAttributeCfg `mapstructure:"boolean_attribute"`
 	// Configs for OTTL condition filter sampling policy evaluator
 	OTTLConditionCfg OTTLConditionCfg `mapstructure:"ottl_condition"`
+	// Configs for adaptive sampling policy evaluator.
+	AdaptiveCfg AdaptiveCfg `mapstructure:"adaptive"`
 }
 
 // CompositeSubPolicyCfg holds the common configuration to all policies under composite policy.
@@ -231,6 +238,26 @@ type OTTLConditionCfg struct {
 	ErrorMode           ottl.ErrorMode `mapstructure:"error_mode"`
 	SpanConditions      []string       `mapstructure:"span"`
 	SpanEventConditions []string       `mapstructure:"spanevent"`
+	// TraceConditions are evaluated once for the whole trace, with access to aggregates
+	// over all its spans and to the fields of its root span.
+	TraceConditions []string `mapstructure:"trace"`
+}
+
+// AdaptiveCfg holds the configurable settings to create an adaptive sampling
+// policy evaluator.
+type AdaptiveCfg struct {
+	// TracesPerSecond is the target number of sampled traces per second for each key.
+	TracesPerSecond float64 `mapstructure:"traces_per_second"`
+	// Keys are the names of the attributes, looked up in the root span and then in its resource,
+	// whose values form the key of a trace. "span.name" refers to the name of the root span.
+	// If empty, all traces share a single budget.
+	Keys []string `mapstructure:"keys"`
+	// AdjustmentInterval is how often the sampling probability of each key is recomputed.
+	// Defaults to 10s.
+	AdjustmentInterval time.Duration `mapstructure:"adjustment_interval"`
+	// MinSamplingPercentage is the lowest percentage at which traces are sampled, regardless of the
+	// rate of traces for a key. Defaults to zero, i.e.: no lower bound.
+	MinSamplingPercentage float64 `mapstructure:"min_sampling_percentage"`
 }
 
 type DecisionCacheConfig struct {
@@ -244,6 +271,12 @@ type DecisionCacheConfig struct {
 	// For effective use, this value should be at least an order of magnitude greater than Config.NumTraces.
 	// If left as default 0, a no-op DecisionCache will be used.
 	NonSampledCacheSize int `mapstructure:"non_sampled_cache_size"`
+	// SharedStorage is the ID of a storage extension shared by several collectors, such as the redis storage
+	// extension, used to publish the final decision for each trace ID and look it up when the first spans of a
+	// trace arrive. This lets late spans that reach another collector, e.g. after the load-balancing exporter
+	// rebalanced its ring, follow the original decision. The expiration of the decisions is left to the extension.
+	// If left unset, decisions are not shared.
+	SharedStorage *component.ID `mapstructure:"shared_storage"`
 }
 
 // Config holds the configuration for tail-based sampling.
@@ -266,4 +299,8 @@ type Config struct {
 	Options []Option `mapstructure:"-"`
 	// Make decision as soon as a policy matches
 	SampleOnFirstMatch bool `mapstructure:"sample_on_first_match"`
+	// Storage is the ID of a storage extension used to persist the traces awaiting a decision
+	// and the decision caches, so that they are restored when the processor restarts.
+	// If left unset, everything is kept in memory only.
+	Storage *component.ID `mapstructure:"storage"`
 }
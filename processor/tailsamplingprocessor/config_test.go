@@ -114,6 +114,19 @@ func TestLoadConfig(t *testing.T) {
 							ErrorMode:           ottl.IgnoreError,
 							SpanConditions:      []string{"attributes[\"test_attr_key_1\"] == \"test_attr_val_1\"", "attributes[\"test_attr_key_2\"] != \"test_attr_val_1\""},
 							SpanEventConditions: []string{"name != \"test_span_event_name\"", "attributes[\"test_event_attr_key_2\"] != \"test_event_attr_val_1\""},
+							TraceConditions:     []string{"SpanCountWithAttribute(\"db.system\") > 3 and root_span.duration > 2000000000"},
+						},
+					},
+				},
+				{
+					sharedPolicyCfg: sharedPolicyCfg{
+						Name: "test-policy-12",
+						Type: Adaptive,
+						AdaptiveCfg: AdaptiveCfg{
+							TracesPerSecond:       5,
+							Keys:                  []string{"service.name", "span.name"},
+							AdjustmentInterval:    30 * time.Second,
+							MinSamplingPercentage: 0.1,
 						},
 					},
 				},
@@ -0,0 +1,106 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottltrace // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/ottltrace"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+
+	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+)
+
+// StandardConverters returns the OTTL standard converters along with the converters
+// aggregating the spans of the trace.
+func StandardConverters() map[string]ottl.Factory[TransformContext] {
+	converters := ottlfuncs.StandardConverters[TransformContext]()
+	for _, f := range []ottl.Factory[TransformContext]{
+		newSpanCountWithAttributeFactory(),
+		newSpanCountWithNameFactory(),
+	} {
+		converters[f.Name()] = f
+	}
+	return converters
+}
+
+type spanCountWithAttributeArguments struct {
+	Key   string
+	Value ottl.Optional[string]
+}
+
+func newSpanCountWithAttributeFactory() ottl.Factory[TransformContext] {
+	return ottl.NewFactory("SpanCountWithAttribute", &spanCountWithAttributeArguments{}, createSpanCountWithAttributeFunction)
+}
+
+func createSpanCountWithAttributeFunction(_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[TransformContext], error) {
+	args, ok := oArgs.(*spanCountWithAttributeArguments)
+	if !ok {
+		return nil, errors.New("SpanCountWithAttributeFactory args must be of type *spanCountWithAttributeArguments")
+	}
+
+	return spanCountWithAttribute(args.Key, args.Value), nil
+}
+
+// spanCountWithAttribute counts the spans having the given attribute, with the given
+// value if set.
+func spanCountWithAttribute(key string, value ottl.Optional[string]) ottl.ExprFunc[TransformContext] {
+	return countSpans(func(span ptrace.Span) bool {
+		v, ok := span.Attributes().Get(key)
+		if !ok {
+			return false
+		}
+		return value.IsEmpty() || v.AsString() == value.Get()
+	})
+}
+
+type spanCountWithNameArguments struct {
+	Pattern string
+}
+
+func newSpanCountWithNameFactory() ottl.Factory[TransformContext] {
+	return ottl.NewFactory("SpanCountWithName", &spanCountWithNameArguments{}, createSpanCountWithNameFunction)
+}
+
+func createSpanCountWithNameFunction(_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[TransformContext], error) {
+	args, ok := oArgs.(*spanCountWithNameArguments)
+	if !ok {
+		return nil, errors.New("SpanCountWithNameFactory args must be of type *spanCountWithNameArguments")
+	}
+
+	return spanCountWithName(args.Pattern)
+}
+
+// spanCountWithName counts the spans whose name matches the given regular expression.
+func spanCountWithName(pattern string) (ottl.ExprFunc[TransformContext], error) {
+	compiledPattern, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("the pattern supplied to SpanCountWithName is not a valid regexp pattern: %w", err)
+	}
+	return countSpans(func(span ptrace.Span) bool {
+		return compiledPattern.MatchString(span.Name())
+	}), nil
+}
+
+func countSpans(match func(ptrace.Span) bool) ottl.ExprFunc[TransformContext] {
+	return func(_ context.Context, tCtx TransformContext) (any, error) {
+		var count int64
+		rss := tCtx.traces.ResourceSpans()
+		for i := 0; i < rss.Len(); i++ {
+			ilss := rss.At(i).ScopeSpans()
+			for j := 0; j < ilss.Len(); j++ {
+				spans := ilss.At(j).Spans()
+				for k := 0; k < spans.Len(); k++ {
+					if match(spans.At(k)) {
+						count++
+					}
+				}
+			}
+		}
+		return count, nil
+	}
+}
@@ -0,0 +1,263 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package ottltrace provides an OTTL context evaluated once for a whole trace, exposing
+// aggregates over all its spans and the fields of its root span.
+package ottltrace // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/ottltrace"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+// ContextName is the name of the trace context.
+const ContextName = "trace"
+
+// TransformContext is the OTTL context of a whole trace. The aggregates are computed
+// once, when the context is created.
+type TransformContext struct {
+	traces ptrace.Traces
+	stats  *stats
+}
+
+type stats struct {
+	spanCount    int64
+	errorCount   int64
+	maxDuration  int64
+	start        pcommon.Timestamp
+	end          pcommon.Timestamp
+	rootResource pcommon.Resource
+	rootSpan     ptrace.Span
+	hasRoot      bool
+}
+
+// NewTransformContext creates a TransformContext for all the spans of a trace.
+func NewTransformContext(traces ptrace.Traces) TransformContext {
+	return TransformContext{
+		traces: traces,
+		stats:  computeStats(traces),
+	}
+}
+
+// GetTraces returns the spans of the trace.
+func (tCtx TransformContext) GetTraces() ptrace.Traces {
+	return tCtx.traces
+}
+
+func computeStats(traces ptrace.Traces) *stats {
+	s := &stats{}
+	var (
+		firstResource pcommon.Resource
+		firstSpan     ptrace.Span
+		hasFirst      bool
+	)
+	rss := traces.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		for j := 0; j < rs.ScopeSpans().Len(); j++ {
+			spans := rs.ScopeSpans().At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				span := spans.At(k)
+				s.spanCount++
+				if span.Status().Code() == ptrace.StatusCodeError {
+					s.errorCount++
+				}
+				if d := spanDuration(span); d > s.maxDuration {
+					s.maxDuration = d
+				}
+				if ts := span.StartTimestamp(); ts != 0 && (s.start == 0 || ts < s.start) {
+					s.start = span.StartTimestamp()
+				}
+				if span.EndTimestamp() > s.end {
+					s.end = span.EndTimestamp()
+				}
+				if !s.hasRoot && span.ParentSpanID().IsEmpty() {
+					s.rootResource, s.rootSpan, s.hasRoot = rs.Resource(), span, true
+				}
+				if !hasFirst {
+					firstResource, firstSpan, hasFirst = rs.Resource(), span, true
+				}
+			}
+		}
+	}
+	// without a span lacking a parent, the first span stands for the root span
+	if !s.hasRoot && hasFirst {
+		s.rootResource, s.rootSpan, s.hasRoot = firstResource, firstSpan, true
+	}
+	return s
+}
+
+func spanDuration(span ptrace.Span) int64 {
+	if span.EndTimestamp() < span.StartTimestamp() {
+		return 0
+	}
+	return int64(span.EndTimestamp() - span.StartTimestamp())
+}
+
+// NewParser creates a new trace parser with the provided functions and options.
+func NewParser(
+	functions map[string]ottl.Factory[TransformContext],
+	telemetrySettings component.TelemetrySettings,
+	options ...ottl.Option[TransformContext],
+) (ottl.Parser[TransformContext], error) {
+	return ottl.NewParser[TransformContext](
+		functions,
+		parsePath,
+		telemetrySettings,
+		append([]ottl.Option[TransformContext]{ottl.WithEnumParser[TransformContext](parseEnum)}, options...)...,
+	)
+}
+
+// NewConditionSequence creates a new ottl.ConditionSequence for the trace context.
+func NewConditionSequence(conditions []*ottl.Condition[TransformContext], telemetrySettings component.TelemetrySettings, errorMode ottl.ErrorMode) ottl.ConditionSequence[TransformContext] {
+	return ottl.NewConditionSequence(conditions, telemetrySettings, ottl.WithConditionSequenceErrorMode[TransformContext](errorMode))
+}
+
+var symbolTable = map[ottl.EnumSymbol]ottl.Enum{
+	"SPAN_KIND_UNSPECIFIED": ottl.Enum(ptrace.SpanKindUnspecified),
+	"SPAN_KIND_INTERNAL":    ottl.Enum(ptrace.SpanKindInternal),
+	"SPAN_KIND_SERVER":      ottl.Enum(ptrace.SpanKindServer),
+	"SPAN_KIND_CLIENT":      ottl.Enum(ptrace.SpanKindClient),
+	"SPAN_KIND_PRODUCER":    ottl.Enum(ptrace.SpanKindProducer),
+	"SPAN_KIND_CONSUMER":    ottl.Enum(ptrace.SpanKindConsumer),
+	"STATUS_CODE_UNSET":     ottl.Enum(ptrace.StatusCodeUnset),
+	"STATUS_CODE_OK":        ottl.Enum(ptrace.StatusCodeOk),
+	"STATUS_CODE_ERROR":     ottl.Enum(ptrace.StatusCodeError),
+}
+
+func parseEnum(val *ottl.EnumSymbol) (*ottl.Enum, error) {
+	if val != nil {
+		if enum, ok := symbolTable[*val]; ok {
+			return &enum, nil
+		}
+		return nil, fmt.Errorf("enum symbol, %s, not found", *val)
+	}
+	return nil, errors.New("enum symbol not provided")
+}
+
+func parsePath(path ottl.Path[TransformContext]) (ottl.GetSetter[TransformContext], error) {
+	if path == nil {
+		return nil, errors.New("path cannot be nil")
+	}
+	if path.Context() != "" && path.Context() != ContextName {
+		return nil, fmt.Errorf(`context "%s" from path "%s" is not valid for the trace context`, path.Context(), path.String())
+	}
+	switch path.Name() {
+	case "span_count":
+		return statGetter(func(s *stats) any { return s.spanCount }), nil
+	case "error_count":
+		return statGetter(func(s *stats) any { return s.errorCount }), nil
+	case "max_duration":
+		return statGetter(func(s *stats) any { return s.maxDuration }), nil
+	case "duration":
+		return statGetter(func(s *stats) any {
+			if s.end < s.start {
+				return int64(0)
+			}
+			return int64(s.end - s.start)
+		}), nil
+	case "root_span":
+		if path.Next() == nil {
+			return nil, fmt.Errorf(`path "%s" must refer to a field of the root span`, path.String())
+		}
+		return parseRootSpanPath(path.Next())
+	case "resource":
+		if path.Next() == nil || path.Next().Name() != "attributes" {
+			return nil, fmt.Errorf(`path "%s" must refer to the attributes of the root span's resource`, path.String())
+		}
+		return attributesGetter(path.Next(), func(s *stats) pcommon.Map { return s.rootResource.Attributes() })
+	default:
+		return nil, fmt.Errorf(`"%s" is not a valid path for the trace context`, path.String())
+	}
+}
+
+func parseRootSpanPath(path ottl.Path[TransformContext]) (ottl.GetSetter[TransformContext], error) {
+	switch path.Name() {
+	case "name":
+		return rootSpanGetter(func(span ptrace.Span) any { return span.Name() }), nil
+	case "kind":
+		return rootSpanGetter(func(span ptrace.Span) any { return int64(span.Kind()) }), nil
+	case "duration":
+		return rootSpanGetter(func(span ptrace.Span) any { return spanDuration(span) }), nil
+	case "status":
+		if path.Next() == nil || path.Next().Name() != "code" {
+			return nil, fmt.Errorf(`"%s" is not a valid path for the trace context, only "code" is supported for the root span status`, path.String())
+		}
+		return rootSpanGetter(func(span ptrace.Span) any { return int64(span.Status().Code()) }), nil
+	case "attributes":
+		return attributesGetter(path, func(s *stats) pcommon.Map { return s.rootSpan.Attributes() })
+	default:
+		return nil, fmt.Errorf(`"%s" is not a valid path for the root span of the trace context`, path.String())
+	}
+}
+
+func statGetter(get func(*stats) any) ottl.StandardGetSetter[TransformContext] {
+	return ottl.StandardGetSetter[TransformContext]{
+		Getter: func(_ context.Context, tCtx TransformContext) (any, error) {
+			return get(tCtx.stats), nil
+		},
+		Setter: readOnly,
+	}
+}
+
+func rootSpanGetter(get func(ptrace.Span) any) ottl.StandardGetSetter[TransformContext] {
+	return ottl.StandardGetSetter[TransformContext]{
+		Getter: func(_ context.Context, tCtx TransformContext) (any, error) {
+			if !tCtx.stats.hasRoot {
+				return nil, nil
+			}
+			return get(tCtx.stats.rootSpan), nil
+		},
+		Setter: readOnly,
+	}
+}
+
+func attributesGetter(path ottl.Path[TransformContext], attributes func(*stats) pcommon.Map) (ottl.GetSetter[TransformContext], error) {
+	keys := path.Keys()
+	if len(keys) != 1 {
+		return nil, fmt.Errorf(`path "%s" must refer to a single attribute, such as attributes["key"]`, path.String())
+	}
+	key := keys[0]
+	return ottl.StandardGetSetter[TransformContext]{
+		Getter: func(ctx context.Context, tCtx TransformContext) (any, error) {
+			if !tCtx.stats.hasRoot {
+				return nil, nil
+			}
+			s, err := key.String(ctx, tCtx)
+			if err != nil {
+				return nil, err
+			}
+			if s == nil {
+				return nil, fmt.Errorf(`the key of path "%s" must be a string`, path.String())
+			}
+			v, ok := attributes(tCtx.stats).Get(*s)
+			if !ok {
+				return nil, nil
+			}
+			return getValue(v), nil
+		},
+		Setter: readOnly,
+	}, nil
+}
+
+func getValue(val pcommon.Value) any {
+	switch val.Type() {
+	case pcommon.ValueTypeMap:
+		return val.Map()
+	case pcommon.ValueTypeSlice:
+		return val.Slice()
+	default:
+		return val.AsRaw()
+	}
+}
+
+func readOnly(context.Context, TransformContext, any) error {
+	return errors.New("the trace context is read-only")
+}
@@ -0,0 +1,108 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottltrace
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func newTestTraces() ptrace.Traces {
+	start := time.Unix(100, 0)
+	traces := ptrace.NewTraces()
+	rs := traces.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().PutStr("service.name", "frontend")
+	spans := rs.ScopeSpans().AppendEmpty().Spans()
+
+	child := spans.AppendEmpty()
+	child.SetName("SELECT users")
+	child.SetParentSpanID([8]byte{1})
+	child.SetStartTimestamp(pcommon.NewTimestampFromTime(start.Add(time.Second)))
+	child.SetEndTimestamp(pcommon.NewTimestampFromTime(start.Add(4 * time.Second)))
+	child.Attributes().PutStr("db.system", "postgresql")
+	child.Status().SetCode(ptrace.StatusCodeError)
+
+	root := spans.AppendEmpty()
+	root.SetName("GET /users")
+	root.SetKind(ptrace.SpanKindServer)
+	root.SetStartTimestamp(pcommon.NewTimestampFromTime(start))
+	root.SetEndTimestamp(pcommon.NewTimestampFromTime(start.Add(2 * time.Second)))
+	root.Attributes().PutStr("http.route", "/users")
+
+	other := spans.AppendEmpty()
+	other.SetName("SELECT orders")
+	other.SetParentSpanID([8]byte{1})
+	other.Attributes().PutStr("db.system", "mysql")
+	return traces
+}
+
+func TestPaths(t *testing.T) {
+	tests := []struct {
+		name      string
+		condition string
+	}{
+		{name: "span count", condition: "span_count == 3"},
+		{name: "error count", condition: "error_count == 1"},
+		{name: "max duration", condition: "max_duration == 3000000000"},
+		{name: "trace duration", condition: "duration == 4000000000"},
+		{name: "root span name", condition: `root_span.name == "GET /users"`},
+		{name: "root span kind", condition: "root_span.kind == SPAN_KIND_SERVER"},
+		{name: "root span duration", condition: "root_span.duration == 2000000000"},
+		{name: "root span status", condition: "root_span.status.code == STATUS_CODE_UNSET"},
+		{name: "root span attribute", condition: `root_span.attributes["http.route"] == "/users"`},
+		{name: "root span missing attribute", condition: `root_span.attributes["missing"] == nil`},
+		{name: "resource attribute", condition: `resource.attributes["service.name"] == "frontend"`},
+		{name: "span count with attribute", condition: `SpanCountWithAttribute("db.system") == 2`},
+		{name: "span count with attribute value", condition: `SpanCountWithAttribute("db.system", "mysql") == 1`},
+		{name: "span count with name", condition: `SpanCountWithName("^SELECT ") == 2`},
+	}
+
+	parser, err := NewParser(StandardConverters(), componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	tCtx := NewTransformContext(newTestTraces())
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			condition, err := parser.ParseCondition(tt.condition)
+			require.NoError(t, err)
+			ok, err := condition.Eval(context.Background(), tCtx)
+			require.NoError(t, err)
+			assert.True(t, ok)
+		})
+	}
+}
+
+func TestInvalidPaths(t *testing.T) {
+	parser, err := NewParser(StandardConverters(), componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+
+	for _, condition := range []string{
+		"name == \"test\"",
+		"root_span == nil",
+		"root_span.status == nil",
+		"root_span.attributes == nil",
+		"resource.dropped_attributes_count == 0",
+		`SpanCountWithName("[") > 0`,
+	} {
+		_, err := parser.ParseCondition(condition)
+		assert.Error(t, err, condition)
+	}
+}
+
+func TestEmptyTrace(t *testing.T) {
+	parser, err := NewParser(StandardConverters(), componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+
+	condition, err := parser.ParseCondition(`span_count == 0 and root_span.name == nil`)
+	require.NoError(t, err)
+	ok, err := condition.Eval(context.Background(), NewTransformContext(ptrace.NewTraces()))
+	require.NoError(t, err)
+	assert.True(t, ok)
+}
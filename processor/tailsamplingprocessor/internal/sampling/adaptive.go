@@ -0,0 +1,271 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package sampling // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/sampling"
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+
+	otelsampling "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/sampling"
+)
+
+const (
+	// SpanNameKey can be used as an adaptive key to refer to the name of the root span.
+	SpanNameKey = "span.name"
+
+	// adaptiveSmoothing is the weight given to the last interval when updating the
+	// estimated rate of traces per key.
+	adaptiveSmoothing = 0.5
+	// maxAdaptiveKeys bounds the number of keys tracked by a single policy. Traces with
+	// keys beyond that limit share a single budget.
+	maxAdaptiveKeys = 10_000
+	// overflowKey is the key used once maxAdaptiveKeys is reached.
+	overflowKey = "\x00overflow"
+)
+
+// adaptiveKeyState holds the sampling state for a single key.
+type adaptiveKeyState struct {
+	// seen is the number of traces evaluated for the key during the current interval.
+	seen float64
+	// rate is the smoothed number of traces per second for the key.
+	rate float64
+	// threshold is the sampling threshold currently applied to the key.
+	threshold otelsampling.Threshold
+}
+
+type adaptive struct {
+	logger          *zap.Logger
+	tracesPerSecond float64
+	keys            []string
+	interval        time.Duration
+	minThreshold    otelsampling.Threshold
+
+	intervalStart time.Time
+	states        map[string]*adaptiveKeyState
+	now           func() time.Time
+
+	// lastTraceID is the ID of the last trace evaluated, and lastThreshold the threshold with
+	// which it was sampled, if lastSampled is set
+	lastTraceID   pcommon.TraceID
+	lastSampled   bool
+	lastThreshold otelsampling.Threshold
+}
+
+// ThresholdEvaluator is implemented by the policy evaluators sampling traces with a probability.
+// The processor records the threshold in the tracestate of the spans of a trace, when no other
+// policy samples it.
+type ThresholdEvaluator interface {
+	PolicyEvaluator
+	// SamplingThreshold returns the threshold with which the trace was sampled, if it was sampled
+	// by the last evaluation.
+	SamplingThreshold(traceID pcommon.TraceID) (otelsampling.Threshold, bool)
+}
+
+var _ ThresholdEvaluator = (*adaptive)(nil)
+
+// NewAdaptive creates a policy evaluator that samples traces probabilistically, adjusting the
+// probability for each distinct value of the given keys so that about tracesPerSecond traces
+// are sampled per second and per value. The probability is never lower than minSamplingPercentage.
+// The applied probability is returned by SamplingThreshold for the last sampled trace.
+func NewAdaptive(settings component.TelemetrySettings, tracesPerSecond float64, keys []string, interval time.Duration, minSamplingPercentage float64) PolicyEvaluator {
+	minThreshold, err := otelsampling.ProbabilityToThreshold(minSamplingPercentage / 100)
+	if err != nil || minSamplingPercentage <= 0 {
+		minThreshold = otelsampling.NeverSampleThreshold
+	}
+	return &adaptive{
+		logger:          settings.Logger,
+		tracesPerSecond: tracesPerSecond,
+		keys:            keys,
+		interval:        interval,
+		minThreshold:    minThreshold,
+		states:          make(map[string]*adaptiveKeyState),
+		now:             time.Now,
+	}
+}
+
+// Evaluate looks at the trace data and returns a corresponding SamplingDecision.
+func (a *adaptive) Evaluate(_ context.Context, traceID pcommon.TraceID, trace *TraceData) (Decision, error) {
+	a.logger.Debug("Evaluating spans in adaptive filter")
+
+	a.adjust()
+
+	trace.Lock()
+	defer trace.Unlock()
+	batches := trace.ReceivedBatches
+
+	state := a.state(a.key(batches))
+	state.seen++
+	a.lastTraceID, a.lastSampled = traceID, false
+
+	rnd := a.randomness(traceID, batches)
+	if !state.threshold.ShouldSample(rnd) {
+		return NotSampled, nil
+	}
+
+	a.lastSampled, a.lastThreshold = true, state.threshold
+	return Sampled, nil
+}
+
+// SamplingThreshold returns the threshold with which the trace was sampled, if it is the last
+// trace evaluated and it was sampled.
+func (a *adaptive) SamplingThreshold(traceID pcommon.TraceID) (otelsampling.Threshold, bool) {
+	if traceID != a.lastTraceID || !a.lastSampled {
+		return otelsampling.Threshold{}, false
+	}
+	return a.lastThreshold, true
+}
+
+// adjust recomputes the threshold of every key once per interval, from the rate of traces
+// observed during that interval.
+func (a *adaptive) adjust() {
+	now := a.now()
+	if a.intervalStart.IsZero() {
+		a.intervalStart = now
+		return
+	}
+	elapsed := now.Sub(a.intervalStart)
+	if elapsed < a.interval {
+		return
+	}
+	a.intervalStart = now
+
+	for key, state := range a.states {
+		observed := state.seen / elapsed.Seconds()
+		state.rate = adaptiveSmoothing*observed + (1-adaptiveSmoothing)*state.rate
+		state.seen = 0
+
+		if state.rate < 1e-3 {
+			// nothing was seen for a while, stop tracking the key
+			delete(a.states, key)
+			continue
+		}
+		state.threshold = a.thresholdFor(a.tracesPerSecond / state.rate)
+	}
+}
+
+// thresholdFor converts a probability into a threshold, bounded by the minimum probability.
+func (a *adaptive) thresholdFor(probability float64) otelsampling.Threshold {
+	if probability >= 1 {
+		return otelsampling.AlwaysSampleThreshold
+	}
+	th, err := otelsampling.ProbabilityToThreshold(probability)
+	if err != nil || otelsampling.ThresholdGreater(th, a.minThreshold) {
+		return a.minThreshold
+	}
+	return th
+}
+
+func (a *adaptive) state(key string) *adaptiveKeyState {
+	state, ok := a.states[key]
+	if ok {
+		return state
+	}
+	if len(a.states) >= maxAdaptiveKeys {
+		key = overflowKey
+		if state, ok = a.states[key]; ok {
+			return state
+		}
+	}
+	// new keys start sampling everything until their rate is known
+	state = &adaptiveKeyState{threshold: otelsampling.AlwaysSampleThreshold}
+	a.states[key] = state
+	return state
+}
+
+// key builds the key of a trace from the values of the configured keys, looked up in the
+// attributes of the root span and of its resource.
+func (a *adaptive) key(batches ptrace.Traces) string {
+	if len(a.keys) == 0 {
+		return ""
+	}
+	resource, span, ok := rootSpan(batches)
+	if !ok {
+		return ""
+	}
+
+	values := make([]string, len(a.keys))
+	for i, k := range a.keys {
+		if k == SpanNameKey {
+			values[i] = span.Name()
+			continue
+		}
+		if v, ok := span.Attributes().Get(k); ok {
+			values[i] = v.AsString()
+		} else if v, ok := resource.Attributes().Get(k); ok {
+			values[i] = v.AsString()
+		}
+	}
+	return strings.Join(values, "\x00")
+}
+
+// randomness returns the explicit randomness of the trace, if set in the tracestate of its
+// root span, or the randomness of its trace ID otherwise.
+func (*adaptive) randomness(traceID pcommon.TraceID, batches ptrace.Traces) otelsampling.Randomness {
+	if _, span, ok := rootSpan(batches); ok {
+		if ts, err := otelsampling.NewW3CTraceState(span.TraceState().AsRaw()); err == nil {
+			if rnd, ok := ts.OTelValue().RValueRandomness(); ok {
+				return rnd
+			}
+		}
+	}
+	return otelsampling.TraceIDToRandomness(traceID)
+}
+
+// rootSpan returns the span without a parent, or the first span if there is none.
+func rootSpan(batches ptrace.Traces) (pcommon.Resource, ptrace.Span, bool) {
+	var (
+		firstResource pcommon.Resource
+		firstSpan     ptrace.Span
+		found         bool
+	)
+	rss := batches.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		for j := 0; j < rs.ScopeSpans().Len(); j++ {
+			spans := rs.ScopeSpans().At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				span := spans.At(k)
+				if span.ParentSpanID().IsEmpty() {
+					return rs.Resource(), span, true
+				}
+				if !found {
+					firstResource, firstSpan, found = rs.Resource(), span, true
+				}
+			}
+		}
+	}
+	return firstResource, firstSpan, found
+}
+
+// UpdateThreshold records the sampling threshold in the tracestate of every span, unless
+// the span was already sampled with a lower probability.
+func UpdateThreshold(batches ptrace.Traces, th otelsampling.Threshold) {
+	rss := batches.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		ilss := rss.At(i).ScopeSpans()
+		for j := 0; j < ilss.Len(); j++ {
+			spans := ilss.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				span := spans.At(k)
+				ts, err := otelsampling.NewW3CTraceState(span.TraceState().AsRaw())
+				if err != nil {
+					continue
+				}
+				if err := ts.OTelValue().UpdateTValueWithSampling(th); err != nil {
+					continue
+				}
+				var w strings.Builder
+				if err := ts.Serialize(&w); err == nil {
+					span.TraceState().FromRaw(w.String())
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,137 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package sampling
+
+import (
+	"context"
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	otelsampling "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/sampling"
+)
+
+func newAdaptiveTrace(service, tracestate string) *TraceData {
+	traces := ptrace.NewTraces()
+	rs := traces.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().PutStr("service.name", service)
+	span := rs.ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	span.SetName("GET /")
+	span.TraceState().FromRaw(tracestate)
+	return &TraceData{ReceivedBatches: traces}
+}
+
+func adaptiveTraceID(i uint64) pcommon.TraceID {
+	var id pcommon.TraceID
+	binary.BigEndian.PutUint64(id[8:], i*0x9E3779B97F4A7C15)
+	return id
+}
+
+func TestAdaptiveSamplesEverythingUntilRateIsKnown(t *testing.T) {
+	a := NewAdaptive(componenttest.NewNopTelemetrySettings(), 1, []string{"service.name"}, time.Second, 0)
+
+	for i := uint64(0); i < 100; i++ {
+		decision, err := a.Evaluate(context.Background(), adaptiveTraceID(i), newAdaptiveTrace("noisy", ""))
+		require.NoError(t, err)
+		assert.Equal(t, Sampled, decision)
+	}
+}
+
+func TestAdaptiveAdjustsProbabilityPerKey(t *testing.T) {
+	now := time.Unix(1_000, 0)
+	a := NewAdaptive(componenttest.NewNopTelemetrySettings(), 10, []string{"service.name", SpanNameKey}, time.Second, 0).(*adaptive)
+	a.now = func() time.Time { return now }
+
+	sampled := map[string]int{}
+	id := uint64(0)
+	for second := 0; second < 20; second++ {
+		// 1000 traces per second for the noisy service, 5 for the quiet one
+		for i := 0; i < 1000; i++ {
+			id++
+			if d, _ := a.Evaluate(context.Background(), adaptiveTraceID(id), newAdaptiveTrace("noisy", "")); d == Sampled && second >= 10 {
+				sampled["noisy"]++
+			}
+			if i%200 == 0 {
+				id++
+				if d, _ := a.Evaluate(context.Background(), adaptiveTraceID(id), newAdaptiveTrace("quiet", "")); d == Sampled && second >= 10 {
+					sampled["quiet"]++
+				}
+			}
+		}
+		now = now.Add(time.Second)
+	}
+
+	// about 10 traces per second over the last 10 seconds
+	assert.InDelta(t, 100, sampled["noisy"], 40)
+	// the quiet service is below its budget, so everything is sampled
+	assert.Equal(t, 50, sampled["quiet"])
+}
+
+func TestAdaptiveMinSamplingPercentage(t *testing.T) {
+	now := time.Unix(1_000, 0)
+	a := NewAdaptive(componenttest.NewNopTelemetrySettings(), 1, nil, time.Second, 50).(*adaptive)
+	a.now = func() time.Time { return now }
+
+	for i := uint64(0); i < 1000; i++ {
+		_, _ = a.Evaluate(context.Background(), adaptiveTraceID(i), newAdaptiveTrace("noisy", ""))
+	}
+	now = now.Add(time.Second)
+	_, _ = a.Evaluate(context.Background(), adaptiveTraceID(0), newAdaptiveTrace("noisy", ""))
+
+	assert.InDelta(t, 0.5, a.states[""].threshold.Probability(), 1e-9)
+}
+
+func TestAdaptiveSamplingThreshold(t *testing.T) {
+	now := time.Unix(1_000, 0)
+	a := NewAdaptive(componenttest.NewNopTelemetrySettings(), 1, nil, time.Second, 0).(*adaptive)
+	a.now = func() time.Time { return now }
+	// force a probability of 25%
+	a.states[""] = &adaptiveKeyState{threshold: a.thresholdFor(0.25)}
+	a.intervalStart = now
+
+	// the explicit randomness makes the decision deterministic
+	sampledID := adaptiveTraceID(1)
+	trace := newAdaptiveTrace("svc", "ot=rv:ffffffffffffff")
+	decision, err := a.Evaluate(context.Background(), sampledID, trace)
+	require.NoError(t, err)
+	require.Equal(t, Sampled, decision)
+
+	// the tracestate is left to the processor, which only updates it when the policy decides
+	span := trace.ReceivedBatches.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0)
+	assert.Equal(t, "ot=rv:ffffffffffffff", span.TraceState().AsRaw())
+	th, ok := a.SamplingThreshold(sampledID)
+	require.True(t, ok)
+	assert.Equal(t, "c", th.TValue())
+	_, ok = a.SamplingThreshold(adaptiveTraceID(2))
+	assert.False(t, ok)
+
+	decision, err = a.Evaluate(context.Background(), adaptiveTraceID(2), newAdaptiveTrace("svc", "ot=rv:00000000000000"))
+	require.NoError(t, err)
+	assert.Equal(t, NotSampled, decision)
+	_, ok = a.SamplingThreshold(adaptiveTraceID(2))
+	assert.False(t, ok)
+	_, ok = a.SamplingThreshold(sampledID)
+	assert.False(t, ok)
+}
+
+func TestUpdateThreshold(t *testing.T) {
+	th, err := otelsampling.ProbabilityToThreshold(0.25)
+	require.NoError(t, err)
+
+	trace := newAdaptiveTrace("svc", "ot=rv:ffffffffffffff")
+	UpdateThreshold(trace.ReceivedBatches, th)
+
+	span := trace.ReceivedBatches.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0)
+	ts, err := otelsampling.NewW3CTraceState(span.TraceState().AsRaw())
+	require.NoError(t, err)
+	got, ok := ts.OTelValue().TValueThreshold()
+	require.True(t, ok)
+	assert.Equal(t, "c", got.TValue())
+}
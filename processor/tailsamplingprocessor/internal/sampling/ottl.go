@@ -15,11 +15,13 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspan"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspanevent"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/ottltrace"
 )
 
 type ottlConditionFilter struct {
 	sampleSpanExpr      *ottl.ConditionSequence[ottlspan.TransformContext]
 	sampleSpanEventExpr *ottl.ConditionSequence[ottlspanevent.TransformContext]
+	sampleTraceExpr     *ottl.ConditionSequence[ottltrace.TransformContext]
 	errorMode           ottl.ErrorMode
 	logger              *zap.Logger
 }
@@ -27,7 +29,8 @@ type ottlConditionFilter struct {
 var _ PolicyEvaluator = (*ottlConditionFilter)(nil)
 
 // NewOTTLConditionFilter looks at the trace data and returns a corresponding SamplingDecision.
-func NewOTTLConditionFilter(settings component.TelemetrySettings, spanConditions, spanEventConditions []string, errMode ottl.ErrorMode) (PolicyEvaluator, error) {
+// Trace conditions are evaluated once for the whole trace, the other ones for each span or span event.
+func NewOTTLConditionFilter(settings component.TelemetrySettings, spanConditions, spanEventConditions, traceConditions []string, errMode ottl.ErrorMode) (PolicyEvaluator, error) {
 	filter := &ottlConditionFilter{
 		errorMode: errMode,
 		logger:    settings.Logger,
@@ -35,7 +38,7 @@ func NewOTTLConditionFilter(settings component.TelemetrySettings, spanConditions
 
 	var err error
 
-	if len(spanConditions) == 0 && len(spanEventConditions) == 0 {
+	if len(spanConditions) == 0 && len(spanEventConditions) == 0 && len(traceConditions) == 0 {
 		return nil, errors.New("expected at least one OTTL condition to filter on")
 	}
 
@@ -51,13 +54,26 @@ func NewOTTLConditionFilter(settings component.TelemetrySettings, spanConditions
 		}
 	}
 
+	if len(traceConditions) > 0 {
+		parser, err := ottltrace.NewParser(ottltrace.StandardConverters(), settings)
+		if err != nil {
+			return nil, err
+		}
+		conditions, err := parser.ParseConditions(traceConditions)
+		if err != nil {
+			return nil, err
+		}
+		expr := ottltrace.NewConditionSequence(conditions, settings, errMode)
+		filter.sampleTraceExpr = &expr
+	}
+
 	return filter, nil
 }
 
 func (ocf *ottlConditionFilter) Evaluate(ctx context.Context, traceID pcommon.TraceID, trace *TraceData) (Decision, error) {
 	ocf.logger.Debug("Evaluating with OTTL conditions filter", zap.String("traceID", traceID.String()))
 
-	if ocf.sampleSpanExpr == nil && ocf.sampleSpanEventExpr == nil {
+	if ocf.sampleSpanExpr == nil && ocf.sampleSpanEventExpr == nil && ocf.sampleTraceExpr == nil {
 		return NotSampled, nil
 	}
 
@@ -65,6 +81,20 @@ func (ocf *ottlConditionFilter) Evaluate(ctx context.Context, traceID pcommon.Tr
 	defer trace.Unlock()
 	batches := trace.ReceivedBatches
 
+	// Trace evaluation
+	if ocf.sampleTraceExpr != nil {
+		ok, err := ocf.sampleTraceExpr.Eval(ctx, ottltrace.NewTransformContext(batches))
+		if err != nil {
+			return Error, err
+		}
+		if ok {
+			return Sampled, nil
+		}
+		if ocf.sampleSpanExpr == nil && ocf.sampleSpanEventExpr == nil {
+			return NotSampled, nil
+		}
+	}
+
 	for i := 0; i < batches.ResourceSpans().Len(); i++ {
 		rs := batches.ResourceSpans().At(i)
 		resource := rs.Resource()
@@ -6,8 +6,10 @@ package sampling
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/ptrace"
@@ -87,7 +89,7 @@ func TestEvaluate_OTTL(t *testing.T) {
 
 	for _, c := range cases {
 		t.Run(c.Desc, func(t *testing.T) {
-			filter, err := NewOTTLConditionFilter(componenttest.NewNopTelemetrySettings(), c.SpanConditions, c.SpanEventConditions, ottl.IgnoreError)
+			filter, err := NewOTTLConditionFilter(componenttest.NewNopTelemetrySettings(), c.SpanConditions, c.SpanEventConditions, nil, ottl.IgnoreError)
 			assert.Equal(t, err != nil, c.WantErr)
 
 			if err == nil {
@@ -99,6 +101,61 @@ func TestEvaluate_OTTL(t *testing.T) {
 	}
 }
 
+func TestEvaluate_OTTLTraceConditions(t *testing.T) {
+	traceID := pcommon.TraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
+	newTrace := func(dbSpans int, rootDuration time.Duration) *TraceData {
+		traces := ptrace.NewTraces()
+		spans := traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+		root := spans.AppendEmpty()
+		root.SetName("GET /users")
+		root.SetStartTimestamp(pcommon.NewTimestampFromTime(time.Unix(0, 0)))
+		root.SetEndTimestamp(pcommon.NewTimestampFromTime(time.Unix(0, 0).Add(rootDuration)))
+		for i := 0; i < dbSpans; i++ {
+			span := spans.AppendEmpty()
+			span.SetParentSpanID([8]byte{1})
+			span.Attributes().PutStr("db.system", "postgresql")
+		}
+		return &TraceData{ReceivedBatches: traces}
+	}
+	conditions := []string{`SpanCountWithAttribute("db.system") > 3 and root_span.duration > 2000000000`}
+
+	cases := []struct {
+		Desc         string
+		DBSpans      int
+		RootDuration time.Duration
+		Decision     Decision
+	}{
+		{"many DB spans and slow root span", 4, 3 * time.Second, Sampled},
+		{"few DB spans and slow root span", 3, 3 * time.Second, NotSampled},
+		{"many DB spans and fast root span", 4, time.Second, NotSampled},
+	}
+
+	filter, err := NewOTTLConditionFilter(componenttest.NewNopTelemetrySettings(), nil, nil, conditions, ottl.PropagateError)
+	require.NoError(t, err)
+	for _, c := range cases {
+		t.Run(c.Desc, func(t *testing.T) {
+			decision, err := filter.Evaluate(context.Background(), traceID, newTrace(c.DBSpans, c.RootDuration))
+			require.NoError(t, err)
+			assert.Equal(t, c.Decision, decision)
+		})
+	}
+}
+
+func TestEvaluate_OTTLTraceConditionsWithSpanConditions(t *testing.T) {
+	traceID := pcommon.TraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
+	filter, err := NewOTTLConditionFilter(componenttest.NewNopTelemetrySettings(),
+		[]string{`attributes["attr_k_1"] == "attr_v_1"`}, nil, []string{"error_count > 0"}, ottl.PropagateError)
+	require.NoError(t, err)
+
+	// the span condition still samples the trace when the trace condition doesn't match
+	decision, err := filter.Evaluate(context.Background(), traceID, newTraceWithSpansAttributes([]spanWithAttributes{{SpanAttributes: map[string]string{"attr_k_1": "attr_v_1"}}}))
+	require.NoError(t, err)
+	assert.Equal(t, Sampled, decision)
+
+	_, err = NewOTTLConditionFilter(componenttest.NewNopTelemetrySettings(), nil, nil, []string{"unknown_field > 0"}, ottl.PropagateError)
+	assert.Error(t, err)
+}
+
 type spanWithAttributes struct {
 	SpanAttributes      map[string]string
 	SpanEventAttributes map[string]string
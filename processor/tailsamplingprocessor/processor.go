@@ -16,14 +16,17 @@ import (
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/extension/xextension/storage"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.opentelemetry.io/collector/processor"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/metric"
+	"go.uber.org/multierr"
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/timeutils"
+	otelsampling "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/sampling"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/cache"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/idbatcher"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/metadata"
@@ -60,12 +63,18 @@ type tailSamplingSpanProcessor struct {
 	decisionBatcher    idbatcher.Batcher
 	sampledIDCache     cache.Cache[bool]
 	nonSampledIDCache  cache.Cache[bool]
+	thresholdCache     cache.Cache[otelsampling.Threshold]
 	deleteChan         chan pcommon.TraceID
 	numTracesOnMap     *atomic.Uint64
 	recordPolicy       bool
 	setPolicyMux       sync.Mutex
 	pendingPolicy      []PolicyCfg
 	sampleOnFirstMatch bool
+	storageID          *component.ID
+	traceStorage       *traceStorage
+	sharedStorageID    *component.ID
+	sharedDecisions    cache.Cache[bool]
+	sharedClient       storage.Client
 }
 
 // spanAndScope a structure for holding information about span and its instrumentation scope.
@@ -101,14 +110,19 @@ func newTracesProcessor(ctx context.Context, set processor.Settings, nextConsume
 	nopCache := cache.NewNopDecisionCache[bool]()
 	sampledDecisions := nopCache
 	nonSampledDecisions := nopCache
+	thresholds := cache.NewNopDecisionCache[otelsampling.Threshold]()
 	if cfg.DecisionCache.SampledCacheSize > 0 {
-		sampledDecisions, err = cache.NewLRUDecisionCache[bool](cfg.DecisionCache.SampledCacheSize)
+		sampledDecisions, err = newDecisionCache(cfg.DecisionCache.SampledCacheSize, cfg.Storage != nil)
+		if err != nil {
+			return nil, err
+		}
+		thresholds, err = cache.NewLRUDecisionCache[otelsampling.Threshold](cfg.DecisionCache.SampledCacheSize)
 		if err != nil {
 			return nil, err
 		}
 	}
 	if cfg.DecisionCache.NonSampledCacheSize > 0 {
-		nonSampledDecisions, err = cache.NewLRUDecisionCache[bool](cfg.DecisionCache.NonSampledCacheSize)
+		nonSampledDecisions, err = newDecisionCache(cfg.DecisionCache.NonSampledCacheSize, cfg.Storage != nil)
 		if err != nil {
 			return nil, err
 		}
@@ -122,10 +136,14 @@ func newTracesProcessor(ctx context.Context, set processor.Settings, nextConsume
 		maxNumTraces:       cfg.NumTraces,
 		sampledIDCache:     sampledDecisions,
 		nonSampledIDCache:  nonSampledDecisions,
+		thresholdCache:     thresholds,
 		logger:             telemetrySettings.Logger,
 		numTracesOnMap:     &atomic.Uint64{},
 		deleteChan:         make(chan pcommon.TraceID, cfg.NumTraces),
 		sampleOnFirstMatch: cfg.SampleOnFirstMatch,
+		storageID:          cfg.Storage,
+		sharedStorageID:    cfg.DecisionCache.SharedStorage,
+		sharedDecisions:    nopCache,
 	}
 	tsp.policyTicker = &timeutils.PolicyTicker{OnTickFunc: tsp.samplingPolicyOnTick}
 
@@ -158,6 +176,15 @@ func newTracesProcessor(ctx context.Context, set processor.Settings, nextConsume
 	return tsp, nil
 }
 
+// newDecisionCache returns an LRU decision cache of the given size, which can be persisted
+// to a storage extension if persistent is true.
+func newDecisionCache(size int, persistent bool) (cache.Cache[bool], error) {
+	if persistent {
+		return cache.NewStorageDecisionCache(size)
+	}
+	return cache.NewLRUDecisionCache[bool](size)
+}
+
 // withDecisionBatcher sets the batcher used to batch trace IDs for policy evaluation.
 func withDecisionBatcher(batcher idbatcher.Batcher) Option {
 	return func(tsp *tailSamplingSpanProcessor) {
@@ -193,6 +220,13 @@ func WithNonSampledDecisionCache(c cache.Cache[bool]) Option {
 	}
 }
 
+// withSharedDecisionCache sets the cache which the processor uses to share decisions with other collectors.
+func withSharedDecisionCache(c cache.Cache[bool]) Option {
+	return func(tsp *tailSamplingSpanProcessor) {
+		tsp.sharedDecisions = c
+	}
+}
+
 func withRecordPolicy() Option {
 	return func(tsp *tailSamplingSpanProcessor) {
 		tsp.recordPolicy = true
@@ -254,13 +288,25 @@ func getSharedPolicyEvaluator(settings component.TelemetrySettings, cfg *sharedP
 		return sampling.NewBooleanAttributeFilter(settings, bafCfg.Key, bafCfg.Value, bafCfg.InvertMatch), nil
 	case OTTLCondition:
 		ottlfCfg := cfg.OTTLConditionCfg
-		return sampling.NewOTTLConditionFilter(settings, ottlfCfg.SpanConditions, ottlfCfg.SpanEventConditions, ottlfCfg.ErrorMode)
+		return sampling.NewOTTLConditionFilter(settings, ottlfCfg.SpanConditions, ottlfCfg.SpanEventConditions, ottlfCfg.TraceConditions, ottlfCfg.ErrorMode)
+	case Adaptive:
+		aCfg := cfg.AdaptiveCfg
+		if aCfg.TracesPerSecond <= 0 {
+			return nil, errors.New("adaptive policy requires a positive traces_per_second")
+		}
+		interval := aCfg.AdjustmentInterval
+		if interval <= 0 {
+			interval = defaultAdaptiveAdjustmentInterval
+		}
+		return sampling.NewAdaptive(settings, aCfg.TracesPerSecond, aCfg.Keys, interval, aCfg.MinSamplingPercentage), nil
 
 	default:
 		return nil, fmt.Errorf("unknown sampling policy type %s", cfg.Type)
 	}
 }
 
+const defaultAdaptiveAdjustmentInterval = 10 * time.Second
+
 type policyMetrics struct {
 	idNotFoundOnMapCount, evaluateErrorCount, decisionSampled, decisionNotSampled int64
 }
@@ -379,14 +425,25 @@ func (tsp *tailSamplingSpanProcessor) samplingPolicyOnTick() {
 		trace.ReceivedBatches = ptrace.NewTraces()
 		trace.Unlock()
 
+		tsp.removeFromStorage(ctx, id)
+
 		switch decision {
 		case sampling.Sampled:
+			if th := trace.SamplingThreshold; th != nil {
+				sampling.UpdateThreshold(allSpans, *th)
+				// Late spans released with the sampled cache get the threshold of the trace too
+				tsp.thresholdCache.Put(id, *th)
+			}
+			tsp.sharedDecisions.Put(id, true)
 			tsp.releaseSampledTrace(ctx, id, allSpans)
 		case sampling.NotSampled:
+			tsp.sharedDecisions.Put(id, false)
 			tsp.releaseNotSampledTrace(id)
 		}
 	}
 
+	tsp.flushStorage(ctx)
+
 	tsp.telemetry.ProcessorTailSamplingSamplingDecisionTimerLatency.Record(tsp.ctx, int64(time.Since(startTime)/time.Millisecond))
 	tsp.telemetry.ProcessorTailSamplingSamplingTracesOnMemory.Record(tsp.ctx, int64(tsp.numTracesOnMap.Load()))
 	tsp.telemetry.ProcessorTailSamplingSamplingTraceDroppedTooEarly.Add(tsp.ctx, metrics.idNotFoundOnMapCount)
@@ -415,6 +472,11 @@ func (tsp *tailSamplingSpanProcessor) makeDecision(id pcommon.TraceID, trace *sa
 	ctx := context.Background()
 	startTime := time.Now()
 
+	// The threshold of the probabilistic policies sampling the trace, recorded in its tracestate
+	// only if no other policy samples it
+	var samplingThreshold *otelsampling.Threshold
+	sampledWithoutThreshold := false
+
 	// Check all policies before making a final decision.
 	for _, p := range tsp.policies {
 		decision, err := p.evaluator.Evaluate(ctx, id, trace)
@@ -441,6 +503,17 @@ func (tsp *tailSamplingSpanProcessor) makeDecision(id pcommon.TraceID, trace *sa
 			samplingDecisions[decision] = p
 		}
 
+		if decision == sampling.Sampled || decision == sampling.InvertSampled {
+			th, ok := policyThreshold(p, id, decision)
+			switch {
+			case !ok:
+				sampledWithoutThreshold = true
+			case samplingThreshold == nil || otelsampling.ThresholdLessThan(th, *samplingThreshold):
+				// With consistent randomness, the trace is sampled with the greatest probability
+				samplingThreshold = &th
+			}
+		}
+
 		// Break early if dropped. This can drastically reduce tick/decision latency.
 		if decision == sampling.Dropped {
 			break
@@ -470,6 +543,12 @@ func (tsp *tailSamplingSpanProcessor) makeDecision(id pcommon.TraceID, trace *sa
 		sampling.SetAttrOnScopeSpans(trace, "tailsampling.policy", sampledPolicy.name)
 	}
 
+	if finalDecision == sampling.Sampled && !sampledWithoutThreshold && samplingThreshold != nil {
+		trace.Lock()
+		trace.SamplingThreshold = samplingThreshold
+		trace.Unlock()
+	}
+
 	switch finalDecision {
 	case sampling.Sampled:
 		metrics.decisionSampled++
@@ -480,6 +559,15 @@ func (tsp *tailSamplingSpanProcessor) makeDecision(id pcommon.TraceID, trace *sa
 	return finalDecision
 }
 
+// policyThreshold returns the threshold with which a probabilistic policy sampled a trace.
+func policyThreshold(p *policy, id pcommon.TraceID, decision sampling.Decision) (otelsampling.Threshold, bool) {
+	evaluator, ok := p.evaluator.(sampling.ThresholdEvaluator)
+	if !ok || decision != sampling.Sampled {
+		return otelsampling.Threshold{}, false
+	}
+	return evaluator.SamplingThreshold(id)
+}
+
 // ConsumeTraces is required by the processor.Traces interface.
 func (tsp *tailSamplingSpanProcessor) ConsumeTraces(_ context.Context, td ptrace.Traces) error {
 	resourceSpans := td.ResourceSpans()
@@ -521,6 +609,9 @@ func (tsp *tailSamplingSpanProcessor) processTraces(resourceSpans ptrace.Resourc
 			tsp.logger.Debug("Trace ID is in the sampled cache", zap.Stringer("id", id))
 			traceTd := ptrace.NewTraces()
 			appendToTraces(traceTd, resourceSpans, spans)
+			if th, ok := tsp.thresholdCache.Get(id); ok {
+				sampling.UpdateThreshold(traceTd, th)
+			}
 			tsp.releaseSampledTrace(tsp.ctx, id, traceTd)
 			tsp.telemetry.ProcessorTailSamplingEarlyReleasesFromCacheDecision.
 				Add(tsp.ctx, int64(len(spans)), attrSampledTrue)
@@ -538,6 +629,23 @@ func (tsp *tailSamplingSpanProcessor) processTraces(resourceSpans ptrace.Resourc
 
 		d, loaded := tsp.idToTrace.Load(id)
 		if !loaded {
+			// Another collector may have made a decision for this trace already.
+			if sampled, ok := tsp.sharedDecisions.Get(id); ok {
+				tsp.logger.Debug("Trace ID is in the shared decisions", zap.Stringer("id", id), zap.Bool("sampled", sampled))
+				if sampled {
+					traceTd := ptrace.NewTraces()
+					appendToTraces(traceTd, resourceSpans, spans)
+					tsp.releaseSampledTrace(tsp.ctx, id, traceTd)
+					tsp.telemetry.ProcessorTailSamplingEarlyReleasesFromCacheDecision.
+						Add(tsp.ctx, lenSpans, attrSampledTrue)
+				} else {
+					tsp.releaseNotSampledTrace(id)
+					tsp.telemetry.ProcessorTailSamplingEarlyReleasesFromCacheDecision.
+						Add(tsp.ctx, lenSpans, attrSampledFalse)
+				}
+				continue
+			}
+
 			spanCount := &atomic.Int64{}
 			spanCount.Store(lenSpans)
 
@@ -549,18 +657,7 @@ func (tsp *tailSamplingSpanProcessor) processTraces(resourceSpans ptrace.Resourc
 
 			if d, loaded = tsp.idToTrace.LoadOrStore(id, td); !loaded {
 				newTraceIDs++
-				tsp.decisionBatcher.AddToCurrentBatch(id)
-				tsp.numTracesOnMap.Add(1)
-				postDeletion := false
-				for !postDeletion {
-					select {
-					case tsp.deleteChan <- id:
-						postDeletion = true
-					default:
-						traceKeyToDrop := <-tsp.deleteChan
-						tsp.dropTrace(traceKeyToDrop, currTime)
-					}
-				}
+				tsp.trackNewTrace(id, currTime)
 			}
 		}
 
@@ -571,10 +668,16 @@ func (tsp *tailSamplingSpanProcessor) processTraces(resourceSpans ptrace.Resourc
 
 		actualData.Lock()
 		finalDecision := actualData.FinalDecision
+		samplingThreshold := actualData.SamplingThreshold
 
 		if finalDecision == sampling.Unspecified {
 			// If the final decision hasn't been made, add the new spans under the lock.
 			appendToTraces(actualData.ReceivedBatches, resourceSpans, spans)
+			if tsp.traceStorage != nil {
+				// Persisting under the lock guarantees the batch is written before the
+				// trace is removed from the storage once a decision is made.
+				tsp.persistSpans(id, actualData.ArrivalTime, resourceSpans, spans)
+			}
 			actualData.Unlock()
 			continue
 		}
@@ -585,6 +688,9 @@ func (tsp *tailSamplingSpanProcessor) processTraces(resourceSpans ptrace.Resourc
 		case sampling.Sampled:
 			traceTd := ptrace.NewTraces()
 			appendToTraces(traceTd, resourceSpans, spans)
+			if samplingThreshold != nil {
+				sampling.UpdateThreshold(traceTd, *samplingThreshold)
+			}
 			tsp.releaseSampledTrace(tsp.ctx, id, traceTd)
 		case sampling.NotSampled:
 			tsp.releaseNotSampledTrace(id)
@@ -600,23 +706,149 @@ func (tsp *tailSamplingSpanProcessor) processTraces(resourceSpans ptrace.Resourc
 	tsp.telemetry.ProcessorTailSamplingNewTraceIDReceived.Add(tsp.ctx, newTraceIDs)
 }
 
+// trackNewTrace schedules the decision for a trace just added to idToTrace, and makes room
+// for it by dropping the oldest trace when the processor already holds maxNumTraces.
+func (tsp *tailSamplingSpanProcessor) trackNewTrace(id pcommon.TraceID, currTime time.Time) {
+	tsp.decisionBatcher.AddToCurrentBatch(id)
+	tsp.numTracesOnMap.Add(1)
+	postDeletion := false
+	for !postDeletion {
+		select {
+		case tsp.deleteChan <- id:
+			postDeletion = true
+		default:
+			traceKeyToDrop := <-tsp.deleteChan
+			tsp.dropTrace(traceKeyToDrop, currTime)
+		}
+	}
+}
+
 func (*tailSamplingSpanProcessor) Capabilities() consumer.Capabilities {
 	return consumer.Capabilities{MutatesData: false}
 }
 
 // Start is invoked during service startup.
-func (tsp *tailSamplingSpanProcessor) Start(context.Context, component.Host) error {
+func (tsp *tailSamplingSpanProcessor) Start(ctx context.Context, host component.Host) error {
+	if tsp.sharedStorageID != nil {
+		client, err := getStorageClient(ctx, host, *tsp.sharedStorageID, tsp.set.ID, sharedDecisionsClientName)
+		if err != nil {
+			return err
+		}
+		tsp.sharedClient = client
+		// the local cache holds the decisions of up to as many traces as the processor keeps in memory
+		tsp.sharedDecisions, err = cache.NewSharedDecisionCache(client, tsp.logger, int(max(tsp.maxNumTraces, 1)), sharedDecisionMissTTL)
+		if err != nil {
+			return err
+		}
+	}
+
+	if tsp.storageID != nil {
+		client, err := getStorageClient(ctx, host, *tsp.storageID, tsp.set.ID, "")
+		if err != nil {
+			return err
+		}
+		tsp.traceStorage = newTraceStorage(client, tsp.logger)
+		if err := tsp.restoreFromStorage(ctx); err != nil {
+			return err
+		}
+	}
+
 	tsp.policyTicker.Start(tsp.tickerFrequency)
 	return nil
 }
 
 // Shutdown is invoked during service shutdown.
-func (tsp *tailSamplingSpanProcessor) Shutdown(context.Context) error {
+func (tsp *tailSamplingSpanProcessor) Shutdown(ctx context.Context) error {
 	tsp.decisionBatcher.Stop()
 	tsp.policyTicker.Stop()
+
+	var errs error
+	if tsp.sharedClient != nil {
+		errs = multierr.Append(errs, tsp.sharedClient.Close(ctx))
+	}
+	if tsp.traceStorage != nil {
+		tsp.flushStorage(ctx)
+		errs = multierr.Append(errs, tsp.traceStorage.client.Close(ctx))
+	}
+	return errs
+}
+
+// restoreFromStorage restores the decision caches and the traces awaiting a decision
+// from the storage extension.
+func (tsp *tailSamplingSpanProcessor) restoreFromStorage(ctx context.Context) error {
+	client := tsp.traceStorage.client
+	if c, ok := tsp.sampledIDCache.(cache.PersistentCache); ok {
+		if err := c.Attach(ctx, client, sampledCacheKey); err != nil {
+			return err
+		}
+	}
+	if c, ok := tsp.nonSampledIDCache.(cache.PersistentCache); ok {
+		if err := c.Attach(ctx, client, nonSampledCacheKey); err != nil {
+			return err
+		}
+	}
+
+	traces, err := tsp.traceStorage.restore(ctx)
+	if err != nil {
+		return err
+	}
+
+	currTime := time.Now()
+	for _, t := range traces {
+		spanCount := &atomic.Int64{}
+		spanCount.Store(int64(t.batches.SpanCount()))
+
+		td := &sampling.TraceData{
+			ArrivalTime:     t.arrivalTime,
+			SpanCount:       spanCount,
+			ReceivedBatches: t.batches,
+		}
+		if _, loaded := tsp.idToTrace.LoadOrStore(t.id, td); !loaded {
+			tsp.trackNewTrace(t.id, currTime)
+		}
+	}
+
+	tsp.logger.Info("Restored traces awaiting a sampling decision", zap.Int("traces", len(traces)))
 	return nil
 }
 
+// persistSpans writes the given spans of a trace awaiting a decision to the storage.
+func (tsp *tailSamplingSpanProcessor) persistSpans(id pcommon.TraceID, arrivalTime time.Time, rss ptrace.ResourceSpans, spans []spanAndScope) {
+	td := ptrace.NewTraces()
+	appendToTraces(td, rss, spans)
+	if err := tsp.traceStorage.appendBatch(tsp.ctx, id, arrivalTime, td); err != nil {
+		tsp.logger.Warn("Failed to persist spans", zap.Stringer("id", id), zap.Error(err))
+	}
+}
+
+// removeFromStorage deletes the spans persisted for a trace.
+func (tsp *tailSamplingSpanProcessor) removeFromStorage(ctx context.Context, id pcommon.TraceID) {
+	if tsp.traceStorage == nil {
+		return
+	}
+	if err := tsp.traceStorage.remove(ctx, id); err != nil {
+		tsp.logger.Warn("Failed to remove persisted spans", zap.Stringer("id", id), zap.Error(err))
+	}
+}
+
+// flushStorage writes the list of traces awaiting a decision and the decision caches
+// to the storage.
+func (tsp *tailSamplingSpanProcessor) flushStorage(ctx context.Context) {
+	if tsp.traceStorage == nil {
+		return
+	}
+	if err := tsp.traceStorage.flush(ctx); err != nil {
+		tsp.logger.Warn("Failed to persist traces awaiting a decision", zap.Error(err))
+	}
+	for _, c := range []cache.Cache[bool]{tsp.sampledIDCache, tsp.nonSampledIDCache} {
+		if pc, ok := c.(cache.PersistentCache); ok {
+			if err := pc.Flush(ctx); err != nil {
+				tsp.logger.Warn("Failed to persist decision cache", zap.Error(err))
+			}
+		}
+	}
+}
+
 func (tsp *tailSamplingSpanProcessor) dropTrace(traceID pcommon.TraceID, deletionTime time.Time) {
 	var trace *sampling.TraceData
 	if d, ok := tsp.idToTrace.Load(traceID); ok {
@@ -629,6 +861,7 @@ func (tsp *tailSamplingSpanProcessor) dropTrace(traceID pcommon.TraceID, deletio
 		tsp.logger.Debug("Attempt to delete trace ID not on table", zap.Stringer("id", traceID))
 		return
 	}
+	tsp.removeFromStorage(tsp.ctx, traceID)
 
 	tsp.telemetry.ProcessorTailSamplingSamplingTraceRemovalAge.Record(tsp.ctx, int64(deletionTime.Sub(trace.ArrivalTime)/time.Second))
 }
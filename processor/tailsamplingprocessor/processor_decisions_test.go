@@ -16,6 +16,7 @@ import (
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/metric"
 
+	otelsampling "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/sampling"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/cache"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/metadata"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/sampling"
@@ -694,3 +695,131 @@ func TestSampleOnFirstMatch(t *testing.T) {
 	// The final decision SHOULD be Sampled.
 	require.Equal(t, 1, nextConsumer.SpanCount())
 }
+
+func TestAdaptiveThresholdRecordedWhenDeciding(t *testing.T) {
+	adaptivePolicy := PolicyCfg{
+		sharedPolicyCfg: sharedPolicyCfg{
+			Name:        "adaptive",
+			Type:        Adaptive,
+			AdaptiveCfg: AdaptiveCfg{TracesPerSecond: 10},
+		},
+	}
+	alwaysSamplePolicy := PolicyCfg{
+		sharedPolicyCfg: sharedPolicyCfg{
+			Name: "always",
+			Type: AlwaysSample,
+		},
+	}
+
+	tests := []struct {
+		name     string
+		policies []PolicyCfg
+		// wantThreshold is the expected th value of the tracestate of the spans, if any
+		wantThreshold string
+	}{
+		{
+			name:          "sampled by adaptive",
+			policies:      []PolicyCfg{adaptivePolicy},
+			wantThreshold: "0",
+		},
+		{
+			name:     "sampled by adaptive and always_sample",
+			policies: []PolicyCfg{adaptivePolicy, alwaysSamplePolicy},
+		},
+		{
+			name:     "sampled by always_sample and adaptive",
+			policies: []PolicyCfg{alwaysSamplePolicy, adaptivePolicy},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nextConsumer := new(consumertest.TracesSink)
+			cfg := Config{
+				DecisionWait: defaultTestDecisionWait,
+				NumTraces:    defaultNumTraces,
+				DecisionCache: DecisionCacheConfig{
+					SampledCacheSize: 100,
+				},
+				PolicyCfgs: tt.policies,
+				Options: []Option{
+					withDecisionBatcher(newSyncIDBatcher()),
+				},
+			}
+			p, err := newTracesProcessor(context.Background(), processortest.NewNopSettings(metadata.Type), nextConsumer, cfg)
+			require.NoError(t, err)
+			require.NoError(t, p.Start(context.Background(), componenttest.NewNopHost()))
+			defer func() {
+				require.NoError(t, p.Shutdown(context.Background()))
+			}()
+			tsp := p.(*tailSamplingSpanProcessor)
+
+			traceID := uInt64ToTraceID(1)
+			require.NoError(t, p.ConsumeTraces(context.Background(), simpleTracesWithID(traceID)))
+			tsp.policyTicker.OnTick()
+			tsp.policyTicker.OnTick()
+			require.Equal(t, 1, nextConsumer.SpanCount())
+
+			// The late span is released with the sampled decision cache
+			_, ok := tsp.idToTrace.Load(traceID)
+			require.False(t, ok)
+			require.NoError(t, p.ConsumeTraces(context.Background(), simpleTracesWithID(traceID)))
+			require.Len(t, nextConsumer.AllTraces(), 2)
+
+			for _, td := range nextConsumer.AllTraces() {
+				span := td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0)
+				ts, err := otelsampling.NewW3CTraceState(span.TraceState().AsRaw())
+				require.NoError(t, err)
+				th, ok := ts.OTelValue().TValueThreshold()
+				if tt.wantThreshold == "" {
+					assert.False(t, ok, "unexpected threshold %q", th.TValue())
+					continue
+				}
+				require.True(t, ok)
+				assert.Equal(t, tt.wantThreshold, th.TValue())
+			}
+		})
+	}
+}
+
+func TestAdaptiveThresholdRecordedOnLateSpanOfTraceInMemory(t *testing.T) {
+	nextConsumer := new(consumertest.TracesSink)
+	cfg := Config{
+		DecisionWait: defaultTestDecisionWait,
+		NumTraces:    defaultNumTraces,
+		PolicyCfgs: []PolicyCfg{
+			{
+				sharedPolicyCfg: sharedPolicyCfg{
+					Name:        "adaptive",
+					Type:        Adaptive,
+					AdaptiveCfg: AdaptiveCfg{TracesPerSecond: 10},
+				},
+			},
+		},
+		Options: []Option{
+			withDecisionBatcher(newSyncIDBatcher()),
+		},
+	}
+	p, err := newTracesProcessor(context.Background(), processortest.NewNopSettings(metadata.Type), nextConsumer, cfg)
+	require.NoError(t, err)
+	require.NoError(t, p.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() {
+		require.NoError(t, p.Shutdown(context.Background()))
+	}()
+	tsp := p.(*tailSamplingSpanProcessor)
+
+	traceID := uInt64ToTraceID(1)
+	require.NoError(t, p.ConsumeTraces(context.Background(), simpleTracesWithID(traceID)))
+	tsp.policyTicker.OnTick()
+	tsp.policyTicker.OnTick()
+
+	// Without a decision cache, the late span is released with the decision of the trace in memory
+	_, ok := tsp.idToTrace.Load(traceID)
+	require.True(t, ok)
+	require.NoError(t, p.ConsumeTraces(context.Background(), simpleTracesWithID(traceID)))
+	require.Len(t, nextConsumer.AllTraces(), 2)
+
+	for _, td := range nextConsumer.AllTraces() {
+		span := td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0)
+		assert.Equal(t, "ot=th:0", span.TraceState().AsRaw())
+	}
+}
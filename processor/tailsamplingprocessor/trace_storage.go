@@ -0,0 +1,274 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tailsamplingprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor"
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension/xextension/storage"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+)
+
+const (
+	// traceIndexKey holds the IDs and arrival times of the traces awaiting a decision.
+	traceIndexKey = "traces"
+	// newTraceKeyFormat is the key of the index entry of a trace first seen after the last flush.
+	newTraceKeyFormat = "traces/new/%d"
+	// traceBatchKeyFormat is the key of a single batch of spans received for a trace.
+	traceBatchKeyFormat = "trace/%s/%d"
+	// sampledCacheKey and nonSampledCacheKey hold the decision caches.
+	sampledCacheKey    = "decisions/sampled"
+	nonSampledCacheKey = "decisions/non_sampled"
+	// sharedDecisionsClientName is the name of the storage client holding the decisions
+	// shared with other collectors.
+	sharedDecisionsClientName = "shared_decisions"
+	// sharedDecisionMissTTL is how long a trace ID without a shared decision isn't looked up again.
+	sharedDecisionMissTTL = time.Second
+
+	// indexEntrySize is the encoded size of a trace ID followed by its arrival time.
+	indexEntrySize = 16 + 8
+)
+
+// pendingTrace tracks what has been persisted for a trace awaiting a decision.
+type pendingTrace struct {
+	arrivalTime time.Time
+	batches     int
+}
+
+// restoredTrace is a trace read back from the storage on start.
+type restoredTrace struct {
+	id          pcommon.TraceID
+	arrivalTime time.Time
+	batches     ptrace.Traces
+}
+
+// traceStorage persists the batches of traces awaiting a sampling decision to a
+// storage.Client, so that they can be restored after a restart.
+//
+// Each received batch is written under its own key as soon as it arrives. The first batch
+// of a trace is written along with an index entry of its own, so that no batch is written
+// without the trace being indexed. flush, which the processor calls on every tick, compacts
+// the list of pending traces into a single key and deletes those index entries.
+type traceStorage struct {
+	client      storage.Client
+	logger      *zap.Logger
+	marshaler   ptrace.ProtoMarshaler
+	unmarshaler ptrace.ProtoUnmarshaler
+
+	mu      sync.Mutex
+	pending map[pcommon.TraceID]*pendingTrace
+	dirty   bool
+
+	// indexMu orders the writes of the index entries of new traces with the flushes of the list.
+	indexMu sync.Mutex
+	// newTraces is the number of index entries of new traces written since the last flush.
+	newTraces int
+}
+
+func newTraceStorage(client storage.Client, logger *zap.Logger) *traceStorage {
+	return &traceStorage{
+		client:  client,
+		logger:  logger,
+		pending: make(map[pcommon.TraceID]*pendingTrace),
+	}
+}
+
+// getStorageClient returns the client with the given name of the storage extension with the given ID.
+func getStorageClient(ctx context.Context, host component.Host, storageID, componentID component.ID, name string) (storage.Client, error) {
+	ext, ok := host.GetExtensions()[storageID]
+	if !ok {
+		return nil, fmt.Errorf("storage extension '%s' not found", storageID)
+	}
+
+	storageExt, ok := ext.(storage.Extension)
+	if !ok {
+		return nil, fmt.Errorf("non-storage extension '%s' found", storageID)
+	}
+
+	return storageExt.GetClient(ctx, component.KindProcessor, componentID, name)
+}
+
+// appendBatch persists a batch of spans received for a trace awaiting a decision.
+func (s *traceStorage) appendBatch(ctx context.Context, id pcommon.TraceID, arrivalTime time.Time, td ptrace.Traces) error {
+	data, err := s.marshaler.MarshalTraces(td)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	pt, ok := s.pending[id]
+	if !ok {
+		pt = &pendingTrace{arrivalTime: arrivalTime}
+		s.pending[id] = pt
+		s.dirty = true
+	}
+	key := batchKey(id, pt.batches)
+	pt.batches++
+	s.mu.Unlock()
+
+	if ok {
+		return s.client.Set(ctx, key, data)
+	}
+
+	s.indexMu.Lock()
+	defer s.indexMu.Unlock()
+	err = s.client.Batch(ctx,
+		storage.SetOperation(key, data),
+		storage.SetOperation(newTraceKey(s.newTraces), encodeIndexEntry(nil, id, arrivalTime)),
+	)
+	if err != nil {
+		return err
+	}
+	s.newTraces++
+	return nil
+}
+
+// remove deletes all the batches persisted for the given trace.
+func (s *traceStorage) remove(ctx context.Context, id pcommon.TraceID) error {
+	s.mu.Lock()
+	pt, ok := s.pending[id]
+	if ok {
+		delete(s.pending, id)
+		s.dirty = true
+	}
+	s.mu.Unlock()
+
+	if !ok {
+		return nil
+	}
+
+	ops := make([]*storage.Operation, 0, pt.batches)
+	for i := 0; i < pt.batches; i++ {
+		ops = append(ops, storage.DeleteOperation(batchKey(id, i)))
+	}
+	return s.client.Batch(ctx, ops...)
+}
+
+// flush writes the list of pending traces if it changed since the last flush, and deletes
+// the index entries of the new traces it now includes.
+func (s *traceStorage) flush(ctx context.Context) error {
+	s.indexMu.Lock()
+	defer s.indexMu.Unlock()
+
+	s.mu.Lock()
+	if !s.dirty {
+		s.mu.Unlock()
+		return nil
+	}
+	data := make([]byte, 0, len(s.pending)*indexEntrySize)
+	for id, pt := range s.pending {
+		data = encodeIndexEntry(data, id, pt.arrivalTime)
+	}
+	s.dirty = false
+	s.mu.Unlock()
+
+	ops := make([]*storage.Operation, 0, 1+s.newTraces)
+	ops = append(ops, storage.SetOperation(traceIndexKey, data))
+	for i := 0; i < s.newTraces; i++ {
+		ops = append(ops, storage.DeleteOperation(newTraceKey(i)))
+	}
+	if err := s.client.Batch(ctx, ops...); err != nil {
+		s.mu.Lock()
+		s.dirty = true
+		s.mu.Unlock()
+		return fmt.Errorf("failed to write trace index: %w", err)
+	}
+	s.newTraces = 0
+	return nil
+}
+
+// restore reads back the traces that were pending when the index was last flushed, along
+// with the traces first seen after that flush, and all their persisted batches.
+func (s *traceStorage) restore(ctx context.Context) ([]restoredTrace, error) {
+	data, err := s.client.Get(ctx, traceIndexKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read trace index: %w", err)
+	}
+	if len(data)%indexEntrySize != 0 {
+		return nil, fmt.Errorf("trace index is corrupted: unexpected length %d", len(data))
+	}
+
+	s.indexMu.Lock()
+	defer s.indexMu.Unlock()
+	for ; ; s.newTraces++ {
+		entry, err := s.client.Get(ctx, newTraceKey(s.newTraces))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read index entry %d of new traces: %w", s.newTraces, err)
+		}
+		if entry == nil {
+			break
+		}
+		if len(entry) != indexEntrySize {
+			return nil, fmt.Errorf("index entry %d of new traces is corrupted: unexpected length %d", s.newTraces, len(entry))
+		}
+		data = append(data, entry...)
+	}
+
+	restored := make([]restoredTrace, 0, len(data)/indexEntrySize)
+	for i := 0; i < len(data); i += indexEntrySize {
+		var id pcommon.TraceID
+		copy(id[:], data[i:i+16])
+		arrivalTime := time.Unix(0, int64(binary.LittleEndian.Uint64(data[i+16:])))
+
+		s.mu.Lock()
+		_, seen := s.pending[id]
+		s.mu.Unlock()
+		if seen {
+			continue
+		}
+
+		batches := ptrace.NewTraces()
+		n := 0
+		for ; ; n++ {
+			buf, err := s.client.Get(ctx, batchKey(id, n))
+			if err != nil {
+				return nil, fmt.Errorf("failed to read batch %d of trace %s: %w", n, id, err)
+			}
+			if buf == nil {
+				break
+			}
+			td, err := s.unmarshaler.UnmarshalTraces(buf)
+			if err != nil {
+				s.logger.Warn("Discarding unreadable persisted batch", zap.Stringer("id", id), zap.Int("batch", n), zap.Error(err))
+				continue
+			}
+			td.ResourceSpans().MoveAndAppendTo(batches.ResourceSpans())
+		}
+
+		s.mu.Lock()
+		s.pending[id] = &pendingTrace{arrivalTime: arrivalTime, batches: n}
+		s.dirty = true
+		s.mu.Unlock()
+
+		if batches.SpanCount() == 0 {
+			if err := s.remove(ctx, id); err != nil {
+				return nil, fmt.Errorf("failed to remove empty trace %s: %w", id, err)
+			}
+			continue
+		}
+		restored = append(restored, restoredTrace{id: id, arrivalTime: arrivalTime, batches: batches})
+	}
+	return restored, nil
+}
+
+func batchKey(id pcommon.TraceID, n int) string {
+	return fmt.Sprintf(traceBatchKeyFormat, id, n)
+}
+
+func newTraceKey(n int) string {
+	return fmt.Sprintf(newTraceKeyFormat, n)
+}
+
+// encodeIndexEntry appends the trace ID and its arrival time to the encoded index.
+func encodeIndexEntry(data []byte, id pcommon.TraceID, arrivalTime time.Time) []byte {
+	data = append(data, id[:]...)
+	return binary.LittleEndian.AppendUint64(data, uint64(arrivalTime.UnixNano()))
+}
@@ -0,0 +1,233 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tailsamplingprocessor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/processor/processortest"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/cache"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/metadata"
+)
+
+func newStorageBackedProcessor(t *testing.T, storageID component.ID, sink *consumertest.TracesSink) *tailSamplingSpanProcessor {
+	cfg := Config{
+		DecisionWait: defaultTestDecisionWait,
+		NumTraces:    defaultNumTraces,
+		DecisionCache: DecisionCacheConfig{
+			SampledCacheSize: 100,
+		},
+		Storage: &storageID,
+		PolicyCfgs: []PolicyCfg{
+			{
+				sharedPolicyCfg: sharedPolicyCfg{
+					Name: "always",
+					Type: AlwaysSample,
+				},
+			},
+		},
+		Options: []Option{
+			withDecisionBatcher(newSyncIDBatcher()),
+		},
+	}
+	p, err := newTracesProcessor(context.Background(), processortest.NewNopSettings(metadata.Type), sink, cfg)
+	require.NoError(t, err)
+	return p.(*tailSamplingSpanProcessor)
+}
+
+func TestPendingTracesAreRestoredFromStorage(t *testing.T) {
+	ext := storagetest.NewFileBackedStorageExtension("test", t.TempDir())
+	host := storagetest.NewStorageHost().WithExtension(ext.ID, ext)
+
+	first := new(consumertest.TracesSink)
+	tsp := newStorageBackedProcessor(t, ext.ID, first)
+	require.NoError(t, tsp.Start(context.Background(), host))
+
+	traceIDs, batches := generateIDsAndBatches(3)
+	for _, batch := range batches {
+		require.NoError(t, tsp.ConsumeTraces(context.Background(), batch))
+	}
+	// The first tick always gets an empty batch, but persists the pending traces.
+	tsp.policyTicker.OnTick()
+	require.NoError(t, tsp.Shutdown(context.Background()))
+	assert.Empty(t, first.AllTraces())
+
+	second := new(consumertest.TracesSink)
+	tsp = newStorageBackedProcessor(t, ext.ID, second)
+	require.NoError(t, tsp.Start(context.Background(), host))
+	defer func() {
+		require.NoError(t, tsp.Shutdown(context.Background()))
+	}()
+	assert.Equal(t, uint64(3), tsp.numTracesOnMap.Load())
+
+	tsp.policyTicker.OnTick()
+	tsp.policyTicker.OnTick()
+
+	require.Len(t, second.AllTraces(), 3)
+	for i, traceID := range traceIDs {
+		trace := findTrace(t, second.AllTraces(), traceID)
+		assert.Equal(t, i+1, trace.SpanCount())
+	}
+}
+
+func TestDecisionCacheIsRestoredFromStorage(t *testing.T) {
+	ext := storagetest.NewFileBackedStorageExtension("test", t.TempDir())
+	host := storagetest.NewStorageHost().WithExtension(ext.ID, ext)
+
+	first := new(consumertest.TracesSink)
+	tsp := newStorageBackedProcessor(t, ext.ID, first)
+	require.NoError(t, tsp.Start(context.Background(), host))
+
+	traceIDs, batches := generateIDsAndBatches(1)
+	require.NoError(t, tsp.ConsumeTraces(context.Background(), batches[0]))
+	tsp.policyTicker.OnTick()
+	tsp.policyTicker.OnTick()
+	require.Len(t, first.AllTraces(), 1)
+	require.NoError(t, tsp.Shutdown(context.Background()))
+
+	second := new(consumertest.TracesSink)
+	tsp = newStorageBackedProcessor(t, ext.ID, second)
+	require.NoError(t, tsp.Start(context.Background(), host))
+	defer func() {
+		require.NoError(t, tsp.Shutdown(context.Background()))
+	}()
+	assert.Zero(t, tsp.numTracesOnMap.Load(), "a trace with a decision must not be restored")
+
+	// A late span is released right away thanks to the restored decision.
+	require.NoError(t, tsp.ConsumeTraces(context.Background(), simpleTracesWithID(traceIDs[0])))
+	assert.Len(t, second.AllTraces(), 1)
+	assert.Zero(t, tsp.numTracesOnMap.Load())
+}
+
+func TestTraceStorageRemove(t *testing.T) {
+	client := storagetest.NewInMemoryClient(component.KindProcessor, component.MustNewID("tail_sampling"), "")
+	s := newTraceStorage(client, zap.NewNop())
+
+	traceIDs, batches := generateIDsAndBatches(2)
+	ctx := context.Background()
+	for i, id := range traceIDs {
+		require.NoError(t, s.appendBatch(ctx, id, time.Now(), batches[i]))
+	}
+	require.NoError(t, s.flush(ctx))
+	require.NoError(t, s.remove(ctx, traceIDs[0]))
+	require.NoError(t, s.flush(ctx))
+
+	restored, err := newTraceStorage(client, zap.NewNop()).restore(ctx)
+	require.NoError(t, err)
+	require.Len(t, restored, 1)
+	assert.Equal(t, traceIDs[1], restored[0].id)
+	assert.Equal(t, batches[1].SpanCount(), restored[0].batches.SpanCount())
+
+	data, err := client.Get(ctx, batchKey(traceIDs[0], 0))
+	require.NoError(t, err)
+	assert.Nil(t, data)
+}
+
+func TestStorageExtensionNotFound(t *testing.T) {
+	tsp := newStorageBackedProcessor(t, storagetest.NewStorageID("missing"), new(consumertest.TracesSink))
+	assert.ErrorContains(t, tsp.Start(context.Background(), componenttest.NewNopHost()), "not found")
+}
+
+func TestSharedDecisionIsHonoredByAnotherProcessor(t *testing.T) {
+	client := storagetest.NewInMemoryClient(component.KindProcessor, component.MustNewID("tail_sampling"), sharedDecisionsClientName)
+	newProcessor := func(sink *consumertest.TracesSink, policy PolicyType) *tailSamplingSpanProcessor {
+		sharedDecisions, err := cache.NewSharedDecisionCache(client, zap.NewNop(), defaultNumTraces, sharedDecisionMissTTL)
+		require.NoError(t, err)
+		cfg := Config{
+			DecisionWait: defaultTestDecisionWait,
+			NumTraces:    defaultNumTraces,
+			PolicyCfgs: []PolicyCfg{
+				{
+					sharedPolicyCfg: sharedPolicyCfg{
+						Name: "policy",
+						Type: policy,
+					},
+				},
+			},
+			Options: []Option{
+				withDecisionBatcher(newSyncIDBatcher()),
+				withSharedDecisionCache(sharedDecisions),
+			},
+		}
+		p, err := newTracesProcessor(context.Background(), processortest.NewNopSettings(metadata.Type), sink, cfg)
+		require.NoError(t, err)
+		require.NoError(t, p.Start(context.Background(), componenttest.NewNopHost()))
+		t.Cleanup(func() {
+			require.NoError(t, p.Shutdown(context.Background()))
+		})
+		return p.(*tailSamplingSpanProcessor)
+	}
+
+	first := new(consumertest.TracesSink)
+	firstTSP := newProcessor(first, AlwaysSample)
+	// The second processor would drop every trace if it made its own decisions.
+	second := new(consumertest.TracesSink)
+	secondTSP := newProcessor(second, StringAttribute)
+
+	traceIDs, batches := generateIDsAndBatches(2)
+	require.NoError(t, firstTSP.ConsumeTraces(context.Background(), batches[0]))
+	firstTSP.policyTicker.OnTick()
+	firstTSP.policyTicker.OnTick()
+	require.Len(t, first.AllTraces(), 1)
+
+	// Late spans of the decided trace are released right away, while the unknown
+	// trace waits for the second processor's own decision.
+	require.NoError(t, secondTSP.ConsumeTraces(context.Background(), simpleTracesWithID(traceIDs[0])))
+	require.NoError(t, secondTSP.ConsumeTraces(context.Background(), batches[1]))
+	assert.Len(t, second.AllTraces(), 1)
+	assert.Equal(t, uint64(1), secondTSP.numTracesOnMap.Load())
+
+	secondTSP.policyTicker.OnTick()
+	secondTSP.policyTicker.OnTick()
+	assert.Len(t, second.AllTraces(), 1)
+
+	sharedDecisions, err := cache.NewSharedDecisionCache(client, zap.NewNop(), defaultNumTraces, sharedDecisionMissTTL)
+	require.NoError(t, err)
+	sampled, ok := sharedDecisions.Get(traceIDs[1])
+	assert.True(t, ok)
+	assert.False(t, sampled)
+}
+
+func TestTraceStorageRestoresTracesNotFlushed(t *testing.T) {
+	client := storagetest.NewInMemoryClient(component.KindProcessor, component.MustNewID("tail_sampling"), "")
+	s := newTraceStorage(client, zap.NewNop())
+
+	traceIDs, batches := generateIDsAndBatches(3)
+	ctx := context.Background()
+	require.NoError(t, s.appendBatch(ctx, traceIDs[0], time.Now(), batches[0]))
+	require.NoError(t, s.flush(ctx))
+	// The process crashes before the next flush
+	require.NoError(t, s.appendBatch(ctx, traceIDs[1], time.Now(), batches[1]))
+	require.NoError(t, s.appendBatch(ctx, traceIDs[2], time.Now(), batches[2]))
+	require.NoError(t, s.remove(ctx, traceIDs[2]))
+
+	s = newTraceStorage(client, zap.NewNop())
+	restored, err := s.restore(ctx)
+	require.NoError(t, err)
+	require.Len(t, restored, 2)
+	assert.Equal(t, traceIDs[0], restored[0].id)
+	assert.Equal(t, traceIDs[1], restored[1].id)
+	assert.Equal(t, batches[1].SpanCount(), restored[1].batches.SpanCount())
+
+	// The next flush compacts the index entries of the new traces into the list
+	require.NoError(t, s.flush(ctx))
+	for i := range 2 {
+		data, err := client.Get(ctx, newTraceKey(i))
+		require.NoError(t, err)
+		assert.Nil(t, data)
+	}
+	restored, err = newTraceStorage(client, zap.NewNop()).restore(ctx)
+	require.NoError(t, err)
+	assert.Len(t, restored, 2)
+}
@@ -42,6 +42,11 @@ type Config struct {
 	// The default value is `propagate`.
 	ErrorMode ottl.ErrorMode `mapstructure:"error_mode"`
 
+	// FunctionDefinitions are user-defined functions that can be called from the statements
+	// of all signals and contexts, either converters defined by an expression or editors
+	// defined by a block of statements.
+	FunctionDefinitions []string `mapstructure:"function_definitions"`
+
 	TraceStatements   []common.ContextStatements `mapstructure:"trace_statements"`
 	MetricStatements  []common.ContextStatements `mapstructure:"metric_statements"`
 	LogStatements     []common.ContextStatements `mapstructure:"log_statements"`
@@ -145,7 +150,7 @@ func (c *Config) Validate() error {
 	var errors error
 
 	if len(c.TraceStatements) > 0 {
-		pc, err := common.NewTraceParserCollection(component.TelemetrySettings{Logger: zap.NewNop()}, common.WithSpanParser(c.spanFunctions), common.WithSpanEventParser(c.spanEventFunctions))
+		pc, err := common.NewTraceParserCollection(component.TelemetrySettings{Logger: zap.NewNop()}, common.WithSpanParser(c.spanFunctions), common.WithSpanEventParser(c.spanEventFunctions), common.WithTraceFunctionDefinitions(c.FunctionDefinitions))
 		if err != nil {
 			return err
 		}
@@ -158,7 +163,7 @@ func (c *Config) Validate() error {
 	}
 
 	if len(c.MetricStatements) > 0 {
-		pc, err := common.NewMetricParserCollection(component.TelemetrySettings{Logger: zap.NewNop()}, common.WithMetricParser(c.metricFunctions), common.WithDataPointParser(c.dataPointFunctions))
+		pc, err := common.NewMetricParserCollection(component.TelemetrySettings{Logger: zap.NewNop()}, common.WithMetricParser(c.metricFunctions), common.WithDataPointParser(c.dataPointFunctions), common.WithMetricFunctionDefinitions(c.FunctionDefinitions))
 		if err != nil {
 			return err
 		}
@@ -171,7 +176,7 @@ func (c *Config) Validate() error {
 	}
 
 	if len(c.LogStatements) > 0 {
-		pc, err := common.NewLogParserCollection(component.TelemetrySettings{Logger: zap.NewNop()}, common.WithLogParser(c.logFunctions))
+		pc, err := common.NewLogParserCollection(component.TelemetrySettings{Logger: zap.NewNop()}, common.WithLogParser(c.logFunctions), common.WithLogFunctionDefinitions(c.FunctionDefinitions))
 		if err != nil {
 			return err
 		}
@@ -184,7 +189,7 @@ func (c *Config) Validate() error {
 	}
 
 	if len(c.ProfileStatements) > 0 {
-		pc, err := common.NewProfileParserCollection(component.TelemetrySettings{Logger: zap.NewNop()}, common.WithProfileParser(c.profileFunctions))
+		pc, err := common.NewProfileParserCollection(component.TelemetrySettings{Logger: zap.NewNop()}, common.WithProfileParser(c.profileFunctions), common.WithProfileFunctionDefinitions(c.FunctionDefinitions))
 		if err != nil {
 			return err
 		}
@@ -334,6 +334,41 @@ func TestLoadConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			id: component.NewIDWithName(metadata.Type, "function_definitions"),
+			expected: &Config{
+				ErrorMode: ottl.PropagateError,
+				FunctionDefinitions: []string{
+					`normalize_http(attrs) { set(attrs["http.request.method"], attrs["http.method"]) where attrs["http.method"] != nil; delete_key(attrs, "http.method") }`,
+					`IsHealthCheck(path) = IsMatch(path, "^/health")`,
+				},
+				TraceStatements: []common.ContextStatements{
+					{
+						Statements: []string{`normalize_http(span.attributes) where not IsHealthCheck(span.attributes["http.path"])`},
+					},
+				},
+				MetricStatements: []common.ContextStatements{
+					{
+						Statements: []string{`normalize_http(datapoint.attributes)`},
+					},
+				},
+				LogStatements: []common.ContextStatements{
+					{
+						Statements: []string{
+							`normalize_http(log.attributes)`,
+							`normalize_http(resource.attributes)`,
+						},
+					},
+				},
+				ProfileStatements: []common.ContextStatements{},
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "bad_function_definition"),
+			errors: []error{
+				errors.New("converter definitions must be an expression"),
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.id.Name(), func(t *testing.T) {
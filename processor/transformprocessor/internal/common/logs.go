@@ -71,6 +71,10 @@ func WithLogErrorMode(errorMode ottl.ErrorMode) LogParserCollectionOption {
 	return LogParserCollectionOption(ottl.WithParserCollectionErrorMode[LogsConsumer](errorMode))
 }
 
+func WithLogFunctionDefinitions(definitions []string) LogParserCollectionOption {
+	return LogParserCollectionOption(ottl.WithParserCollectionFunctionDefinitions[LogsConsumer](definitions))
+}
+
 func NewLogParserCollection(settings component.TelemetrySettings, options ...LogParserCollectionOption) (*LogParserCollection, error) {
 	pcOptions := []ottl.ParserCollectionOption[LogsConsumer]{
 		withCommonContextParsers[LogsConsumer](),
@@ -191,6 +191,10 @@ func WithMetricErrorMode(errorMode ottl.ErrorMode) MetricParserCollectionOption
 	return MetricParserCollectionOption(ottl.WithParserCollectionErrorMode[MetricsConsumer](errorMode))
 }
 
+func WithMetricFunctionDefinitions(definitions []string) MetricParserCollectionOption {
+	return MetricParserCollectionOption(ottl.WithParserCollectionFunctionDefinitions[MetricsConsumer](definitions))
+}
+
 func NewMetricParserCollection(settings component.TelemetrySettings, options ...MetricParserCollectionOption) (*MetricParserCollection, error) {
 	pcOptions := []ottl.ParserCollectionOption[MetricsConsumer]{
 		withCommonContextParsers[MetricsConsumer](),
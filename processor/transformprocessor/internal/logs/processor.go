@@ -23,8 +23,9 @@ type Processor struct {
 	flatMode bool
 }
 
-func NewProcessor(contextStatements []common.ContextStatements, errorMode ottl.ErrorMode, flatMode bool, settings component.TelemetrySettings, logFunctions map[string]ottl.Factory[ottllog.TransformContext]) (*Processor, error) {
-	pc, err := common.NewLogParserCollection(settings, common.WithLogParser(logFunctions), common.WithLogErrorMode(errorMode))
+func NewProcessor(contextStatements []common.ContextStatements, errorMode ottl.ErrorMode, flatMode bool, settings component.TelemetrySettings, logFunctions map[string]ottl.Factory[ottllog.TransformContext], options ...common.LogParserCollectionOption) (*Processor, error) {
+	pcOptions := append([]common.LogParserCollectionOption{common.WithLogParser(logFunctions), common.WithLogErrorMode(errorMode)}, options...)
+	pc, err := common.NewLogParserCollection(settings, pcOptions...)
 	if err != nil {
 		return nil, err
 	}
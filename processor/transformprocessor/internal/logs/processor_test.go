@@ -1204,6 +1204,58 @@ func Test_ProcessLogs_InferredContextFromConditions(t *testing.T) {
 	}
 }
 
+func Test_ProcessLogs_FunctionDefinitions(t *testing.T) {
+	definitions := []string{
+		`tag(value) { set(attributes["test"], value) }`,
+		`Operation(prefix) = Concat([prefix, body], ":")`,
+	}
+	tests := []struct {
+		name              string
+		contextStatements []common.ContextStatements
+		want              func(td plog.Logs)
+	}{
+		{
+			name: "inferred context",
+			contextStatements: []common.ContextStatements{
+				{
+					Statements: []string{`tag(Operation("op")) where log.body == "operationA"`},
+				},
+			},
+			want: func(td plog.Logs) {
+				td.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes().PutStr("test", "op:operationA")
+			},
+		},
+		{
+			name: "resource context",
+			contextStatements: []common.ContextStatements{
+				{
+					Context:    "resource",
+					Statements: []string{`tag("pass")`},
+				},
+			},
+			want: func(td plog.Logs) {
+				td.ResourceLogs().At(0).Resource().Attributes().PutStr("test", "pass")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			td := constructLogs()
+			processor, err := NewProcessor(tt.contextStatements, ottl.PropagateError, false, componenttest.NewNopTelemetrySettings(), DefaultLogFunctions, common.WithLogFunctionDefinitions(definitions))
+			require.NoError(t, err)
+
+			_, err = processor.ProcessLogs(context.Background(), td)
+			assert.NoError(t, err)
+
+			exTd := constructLogs()
+			tt.want(exTd)
+
+			assert.Equal(t, exTd, td)
+		})
+	}
+}
+
 func Test_NewProcessor_ConditionsParse(t *testing.T) {
 	type testCase struct {
 		name          string
@@ -21,8 +21,9 @@ type Processor struct {
 	logger   *zap.Logger
 }
 
-func NewProcessor(contextStatements []common.ContextStatements, errorMode ottl.ErrorMode, settings component.TelemetrySettings, profileFunctions map[string]ottl.Factory[ottlprofile.TransformContext]) (*Processor, error) {
-	pc, err := common.NewProfileParserCollection(settings, common.WithProfileParser(profileFunctions), common.WithProfileErrorMode(errorMode))
+func NewProcessor(contextStatements []common.ContextStatements, errorMode ottl.ErrorMode, settings component.TelemetrySettings, profileFunctions map[string]ottl.Factory[ottlprofile.TransformContext], options ...common.ProfileParserCollectionOption) (*Processor, error) {
+	pcOptions := append([]common.ProfileParserCollectionOption{common.WithProfileParser(profileFunctions), common.WithProfileErrorMode(errorMode)}, options...)
+	pc, err := common.NewProfileParserCollection(settings, pcOptions...)
 	if err != nil {
 		return nil, err
 	}
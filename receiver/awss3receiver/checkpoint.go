@@ -0,0 +1,77 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package awss3receiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/awss3receiver"
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension/xextension/storage"
+)
+
+const checkpointKey = "replay_checkpoint"
+
+// replayCheckpoint is the progress of a time-based replay.
+type replayCheckpoint struct {
+	// StartTime and EndTime identify the replay the checkpoint belongs to.
+	StartTime time.Time `json:"start_time"`
+	EndTime   time.Time `json:"end_time"`
+	// Partition is the time of the first partition that wasn't completely replayed.
+	Partition time.Time `json:"partition"`
+	// Key is the last object of Partition that was replayed, if any.
+	Key string `json:"key,omitempty"`
+}
+
+// checkpointStore persists the progress of a time-based replay in a storage client.
+type checkpointStore struct {
+	client storage.Client
+}
+
+func newCheckpointStore(ctx context.Context, host component.Host, storageID component.ID, id component.ID, telemetryType string) (*checkpointStore, error) {
+	ext, ok := host.GetExtensions()[storageID]
+	if !ok {
+		return nil, fmt.Errorf("storage extension %q not found", storageID)
+	}
+	storageExt, ok := ext.(storage.Extension)
+	if !ok {
+		return nil, fmt.Errorf("extension %q is not a storage extension", storageID)
+	}
+	client, err := storageExt.GetClient(ctx, component.KindReceiver, id, telemetryType)
+	if err != nil {
+		return nil, err
+	}
+	return &checkpointStore{client: client}, nil
+}
+
+// load returns the checkpoint of the replay between start and end, or nil if there is none.
+// The checkpoint of a replay of another time range is ignored.
+func (s *checkpointStore) load(ctx context.Context, start, end time.Time) (*replayCheckpoint, error) {
+	buf, err := s.client.Get(ctx, checkpointKey)
+	if err != nil || buf == nil {
+		return nil, err
+	}
+	var checkpoint replayCheckpoint
+	if err := json.Unmarshal(buf, &checkpoint); err != nil {
+		return nil, err
+	}
+	if !checkpoint.StartTime.Equal(start) || !checkpoint.EndTime.Equal(end) {
+		return nil, nil
+	}
+	return &checkpoint, nil
+}
+
+func (s *checkpointStore) save(ctx context.Context, checkpoint replayCheckpoint) error {
+	buf, err := json.Marshal(checkpoint)
+	if err != nil {
+		return err
+	}
+	return s.client.Set(ctx, checkpointKey, buf)
+}
+
+func (s *checkpointStore) close(ctx context.Context) error {
+	return s.client.Close(ctx)
+}
@@ -0,0 +1,198 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package awss3receiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/awss3receiver"
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/awss3receiver/internal/metadata"
+)
+
+// fakeS3 is a minimal S3-compatible server supporting ListObjectsV2 and GetObject on a
+// single bucket with path-style addressing.
+type fakeS3 struct {
+	bucket  string
+	objects map[string][]byte
+	// delays slows down the download of some objects.
+	delays map[string]time.Duration
+}
+
+type listBucketResult struct {
+	XMLName     xml.Name `xml:"ListBucketResult"`
+	Name        string   `xml:"Name"`
+	Prefix      string   `xml:"Prefix"`
+	KeyCount    int      `xml:"KeyCount"`
+	MaxKeys     int      `xml:"MaxKeys"`
+	IsTruncated bool     `xml:"IsTruncated"`
+	Contents    []struct {
+		Key  string `xml:"Key"`
+		Size int    `xml:"Size"`
+	} `xml:"Contents"`
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, "/"+f.bucket)
+	if path == "" || path == "/" {
+		f.listObjects(w, r)
+		return
+	}
+	key := strings.TrimPrefix(path, "/")
+	data, ok := f.objects[key]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	time.Sleep(f.delays[key])
+	_, _ = w.Write(data)
+}
+
+func (f *fakeS3) listObjects(w http.ResponseWriter, r *http.Request) {
+	prefix := r.URL.Query().Get("prefix")
+	startAfter := r.URL.Query().Get("start-after")
+	result := listBucketResult{Name: f.bucket, Prefix: prefix, MaxKeys: 1000}
+	keys := make([]string, 0, len(f.objects))
+	for key := range f.objects {
+		if strings.HasPrefix(key, prefix) && key > startAfter {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+	for _, key := range keys {
+		result.Contents = append(result.Contents, struct {
+			Key  string `xml:"Key"`
+			Size int    `xml:"Size"`
+		}{Key: key, Size: len(f.objects[key])})
+	}
+	result.KeyCount = len(keys)
+	w.Header().Set("Content-Type", "application/xml")
+	_ = xml.NewEncoder(w).Encode(result)
+}
+
+func TestTimeBasedReplayResumesFromCheckpoint(t *testing.T) {
+	t.Setenv("AWS_ACCESS_KEY_ID", "test")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "test")
+	t.Setenv("AWS_EC2_METADATA_DISABLED", "true")
+
+	start := time.Date(2024, 1, 31, 15, 0, 0, 0, time.UTC)
+	s3 := &fakeS3{bucket: "bucket", objects: map[string][]byte{}, delays: map[string]time.Duration{}}
+	var keys []string
+	for minute := 0; minute < 3; minute++ {
+		for i := 1; i <= 3; i++ {
+			key := fmt.Sprintf("%s/traces_%d.json", getTimeKeyPartitionMinute(start.Add(time.Duration(minute)*time.Minute)), i)
+			td := ptrace.NewTraces()
+			td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName(key)
+			data, err := (&ptrace.JSONMarshaler{}).MarshalTraces(td)
+			require.NoError(t, err)
+			s3.objects[key] = data
+			keys = append(keys, key)
+		}
+		// the first object of each partition is the slowest to download
+		s3.delays[keys[len(keys)-3]] = 20 * time.Millisecond
+	}
+	server := httptest.NewServer(s3)
+	defer server.Close()
+
+	ext := storagetest.NewFileBackedStorageExtension("test", t.TempDir())
+	host := storagetest.NewStorageHost().WithExtension(ext.ID, ext)
+	cfg := createDefaultConfig().(*Config)
+	cfg.S3Downloader.S3Bucket = "bucket"
+	cfg.S3Downloader.Endpoint = server.URL
+	cfg.S3Downloader.S3ForcePathStyle = true
+	cfg.StartTime = "2024-01-31 15:00"
+	cfg.EndTime = "2024-01-31 15:03"
+	cfg.Replay.MaxParallelDownloads = 3
+	cfg.StorageID = &ext.ID
+	require.NoError(t, cfg.Validate())
+
+	run := func(consume consumer.ConsumeTracesFunc) receiver.Traces {
+		tc, err := consumer.NewTraces(consume)
+		require.NoError(t, err)
+		rcv, err := NewFactory().CreateTraces(context.Background(), receivertest.NewNopSettings(metadata.Type), cfg, tc)
+		require.NoError(t, err)
+		require.NoError(t, rcv.Start(context.Background(), host))
+		return rcv
+	}
+	spanName := func(td ptrace.Traces) string {
+		return td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Name()
+	}
+
+	// the first run fails on the second object of the second partition
+	var mu sync.Mutex
+	var received []string
+	failed := make(chan struct{})
+	rcv := run(func(_ context.Context, td ptrace.Traces) error {
+		mu.Lock()
+		defer mu.Unlock()
+		if spanName(td) == keys[4] {
+			close(failed)
+			return assert.AnError
+		}
+		received = append(received, spanName(td))
+		return nil
+	})
+	select {
+	case <-failed:
+	case <-time.After(10 * time.Second):
+		require.FailNow(t, "the replay did not reach the failing object")
+	}
+	// the checkpoint is persisted when the receiver shuts down
+	require.NoError(t, rcv.Shutdown(context.Background()))
+	assert.Equal(t, keys[:4], received)
+
+	// the second run resumes with the failed object
+	var resumed []string
+	rcv = run(func(_ context.Context, td ptrace.Traces) error {
+		mu.Lock()
+		defer mu.Unlock()
+		resumed = append(resumed, spanName(td))
+		return nil
+	})
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(resumed) == len(keys)-4
+	}, 10*time.Second, 10*time.Millisecond)
+	require.NoError(t, rcv.Shutdown(context.Background()))
+	assert.Equal(t, keys[4:], resumed)
+}
+
+func TestCheckpointStoreIgnoresOtherTimeRange(t *testing.T) {
+	store := &checkpointStore{client: storagetest.NewInMemoryClient(component.KindReceiver, component.NewID(metadata.Type), "traces")}
+	start := time.Date(2024, 1, 31, 15, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+
+	checkpoint, err := store.load(context.Background(), start, end)
+	require.NoError(t, err)
+	assert.Nil(t, checkpoint)
+
+	saved := replayCheckpoint{StartTime: start, EndTime: end, Partition: start.Add(time.Minute), Key: "key"}
+	require.NoError(t, store.save(context.Background(), saved))
+	checkpoint, err = store.load(context.Background(), start, end)
+	require.NoError(t, err)
+	require.NotNil(t, checkpoint)
+	assert.Equal(t, "key", checkpoint.Key)
+	assert.True(t, saved.Partition.Equal(checkpoint.Partition))
+
+	checkpoint, err = store.load(context.Background(), start, end.Add(time.Hour))
+	require.NoError(t, err)
+	assert.Nil(t, checkpoint)
+}
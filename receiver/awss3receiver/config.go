@@ -59,6 +59,18 @@ type Encoding struct {
 	_ struct{}
 }
 
+// ReplayConfig controls how the objects of a time-based replay are read.
+type ReplayConfig struct {
+	// MaxParallelDownloads is the maximum number of objects downloaded at the same time.
+	// Objects are still emitted in order.
+	MaxParallelDownloads int `mapstructure:"max_parallel_downloads"`
+	// MaxObjectsPerSecond limits the rate at which objects are emitted. Zero means no limit.
+	MaxObjectsPerSecond float64 `mapstructure:"max_objects_per_second"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
 // Config defines the configuration for the file receiver.
 type Config struct {
 	S3Downloader  S3DownloaderConfig `mapstructure:"s3downloader"`
@@ -68,6 +80,11 @@ type Config struct {
 	Notifications Notifications      `mapstructure:"notifications"`
 	// SQS configures receiving S3 object change notifications via an SQS queue.
 	SQS *SQSConfig `mapstructure:"sqs"`
+	// Replay configures the time-based replay.
+	Replay ReplayConfig `mapstructure:"replay"`
+	// StorageID is the storage extension used to checkpoint the progress of the time-based
+	// replay, so that it resumes where it stopped after a restart.
+	StorageID *component.ID `mapstructure:"storage"`
 }
 
 const (
@@ -82,6 +99,9 @@ func createDefaultConfig() component.Config {
 			S3Partition:         S3PartitionMinute,
 			EndpointPartitionID: "aws",
 		},
+		Replay: ReplayConfig{
+			MaxParallelDownloads: 1,
+		},
 	}
 }
 
@@ -129,6 +149,16 @@ func (c Config) Validate() error {
 		}
 	}
 
+	if c.Replay.MaxParallelDownloads < 0 {
+		errs = multierr.Append(errs, errors.New("replay.max_parallel_downloads must not be negative"))
+	}
+	if c.Replay.MaxObjectsPerSecond < 0 {
+		errs = multierr.Append(errs, errors.New("replay.max_objects_per_second must not be negative"))
+	}
+	if c.StorageID != nil && hasSQS {
+		errs = multierr.Append(errs, errors.New("storage is only supported with starttime/endtime"))
+	}
+
 	// Validate SQS notifications if configured
 	if c.SQS != nil {
 		if c.SQS.QueueURL == "" {
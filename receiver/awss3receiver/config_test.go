@@ -67,6 +67,7 @@ func TestLoadConfig(t *testing.T) {
 	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
 	require.NoError(t, err)
 	opampExtension := component.NewIDWithName(component.MustNewType("opamp"), "bar")
+	storageExtension := component.NewID(component.MustNewType("file_storage"))
 	tests := []struct {
 		id           component.ID
 		expected     component.Config
@@ -89,6 +90,9 @@ func TestLoadConfig(t *testing.T) {
 					S3Partition:         "minute",
 					EndpointPartitionID: "aws",
 				},
+				Replay: ReplayConfig{
+					MaxParallelDownloads: 1,
+				},
 				StartTime: "2024-01-31 15:00",
 				EndTime:   "2024-02-03",
 			},
@@ -102,6 +106,9 @@ func TestLoadConfig(t *testing.T) {
 					S3Partition:         "minute",
 					EndpointPartitionID: "aws",
 				},
+				Replay: ReplayConfig{
+					MaxParallelDownloads: 1,
+				},
 				StartTime: "2024-01-31 15:00",
 				EndTime:   "2024-02-03",
 				Encodings: []Encoding{
@@ -128,6 +135,9 @@ func TestLoadConfig(t *testing.T) {
 					S3Partition:         "minute",
 					EndpointPartitionID: "aws",
 				},
+				Replay: ReplayConfig{
+					MaxParallelDownloads: 1,
+				},
 				StartTime: "2024-01-31T15:00:00Z",
 				EndTime:   "2024-02-03T00:00:00Z",
 			},
@@ -141,6 +151,9 @@ func TestLoadConfig(t *testing.T) {
 					S3Partition:         "minute",
 					EndpointPartitionID: "aws",
 				},
+				Replay: ReplayConfig{
+					MaxParallelDownloads: 1,
+				},
 				SQS: &SQSConfig{
 					QueueURL: "https://sqs.us-east-1.amazonaws.com/123456789012/test-queue",
 					Region:   "us-east-1",
@@ -148,6 +161,28 @@ func TestLoadConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			id: component.NewIDWithName(metadata.Type, "6"),
+			expected: &Config{
+				S3Downloader: S3DownloaderConfig{
+					Region:              "us-east-1",
+					S3Bucket:            "abucket",
+					S3Partition:         "hour",
+					EndpointPartitionID: "aws",
+				},
+				StartTime: "2024-01-31T15:00:00Z",
+				EndTime:   "2024-02-03T00:00:00Z",
+				Replay: ReplayConfig{
+					MaxParallelDownloads: 8,
+					MaxObjectsPerSecond:  2.5,
+				},
+				StorageID: &storageExtension,
+			},
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "7"),
+			errorMessage: "replay.max_parallel_downloads must not be negative; replay.max_objects_per_second must not be negative; storage is only supported with starttime/endtime",
+		},
 	}
 
 	for _, tt := range tests {
@@ -11,6 +11,7 @@ import (
 	"fmt"
 	"io"
 	"strings"
+	"sync"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
@@ -34,9 +35,13 @@ type receiverProcessor interface {
 }
 
 type awss3Receiver struct {
+	id              component.ID
 	reader          s3Reader
 	logger          *zap.Logger
 	cancel          context.CancelFunc
+	readerDone      sync.WaitGroup
+	storageID       *component.ID
+	checkpoints     *checkpointStore
 	obsrecv         *receiverhelper.ObsReport
 	encodingsConfig []Encoding
 	telemetryType   string
@@ -76,6 +81,7 @@ func newAWSS3Receiver(ctx context.Context, cfg *Config, telemetryType string, se
 	}
 
 	return &awss3Receiver{
+		id:              settings.ID,
 		reader:          reader,
 		telemetryType:   telemetryType,
 		logger:          settings.Logger,
@@ -84,6 +90,7 @@ func newAWSS3Receiver(ctx context.Context, cfg *Config, telemetryType string, se
 		dataProcessor:   processor,
 		encodingsConfig: cfg.Encodings,
 		notifier:        notifier,
+		storageID:       cfg.StorageID,
 	}, nil
 }
 
@@ -100,22 +107,37 @@ func (r *awss3Receiver) Start(ctx context.Context, host component.Host) error {
 		return err
 	}
 
+	if timeBasedReader, ok := r.reader.(*s3TimeBasedReader); ok && r.storageID != nil {
+		r.checkpoints, err = newCheckpointStore(ctx, host, *r.storageID, r.id, r.telemetryType)
+		if err != nil {
+			return err
+		}
+		timeBasedReader.checkpoints = r.checkpoints
+	}
+
 	var cancelCtx context.Context
 	cancelCtx, r.cancel = context.WithCancel(context.Background())
+	r.readerDone.Add(1)
 	go func() {
+		defer r.readerDone.Done()
 		_ = r.reader.readAll(cancelCtx, r.telemetryType, r.receiveBytes)
 	}()
 	return nil
 }
 
 func (r *awss3Receiver) Shutdown(ctx context.Context) error {
+	if r.cancel != nil {
+		r.cancel()
+	}
+	// wait for the reader to stop before releasing what it uses
+	r.readerDone.Wait()
 	if r.notifier != nil {
 		if err := r.notifier.Shutdown(ctx); err != nil {
 			return err
 		}
 	}
-	if r.cancel != nil {
-		r.cancel()
+	if r.checkpoints != nil {
+		return r.checkpoints.close(ctx)
 	}
 	return nil
 }
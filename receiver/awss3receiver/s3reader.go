@@ -11,6 +11,7 @@ import (
 
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"go.uber.org/zap"
+	"golang.org/x/time/rate"
 )
 
 type s3TimeBasedReader struct {
@@ -25,6 +26,13 @@ type s3TimeBasedReader struct {
 	startTime         time.Time
 	endTime           time.Time
 	notifier          statusNotifier
+
+	// maxParallelDownloads bounds the number of objects downloaded ahead of the one being emitted.
+	maxParallelDownloads int
+	// limiter, if set, limits the rate at which objects are emitted.
+	limiter *rate.Limiter
+	// checkpoints, if set, records the progress of the replay.
+	checkpoints *checkpointStore
 }
 
 func newS3TimeBasedReader(ctx context.Context, notifier statusNotifier, logger *zap.Logger, cfg *Config) (*s3TimeBasedReader, error) {
@@ -43,6 +51,10 @@ func newS3TimeBasedReader(ctx context.Context, notifier statusNotifier, logger *
 	if cfg.S3Downloader.S3Partition != S3PartitionHour && cfg.S3Downloader.S3Partition != S3PartitionMinute {
 		return nil, errors.New("s3_partition must be either 'hour' or 'minute'")
 	}
+	var limiter *rate.Limiter
+	if cfg.Replay.MaxObjectsPerSecond > 0 {
+		limiter = rate.NewLimiter(rate.Limit(cfg.Replay.MaxObjectsPerSecond), 1)
+	}
 
 	return &s3TimeBasedReader{
 		logger:            logger,
@@ -55,6 +67,9 @@ func newS3TimeBasedReader(ctx context.Context, notifier statusNotifier, logger *
 		startTime:         startTime,
 		endTime:           endTime,
 		notifier:          notifier,
+
+		maxParallelDownloads: cfg.Replay.MaxParallelDownloads,
+		limiter:              limiter,
 	}, nil
 }
 
@@ -67,7 +82,8 @@ func (s3Reader *s3TimeBasedReader) readAll(ctx context.Context, telemetryType st
 		timeStep = time.Minute
 	}
 	s3Reader.logger.Info("Start reading telemetry", zap.Time("start_time", s3Reader.startTime), zap.Time("end_time", s3Reader.endTime))
-	for currentTime := s3Reader.startTime; currentTime.Before(s3Reader.endTime); currentTime = currentTime.Add(timeStep) {
+	startTime, startAfter := s3Reader.resumePoint(ctx)
+	for currentTime := startTime; currentTime.Before(s3Reader.endTime); currentTime = currentTime.Add(timeStep) {
 		s3Reader.sendStatus(ctx, statusNotification{
 			TelemetryType: telemetryType,
 			IngestStatus:  IngestStatusIngesting,
@@ -90,7 +106,7 @@ func (s3Reader *s3TimeBasedReader) readAll(ctx context.Context, telemetryType st
 			return ctx.Err()
 		default:
 			s3Reader.logger.Info("Reading telemetry", zap.Time("time", currentTime))
-			if err := s3Reader.readTelemetryForTime(ctx, currentTime, telemetryType, dataCallback); err != nil {
+			if err := s3Reader.readTelemetryForTime(ctx, currentTime, telemetryType, startAfter, dataCallback); err != nil {
 				s3Reader.sendStatus(ctx, statusNotification{
 					TelemetryType:  telemetryType,
 					IngestStatus:   IngestStatusFailed,
@@ -102,6 +118,8 @@ func (s3Reader *s3TimeBasedReader) readAll(ctx context.Context, telemetryType st
 				s3Reader.logger.Error("Error reading telemetry", zap.Error(err), zap.Time("time", currentTime))
 				return err
 			}
+			startAfter = ""
+			s3Reader.saveCheckpoint(ctx, currentTime.Add(timeStep), "")
 		}
 	}
 	s3Reader.sendStatus(ctx, statusNotification{
@@ -115,15 +133,101 @@ func (s3Reader *s3TimeBasedReader) readAll(ctx context.Context, telemetryType st
 	return nil
 }
 
-func (s3Reader *s3TimeBasedReader) readTelemetryForTime(ctx context.Context, t time.Time, telemetryType string, dataCallback s3ObjectCallback) error {
+// resumePoint returns the partition to start the replay from and the last object of
+// that partition that was already replayed, according to the checkpoint.
+func (s3Reader *s3TimeBasedReader) resumePoint(ctx context.Context) (time.Time, string) {
+	if s3Reader.checkpoints == nil {
+		return s3Reader.startTime, ""
+	}
+	checkpoint, err := s3Reader.checkpoints.load(ctx, s3Reader.startTime, s3Reader.endTime)
+	if err != nil {
+		s3Reader.logger.Warn("Failed to load replay checkpoint, starting from the beginning", zap.Error(err))
+		return s3Reader.startTime, ""
+	}
+	if checkpoint == nil {
+		return s3Reader.startTime, ""
+	}
+	s3Reader.logger.Info("Resuming reading telemetry", zap.Time("time", checkpoint.Partition), zap.String("key", checkpoint.Key))
+	return checkpoint.Partition, checkpoint.Key
+}
+
+func (s3Reader *s3TimeBasedReader) saveCheckpoint(ctx context.Context, partition time.Time, key string) {
+	if s3Reader.checkpoints == nil {
+		return
+	}
+	err := s3Reader.checkpoints.save(ctx, replayCheckpoint{
+		StartTime: s3Reader.startTime,
+		EndTime:   s3Reader.endTime,
+		Partition: partition,
+		Key:       key,
+	})
+	if err != nil {
+		s3Reader.logger.Warn("Failed to save replay checkpoint", zap.Error(err), zap.Time("time", partition), zap.String("key", key))
+	}
+}
+
+// objectDownload is an object being downloaded ahead of its emission.
+type objectDownload struct {
+	key  string
+	data []byte
+	err  error
+	done chan struct{}
+}
+
+// readTelemetryForTime reads the objects of the partition of the given time, skipping the ones up to
+// startAfter. Objects are downloaded in parallel but passed to the callback in the listing order.
+func (s3Reader *s3TimeBasedReader) readTelemetryForTime(ctx context.Context, t time.Time, telemetryType, startAfter string, dataCallback s3ObjectCallback) error {
 	params := &s3.ListObjectsV2Input{
 		Bucket: &s3Reader.s3Bucket,
 	}
 	prefix := s3Reader.getObjectPrefixForTime(t, telemetryType)
 	params.Prefix = &prefix
+	if startAfter != "" {
+		params.StartAfter = &startAfter
+	}
 	s3Reader.logger.Debug("Finding telemetry with prefix", zap.String("prefix", prefix))
 	p := s3Reader.listObjectsClient.NewListObjectsV2Paginator(params)
 
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	parallelism := max(s3Reader.maxParallelDownloads, 1)
+	// slots holds a token for every object downloaded and not yet emitted.
+	slots := make(chan struct{}, parallelism)
+	downloads := make(chan *objectDownload, parallelism)
+	listErr := make(chan error, 1)
+	go func() {
+		defer close(downloads)
+		listErr <- s3Reader.downloadObjects(ctx, p, prefix, t, slots, downloads)
+	}()
+
+	for download := range downloads {
+		select {
+		case <-download.done:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		if download.err != nil {
+			return download.err
+		}
+		s3Reader.logger.Debug("Retrieved telemetry", zap.String("key", download.key))
+		if s3Reader.limiter != nil {
+			if err := s3Reader.limiter.Wait(ctx); err != nil {
+				return err
+			}
+		}
+		if err := dataCallback(ctx, download.key, download.data); err != nil {
+			return err
+		}
+		s3Reader.saveCheckpoint(ctx, t, download.key)
+		<-slots
+	}
+	return <-listErr
+}
+
+// downloadObjects lists the objects of a partition and starts downloading them as long as
+// slots are available, sending them to downloads in the listing order.
+func (s3Reader *s3TimeBasedReader) downloadObjects(ctx context.Context, p ListObjectsV2Pager, prefix string, t time.Time, slots chan struct{}, downloads chan<- *objectDownload) error {
 	firstPage := true
 	for p.HasMorePages() {
 		page, err := p.NextPage(ctx)
@@ -132,17 +236,20 @@ func (s3Reader *s3TimeBasedReader) readTelemetryForTime(ctx context.Context, t t
 		}
 		if firstPage && len(page.Contents) == 0 {
 			s3Reader.logger.Info("No telemetry found for time", zap.String("prefix", prefix), zap.Time("time", t))
-		} else {
-			for _, obj := range page.Contents {
-				data, err := retrieveS3Object(ctx, s3Reader.getObjectClient, s3Reader.s3Bucket, *obj.Key)
-				if err != nil {
-					return err
-				}
-				s3Reader.logger.Debug("Retrieved telemetry", zap.String("key", *obj.Key))
-				if err := dataCallback(ctx, *obj.Key, data); err != nil {
-					return err
-				}
+		}
+		for _, obj := range page.Contents {
+			select {
+			case slots <- struct{}{}:
+			case <-ctx.Done():
+				return ctx.Err()
 			}
+			download := &objectDownload{key: *obj.Key, done: make(chan struct{})}
+			go func() {
+				defer close(download.done)
+				download.data, download.err = retrieveS3Object(ctx, s3Reader.getObjectClient, s3Reader.s3Bucket, download.key)
+			}()
+			// downloads has room for every slot, this never blocks.
+			downloads <- download
 		}
 		firstPage = false
 	}
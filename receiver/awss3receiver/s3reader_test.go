@@ -9,6 +9,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -17,6 +18,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
 	"go.uber.org/zap"
+	"golang.org/x/time/rate"
 )
 
 var testTime = time.Date(2021, 0o2, 0o1, 17, 32, 0o0, 0o0, time.UTC)
@@ -180,7 +182,7 @@ func Test_readTelemetryForTime(t *testing.T) {
 
 	dataCallbackKeys := make([]string, 0)
 
-	err := reader.readTelemetryForTime(context.Background(), testTime, "traces", func(_ context.Context, key string, data []byte) error {
+	err := reader.readTelemetryForTime(context.Background(), testTime, "traces", "", func(_ context.Context, key string, data []byte) error {
 		t.Helper()
 		require.Equal(t, "this is the body of the object", string(data))
 		dataCallbackKeys = append(dataCallbackKeys, key)
@@ -227,7 +229,7 @@ func Test_readTelemetryForTime_GetObjectError(t *testing.T) {
 		endTime:     testTime.Add(time.Minute),
 	}
 
-	err := reader.readTelemetryForTime(context.Background(), testTime, "traces", func(_ context.Context, _ string, _ []byte) error {
+	err := reader.readTelemetryForTime(context.Background(), testTime, "traces", "", func(_ context.Context, _ string, _ []byte) error {
 		t.Helper()
 		t.Fail()
 		return nil
@@ -262,7 +264,7 @@ func Test_readTelemetryForTime_ListObjectsNoResults(t *testing.T) {
 		endTime:     testTime.Add(time.Minute),
 	}
 
-	err := reader.readTelemetryForTime(context.Background(), testTime, "traces", func(_ context.Context, _ string, _ []byte) error {
+	err := reader.readTelemetryForTime(context.Background(), testTime, "traces", "", func(_ context.Context, _ string, _ []byte) error {
 		t.Helper()
 		t.Fail()
 		return nil
@@ -309,7 +311,7 @@ func Test_readTelemetryForTime_NextPageError(t *testing.T) {
 		endTime:     testTime.Add(time.Minute),
 	}
 
-	err := reader.readTelemetryForTime(context.Background(), testTime, "traces", func(_ context.Context, _ string, _ []byte) error {
+	err := reader.readTelemetryForTime(context.Background(), testTime, "traces", "", func(_ context.Context, _ string, _ []byte) error {
 		t.Helper()
 		t.Fail()
 		return nil
@@ -513,3 +515,71 @@ func Test_readAll_ContextDone(t *testing.T) {
 		},
 	}, notifier.messages)
 }
+
+func Test_readTelemetryForTime_ParallelDownloadsInOrder(t *testing.T) {
+	keys := []string{"traces_1", "traces_2", "traces_3", "traces_4"}
+	contents := make([]types.Object, 0, len(keys))
+	for i := range keys {
+		contents = append(contents, types.Object{Key: &keys[i]})
+	}
+	var inFlight, maxInFlight atomic.Int32
+	reader := s3TimeBasedReader{
+		listObjectsClient: mockListObjectsAPI(func(*s3.ListObjectsV2Input) ListObjectsV2Pager {
+			return &mockListObjectsV2Pager{Pages: []*s3.ListObjectsV2Output{{Contents: contents}}}
+		}),
+		getObjectClient: mockGetObjectAPI(func(_ context.Context, params *s3.GetObjectInput, _ ...func(*s3.Options)) (*s3.GetObjectOutput, error) {
+			n := inFlight.Add(1)
+			defer inFlight.Add(-1)
+			for {
+				current := maxInFlight.Load()
+				if n <= current || maxInFlight.CompareAndSwap(current, n) {
+					break
+				}
+			}
+			// the first objects are the slowest to download
+			if *params.Key == keys[0] {
+				time.Sleep(30 * time.Millisecond)
+			}
+			return &s3.GetObjectOutput{Body: io.NopCloser(bytes.NewReader([]byte(*params.Key)))}, nil
+		}),
+		logger:               zap.NewNop(),
+		s3Bucket:             "bucket",
+		s3Partition:          "minute",
+		startTime:            testTime,
+		endTime:              testTime.Add(time.Minute),
+		maxParallelDownloads: 2,
+		limiter:              rate.NewLimiter(rate.Every(20*time.Millisecond), 1),
+	}
+
+	var received []string
+	start := time.Now()
+	err := reader.readTelemetryForTime(context.Background(), testTime, "traces", "", func(_ context.Context, key string, data []byte) error {
+		require.Equal(t, key, string(data))
+		received = append(received, key)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, keys, received)
+	require.LessOrEqual(t, maxInFlight.Load(), int32(2))
+	// the first object is emitted right away, the others wait for the limiter
+	require.GreaterOrEqual(t, time.Since(start), 3*20*time.Millisecond)
+}
+
+func Test_readTelemetryForTime_StartAfter(t *testing.T) {
+	reader := s3TimeBasedReader{
+		listObjectsClient: mockListObjectsAPI(func(params *s3.ListObjectsV2Input) ListObjectsV2Pager {
+			require.NotNil(t, params.StartAfter)
+			require.Equal(t, "year=2021/month=02/day=01/hour=17/minute=32/traces_1", *params.StartAfter)
+			return &mockListObjectsV2Pager{}
+		}),
+		logger:      zap.NewNop(),
+		s3Bucket:    "bucket",
+		s3Partition: "minute",
+		startTime:   testTime,
+		endTime:     testTime.Add(time.Minute),
+	}
+	err := reader.readTelemetryForTime(context.Background(), testTime, "traces", "year=2021/month=02/day=01/hour=17/minute=32/traces_1", func(context.Context, string, []byte) error {
+		return nil
+	})
+	require.NoError(t, err)
+}
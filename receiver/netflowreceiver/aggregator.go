@@ -0,0 +1,305 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package netflowreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/netflowreceiver"
+
+import (
+	"net/netip"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/netsampler/goflow2/v2/producer"
+	protoproducer "github.com/netsampler/goflow2/v2/producer/proto"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	semconv "go.opentelemetry.io/otel/semconv/v1.27.0"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/netflowreceiver/internal/metadata"
+)
+
+// overflowAttribute marks the series of the flows that exceeded max_series, as done by the OpenTelemetry SDKs
+const overflowAttribute = "otel.metric.overflow"
+
+// dimensionFunc returns the value of a dimension of a flow, either a string or an int64
+// The options of the exporter of the flow are nil when it did not send options data
+type dimensionFunc func(pm *protoproducer.ProtoProducerMessage, exporter *exporterOptions, cfg *AggregationConfig) any
+
+// flowDimensions are the dimensions the metrics can be keyed by, named after the attributes they produce
+var flowDimensions = map[string]dimensionFunc{
+	string(semconv.SourceAddressKey): func(pm *protoproducer.ProtoProducerMessage, _ *exporterOptions, _ *AggregationConfig) any {
+		return addrString(pm.SrcAddr)
+	},
+	"source.subnet": func(pm *protoproducer.ProtoProducerMessage, _ *exporterOptions, cfg *AggregationConfig) any {
+		return subnetString(pm.SrcAddr, cfg)
+	},
+	string(semconv.SourcePortKey): func(pm *protoproducer.ProtoProducerMessage, _ *exporterOptions, _ *AggregationConfig) any {
+		return int64(pm.SrcPort)
+	},
+	"source.as.number": func(pm *protoproducer.ProtoProducerMessage, _ *exporterOptions, _ *AggregationConfig) any {
+		return int64(pm.SrcAs)
+	},
+	string(semconv.DestinationAddressKey): func(pm *protoproducer.ProtoProducerMessage, _ *exporterOptions, _ *AggregationConfig) any {
+		return addrString(pm.DstAddr)
+	},
+	"destination.subnet": func(pm *protoproducer.ProtoProducerMessage, _ *exporterOptions, cfg *AggregationConfig) any {
+		return subnetString(pm.DstAddr, cfg)
+	},
+	string(semconv.DestinationPortKey): func(pm *protoproducer.ProtoProducerMessage, _ *exporterOptions, _ *AggregationConfig) any {
+		return int64(pm.DstPort)
+	},
+	"destination.as.number": func(pm *protoproducer.ProtoProducerMessage, _ *exporterOptions, _ *AggregationConfig) any {
+		return int64(pm.DstAs)
+	},
+	string(semconv.NetworkTransportKey): func(pm *protoproducer.ProtoProducerMessage, _ *exporterOptions, _ *AggregationConfig) any {
+		return getTransportName(pm.Proto)
+	},
+	string(semconv.NetworkTypeKey): func(pm *protoproducer.ProtoProducerMessage, _ *exporterOptions, _ *AggregationConfig) any {
+		return getEtypeName(pm.Etype)
+	},
+	"flow.type": func(pm *protoproducer.ProtoProducerMessage, _ *exporterOptions, _ *AggregationConfig) any {
+		return getFlowTypeName(int32(pm.Type))
+	},
+	"flow.sampler_address": func(pm *protoproducer.ProtoProducerMessage, _ *exporterOptions, _ *AggregationConfig) any {
+		return addrString(pm.SamplerAddress)
+	},
+	"flow.input_interface.name": func(pm *protoproducer.ProtoProducerMessage, exporter *exporterOptions, _ *AggregationConfig) any {
+		return interfaceNameOrIndex(exporter, pm.InIf)
+	},
+	"flow.output_interface.name": func(pm *protoproducer.ProtoProducerMessage, exporter *exporterOptions, _ *AggregationConfig) any {
+		return interfaceNameOrIndex(exporter, pm.OutIf)
+	},
+}
+
+func addrString(b []byte) string {
+	addr, _ := netip.AddrFromSlice(b)
+	return addr.Unmap().String()
+}
+
+// interfaceNameOrIndex returns the name of an interface sent by the exporter, or its index when the name is unknown
+func interfaceNameOrIndex(exporter *exporterOptions, index uint32) string {
+	if name, ok := exporter.interfaceName(index); ok {
+		return name
+	}
+	return strconv.FormatUint(uint64(index), 10)
+}
+
+// subnetString returns the subnet of an address, using the prefix length configured for its IP version
+func subnetString(b []byte, cfg *AggregationConfig) string {
+	addr, _ := netip.AddrFromSlice(b)
+	addr = addr.Unmap()
+	if !addr.IsValid() {
+		return addr.String()
+	}
+	bits := cfg.IPv4PrefixLength
+	if addr.Is6() {
+		bits = cfg.IPv6PrefixLength
+	}
+	prefix, err := addr.Prefix(bits)
+	if err != nil {
+		return addr.String()
+	}
+	return prefix.String()
+}
+
+// flowSeries holds the totals of the flows sharing the same dimension values
+type flowSeries struct {
+	values  []any
+	bytes   int64
+	packets int64
+	flows   int64
+}
+
+// flowAggregator rolls flows up into delta sums, which are emitted every interval
+// It is shared by the workers decoding the flows
+type flowAggregator struct {
+	cfg        *AggregationConfig
+	dimensions []dimensionFunc
+
+	mu    sync.Mutex
+	start time.Time
+	// series contains the series by the key of their dimension values
+	series map[string]*flowSeries
+	// overflow is the series of the flows that exceeded max_series
+	overflow *flowSeries
+	// talkers contains the bytes by source address
+	talkers map[string]int64
+}
+
+func newFlowAggregator(cfg *AggregationConfig, now time.Time) *flowAggregator {
+	dimensions := make([]dimensionFunc, 0, len(cfg.Dimensions))
+	for _, name := range cfg.Dimensions {
+		dimensions = append(dimensions, flowDimensions[name])
+	}
+
+	return &flowAggregator{
+		cfg:        cfg,
+		dimensions: dimensions,
+		start:      now,
+		series:     map[string]*flowSeries{},
+		talkers:    map[string]int64{},
+	}
+}
+
+// add adds the flows of a packet to the current interval
+func (a *flowAggregator) add(flowMessageSet []producer.ProducerMessage, exporter *exporterOptions) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for _, msg := range flowMessageSet {
+		// we know msg is ProtoProducerMessage because that is the parent producer
+		pm, ok := msg.(*protoproducer.ProtoProducerMessage)
+		if !ok {
+			continue
+		}
+		a.addFlow(pm, exporter)
+	}
+}
+
+func (a *flowAggregator) addFlow(pm *protoproducer.ProtoProducerMessage, exporter *exporterOptions) {
+	values := make([]any, len(a.dimensions))
+	var key strings.Builder
+	for i, dimension := range a.dimensions {
+		values[i] = dimension(pm, exporter, a.cfg)
+		switch v := values[i].(type) {
+		case string:
+			key.WriteString(v)
+		case int64:
+			key.WriteString(strconv.FormatInt(v, 10))
+		}
+		key.WriteByte(0)
+	}
+
+	series, ok := a.series[key.String()]
+	if !ok {
+		if len(a.series) < a.cfg.MaxSeries {
+			series = &flowSeries{values: values}
+			a.series[key.String()] = series
+		} else {
+			if a.overflow == nil {
+				a.overflow = &flowSeries{}
+			}
+			series = a.overflow
+		}
+	}
+	series.bytes += int64(pm.Bytes)
+	series.packets += int64(pm.Packets)
+	series.flows++
+
+	if a.cfg.TopTalkers > 0 {
+		// The talkers are bounded like the series, a new source address is not tracked once max_series is reached
+		source := addrString(pm.SrcAddr)
+		if _, ok := a.talkers[source]; ok || len(a.talkers) < a.cfg.MaxSeries {
+			a.talkers[source] += int64(pm.Bytes)
+		}
+	}
+}
+
+// flush returns the metrics of the current interval and starts a new one
+func (a *flowAggregator) flush(now time.Time) pmetric.Metrics {
+	a.mu.Lock()
+	start, series, overflow, talkers := a.start, a.series, a.overflow, a.talkers
+	a.start = now
+	a.series = map[string]*flowSeries{}
+	a.overflow = nil
+	a.talkers = map[string]int64{}
+	a.mu.Unlock()
+
+	metrics := pmetric.NewMetrics()
+	if len(series) == 0 && overflow == nil {
+		return metrics
+	}
+
+	scopeMetrics := metrics.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty()
+	scopeMetrics.Scope().SetName(metadata.ScopeName)
+	scopeMetrics.Scope().Attributes().PutStr("receiver", metadata.Type.String())
+
+	startTimestamp := pcommon.NewTimestampFromTime(start)
+	timestamp := pcommon.NewTimestampFromTime(now)
+	bytes := appendDeltaSum(scopeMetrics.Metrics(), "flow.io.bytes", "The number of bytes of the flows.", "By")
+	packets := appendDeltaSum(scopeMetrics.Metrics(), "flow.io.packets", "The number of packets of the flows.", "{packet}")
+	flows := appendDeltaSum(scopeMetrics.Metrics(), "flow.count", "The number of flows.", "{flow}")
+
+	appendSeries := func(s *flowSeries, isOverflow bool) {
+		for _, dp := range []struct {
+			dataPoints pmetric.NumberDataPointSlice
+			value      int64
+		}{{bytes, s.bytes}, {packets, s.packets}, {flows, s.flows}} {
+			dataPoint := dp.dataPoints.AppendEmpty()
+			dataPoint.SetStartTimestamp(startTimestamp)
+			dataPoint.SetTimestamp(timestamp)
+			dataPoint.SetIntValue(dp.value)
+			if isOverflow {
+				dataPoint.Attributes().PutBool(overflowAttribute, true)
+				continue
+			}
+			for i, value := range s.values {
+				switch v := value.(type) {
+				case string:
+					dataPoint.Attributes().PutStr(a.cfg.Dimensions[i], v)
+				case int64:
+					dataPoint.Attributes().PutInt(a.cfg.Dimensions[i], v)
+				}
+			}
+		}
+	}
+
+	// Series are sorted by key for the metrics to be stable across intervals
+	keys := make([]string, 0, len(series))
+	for key := range series {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		appendSeries(series[key], false)
+	}
+	if overflow != nil {
+		appendSeries(overflow, true)
+	}
+
+	if a.cfg.TopTalkers > 0 && len(talkers) > 0 {
+		appendTopTalkers(scopeMetrics.Metrics(), talkers, a.cfg.TopTalkers, startTimestamp, timestamp)
+	}
+
+	return metrics
+}
+
+// appendTopTalkers adds the metric of the source addresses with the most bytes
+func appendTopTalkers(metrics pmetric.MetricSlice, talkers map[string]int64, n int, startTimestamp, timestamp pcommon.Timestamp) {
+	sources := make([]string, 0, len(talkers))
+	for source := range talkers {
+		sources = append(sources, source)
+	}
+	sort.Slice(sources, func(i, j int) bool {
+		if talkers[sources[i]] != talkers[sources[j]] {
+			return talkers[sources[i]] > talkers[sources[j]]
+		}
+		return sources[i] < sources[j]
+	})
+	if len(sources) > n {
+		sources = sources[:n]
+	}
+
+	dataPoints := appendDeltaSum(metrics, "flow.talker.io.bytes", "The number of bytes sent by the source addresses with the most bytes.", "By")
+	for _, source := range sources {
+		dataPoint := dataPoints.AppendEmpty()
+		dataPoint.SetStartTimestamp(startTimestamp)
+		dataPoint.SetTimestamp(timestamp)
+		dataPoint.SetIntValue(talkers[source])
+		dataPoint.Attributes().PutStr(string(semconv.SourceAddressKey), source)
+	}
+}
+
+// appendDeltaSum adds a monotonic delta sum metric, returning its data points
+func appendDeltaSum(metrics pmetric.MetricSlice, name, description, unit string) pmetric.NumberDataPointSlice {
+	metric := metrics.AppendEmpty()
+	metric.SetName(name)
+	metric.SetDescription(description)
+	metric.SetUnit(unit)
+	sum := metric.SetEmptySum()
+	sum.SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+	sum.SetIsMonotonic(true)
+	return sum.DataPoints()
+}
@@ -0,0 +1,146 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package netflowreceiver
+
+import (
+	"net/netip"
+	"testing"
+	"time"
+
+	flowpb "github.com/netsampler/goflow2/v2/pb"
+	"github.com/netsampler/goflow2/v2/producer"
+	protoproducer "github.com/netsampler/goflow2/v2/producer/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+func newTestFlow(src, dst string, dstPort, proto uint32, bytes, packets uint64) *protoproducer.ProtoProducerMessage {
+	return &protoproducer.ProtoProducerMessage{
+		FlowMessage: flowpb.FlowMessage{
+			Type:    flowpb.FlowMessage_NETFLOW_V9,
+			SrcAddr: netip.MustParseAddr(src).AsSlice(),
+			DstAddr: netip.MustParseAddr(dst).AsSlice(),
+			SrcPort: 40000,
+			DstPort: dstPort,
+			Proto:   proto,
+			Etype:   0x800,
+			Bytes:   bytes,
+			Packets: packets,
+			SrcAs:   64512,
+		},
+	}
+}
+
+func TestFlowAggregator(t *testing.T) {
+	cfg := newDefaultAggregationConfig()
+	cfg.Dimensions = []string{"source.subnet", "destination.port", "network.transport"}
+	cfg.TopTalkers = 1
+
+	start := time.Unix(1736309640, 0)
+	now := start.Add(time.Minute)
+	aggregator := newFlowAggregator(cfg, start)
+	aggregator.add([]producer.ProducerMessage{
+		newTestFlow("10.0.1.1", "192.168.1.1", 443, 6, 1000, 10),
+		newTestFlow("10.0.1.2", "192.168.1.2", 443, 6, 500, 5),
+		newTestFlow("10.0.2.1", "192.168.1.1", 53, 17, 100, 1),
+	}, nil)
+	aggregator.add([]producer.ProducerMessage{
+		newTestFlow("10.0.1.1", "192.168.1.3", 443, 6, 2000, 20),
+	}, nil)
+
+	metrics := aggregator.flush(now)
+	require.Equal(t, 1, metrics.ResourceMetrics().Len())
+	scopeMetrics := metrics.ResourceMetrics().At(0).ScopeMetrics().At(0)
+	assert.Equal(t, "otelcol/netflowreceiver", scopeMetrics.Scope().Name())
+	require.Equal(t, 4, scopeMetrics.Metrics().Len())
+
+	expected := []struct {
+		name   string
+		unit   string
+		values []int64
+	}{
+		{name: "flow.io.bytes", unit: "By", values: []int64{3500, 100}},
+		{name: "flow.io.packets", unit: "{packet}", values: []int64{35, 1}},
+		{name: "flow.count", unit: "{flow}", values: []int64{3, 1}},
+	}
+	for i, e := range expected {
+		metric := scopeMetrics.Metrics().At(i)
+		assert.Equal(t, e.name, metric.Name())
+		assert.Equal(t, e.unit, metric.Unit())
+		assert.Equal(t, pmetric.AggregationTemporalityDelta, metric.Sum().AggregationTemporality())
+		assert.True(t, metric.Sum().IsMonotonic())
+
+		dataPoints := metric.Sum().DataPoints()
+		require.Equal(t, len(e.values), dataPoints.Len())
+		for j, value := range e.values {
+			assert.Equal(t, value, dataPoints.At(j).IntValue())
+			assert.Equal(t, pcommon.NewTimestampFromTime(start), dataPoints.At(j).StartTimestamp())
+			assert.Equal(t, pcommon.NewTimestampFromTime(now), dataPoints.At(j).Timestamp())
+		}
+		assert.Equal(t, map[string]any{
+			"source.subnet":     "10.0.1.0/24",
+			"destination.port":  int64(443),
+			"network.transport": "tcp",
+		}, dataPoints.At(0).Attributes().AsRaw())
+		assert.Equal(t, map[string]any{
+			"source.subnet":     "10.0.2.0/24",
+			"destination.port":  int64(53),
+			"network.transport": "udp",
+		}, dataPoints.At(1).Attributes().AsRaw())
+	}
+
+	talkers := scopeMetrics.Metrics().At(3)
+	assert.Equal(t, "flow.talker.io.bytes", talkers.Name())
+	require.Equal(t, 1, talkers.Sum().DataPoints().Len())
+	assert.Equal(t, int64(3000), talkers.Sum().DataPoints().At(0).IntValue())
+	assert.Equal(t, map[string]any{"source.address": "10.0.1.1"}, talkers.Sum().DataPoints().At(0).Attributes().AsRaw())
+
+	// The next interval starts empty
+	assert.Equal(t, 0, aggregator.flush(now.Add(time.Minute)).DataPointCount())
+	aggregator.add([]producer.ProducerMessage{newTestFlow("10.0.1.1", "192.168.1.1", 443, 6, 1000, 10)}, nil)
+	dataPoint := aggregator.flush(now.Add(2 * time.Minute)).ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints().At(0)
+	assert.Equal(t, pcommon.NewTimestampFromTime(now.Add(time.Minute)), dataPoint.StartTimestamp())
+	assert.Equal(t, int64(1000), dataPoint.IntValue())
+}
+
+func TestFlowAggregatorOverflow(t *testing.T) {
+	cfg := newDefaultAggregationConfig()
+	cfg.Dimensions = []string{"source.address", "source.as.number"}
+	cfg.MaxSeries = 1
+
+	aggregator := newFlowAggregator(cfg, time.Now())
+	aggregator.add([]producer.ProducerMessage{
+		newTestFlow("10.0.1.1", "192.168.1.1", 443, 6, 1000, 10),
+		newTestFlow("10.0.1.2", "192.168.1.1", 443, 6, 500, 5),
+		newTestFlow("10.0.1.3", "192.168.1.1", 443, 6, 100, 1),
+		newTestFlow("10.0.1.1", "192.168.1.1", 443, 6, 1000, 10),
+	}, nil)
+
+	dataPoints := aggregator.flush(time.Now()).ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints()
+	require.Equal(t, 2, dataPoints.Len())
+	assert.Equal(t, int64(2000), dataPoints.At(0).IntValue())
+	assert.Equal(t, map[string]any{"source.address": "10.0.1.1", "source.as.number": int64(64512)}, dataPoints.At(0).Attributes().AsRaw())
+	assert.Equal(t, int64(600), dataPoints.At(1).IntValue())
+	assert.Equal(t, map[string]any{overflowAttribute: true}, dataPoints.At(1).Attributes().AsRaw())
+}
+
+func TestSubnetString(t *testing.T) {
+	cfg := newDefaultAggregationConfig()
+	cfg.IPv6PrefixLength = 48
+
+	assert.Equal(t, "10.0.1.0/24", subnetString(netip.MustParseAddr("10.0.1.12").AsSlice(), cfg))
+	assert.Equal(t, "10.0.1.0/24", subnetString(netip.MustParseAddr("::ffff:10.0.1.12").AsSlice(), cfg))
+	assert.Equal(t, "2001:db8:1::/48", subnetString(netip.MustParseAddr("2001:db8:1:2::1").AsSlice(), cfg))
+	assert.Equal(t, "invalid IP", subnetString(nil, cfg))
+}
+
+func TestInterfaceNameOrIndex(t *testing.T) {
+	exporter := &exporterOptions{interfaces: map[uint32]string{1: "GigabitEthernet0/1"}}
+
+	assert.Equal(t, "GigabitEthernet0/1", interfaceNameOrIndex(exporter, 1))
+	assert.Equal(t, "2", interfaceNameOrIndex(exporter, 2))
+	assert.Equal(t, "1", interfaceNameOrIndex(nil, 1))
+}
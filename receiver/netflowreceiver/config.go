@@ -5,6 +5,10 @@ package netflowreceiver // import "github.com/open-telemetry/opentelemetry-colle
 
 import (
 	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/confmap"
 )
 
 // Config represents the receiver config settings within the collector's config.yaml
@@ -33,6 +37,55 @@ type Config struct {
 
 	// SendRaw determines whether to send raw flow messages instead of parsing them
 	SendRaw bool `mapstructure:"send_raw"`
+
+	// ScaleBySamplingRate multiplies the bytes and packets of sampled flows by their sampling rate,
+	// to estimate the traffic the flows were sampled from
+	ScaleBySamplingRate bool `mapstructure:"scale_by_sampling_rate"`
+
+	// Aggregation rolls the flows up into metrics, emitted by the metrics receiver
+	// When nil, the receiver can only be used in logs pipelines
+	Aggregation *AggregationConfig `mapstructure:"aggregation"`
+}
+
+// AggregationConfig defines how flows are rolled up into metrics
+type AggregationConfig struct {
+	// Interval is the period over which flows are aggregated before the metrics are emitted
+	Interval time.Duration `mapstructure:"interval"`
+
+	// Dimensions are the flow attributes that the metrics are keyed by
+	Dimensions []string `mapstructure:"dimensions"`
+
+	// IPv4PrefixLength is the prefix length of the source.subnet and destination.subnet dimensions for IPv4 addresses
+	IPv4PrefixLength int `mapstructure:"ipv4_prefix_length"`
+
+	// IPv6PrefixLength is the prefix length of the source.subnet and destination.subnet dimensions for IPv6 addresses
+	IPv6PrefixLength int `mapstructure:"ipv6_prefix_length"`
+
+	// TopTalkers is the number of source addresses with the most bytes reported per interval
+	// Top talkers are not reported when set to 0
+	TopTalkers int `mapstructure:"top_talkers"`
+
+	// MaxSeries is the maximum number of series per interval
+	// Flows that would create more series are added to a single overflow series
+	MaxSeries int `mapstructure:"max_series"`
+}
+
+func newDefaultAggregationConfig() *AggregationConfig {
+	return &AggregationConfig{
+		Interval:         defaultAggregationInterval,
+		Dimensions:       []string{"source.subnet", "destination.subnet", "network.transport"},
+		IPv4PrefixLength: defaultIPv4PrefixLength,
+		IPv6PrefixLength: defaultIPv6PrefixLength,
+		MaxSeries:        defaultMaxSeries,
+	}
+}
+
+// Unmarshal a confmap.Conf into the config struct, applying the aggregation defaults when aggregation is configured
+func (cfg *Config) Unmarshal(conf *confmap.Conf) error {
+	if conf.IsSet("aggregation") && cfg.Aggregation == nil {
+		cfg.Aggregation = newDefaultAggregationConfig()
+	}
+	return conf.Unmarshal(cfg)
 }
 
 // Validate checks if the receiver configuration is valid
@@ -66,5 +119,43 @@ func (cfg *Config) Validate() error {
 		return errors.New("port must be greater than 0")
 	}
 
+	if cfg.Aggregation != nil {
+		return validateAggregation(cfg.Aggregation)
+	}
+
+	return nil
+}
+
+// validateAggregation checks if the aggregation configuration is valid
+func validateAggregation(cfg *AggregationConfig) error {
+	if cfg.Interval <= 0 {
+		return errors.New("aggregation interval must be greater than 0")
+	}
+
+	if len(cfg.Dimensions) == 0 {
+		return errors.New("aggregation dimensions must not be empty")
+	}
+	for _, dimension := range cfg.Dimensions {
+		if _, ok := flowDimensions[dimension]; !ok {
+			return fmt.Errorf("aggregation dimension %q is not supported", dimension)
+		}
+	}
+
+	if cfg.IPv4PrefixLength < 0 || cfg.IPv4PrefixLength > 32 {
+		return errors.New("aggregation ipv4_prefix_length must be between 0 and 32")
+	}
+
+	if cfg.IPv6PrefixLength < 0 || cfg.IPv6PrefixLength > 128 {
+		return errors.New("aggregation ipv6_prefix_length must be between 0 and 128")
+	}
+
+	if cfg.TopTalkers < 0 {
+		return errors.New("aggregation top_talkers must not be negative")
+	}
+
+	if cfg.MaxSeries <= 0 {
+		return errors.New("aggregation max_series must be greater than 0")
+	}
+
 	return nil
 }
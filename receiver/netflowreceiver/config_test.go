@@ -6,6 +6,7 @@ package netflowreceiver
 import (
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -71,6 +72,46 @@ func TestLoadConfig(t *testing.T) {
 				SendRaw:   true,
 			},
 		},
+		{
+			id: component.NewIDWithName(metadata.Type, "scale_by_sampling_rate"),
+			expected: &Config{
+				Scheme:              "netflow",
+				Port:                2055,
+				Sockets:             1,
+				Workers:             1,
+				QueueSize:           1000,
+				ScaleBySamplingRate: true,
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "aggregation_defaults"),
+			expected: &Config{
+				Scheme:      "netflow",
+				Port:        2055,
+				Sockets:     1,
+				Workers:     1,
+				QueueSize:   1000,
+				Aggregation: newDefaultAggregationConfig(),
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "aggregation"),
+			expected: &Config{
+				Scheme:    "sflow",
+				Port:      6343,
+				Sockets:   1,
+				Workers:   1,
+				QueueSize: 1000,
+				Aggregation: &AggregationConfig{
+					Interval:         30 * time.Second,
+					Dimensions:       []string{"source.subnet", "destination.port", "network.transport", "source.as.number"},
+					IPv4PrefixLength: 16,
+					IPv6PrefixLength: 48,
+					TopTalkers:       10,
+					MaxSeries:        500,
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -114,6 +155,26 @@ func TestInvalidConfig(t *testing.T) {
 			id:  component.NewIDWithName(metadata.Type, "zero_workers"),
 			err: "workers must be greater than 0",
 		},
+		{
+			id:  component.NewIDWithName(metadata.Type, "invalid_aggregation_interval"),
+			err: "aggregation interval must be greater than 0",
+		},
+		{
+			id:  component.NewIDWithName(metadata.Type, "invalid_aggregation_dimension"),
+			err: `aggregation dimension "source.country" is not supported`,
+		},
+		{
+			id:  component.NewIDWithName(metadata.Type, "invalid_aggregation_prefix_length"),
+			err: "aggregation ipv4_prefix_length must be between 0 and 32",
+		},
+		{
+			id:  component.NewIDWithName(metadata.Type, "invalid_aggregation_top_talkers"),
+			err: "aggregation top_talkers must not be negative",
+		},
+		{
+			id:  component.NewIDWithName(metadata.Type, "invalid_aggregation_max_series"),
+			err: "aggregation max_series must be greater than 0",
+		},
 	}
 
 	for _, tt := range tests {
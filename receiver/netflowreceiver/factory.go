@@ -5,11 +5,14 @@ package netflowreceiver // import "github.com/open-telemetry/opentelemetry-colle
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/receiver"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/sharedcomponent"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/netflowreceiver/internal/metadata"
 )
 
@@ -20,6 +23,13 @@ const (
 	// that for a full queue of 1000 messages, the size in memory will be 9MB.
 	// Source: https://github.com/netsampler/goflow2/blob/v2.2.1/README.md#security-notes-and-assumptions
 	defaultQueueSize = 1_000
+
+	defaultAggregationInterval = time.Minute
+	defaultIPv4PrefixLength    = 24
+	defaultIPv6PrefixLength    = 64
+	// Series are held in memory for the aggregation interval, this bounds the memory used
+	// when flows have high cardinality dimensions such as ports
+	defaultMaxSeries = 10_000
 )
 
 // NewFactory creates a factory for netflow receiver.
@@ -27,9 +37,12 @@ func NewFactory() receiver.Factory {
 	return receiver.NewFactory(
 		metadata.Type,
 		createDefaultConfig,
-		receiver.WithLogs(createLogsReceiver, metadata.LogsStability))
+		receiver.WithLogs(createLogsReceiver, metadata.LogsStability),
+		receiver.WithMetrics(createMetricsReceiver, metadata.MetricsStability))
 }
 
+var errMetricsNoAggregation = errors.New("aggregation must be configured to use the netflow receiver in a metrics pipeline")
+
 // Config defines configuration for netflow receiver.
 // By default we listen for netflow traffic on port 2055
 func createDefaultConfig() component.Config {
@@ -46,12 +59,42 @@ func createDefaultConfig() component.Config {
 // We also create the UDP receiver, which is the piece of software that actually listens
 // for incoming netflow traffic on an UDP port.
 func createLogsReceiver(_ context.Context, params receiver.Settings, cfg component.Config, consumer consumer.Logs) (receiver.Logs, error) {
-	conf := *(cfg.(*Config))
+	r, err := getOrAddReceiver(params, cfg.(*Config))
+	if err != nil {
+		return nil, err
+	}
+	r.Unwrap().(*netflowReceiver).logConsumer = consumer
+	return r, nil
+}
 
-	nr, err := newNetflowLogsReceiver(params, conf, consumer)
+// createMetricsReceiver creates a netflow receiver aggregating the flows into metrics.
+func createMetricsReceiver(_ context.Context, params receiver.Settings, cfg component.Config, consumer consumer.Metrics) (receiver.Metrics, error) {
+	nfCfg := cfg.(*Config)
+	if nfCfg.Aggregation == nil {
+		return nil, errMetricsNoAggregation
+	}
+
+	r, err := getOrAddReceiver(params, nfCfg)
 	if err != nil {
 		return nil, err
 	}
+	r.Unwrap().(*netflowReceiver).metricConsumer = consumer
+	return r, nil
+}
 
-	return nr, nil
+// getOrAddReceiver returns the receiver shared by the logs and metrics receivers of the config,
+// so that a single UDP listener is used when the receiver is in both pipelines
+func getOrAddReceiver(params receiver.Settings, cfg *Config) (*sharedcomponent.SharedComponent, error) {
+	var err error
+	r := receivers.GetOrAdd(cfg, func() component.Component {
+		var nr *netflowReceiver
+		nr, err = newNetflowReceiver(params, *cfg)
+		return nr
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
 }
+
+var receivers = sharedcomponent.NewSharedComponents()
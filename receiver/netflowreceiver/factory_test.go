@@ -30,3 +30,20 @@ func TestCreateReceiver(t *testing.T) {
 	assert.NoError(t, err, "receiver creation failed")
 	assert.NotNil(t, receiver, "receiver creation failed")
 }
+
+func TestCreateMetricsReceiver(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	set := receivertest.NewNopSettings(metadata.Type)
+
+	_, err := factory.CreateMetrics(context.Background(), set, cfg, consumertest.NewNop())
+	assert.ErrorIs(t, err, errMetricsNoAggregation)
+
+	cfg.Aggregation = newDefaultAggregationConfig()
+	metricsReceiver, err := factory.CreateMetrics(context.Background(), set, cfg, consumertest.NewNop())
+	assert.NoError(t, err, "receiver creation failed")
+	logsReceiver, err := factory.CreateLogs(context.Background(), set, cfg, consumertest.NewNop())
+	assert.NoError(t, err, "receiver creation failed")
+	assert.Same(t, metricsReceiver, logsReceiver, "logs and metrics receivers should share the UDP listener")
+	assert.NoError(t, metricsReceiver.Shutdown(context.Background()))
+}
@@ -0,0 +1,284 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package netflowreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/netflowreceiver"
+
+import (
+	"math"
+	"net/netip"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/netsampler/goflow2/v2/decoders/netflow"
+	"github.com/netsampler/goflow2/v2/producer"
+	protoproducer "github.com/netsampler/goflow2/v2/producer/proto"
+)
+
+// nfv9ScopeInterface is the NetFlow v9 scope type of options data describing an interface
+// https://www.cisco.com/en/US/technologies/tk648/tk362/technologies_white_paper09186a00800a3db9.html
+const nfv9ScopeInterface = 2
+
+const (
+	// maxExporters is the maximum number of exporters whose options data is cached
+	maxExporters = 4096
+	// exporterTTL is the time after which the options of an exporter that did not send any packet are evicted
+	exporterTTL = time.Hour
+)
+
+// The information elements holding a sampling rate, by order of preference
+// They have the same numbers in NetFlow v9 and IPFIX
+var samplingRateFields = []uint16{
+	netflow.IPFIX_FIELD_samplingPacketInterval,
+	netflow.IPFIX_FIELD_samplerRandomInterval,
+	netflow.IPFIX_FIELD_samplingInterval,
+}
+
+// The information elements identifying the sampler of a flow
+var samplerIDFields = []uint16{
+	netflow.IPFIX_FIELD_selectorId,
+	netflow.IPFIX_FIELD_samplerId,
+}
+
+// exporterKey identifies an exporter, as a device can export several observation domains
+type exporterKey struct {
+	address             netip.Addr
+	observationDomainID uint32
+}
+
+// exporterOptions holds the options data sent by an exporter, which describe its interfaces and samplers
+type exporterOptions struct {
+	// lastSeen is the time of the last packet of the exporter, in Unix nanoseconds
+	lastSeen atomic.Int64
+
+	mu sync.RWMutex
+	// interfaces contains the interface names by index
+	interfaces map[uint32]string
+	// samplers contains the sampling rates by sampler ID
+	samplers map[uint64]uint64
+	// samplingRate is the sampling rate of options data without a sampler ID
+	samplingRate uint64
+}
+
+// interfaceName returns the name of an interface, if the exporter sent it
+func (e *exporterOptions) interfaceName(index uint32) (string, bool) {
+	if e == nil {
+		return "", false
+	}
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	name, ok := e.interfaces[index]
+	return name, ok
+}
+
+// samplerRate returns the sampling rate of a sampler, or the rate of the exporter when the sampler is unknown
+func (e *exporterOptions) samplerRate(samplerID uint64, hasSamplerID bool) uint64 {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	if hasSamplerID {
+		if rate, ok := e.samplers[samplerID]; ok {
+			return rate
+		}
+	}
+	return e.samplingRate
+}
+
+// update records the interfaces and samplers of an options data record
+func (e *exporterOptions) update(record netflow.OptionsDataRecord, isNFv9 bool) {
+	fields := make([]netflow.DataField, 0, len(record.ScopesValues)+len(record.OptionsValues))
+	fields = append(fields, record.ScopesValues...)
+	fields = append(fields, record.OptionsValues...)
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	name, hasName := fieldString(fields, netflow.IPFIX_FIELD_interfaceName)
+	if !hasName {
+		name, hasName = fieldString(fields, netflow.IPFIX_FIELD_interfaceDescription)
+	}
+	if hasName {
+		index, hasIndex := fieldUint(fields, netflow.IPFIX_FIELD_ingressInterface, netflow.IPFIX_FIELD_egressInterface)
+		if isNFv9 {
+			// In NetFlow v9 the interface is usually the scope of the record, whose types are not information elements
+			if scopeIndex, ok := fieldUint(record.ScopesValues, nfv9ScopeInterface); ok {
+				index, hasIndex = scopeIndex, true
+			}
+		}
+		if hasIndex {
+			if e.interfaces == nil {
+				e.interfaces = map[uint32]string{}
+			}
+			e.interfaces[uint32(index)] = name
+		}
+	}
+
+	if rate, ok := fieldUint(fields, samplingRateFields...); ok && rate > 0 {
+		if samplerID, ok := fieldUint(fields, samplerIDFields...); ok {
+			if e.samplers == nil {
+				e.samplers = map[uint64]uint64{}
+			}
+			e.samplers[samplerID] = rate
+		} else {
+			e.samplingRate = rate
+		}
+	}
+}
+
+// optionsCache caches the options data of the exporters, which are sent periodically and apart from the flows
+// It is shared by the workers decoding the flows. Exporters that stopped sending packets are evicted, and the
+// least recently seen exporter is evicted when the cache is full.
+type optionsCache struct {
+	mu        sync.RWMutex
+	exporters map[exporterKey]*exporterOptions
+	now       func() time.Time
+}
+
+func newOptionsCache() *optionsCache {
+	return &optionsCache{exporters: map[exporterKey]*exporterOptions{}, now: time.Now}
+}
+
+// exporter returns the options of an exporter, creating them if needed
+func (c *optionsCache) exporter(key exporterKey) *exporterOptions {
+	now := c.now().UnixNano()
+
+	c.mu.RLock()
+	e, ok := c.exporters[key]
+	c.mu.RUnlock()
+	if ok {
+		e.lastSeen.Store(now)
+		return e
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok = c.exporters[key]; !ok {
+		c.evict(now)
+		e = &exporterOptions{}
+		c.exporters[key] = e
+	}
+	e.lastSeen.Store(now)
+	return e
+}
+
+// evict removes the expired exporters, and the least recently seen one if the cache is still full
+// It must be called with the write lock held
+func (c *optionsCache) evict(now int64) {
+	var oldestKey exporterKey
+	oldest := int64(math.MaxInt64)
+	for key, e := range c.exporters {
+		lastSeen := e.lastSeen.Load()
+		if now-lastSeen > int64(exporterTTL) {
+			delete(c.exporters, key)
+			continue
+		}
+		if lastSeen < oldest {
+			oldestKey, oldest = key, lastSeen
+		}
+	}
+	if len(c.exporters) >= maxExporters {
+		delete(c.exporters, oldestKey)
+	}
+}
+
+// enrich records the options data of a NetFlow v9 or IPFIX packet, and sets the sampling rate of its flows
+// from the sampler they reference. The options of the exporter of the packet are returned, they are nil
+// for the other protocols which do not have options data. sFlow is left as is: the proto producer already
+// sets the sampling rate of every sample, and sFlow does not export the names of the interfaces.
+func (c *optionsCache) enrich(msg any, args *producer.ProduceArgs, flowMessageSet []producer.ProducerMessage) *exporterOptions {
+	var dataFlowSets []netflow.DataFlowSet
+	var optionsDataFlowSets []netflow.OptionsDataFlowSet
+	var observationDomainID uint32
+	isNFv9 := false
+
+	switch packet := msg.(type) {
+	case *netflow.NFv9Packet:
+		dataFlowSets, _, _, optionsDataFlowSets = protoproducer.SplitNetFlowSets(*packet)
+		observationDomainID = packet.SourceId
+		isNFv9 = true
+	case *netflow.IPFIXPacket:
+		dataFlowSets, _, _, optionsDataFlowSets = protoproducer.SplitIPFIXSets(*packet)
+		observationDomainID = packet.ObservationDomainId
+	default:
+		return nil
+	}
+
+	e := c.exporter(exporterKey{address: args.SamplerAddress.Unmap(), observationDomainID: observationDomainID})
+	for _, flowSet := range optionsDataFlowSets {
+		for _, record := range flowSet.Records {
+			e.update(record, isNFv9)
+		}
+	}
+
+	// The proto producer creates a message for every data record, in order
+	var records []netflow.DataRecord
+	for _, flowSet := range dataFlowSets {
+		records = append(records, flowSet.Records...)
+	}
+	if len(records) != len(flowMessageSet) {
+		return e
+	}
+
+	for i, msg := range flowMessageSet {
+		pm, ok := msg.(*protoproducer.ProtoProducerMessage)
+		if !ok {
+			continue
+		}
+		// A sampling rate in the flow takes precedence over the one of its sampler
+		if rate, ok := fieldUint(records[i].Values, samplingRateFields...); ok && rate > 0 {
+			pm.SamplingRate = rate
+			continue
+		}
+		samplerID, hasSamplerID := fieldUint(records[i].Values, samplerIDFields...)
+		if rate := e.samplerRate(samplerID, hasSamplerID); rate > 0 {
+			pm.SamplingRate = rate
+		}
+	}
+
+	return e
+}
+
+// scaleBySamplingRate multiplies the bytes and packets of a sampled flow by its sampling rate
+func scaleBySamplingRate(pm *protoproducer.ProtoProducerMessage) {
+	if pm.SamplingRate > 1 {
+		pm.Bytes *= pm.SamplingRate
+		pm.Packets *= pm.SamplingRate
+	}
+}
+
+// fieldUint returns the value of the first of the information elements found, decoded as an unsigned integer
+func fieldUint(fields []netflow.DataField, types ...uint16) (uint64, bool) {
+	for _, t := range types {
+		for _, field := range fields {
+			if field.PenProvided || field.Type != t {
+				continue
+			}
+			b, ok := field.Value.([]byte)
+			if !ok || len(b) == 0 || len(b) > 8 {
+				continue
+			}
+			var v uint64
+			for _, octet := range b {
+				v = v<<8 | uint64(octet)
+			}
+			return v, true
+		}
+	}
+	return 0, false
+}
+
+// fieldString returns the value of an information element decoded as a string, without its padding
+func fieldString(fields []netflow.DataField, t uint16) (string, bool) {
+	for _, field := range fields {
+		if field.PenProvided || field.Type != t {
+			continue
+		}
+		b, ok := field.Value.([]byte)
+		if !ok {
+			continue
+		}
+		s := strings.TrimRight(string(b), "\x00 ")
+		return s, s != ""
+	}
+	return "", false
+}
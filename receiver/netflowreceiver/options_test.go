@@ -0,0 +1,209 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package netflowreceiver
+
+import (
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/netsampler/goflow2/v2/decoders/netflow"
+	"github.com/netsampler/goflow2/v2/decoders/sflow"
+	"github.com/netsampler/goflow2/v2/producer"
+	protoproducer "github.com/netsampler/goflow2/v2/producer/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newOptionsDataFlowSet(records ...netflow.OptionsDataRecord) netflow.OptionsDataFlowSet {
+	return netflow.OptionsDataFlowSet{
+		FlowSetHeader: netflow.FlowSetHeader{Id: 257},
+		Records:       records,
+	}
+}
+
+func newDataField(t uint16, value []byte) netflow.DataField {
+	return netflow.DataField{Type: t, Value: value}
+}
+
+func TestOptionsCacheNFv9(t *testing.T) {
+	packet := &netflow.NFv9Packet{
+		Version:  9,
+		SourceId: 256,
+		FlowSets: []any{
+			newOptionsDataFlowSet(
+				// Interface table, scoped by interface
+				netflow.OptionsDataRecord{
+					ScopesValues:  []netflow.DataField{newDataField(nfv9ScopeInterface, []byte{0, 0, 0, 3})},
+					OptionsValues: []netflow.DataField{newDataField(netflow.NFV9_FIELD_IF_NAME, []byte("Gi0/3\x00\x00\x00"))},
+				},
+				// Interface table, scoped by system
+				netflow.OptionsDataRecord{
+					ScopesValues: []netflow.DataField{newDataField(1, []byte{0, 0, 0, 0})},
+					OptionsValues: []netflow.DataField{
+						newDataField(netflow.NFV9_FIELD_INPUT_SNMP, []byte{0, 4}),
+						newDataField(netflow.NFV9_FIELD_IF_DESC, []byte("uplink")),
+					},
+				},
+				// Samplers
+				netflow.OptionsDataRecord{
+					ScopesValues: []netflow.DataField{newDataField(1, []byte{0, 0, 0, 0})},
+					OptionsValues: []netflow.DataField{
+						newDataField(netflow.NFV9_FIELD_FLOW_SAMPLER_ID, []byte{1}),
+						newDataField(netflow.NFV9_FIELD_FLOW_SAMPLER_RANDOM_INTERVAL, []byte{0, 0, 0, 100}),
+					},
+				},
+				netflow.OptionsDataRecord{
+					ScopesValues: []netflow.DataField{newDataField(1, []byte{0, 0, 0, 0})},
+					OptionsValues: []netflow.DataField{
+						newDataField(netflow.NFV9_FIELD_FLOW_SAMPLER_ID, []byte{2}),
+						newDataField(netflow.NFV9_FIELD_FLOW_SAMPLER_RANDOM_INTERVAL, []byte{0, 0, 3, 232}),
+					},
+				},
+			),
+			netflow.DataFlowSet{
+				FlowSetHeader: netflow.FlowSetHeader{Id: 260},
+				Records: []netflow.DataRecord{
+					{Values: []netflow.DataField{newDataField(netflow.NFV9_FIELD_FLOW_SAMPLER_ID, []byte{1})}},
+					{Values: []netflow.DataField{newDataField(netflow.NFV9_FIELD_FLOW_SAMPLER_ID, []byte{2})}},
+					{Values: []netflow.DataField{newDataField(netflow.NFV9_FIELD_FLOW_SAMPLER_ID, []byte{9})}},
+					{Values: []netflow.DataField{newDataField(netflow.NFV9_FIELD_SAMPLING_INTERVAL, []byte{0, 0, 0, 10})}},
+				},
+			},
+		},
+	}
+	flowMessageSet := []producer.ProducerMessage{
+		&protoproducer.ProtoProducerMessage{},
+		&protoproducer.ProtoProducerMessage{},
+		&protoproducer.ProtoProducerMessage{},
+		&protoproducer.ProtoProducerMessage{},
+	}
+
+	cache := newOptionsCache()
+	args := &producer.ProduceArgs{SamplerAddress: netip.MustParseAddr("192.168.1.100")}
+	exporter := cache.enrich(packet, args, flowMessageSet)
+	require.NotNil(t, exporter)
+
+	name, ok := exporter.interfaceName(3)
+	assert.True(t, ok)
+	assert.Equal(t, "Gi0/3", name)
+	name, ok = exporter.interfaceName(4)
+	assert.True(t, ok)
+	assert.Equal(t, "uplink", name)
+	_, ok = exporter.interfaceName(5)
+	assert.False(t, ok)
+
+	// The rates of the samplers referenced by the flows, unless the flow has its own rate
+	expectedRates := []uint64{100, 1000, 0, 10}
+	for i, msg := range flowMessageSet {
+		assert.Equal(t, expectedRates[i], msg.(*protoproducer.ProtoProducerMessage).SamplingRate)
+	}
+
+	// The options are cached for the next packets of the exporter, but not for other observation domains
+	assert.Same(t, exporter, cache.enrich(&netflow.NFv9Packet{SourceId: 256}, args, nil))
+	assert.NotSame(t, exporter, cache.enrich(&netflow.NFv9Packet{SourceId: 257}, args, nil))
+}
+
+func TestOptionsCacheIPFIX(t *testing.T) {
+	packet := &netflow.IPFIXPacket{
+		Version:             10,
+		ObservationDomainId: 1,
+		FlowSets: []any{
+			newOptionsDataFlowSet(
+				netflow.OptionsDataRecord{
+					ScopesValues:  []netflow.DataField{newDataField(netflow.IPFIX_FIELD_ingressInterface, []byte{0, 0, 0, 7})},
+					OptionsValues: []netflow.DataField{newDataField(netflow.IPFIX_FIELD_interfaceName, []byte("xe-0/0/7"))},
+				},
+				// Sampling rate of the exporter, without a selector
+				netflow.OptionsDataRecord{
+					ScopesValues:  []netflow.DataField{newDataField(netflow.IPFIX_FIELD_meteringProcessId, []byte{0, 0, 0, 1})},
+					OptionsValues: []netflow.DataField{newDataField(netflow.IPFIX_FIELD_samplingPacketInterval, []byte{0, 0, 4, 0})},
+				},
+			),
+			netflow.DataFlowSet{
+				FlowSetHeader: netflow.FlowSetHeader{Id: 256},
+				Records:       []netflow.DataRecord{{Values: []netflow.DataField{newDataField(netflow.IPFIX_FIELD_ingressInterface, []byte{0, 0, 0, 7})}}},
+			},
+		},
+	}
+	flowMessageSet := []producer.ProducerMessage{&protoproducer.ProtoProducerMessage{}}
+
+	exporter := newOptionsCache().enrich(packet, &producer.ProduceArgs{}, flowMessageSet)
+	name, ok := exporter.interfaceName(7)
+	assert.True(t, ok)
+	assert.Equal(t, "xe-0/0/7", name)
+	assert.Equal(t, uint64(1024), flowMessageSet[0].(*protoproducer.ProtoProducerMessage).SamplingRate)
+}
+
+func TestOptionsCacheSFlow(t *testing.T) {
+	assert.Nil(t, newOptionsCache().enrich(&sflow.Packet{}, &producer.ProduceArgs{}, nil))
+}
+
+func TestScaleBySamplingRate(t *testing.T) {
+	pm := &protoproducer.ProtoProducerMessage{}
+	pm.Bytes, pm.Packets, pm.SamplingRate = 1500, 1, 1000
+	scaleBySamplingRate(pm)
+	assert.Equal(t, uint64(1_500_000), pm.Bytes)
+	assert.Equal(t, uint64(1000), pm.Packets)
+
+	pm = &protoproducer.ProtoProducerMessage{}
+	pm.Bytes, pm.Packets = 1500, 1
+	scaleBySamplingRate(pm)
+	assert.Equal(t, uint64(1500), pm.Bytes)
+	assert.Equal(t, uint64(1), pm.Packets)
+}
+
+func TestFieldValues(t *testing.T) {
+	fields := []netflow.DataField{
+		{PenProvided: true, Pen: 9, Type: 82, Value: []byte("enterprise")},
+		newDataField(82, []byte("eth0 \x00")),
+		newDataField(34, []byte{1, 0}),
+		newDataField(50, []byte{1, 2, 3, 4, 5, 6, 7, 8, 9}),
+	}
+
+	v, ok := fieldUint(fields, 50, 34)
+	assert.True(t, ok, "values longer than 8 bytes are skipped")
+	assert.Equal(t, uint64(256), v)
+	_, ok = fieldUint(fields, 305)
+	assert.False(t, ok)
+
+	s, ok := fieldString(fields, 82)
+	assert.True(t, ok)
+	assert.Equal(t, "eth0", s)
+	_, ok = fieldString(fields, 83)
+	assert.False(t, ok)
+}
+
+func TestOptionsCacheEviction(t *testing.T) {
+	now := time.Unix(0, 0)
+	cache := newOptionsCache()
+	cache.now = func() time.Time { return now }
+
+	key := func(i int) exporterKey {
+		return exporterKey{address: netip.MustParseAddr("192.168.1.100"), observationDomainID: uint32(i)}
+	}
+
+	// Exporters that did not send any packet within the TTL are evicted when another exporter is added
+	expired := cache.exporter(key(0))
+	now = now.Add(exporterTTL / 2)
+	seen := cache.exporter(key(1))
+	now = now.Add(exporterTTL/2 + time.Second)
+	assert.Same(t, seen, cache.exporter(key(1)))
+	cache.exporter(key(2))
+	assert.Len(t, cache.exporters, 2)
+	assert.NotSame(t, expired, cache.exporter(key(0)))
+
+	// The least recently seen exporter is evicted when the cache is full
+	cache = newOptionsCache()
+	cache.now = func() time.Time { return now }
+	for i := range maxExporters {
+		cache.exporter(key(i))
+		now = now.Add(time.Millisecond)
+	}
+	first := cache.exporter(key(0))
+	cache.exporter(key(maxExporters))
+	assert.Len(t, cache.exporters, maxExporters)
+	assert.Same(t, first, cache.exporters[key(0)])
+	assert.NotContains(t, cache.exporters, key(1))
+}
@@ -213,7 +213,8 @@ func getFlowTypeName(flowType int32) string {
 }
 
 // addMessageAttributes parses the message attributes and adds them to the log record
-func addMessageAttributes(m producer.ProducerMessage, r *plog.LogRecord) error {
+// The interfaces are named with the options of the exporter of the flow, if any
+func addMessageAttributes(m producer.ProducerMessage, exporter *exporterOptions, r *plog.LogRecord) error {
 	// we know msg is ProtoProducerMessage because that is the parent producer
 	pm, ok := m.(*protoproducer.ProtoProducerMessage)
 	if !ok {
@@ -254,5 +255,19 @@ func addMessageAttributes(m producer.ProducerMessage, r *plog.LogRecord) error {
 	r.Attributes().PutStr("flow.sampler_address", samplerAddr.String())
 	r.Attributes().PutInt("flow.tcp_flags", int64(pm.TcpFlags))
 
+	// Interfaces are only set when the flow has them, and only named when the exporter sent their names in options data
+	if pm.InIf != 0 {
+		r.Attributes().PutInt("flow.input_interface.index", int64(pm.InIf))
+		if name, ok := exporter.interfaceName(pm.InIf); ok {
+			r.Attributes().PutStr("flow.input_interface.name", name)
+		}
+	}
+	if pm.OutIf != 0 {
+		r.Attributes().PutInt("flow.output_interface.index", int64(pm.OutIf))
+		if name, ok := exporter.interfaceName(pm.OutIf); ok {
+			r.Attributes().PutStr("flow.output_interface.name", name)
+		}
+	}
+
 	return nil
 }
@@ -58,11 +58,14 @@ func TestConvertToOtel(t *testing.T) {
 			SequenceNum:     1,
 			SamplingRate:    1,
 			TcpFlags:        1,
+			InIf:            1,
+			OutIf:           2,
 		},
 	}
+	exporter := &exporterOptions{interfaces: map[uint32]string{1: "GigabitEthernet0/1"}}
 
 	record := plog.NewLogRecord()
-	err := addMessageAttributes(pm, &record)
+	err := addMessageAttributes(pm, exporter, &record)
 	if err != nil {
 		t.Errorf("TestConvertToOtel() error = %v", err)
 		return
@@ -88,6 +91,9 @@ func TestConvertToOtel(t *testing.T) {
 	expectedAttributes.PutInt("flow.sampling_rate", 1)
 	expectedAttributes.PutStr("flow.sampler_address", "192.168.1.100")
 	expectedAttributes.PutInt("flow.tcp_flags", 1)
+	expectedAttributes.PutInt("flow.input_interface.index", 1)
+	expectedAttributes.PutStr("flow.input_interface.name", "GigabitEthernet0/1")
+	expectedAttributes.PutInt("flow.output_interface.index", 2)
 
 	assert.Equal(t, expectedAttributes, record.Attributes())
 }
@@ -96,7 +102,7 @@ func TestEmptyConvertToOtel(t *testing.T) {
 	pm := &protoproducer.ProtoProducerMessage{}
 
 	record := plog.NewLogRecord()
-	err := addMessageAttributes(pm, &record)
+	err := addMessageAttributes(pm, nil, &record)
 	if err != nil {
 		t.Errorf("TestConvertToOtel() error = %v", err)
 		return
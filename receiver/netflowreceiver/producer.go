@@ -8,6 +8,7 @@ import (
 	"fmt"
 
 	"github.com/netsampler/goflow2/v2/producer"
+	protoproducer "github.com/netsampler/goflow2/v2/producer/proto"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.uber.org/zap"
@@ -16,14 +17,18 @@ import (
 )
 
 // otelLogsProducerWrapper is a wrapper around a producer.ProducerInterface that sends the messages to a log consumer
+// and adds them to the flow aggregator, when there is one
 type otelLogsProducerWrapper struct {
-	wrapped     producer.ProducerInterface
-	logConsumer consumer.Logs
-	logger      *zap.Logger
-	sendRaw     bool
+	wrapped             producer.ProducerInterface
+	logConsumer         consumer.Logs
+	aggregator          *flowAggregator
+	options             *optionsCache
+	logger              *zap.Logger
+	sendRaw             bool
+	scaleBySamplingRate bool
 }
 
-// Produce converts the message into a list log records and sends them to log consumer
+// Produce converts the message into a list log records and sends them to log consumer, and aggregates them into metrics
 func (o *otelLogsProducerWrapper) Produce(msg any, args *producer.ProduceArgs) ([]producer.ProducerMessage, error) {
 	defer func() {
 		if pErr := recover(); pErr != nil {
@@ -39,6 +44,25 @@ func (o *otelLogsProducerWrapper) Produce(msg any, args *producer.ProduceArgs) (
 		return flowMessageSet, err
 	}
 
+	// Name the interfaces and apply the sampling rates with the options data sent by the exporter
+	exporter := o.options.enrich(msg, args, flowMessageSet)
+	if o.scaleBySamplingRate {
+		for _, msg := range flowMessageSet {
+			if pm, ok := msg.(*protoproducer.ProtoProducerMessage); ok {
+				scaleBySamplingRate(pm)
+			}
+		}
+	}
+
+	if o.aggregator != nil {
+		o.aggregator.add(flowMessageSet, exporter)
+	}
+
+	// The receiver is only used in metrics pipelines
+	if o.logConsumer == nil {
+		return flowMessageSet, nil
+	}
+
 	// Create the otel log structure to hold our messages
 	log := plog.NewLogs()
 	scopeLog := log.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty()
@@ -53,7 +77,7 @@ func (o *otelLogsProducerWrapper) Produce(msg any, args *producer.ProduceArgs) (
 			logRecord.Body().SetStr(fmt.Sprintf("%+v", msg))
 		} else {
 			// Parse the message and add the attributes to the log record
-			err = addMessageAttributes(msg, &logRecord)
+			err = addMessageAttributes(msg, exporter, &logRecord)
 			if err != nil {
 				o.logger.Error("error adding message attributes", zap.Error(err))
 			}
@@ -80,11 +104,14 @@ func (o *otelLogsProducerWrapper) Commit(flowMessageSet []producer.ProducerMessa
 	o.wrapped.Commit(flowMessageSet)
 }
 
-func newOtelLogsProducer(wrapped producer.ProducerInterface, logConsumer consumer.Logs, logger *zap.Logger, sendRaw bool) producer.ProducerInterface {
+func newOtelLogsProducer(wrapped producer.ProducerInterface, logConsumer consumer.Logs, aggregator *flowAggregator, logger *zap.Logger, cfg *Config) producer.ProducerInterface {
 	return &otelLogsProducerWrapper{
-		wrapped:     wrapped,
-		logConsumer: logConsumer,
-		logger:      logger,
-		sendRaw:     sendRaw,
+		wrapped:             wrapped,
+		logConsumer:         logConsumer,
+		aggregator:          aggregator,
+		options:             newOptionsCache(),
+		logger:              logger,
+		sendRaw:             cfg.SendRaw,
+		scaleBySamplingRate: cfg.ScaleBySamplingRate,
 	}
 }
@@ -57,7 +57,7 @@ func TestProduce(t *testing.T) {
 	protoProducer, err := protoproducer.CreateProtoProducer(cfgm, protoproducer.CreateSamplingSystem)
 	require.NoError(t, err)
 
-	otelLogsProducer := newOtelLogsProducer(protoProducer, consumertest.NewNop(), zap.NewNop(), false)
+	otelLogsProducer := newOtelLogsProducer(protoProducer, consumertest.NewNop(), nil, zap.NewNop(), &Config{})
 	messages, err := otelLogsProducer.Produce(message, &producer.ProduceArgs{})
 	require.NoError(t, err)
 	require.NotNil(t, messages)
@@ -130,7 +130,7 @@ func TestProduceRaw(t *testing.T) {
 	require.NoError(t, err)
 
 	sink := &consumertest.LogsSink{}
-	otelLogsProducer := newOtelLogsProducer(protoProducer, sink, zap.NewNop(), true)
+	otelLogsProducer := newOtelLogsProducer(protoProducer, sink, nil, zap.NewNop(), &Config{SendRaw: true})
 
 	messages, err := otelLogsProducer.Produce(message, &producer.ProduceArgs{})
 	require.NoError(t, err)
@@ -170,7 +170,7 @@ func TestProducerPanic(t *testing.T) {
 	mockConsumer := consumertest.NewNop()
 
 	// Wrap a panicProducer (instead of ProtoProducer) in the otelLogsProducerWrapper
-	wrapper := newOtelLogsProducer(&panicProducer{}, mockConsumer, logger, false)
+	wrapper := newOtelLogsProducer(&panicProducer{}, mockConsumer, nil, logger, &Config{})
 
 	// Call Produce which should recover from panic
 	messages, err := wrapper.Produce(nil, &producer.ProduceArgs{
@@ -186,3 +186,77 @@ func TestProducerPanic(t *testing.T) {
 	assert.Equal(t, "unexpected error processing the message", log.Message)
 	assert.Equal(t, "producer panic!", log.ContextMap()["error"])
 }
+
+func TestProduceOptionsEnrichment(t *testing.T) {
+	message := &netflow.NFv9Packet{
+		Version:  9,
+		Count:    2,
+		SourceId: 256,
+		FlowSets: []any{
+			netflow.OptionsDataFlowSet{
+				FlowSetHeader: netflow.FlowSetHeader{
+					Id: 257,
+				},
+				Records: []netflow.OptionsDataRecord{
+					{
+						ScopesValues: []netflow.DataField{
+							{Type: nfv9ScopeInterface, Value: []uint8{0x00, 0x00, 0x00, 0x03}},
+						},
+						OptionsValues: []netflow.DataField{
+							{Type: netflow.NFV9_FIELD_IF_NAME, Value: []uint8("Gi0/3")},
+						},
+					},
+					{
+						ScopesValues: []netflow.DataField{
+							{Type: 1, Value: []uint8{0x00, 0x00, 0x00, 0x00}},
+						},
+						OptionsValues: []netflow.DataField{
+							{Type: netflow.NFV9_FIELD_FLOW_SAMPLER_ID, Value: []uint8{0x01}},
+							{Type: netflow.NFV9_FIELD_FLOW_SAMPLER_RANDOM_INTERVAL, Value: []uint8{0x00, 0x00, 0x03, 0xe8}},
+						},
+					},
+				},
+			},
+			netflow.DataFlowSet{
+				FlowSetHeader: netflow.FlowSetHeader{
+					Id: 260,
+				},
+				Records: []netflow.DataRecord{
+					{
+						Values: []netflow.DataField{
+							{Type: netflow.NFV9_FIELD_IN_BYTES, Value: []uint8{0x00, 0x00, 0x05, 0xdc}},
+							{Type: netflow.NFV9_FIELD_IN_PKTS, Value: []uint8{0x00, 0x00, 0x00, 0x01}},
+							{Type: netflow.NFV9_FIELD_INPUT_SNMP, Value: []uint8{0x00, 0x03}},
+							{Type: netflow.NFV9_FIELD_FLOW_SAMPLER_ID, Value: []uint8{0x01}},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	cfgProducer := &protoproducer.ProducerConfig{}
+	cfgm, err := cfgProducer.Compile()
+	require.NoError(t, err)
+
+	protoProducer, err := protoproducer.CreateProtoProducer(cfgm, protoproducer.CreateSamplingSystem)
+	require.NoError(t, err)
+
+	sink := &consumertest.LogsSink{}
+	otelLogsProducer := newOtelLogsProducer(protoProducer, sink, nil, zap.NewNop(), &Config{ScaleBySamplingRate: true})
+
+	messages, err := otelLogsProducer.Produce(message, &producer.ProduceArgs{
+		SamplerAddress: netip.MustParseAddr("192.168.1.100"),
+	})
+	require.NoError(t, err)
+	require.Len(t, messages, 1)
+
+	logs := sink.AllLogs()
+	require.Len(t, logs, 1)
+	attributes := logs[0].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes().AsRaw()
+	assert.Equal(t, int64(1000), attributes["flow.sampling_rate"])
+	assert.Equal(t, int64(1_500_000), attributes["flow.io.bytes"])
+	assert.Equal(t, int64(1000), attributes["flow.io.packets"])
+	assert.Equal(t, int64(3), attributes["flow.input_interface.index"])
+	assert.Equal(t, "Gi0/3", attributes["flow.input_interface.name"])
+}
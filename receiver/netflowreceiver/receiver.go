@@ -8,12 +8,15 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"sync"
+	"time"
 
 	"github.com/netsampler/goflow2/v2/decoders/netflow"
 	protoproducer "github.com/netsampler/goflow2/v2/producer/proto"
 	"github.com/netsampler/goflow2/v2/utils"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/receiver"
 	"go.uber.org/zap"
 )
@@ -29,13 +32,20 @@ func (d dropHandler) Dropped(msg utils.Message) {
 }
 
 type netflowReceiver struct {
-	config      Config
-	logger      *zap.Logger
-	udpReceiver *utils.UDPReceiver
-	logConsumer consumer.Logs
+	config         Config
+	logger         *zap.Logger
+	udpReceiver    *utils.UDPReceiver
+	logConsumer    consumer.Logs
+	metricConsumer consumer.Metrics
+
+	// aggregator rolls the flows up into metrics, it is only set when the receiver is used in a metrics pipeline
+	aggregator *flowAggregator
+	stopFlush  chan struct{}
+	flushWG    sync.WaitGroup
 }
 
-func newNetflowLogsReceiver(params receiver.Settings, cfg Config, consumer consumer.Logs) (receiver.Logs, error) {
+// newNetflowReceiver creates a receiver shared by the logs and metrics pipelines, the consumers are set by the factory
+func newNetflowReceiver(params receiver.Settings, cfg Config) (*netflowReceiver, error) {
 	// UDP receiver configuration
 	udpCfg := &utils.UDPReceiverConfig{
 		Sockets:   cfg.Sockets,
@@ -54,7 +64,6 @@ func newNetflowLogsReceiver(params receiver.Settings, cfg Config, consumer consu
 	nr := &netflowReceiver{
 		logger:      params.Logger,
 		config:      cfg,
-		logConsumer: consumer,
 		udpReceiver: udpReceiver,
 	}
 
@@ -62,6 +71,10 @@ func newNetflowLogsReceiver(params receiver.Settings, cfg Config, consumer consu
 }
 
 func (nr *netflowReceiver) Start(_ context.Context, _ component.Host) error {
+	if nr.metricConsumer != nil {
+		nr.aggregator = newFlowAggregator(nr.config.Aggregation, time.Now())
+	}
+
 	// The function that will decode packets
 	decodeFunc, err := nr.buildDecodeFunc()
 	if err != nil {
@@ -76,6 +89,12 @@ func (nr *netflowReceiver) Start(_ context.Context, _ component.Host) error {
 	// This runs until the receiver is stoppped, consuming from an error channel
 	go nr.handleErrors()
 
+	if nr.aggregator != nil {
+		nr.stopFlush = make(chan struct{})
+		nr.flushWG.Add(1)
+		go nr.flushMetrics()
+	}
+
 	return nil
 }
 
@@ -87,6 +106,10 @@ func (nr *netflowReceiver) Shutdown(context.Context) error {
 	if err != nil {
 		nr.logger.Warn("Error stopping UDP receiver", zap.Error(err))
 	}
+	if nr.stopFlush != nil {
+		close(nr.stopFlush)
+		nr.flushWG.Wait()
+	}
 	return nil
 }
 
@@ -106,9 +129,9 @@ func (nr *netflowReceiver) buildDecodeFunc() (utils.DecoderFunc, error) {
 		return nil, err
 	}
 
-	// the otel log producer converts those messages into OpenTelemetry logs
+	// the otel log producer converts those messages into OpenTelemetry logs, and aggregates them into metrics
 	// it is a wrapper around the protobuf producer
-	otelLogsProducer := newOtelLogsProducer(protoProducer, nr.logConsumer, nr.logger, nr.config.SendRaw)
+	otelLogsProducer := newOtelLogsProducer(protoProducer, nr.logConsumer, nr.aggregator, nr.logger, &nr.config)
 
 	cfgPipe := &utils.PipeConfig{
 		Producer: otelLogsProducer,
@@ -149,3 +172,31 @@ func (nr *netflowReceiver) handleErrors() {
 		}
 	}
 }
+
+// flushMetrics sends the aggregated flows to the metrics consumer every interval, until the receiver is stopped
+func (nr *netflowReceiver) flushMetrics() {
+	defer nr.flushWG.Done()
+
+	ticker := time.NewTicker(nr.config.Aggregation.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			nr.consumeMetrics(nr.aggregator.flush(time.Now()))
+		case <-nr.stopFlush:
+			// Send the flows of the last, partial interval
+			nr.consumeMetrics(nr.aggregator.flush(time.Now()))
+			return
+		}
+	}
+}
+
+func (nr *netflowReceiver) consumeMetrics(metrics pmetric.Metrics) {
+	if metrics.DataPointCount() == 0 {
+		return
+	}
+	if err := nr.metricConsumer.ConsumeMetrics(context.Background(), metrics); err != nil {
+		nr.logger.Error("error sending the aggregated flow metrics", zap.Error(err))
+	}
+}
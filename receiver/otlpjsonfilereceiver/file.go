@@ -5,6 +5,7 @@ package otlpjsonfilereceiver // import "github.com/open-telemetry/opentelemetry-
 
 import (
 	"context"
+	"errors"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
@@ -42,6 +43,22 @@ type Config struct {
 	fileconsumer.Config `mapstructure:",squash"`
 	StorageID           *component.ID `mapstructure:"storage"`
 	ReplayFile          bool          `mapstructure:"replay_file"`
+	// WAL replays the write-ahead log written by the file exporter instead of reading JSON files.
+	WAL *WALConfig `mapstructure:"wal"`
+}
+
+// Validate checks the receiver configuration is valid
+func (c *Config) Validate() error {
+	if c.WAL == nil {
+		return nil
+	}
+	if c.WAL.Directory == "" {
+		return errors.New("wal directory must be non-empty")
+	}
+	if c.WAL.PollInterval < 0 {
+		return errors.New("wal poll_interval must not be negative")
+	}
+	return nil
 }
 
 func createDefaultConfig() component.Config {
@@ -79,6 +96,9 @@ func createLogsReceiver(_ context.Context, settings receiver.Settings, configura
 		return nil, err
 	}
 	cfg := configuration.(*Config)
+	if cfg.WAL != nil {
+		return newWALReceiver(settings, cfg, walSignalLogs, walLogsConsumer(obsrecv, logs)), nil
+	}
 	opts := make([]fileconsumer.Option, 0)
 	if cfg.ReplayFile {
 		opts = append(opts, fileconsumer.WithNoTracking())
@@ -129,6 +149,9 @@ func createMetricsReceiver(_ context.Context, settings receiver.Settings, config
 		return nil, err
 	}
 	cfg := configuration.(*Config)
+	if cfg.WAL != nil {
+		return newWALReceiver(settings, cfg, walSignalMetrics, walMetricsConsumer(obsrecv, metrics)), nil
+	}
 	opts := make([]fileconsumer.Option, 0)
 	if cfg.ReplayFile {
 		opts = append(opts, fileconsumer.WithNoTracking())
@@ -178,6 +201,9 @@ func createTracesReceiver(_ context.Context, settings receiver.Settings, configu
 		return nil, err
 	}
 	cfg := configuration.(*Config)
+	if cfg.WAL != nil {
+		return newWALReceiver(settings, cfg, walSignalTraces, walTracesConsumer(obsrecv, traces)), nil
+	}
 	opts := make([]fileconsumer.Option, 0)
 	if cfg.ReplayFile {
 		opts = append(opts, fileconsumer.WithNoTracking())
@@ -219,6 +245,9 @@ func createTracesReceiver(_ context.Context, settings receiver.Settings, configu
 func createProfilesReceiver(_ context.Context, settings receiver.Settings, configuration component.Config, profiles xconsumer.Profiles) (xreceiver.Profiles, error) {
 	profilesUnmarshaler := &pprofile.JSONUnmarshaler{}
 	cfg := configuration.(*Config)
+	if cfg.WAL != nil {
+		return newWALReceiver(settings, cfg, walSignalProfiles, walProfilesConsumer(profiles)), nil
+	}
 	opts := make([]fileconsumer.Option, 0)
 	if cfg.ReplayFile {
 		opts = append(opts, fileconsumer.WithNoTracking())
@@ -0,0 +1,380 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlpjsonfilereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/otlpjsonfilereceiver"
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/xconsumer"
+	"go.opentelemetry.io/collector/extension/xextension/storage"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/receiver/receiverhelper"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/otlpjsonfilereceiver/internal/metadata"
+)
+
+const (
+	walSignalTraces   = "traces"
+	walSignalMetrics  = "metrics"
+	walSignalLogs     = "logs"
+	walSignalProfiles = "profiles"
+
+	// walSegmentSuffix and walCheckpointSuffix are the extensions of the files written
+	// by the file exporter, see its documentation for the format of the write-ahead log.
+	walSegmentSuffix    = ".wal"
+	walCheckpointSuffix = ".checkpoint"
+	walCheckpointSize   = 16
+	walRecordHeaderSize = 4
+
+	// walPositionKey is the storage key of the position up to which the WAL was replayed.
+	walPositionKey = "position"
+
+	defaultWALPollInterval = time.Second
+)
+
+// WALConfig defines how to replay the write-ahead log written by the file exporter.
+type WALConfig struct {
+	// Directory is the directory of the write-ahead log, the `path` of the file exporter.
+	Directory string `mapstructure:"directory"`
+
+	// PollInterval is the interval between checks for new data. It defaults to 1s.
+	PollInterval time.Duration `mapstructure:"poll_interval"`
+
+	// DeleteConsumed removes the segments once they have been entirely replayed.
+	DeleteConsumed bool `mapstructure:"delete_consumed"`
+}
+
+// walConsumeFunc consumes a single record of the WAL. Records that can't be decoded are
+// skipped, while other errors cause the record to be replayed again on the next poll.
+type walConsumeFunc func(ctx context.Context, buf []byte) error
+
+// walReceiver replays the write-ahead log of a signal, persisting its position in a
+// storage extension so that the replay resumes where it stopped after a restart.
+type walReceiver struct {
+	cfg       WALConfig
+	signal    string
+	id        component.ID
+	storageID *component.ID
+	logger    *zap.Logger
+	consume   walConsumeFunc
+
+	client storage.Client
+	seq    uint64
+	offset int64
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func newWALReceiver(settings receiver.Settings, cfg *Config, signal string, consume walConsumeFunc) *walReceiver {
+	walCfg := *cfg.WAL
+	if walCfg.PollInterval == 0 {
+		walCfg.PollInterval = defaultWALPollInterval
+	}
+	return &walReceiver{
+		cfg:       walCfg,
+		signal:    signal,
+		id:        settings.ID,
+		storageID: cfg.StorageID,
+		logger:    settings.Logger,
+		consume:   consume,
+	}
+}
+
+func (r *walReceiver) Start(ctx context.Context, host component.Host) error {
+	client, err := getWALStorageClient(ctx, host, r.storageID, r.id, r.signal)
+	if err != nil {
+		return err
+	}
+	r.client = client
+
+	position, err := client.Get(ctx, walPositionKey)
+	if err != nil {
+		return fmt.Errorf("failed to read the WAL position: %w", err)
+	}
+	if len(position) == 16 {
+		r.seq = binary.BigEndian.Uint64(position[:8])
+		r.offset = int64(binary.BigEndian.Uint64(position[8:]))
+	}
+
+	pollCtx, cancel := context.WithCancel(context.Background())
+	r.cancel = cancel
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		ticker := time.NewTicker(r.cfg.PollInterval)
+		defer ticker.Stop()
+		for {
+			r.poll(pollCtx)
+			select {
+			case <-ticker.C:
+			case <-pollCtx.Done():
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+func (r *walReceiver) Shutdown(ctx context.Context) error {
+	if r.cancel != nil {
+		r.cancel()
+	}
+	r.wg.Wait()
+	if r.client == nil {
+		return nil
+	}
+	return r.client.Close(ctx)
+}
+
+// getWALStorageClient returns a client named after the signal, as each signal is replayed
+// by its own receiver.
+func getWALStorageClient(ctx context.Context, host component.Host, storageID *component.ID, componentID component.ID, signal string) (storage.Client, error) {
+	if storageID == nil {
+		return storage.NewNopClient(), nil
+	}
+
+	ext, ok := host.GetExtensions()[*storageID]
+	if !ok {
+		return nil, fmt.Errorf("storage extension '%s' not found", storageID)
+	}
+
+	storageExt, ok := ext.(storage.Extension)
+	if !ok {
+		return nil, fmt.Errorf("non-storage extension '%s' found", storageID)
+	}
+
+	return storageExt.GetClient(ctx, component.KindReceiver, componentID, signal)
+}
+
+// poll replays the records written since the last poll, segment after segment.
+func (r *walReceiver) poll(ctx context.Context) {
+	lastSeq, lastOffset, err := r.readCheckpoint()
+	if err != nil {
+		if !os.IsNotExist(err) {
+			r.logger.Error("Failed to read the WAL checkpoint", zap.Error(err))
+		}
+		return
+	}
+
+	for ctx.Err() == nil {
+		if r.seq > lastSeq {
+			r.logger.Warn("The WAL position is ahead of the checkpoint, replaying the WAL from its beginning",
+				zap.Uint64("segment", r.seq), zap.Uint64("checkpoint", lastSeq))
+			r.seq, r.offset = 0, 0
+		}
+		if !r.findSegment(lastSeq) {
+			return
+		}
+
+		limit := int64(-1)
+		if r.seq == lastSeq {
+			limit = int64(lastOffset)
+		}
+		if err := r.replaySegment(ctx, limit); err != nil {
+			r.logger.Error("Failed to replay the WAL", zap.Uint64("segment", r.seq), zap.Error(err))
+			return
+		}
+		if r.seq == lastSeq {
+			return
+		}
+
+		// the segment is complete, move on to the next one
+		if r.cfg.DeleteConsumed {
+			if err := os.Remove(r.segmentPath(r.seq)); err != nil && !os.IsNotExist(err) {
+				r.logger.Warn("Failed to delete a replayed WAL segment", zap.Uint64("segment", r.seq), zap.Error(err))
+			}
+		}
+		r.seq++
+		r.offset = 0
+		r.savePosition(ctx)
+	}
+}
+
+// findSegment moves the position to the first segment at or after the current one,
+// which may have been removed in the meantime. Segments after the one referenced by the
+// checkpoint are not ready yet. It returns false if there is no segment to replay.
+func (r *walReceiver) findSegment(lastSeq uint64) bool {
+	seqs, err := walSegments(r.cfg.Directory, r.signal)
+	if err != nil {
+		r.logger.Error("Failed to list the WAL segments", zap.Error(err))
+		return false
+	}
+	for _, seq := range seqs {
+		if seq < r.seq {
+			continue
+		}
+		if seq > lastSeq {
+			return false
+		}
+		if seq != r.seq {
+			if r.seq != 0 {
+				r.logger.Warn("WAL segments were removed before being replayed",
+					zap.Uint64("from", r.seq), zap.Uint64("to", seq-1))
+			}
+			r.seq, r.offset = seq, 0
+		}
+		return true
+	}
+	return false
+}
+
+// replaySegment consumes the records of the current segment from the current offset, up
+// to limit or to the end of the segment if limit is negative.
+func (r *walReceiver) replaySegment(ctx context.Context, limit int64) error {
+	f, err := os.Open(r.segmentPath(r.seq))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if _, err = f.Seek(r.offset, io.SeekStart); err != nil {
+		return err
+	}
+
+	reader := bufio.NewReader(f)
+	var header [walRecordHeaderSize]byte
+	for ctx.Err() == nil && (limit < 0 || r.offset < limit) {
+		if _, err := io.ReadFull(reader, header[:]); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			r.logger.Warn("Skipping the truncated end of a WAL segment", zap.Uint64("segment", r.seq), zap.Error(err))
+			return nil
+		}
+		buf := make([]byte, binary.BigEndian.Uint32(header[:]))
+		if _, err := io.ReadFull(reader, buf); err != nil {
+			r.logger.Warn("Skipping the truncated end of a WAL segment", zap.Uint64("segment", r.seq), zap.Error(err))
+			return nil
+		}
+
+		if err := r.consume(ctx, buf); err != nil && !consumererror.IsPermanent(err) {
+			return err
+		}
+		r.offset += walRecordHeaderSize + int64(len(buf))
+		r.savePosition(ctx)
+	}
+	return nil
+}
+
+func (r *walReceiver) savePosition(ctx context.Context) {
+	var position [16]byte
+	binary.BigEndian.PutUint64(position[:8], r.seq)
+	binary.BigEndian.PutUint64(position[8:], uint64(r.offset))
+	if err := r.client.Set(ctx, walPositionKey, position[:]); err != nil {
+		r.logger.Error("Failed to save the WAL position", zap.Error(err))
+	}
+}
+
+func (r *walReceiver) readCheckpoint() (uint64, uint64, error) {
+	data, err := os.ReadFile(filepath.Join(r.cfg.Directory, r.signal+walCheckpointSuffix))
+	if err != nil {
+		return 0, 0, err
+	}
+	if len(data) != walCheckpointSize {
+		return 0, 0, fmt.Errorf("unexpected checkpoint length %d", len(data))
+	}
+	return binary.BigEndian.Uint64(data[:8]), binary.BigEndian.Uint64(data[8:]), nil
+}
+
+func (r *walReceiver) segmentPath(seq uint64) string {
+	return filepath.Join(r.cfg.Directory, fmt.Sprintf("%s-%020d%s", r.signal, seq, walSegmentSuffix))
+}
+
+// walSegments returns the sequence numbers of the existing segments of a signal, in order.
+func walSegments(dir, signal string) ([]uint64, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var seqs []uint64
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, signal+"-") || !strings.HasSuffix(name, walSegmentSuffix) {
+			continue
+		}
+		seq, err := strconv.ParseUint(strings.TrimSuffix(strings.TrimPrefix(name, signal+"-"), walSegmentSuffix), 10, 64)
+		if err != nil {
+			continue
+		}
+		seqs = append(seqs, seq)
+	}
+	sort.Slice(seqs, func(i, j int) bool { return seqs[i] < seqs[j] })
+	return seqs, nil
+}
+
+func walTracesConsumer(obsrecv *receiverhelper.ObsReport, traces consumer.Traces) walConsumeFunc {
+	unmarshaler := &ptrace.ProtoUnmarshaler{}
+	return func(ctx context.Context, buf []byte) error {
+		ctx = obsrecv.StartTracesOp(ctx)
+		t, err := unmarshaler.UnmarshalTraces(buf)
+		if err != nil {
+			obsrecv.EndTracesOp(ctx, metadata.Type.String(), 0, err)
+			return nil
+		}
+		err = traces.ConsumeTraces(ctx, t)
+		obsrecv.EndTracesOp(ctx, metadata.Type.String(), t.SpanCount(), err)
+		return err
+	}
+}
+
+func walMetricsConsumer(obsrecv *receiverhelper.ObsReport, metrics consumer.Metrics) walConsumeFunc {
+	unmarshaler := &pmetric.ProtoUnmarshaler{}
+	return func(ctx context.Context, buf []byte) error {
+		ctx = obsrecv.StartMetricsOp(ctx)
+		m, err := unmarshaler.UnmarshalMetrics(buf)
+		if err != nil {
+			obsrecv.EndMetricsOp(ctx, metadata.Type.String(), 0, err)
+			return nil
+		}
+		err = metrics.ConsumeMetrics(ctx, m)
+		obsrecv.EndMetricsOp(ctx, metadata.Type.String(), m.MetricCount(), err)
+		return err
+	}
+}
+
+func walLogsConsumer(obsrecv *receiverhelper.ObsReport, logs consumer.Logs) walConsumeFunc {
+	unmarshaler := &plog.ProtoUnmarshaler{}
+	return func(ctx context.Context, buf []byte) error {
+		ctx = obsrecv.StartLogsOp(ctx)
+		l, err := unmarshaler.UnmarshalLogs(buf)
+		if err != nil {
+			obsrecv.EndLogsOp(ctx, metadata.Type.String(), 0, err)
+			return nil
+		}
+		err = logs.ConsumeLogs(ctx, l)
+		obsrecv.EndLogsOp(ctx, metadata.Type.String(), l.LogRecordCount(), err)
+		return err
+	}
+}
+
+func walProfilesConsumer(profiles xconsumer.Profiles) walConsumeFunc {
+	unmarshaler := &pprofile.ProtoUnmarshaler{}
+	return func(ctx context.Context, buf []byte) error {
+		p, err := unmarshaler.UnmarshalProfiles(buf)
+		if err != nil {
+			return nil
+		}
+		return profiles.ConsumeProfiles(ctx, p)
+	}
+}
@@ -0,0 +1,143 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlpjsonfilereceiver
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pdata/testdata"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/otlpjsonfilereceiver/internal/metadata"
+)
+
+// testWAL writes a WAL the way the file exporter does.
+type testWAL struct {
+	t      *testing.T
+	dir    string
+	signal string
+}
+
+func (w testWAL) appendRecords(seq uint64, records ...[]byte) {
+	f, err := os.OpenFile(filepath.Join(w.dir, fmt.Sprintf("%s-%020d.wal", w.signal, seq)), os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o600)
+	require.NoError(w.t, err)
+	defer f.Close()
+	for _, record := range records {
+		header := binary.BigEndian.AppendUint32(nil, uint32(len(record)))
+		_, err = f.Write(append(header, record...))
+		require.NoError(w.t, err)
+	}
+}
+
+func (w testWAL) checkpoint(seq, offset uint64) {
+	data := binary.BigEndian.AppendUint64(nil, seq)
+	data = binary.BigEndian.AppendUint64(data, offset)
+	require.NoError(w.t, os.WriteFile(filepath.Join(w.dir, w.signal+".checkpoint"), data, 0o600))
+}
+
+func marshalTestTraces(t *testing.T, spanCount int) []byte {
+	buf, err := (&ptrace.ProtoMarshaler{}).MarshalTraces(testdata.GenerateTraces(spanCount))
+	require.NoError(t, err)
+	return buf
+}
+
+func TestWALTracesReceiver(t *testing.T) {
+	dir := t.TempDir()
+	wal := testWAL{t: t, dir: dir, signal: walSignalTraces}
+	first, second, third := marshalTestTraces(t, 1), marshalTestTraces(t, 2), marshalTestTraces(t, 3)
+	wal.appendRecords(1, first)
+	// the second record of the last segment is not synced yet
+	wal.appendRecords(2, second, third)
+	wal.checkpoint(2, uint64(walRecordHeaderSize+len(second)))
+
+	ext := storagetest.NewFileBackedStorageExtension("test", t.TempDir())
+	host := storagetest.NewStorageHost().WithExtension(ext.ID, ext)
+	cfg := createDefaultConfig().(*Config)
+	cfg.StorageID = &ext.ID
+	cfg.WAL = &WALConfig{Directory: dir, PollInterval: 10 * time.Millisecond, DeleteConsumed: true}
+	require.NoError(t, cfg.Validate())
+
+	start := func(sink *consumertest.TracesSink) receiver.Traces {
+		rcv, err := NewFactory().CreateTraces(context.Background(), receivertest.NewNopSettings(metadata.Type), cfg, sink)
+		require.NoError(t, err)
+		require.NoError(t, rcv.Start(context.Background(), host))
+		return rcv
+	}
+
+	sink := new(consumertest.TracesSink)
+	rcv := start(sink)
+	require.Eventually(t, func() bool { return sink.SpanCount() == 3 }, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, rcv.Shutdown(context.Background()))
+
+	_, err := os.Stat(filepath.Join(dir, fmt.Sprintf("traces-%020d.wal", 1)))
+	assert.True(t, os.IsNotExist(err), "the replayed segment must be deleted")
+
+	// once synced, only the remaining record is replayed after a restart
+	wal.checkpoint(2, uint64(2*walRecordHeaderSize+len(second)+len(third)))
+	sink = new(consumertest.TracesSink)
+	rcv = start(sink)
+	require.Eventually(t, func() bool { return sink.SpanCount() == 3 }, 5*time.Second, 10*time.Millisecond)
+	assert.Len(t, sink.AllTraces(), 1)
+	require.NoError(t, rcv.Shutdown(context.Background()))
+}
+
+func TestWALReceiverRetriesFailedRecords(t *testing.T) {
+	dir := t.TempDir()
+	wal := testWAL{t: t, dir: dir, signal: walSignalTraces}
+	record := marshalTestTraces(t, 1)
+	wal.appendRecords(1, record, []byte("not a valid record"), record)
+	wal.checkpoint(2, 0)
+
+	var calls int
+	r := &walReceiver{
+		cfg:    WALConfig{Directory: dir},
+		signal: walSignalTraces,
+		logger: receivertest.NewNopSettings(metadata.Type).Logger,
+		consume: func(_ context.Context, buf []byte) error {
+			calls++
+			if calls == 1 {
+				return assert.AnError
+			}
+			_, err := (&ptrace.ProtoUnmarshaler{}).UnmarshalTraces(buf)
+			return err
+		},
+	}
+	client, err := getWALStorageClient(context.Background(), nil, nil, component.MustNewID("otlpjsonfile"), walSignalTraces)
+	require.NoError(t, err)
+	r.client = client
+
+	r.poll(context.Background())
+	assert.Equal(t, uint64(1), r.seq)
+	assert.Zero(t, r.offset, "a failed record must be retried")
+
+	// the invalid record is consumed like the others, the consume function deciding what to skip
+	r.consume = func(context.Context, []byte) error { return nil }
+	r.poll(context.Background())
+	assert.Equal(t, uint64(2), r.seq)
+	assert.Zero(t, r.offset)
+}
+
+func TestWALConfigValidate(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	assert.NoError(t, cfg.Validate())
+
+	cfg.WAL = &WALConfig{}
+	assert.EqualError(t, cfg.Validate(), "wal directory must be non-empty")
+
+	cfg.WAL = &WALConfig{Directory: "/tmp", PollInterval: -time.Second}
+	assert.EqualError(t, cfg.Validate(), "wal poll_interval must not be negative")
+}
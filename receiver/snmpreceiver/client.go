@@ -113,6 +113,13 @@ func newClient(cfg *Config, logger *zap.Logger) (client, error) {
 // setV3ClientConfigs sets SNMP v3 related configurations on gosnmp client based on config
 func setV3ClientConfigs(client goSNMPWrapper, cfg *Config) {
 	client.SetSecurityModel(gosnmp.UserSecurityModel)
+	msgFlags, securityParams := newUsmSecurityParameters(cfg)
+	client.SetMsgFlags(msgFlags)
+	client.SetSecurityParameters(securityParams)
+}
+
+// newUsmSecurityParameters creates the gosnmp SNMP v3 message flags and security parameters based on config
+func newUsmSecurityParameters(cfg *Config) (gosnmp.SnmpV3MsgFlags, *gosnmp.UsmSecurityParameters) {
 	// Set goSNMP user based on config
 	securityParams := &gosnmp.UsmSecurityParameters{
 		UserName: cfg.User,
@@ -120,13 +127,11 @@ func setV3ClientConfigs(client goSNMPWrapper, cfg *Config) {
 	// Set goSNMP security level & auth/privacy details based on config
 	switch strings.ToUpper(cfg.SecurityLevel) {
 	case "AUTH_NO_PRIV":
-		client.SetMsgFlags(gosnmp.AuthNoPriv)
 		protocol := getAuthProtocol(cfg.AuthType)
 		securityParams.AuthenticationProtocol = protocol
 		securityParams.AuthenticationPassphrase = string(cfg.AuthPassword)
+		return gosnmp.AuthNoPriv, securityParams
 	case "AUTH_PRIV":
-		client.SetMsgFlags(gosnmp.AuthPriv)
-
 		authProtocol := getAuthProtocol(cfg.AuthType)
 		securityParams.AuthenticationProtocol = authProtocol
 		securityParams.AuthenticationPassphrase = string(cfg.AuthPassword)
@@ -134,10 +139,10 @@ func setV3ClientConfigs(client goSNMPWrapper, cfg *Config) {
 		privProtocol := getPrivacyProtocol(cfg.PrivacyType)
 		securityParams.PrivacyProtocol = privProtocol
 		securityParams.PrivacyPassphrase = string(cfg.PrivacyPassword)
+		return gosnmp.AuthPriv, securityParams
 	default:
-		client.SetMsgFlags(gosnmp.NoAuthNoPriv)
+		return gosnmp.NoAuthNoPriv, securityParams
 	}
-	client.SetSecurityParameters(securityParams)
 }
 
 // getAuthProtocol gets gosnmp auth protocol based on config auth type
@@ -226,7 +231,7 @@ func (c *snmpClient) GetScalarData(oids []string, scraperErrors *scrapererror.Sc
 				continue
 			}
 			// Convert data into the more simplified data type
-			clientSNMPData := c.convertSnmpPDUToSnmpData(data)
+			clientSNMPData := convertSnmpPDUToSnmpData(data)
 			// If the value type is not supported, then ignore
 			if clientSNMPData.valueType == notSupportedVal {
 				scraperErrors.AddPartial(1, fmt.Errorf("problem with getting scalar data: data for OID '%s' not a supported type", data.Name))
@@ -282,7 +287,7 @@ func (c *snmpClient) GetIndexedData(oids []string, scraperErrors *scrapererror.S
 				continue
 			}
 			// Convert data into the more simplified data type
-			clientSNMPData := c.convertSnmpPDUToSnmpData(snmpPDU)
+			clientSNMPData := convertSnmpPDUToSnmpData(snmpPDU)
 			// Keep track of which column OID this data came from as well
 			clientSNMPData.columnOID = oid
 			// If the value type is not supported, then ignore
@@ -318,7 +323,7 @@ func chunkArray(initArray []string, chunkSize int) [][]string {
 
 // convertSnmpPDUToSnmpData takes a piece of SnmpPDU data and converts it to the
 // client's snmpData type.
-func (c *snmpClient) convertSnmpPDUToSnmpData(pdu gosnmp.SnmpPDU) snmpData {
+func convertSnmpPDUToSnmpData(pdu gosnmp.SnmpPDU) snmpData {
 	clientSNMPData := snmpData{
 		oid: pdu.Name,
 	}
@@ -327,7 +332,7 @@ func (c *snmpClient) convertSnmpPDUToSnmpData(pdu gosnmp.SnmpPDU) snmpData {
 	switch pdu.Type {
 	// Integer types
 	case gosnmp.Counter64, gosnmp.Counter32, gosnmp.Gauge32, gosnmp.Uinteger32, gosnmp.TimeTicks, gosnmp.Integer:
-		value, err := c.toInt64(pdu.Name, pdu.Value)
+		value, err := toInt64(pdu.Name, pdu.Value)
 		if err != nil {
 			clientSNMPData.valueType = notSupportedVal
 			clientSNMPData.value = value
@@ -346,7 +351,7 @@ func (c *snmpClient) convertSnmpPDUToSnmpData(pdu gosnmp.SnmpPDU) snmpData {
 
 	// Float types
 	case gosnmp.OpaqueFloat, gosnmp.OpaqueDouble:
-		value, err := c.toFloat64(pdu.Name, pdu.Value)
+		value, err := toFloat64(pdu.Name, pdu.Value)
 		if err != nil {
 			clientSNMPData.valueType = notSupportedVal
 			clientSNMPData.value = value
@@ -372,7 +377,7 @@ func (c *snmpClient) convertSnmpPDUToSnmpData(pdu gosnmp.SnmpPDU) snmpData {
 // This is a convenience function to make working with SnmpPDU's easier - it
 // reduces the need for type assertions. A int64 is convenient, as SNMP can
 // return int32, uint32, and int64.
-func (*snmpClient) toInt64(name string, value any) (int64, error) {
+func toInt64(name string, value any) (int64, error) {
 	switch value := value.(type) { // shadow
 	case uint:
 		return int64(value), nil
@@ -405,7 +410,7 @@ func (*snmpClient) toInt64(name string, value any) (int64, error) {
 // This is a convenience function to make working with SnmpPDU's easier - it
 // reduces the need for type assertions. A float64 is convenient, as SNMP can
 // return float32 and float64.
-func (*snmpClient) toFloat64(name string, value any) (float64, error) {
+func toFloat64(name string, value any) (float64, error) {
 	switch value := value.(type) { // shadow
 	case float32:
 		return float64(value), nil
@@ -3,6 +3,7 @@
 package snmpreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/snmpreceiver"
 
 import (
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"net/url"
@@ -24,6 +25,7 @@ const (
 	defaultSecurityLevel      = "no_auth_no_priv"
 	defaultAuthType           = "MD5"
 	defaultPrivacyType        = "DES"
+	defaultTrapEndpoint       = "udp://localhost:162"
 )
 
 var (
@@ -53,6 +55,7 @@ var (
 	errMsgMultipleKeysSetOnResourceAttribute        = `resource attribute '%s' must have only one of oid, scalar_oid, or indexed_value_prefix`
 	errScalarOIDResourceAttributeEndsInNonzeroDigit = `resource attribute '%s' has scalar_oid '%s' that ends in a nonzero digit (scalar oids should not be indexed)`
 	errColumnOIDResourceAttributeEndsInZero         = `resource attribute '%s' has oid '%s' that ends in a zero (column oids should be indexed)`
+	errMsgBadProfile                                = `profile '%s' must be one of IF-MIB, HOST-RESOURCES-MIB, or ENTITY-SENSOR-MIB`
 
 	// Config errors
 	errEmptyEndpoint        = errors.New("endpoint must be specified")
@@ -69,6 +72,13 @@ var (
 	errBadPrivacyType       = errors.New("privacy_type must be either DES, AES, AES192, AES192C, AES256, AES256C")
 	errEmptyPrivacyPassword = errors.New("privacy_password must be specified when security_level is auth_priv")
 	errMetricRequired       = errors.New("must have at least one config under metrics")
+
+	// Trap listener config errors
+	errMsgTrapOIDNameBadOID   = `trap_listener oid_names key '%s' must be a numeric OID`
+	errTrapEndpointBadScheme  = errors.New("trap_listener endpoint scheme must be udp")
+	errEmptyTrapEngineID      = errors.New("trap_listener engine_id must be specified when version is v3")
+	errBadTrapEngineID        = errors.New("trap_listener engine_id must be a hex string of 5 to 32 bytes")
+	errMsgInvalidTrapEndpoint = `invalid trap_listener endpoint '%s': must be in 'udp://[host]:[port]' format`
 )
 
 // Config defines the configuration for the various elements of the receiver.
@@ -132,6 +142,48 @@ type Config struct {
 	// Metrics defines what SNMP metrics will be collected for this receiver and is composed of metric
 	// names along with their metric configurations
 	Metrics map[string]*MetricConfig `mapstructure:"metrics"`
+
+	// TrapListener enables the trap listener mode, in which the receiver listens for traps and informs
+	// instead of polling the endpoint. Traps are received as logs, and as metrics for the variable
+	// bindings matching the OIDs of the Metrics.
+	TrapListener *TrapListenerConfig `mapstructure:"trap_listener"`
+
+	// MIBDirectories are the directories of the MIB files to load. Once loaded, the OIDs of the metric, attribute
+	// and resource attribute configs may be MIB object names, such as "IF-MIB::ifHCInOctets", and the units,
+	// descriptions, metric types and attribute enum values that are not configured are derived from the MIB.
+	MIBDirectories []string `mapstructure:"mib_directories"`
+
+	// Profiles are the prebuilt metric and attribute configs to add for common MIBs, which do not require MIB files.
+	// Configured metrics and attributes take precedence over those of the profiles with the same name.
+	// Valid options: "IF-MIB", "HOST-RESOURCES-MIB", "ENTITY-SENSOR-MIB"
+	Profiles []string `mapstructure:"profiles"`
+
+	// enumLabels contains the labels of the enumerated values of the attribute and resource attribute OIDs, by OID
+	enumLabels map[string]map[int64]string
+	// mibOIDNames contains the names of the objects of the loaded MIBs, by OID
+	mibOIDNames map[string]string
+	// mibsResolved is true once the profiles and the MIB object names of the config are resolved, when it is
+	// unmarshalled or, for a config built in code, when the receiver is created
+	mibsResolved bool
+}
+
+// TrapListenerConfig contains config info about the trap listener mode.
+type TrapListenerConfig struct {
+	// Endpoint is the address to listen on for traps and informs. Must be formatted as udp://{host}:{port}.
+	// Default: udp://localhost:162
+	// If no scheme is given, udp is assumed.
+	// If no port is given, 162 is assumed.
+	Endpoint string `mapstructure:"endpoint"`
+
+	// EngineID is the hex encoded authoritative engine ID of the receiver, with which v3 informs are
+	// acknowledged. Senders of v3 informs must be configured with it.
+	// Only valid for version "v3", for which it is required
+	EngineID string `mapstructure:"engine_id"`
+
+	// OIDNames maps numeric OIDs to the names used for the variable bindings of traps in log records.
+	// An OID without a name is named after its longest named prefix, followed by the rest of the OID.
+	// These names take precedence over the names of the objects of the MIBs of MIBDirectories.
+	OIDNames map[string]string `mapstructure:"oid_names"`
 }
 
 // ResourceAttributeConfig contains config info about all of the resource attributes that will be used by this receiver.
@@ -165,7 +217,8 @@ type AttributeConfig struct {
 	// Description is optional and describes what the attribute represents
 	Description string `mapstructure:"description"`
 	// Enum is required only if OID and IndexedValuePrefix are not defined.
-	// This contains a list of possible values that can be associated with this attribute
+	// This contains a list of possible values that can be associated with this attribute.
+	// For an attribute with an OID, this contains the labels of the enumerated values of the OID, if any.
 	Enum []string `mapstructure:"enum"`
 	// OID is required only if Enum and IndexedValuePrefix are not defined.
 	// This is the column OID which will provide indexed values to be used for this attribute (alongside a metric with ColumnOIDs)
@@ -262,11 +315,79 @@ func (cfg *Config) Validate() error {
 	if strings.EqualFold(cfg.Version, "V3") {
 		combinedErr = errors.Join(combinedErr, validateSecurity(cfg))
 	}
+	if cfg.TrapListener != nil {
+		combinedErr = errors.Join(combinedErr, validateTrapListener(cfg))
+	}
+	for _, name := range cfg.Profiles {
+		if _, ok := profiles[name]; !ok {
+			combinedErr = errors.Join(combinedErr, fmt.Errorf(errMsgBadProfile, name))
+		}
+	}
+	combinedErr = errors.Join(combinedErr, validateMIBNames(cfg))
 	combinedErr = errors.Join(combinedErr, validateMetricConfigs(cfg))
 
 	return combinedErr
 }
 
+// validateTrapListener validates the TrapListener
+func validateTrapListener(cfg *Config) error {
+	var combinedErr error
+
+	// Ensure valid endpoint, if not defaulted
+	u, err := url.Parse(cfg.TrapListener.Endpoint)
+	switch {
+	case cfg.TrapListener.Endpoint == "": // ok
+	case err != nil:
+		combinedErr = errors.Join(combinedErr, fmt.Errorf(errMsgInvalidEndpointWError, cfg.TrapListener.Endpoint, err))
+	case u.Host == "" || u.Port() == "":
+		combinedErr = errors.Join(combinedErr, fmt.Errorf(errMsgInvalidTrapEndpoint, cfg.TrapListener.Endpoint))
+	case !strings.EqualFold(u.Scheme, "udp"):
+		combinedErr = errors.Join(combinedErr, errTrapEndpointBadScheme)
+	}
+
+	// Ensure valid engine ID for v3 informs
+	if strings.EqualFold(cfg.Version, "V3") {
+		if cfg.TrapListener.EngineID == "" {
+			combinedErr = errors.Join(combinedErr, errEmptyTrapEngineID)
+		} else if _, err := decodeEngineID(cfg.TrapListener.EngineID); err != nil {
+			combinedErr = errors.Join(combinedErr, errBadTrapEngineID)
+		}
+	}
+
+	// Ensure the named OIDs are numeric
+	for oid := range cfg.TrapListener.OIDNames {
+		if !isNumericOID(oid) {
+			combinedErr = errors.Join(combinedErr, fmt.Errorf(errMsgTrapOIDNameBadOID, oid))
+		}
+	}
+
+	return combinedErr
+}
+
+// decodeEngineID decodes a hex encoded engine ID, with an optional 0x prefix
+func decodeEngineID(engineID string) ([]byte, error) {
+	decoded, err := hex.DecodeString(strings.TrimPrefix(strings.ToLower(engineID), "0x"))
+	if err != nil {
+		return nil, err
+	}
+	// RFC 3411 SnmpEngineID
+	if len(decoded) < 5 || len(decoded) > 32 {
+		return nil, errBadTrapEngineID
+	}
+	return decoded, nil
+}
+
+// isNumericOID returns true if the OID only contains numbers separated by dots, with an optional leading dot
+func isNumericOID(oid string) bool {
+	parts := strings.Split(strings.TrimPrefix(oid, "."), ".")
+	for _, part := range parts {
+		if part == "" || strings.Trim(part, "0123456789") != "" {
+			return false
+		}
+	}
+	return true
+}
+
 // validateEndpoint validates the Endpoint
 func validateEndpoint(cfg *Config) error {
 	if cfg.Endpoint == "" {
@@ -391,9 +512,9 @@ func validateMetricConfigs(cfg *Config) error {
 	combinedErr = errors.Join(combinedErr, validateAttributeConfigs(cfg))
 	combinedErr = errors.Join(combinedErr, validateResourceAttributeConfigs(cfg))
 
-	// Ensure there is at least one MetricConfig
+	// Ensure there is at least one MetricConfig, unless traps are received as logs
 	metrics := cfg.Metrics
-	if len(metrics) == 0 {
+	if len(metrics) == 0 && cfg.TrapListener == nil {
 		return errors.Join(combinedErr, errMetricRequired)
 	}
 
@@ -458,7 +579,8 @@ func validateColumnOID(metricName string, columnOID ColumnOID, cfg *Config) erro
 				continue
 			}
 
-			if len(attrCfg.Enum) > 0 {
+			// Attributes with an OID or indexed value prefix may have enum values derived from a MIB
+			if len(attrCfg.Enum) > 0 && attrCfg.OID == "" && attrCfg.IndexedValuePrefix == "" {
 				if !slices.Contains(attrCfg.Enum, attribute.Value) {
 					combinedErr = errors.Join(combinedErr, fmt.Errorf(errMsgColumnAttributeBadValue, metricName, attribute.Name, attribute.Value))
 				}
@@ -261,6 +261,9 @@ func TestLoadConfigConnectionConfigs(t *testing.T) {
 				require.ErrorContains(t, xconfmap.Validate(cfg), test.expectedErr)
 			}
 
+			// The MIBs of unmarshalled configs are resolved
+			require.True(t, cfg.(*Config).mibsResolved)
+			cfg.(*Config).mibsResolved = false
 			require.Equal(t, test.expectedCfg, cfg)
 		})
 	}
@@ -1005,11 +1008,305 @@ func TestLoadConfigMetricConfigs(t *testing.T) {
 				require.ErrorContains(t, xconfmap.Validate(cfg), test.expectedErr)
 			}
 
+			// The MIBs of unmarshalled configs are resolved
+			require.True(t, cfg.(*Config).mibsResolved)
+			cfg.(*Config).mibsResolved = false
 			require.Equal(t, test.expectedCfg, cfg)
 		})
 	}
 }
 
+func TestLoadConfigTrapListenerConfigs(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	factory := NewFactory()
+
+	type testCase struct {
+		name        string
+		nameVal     string
+		expectedCfg *Config
+		expectedErr string
+	}
+
+	expectedConfigSimple := factory.CreateDefaultConfig().(*Config)
+	expectedConfigSimple.TrapListener = &TrapListenerConfig{
+		Endpoint: "udp://0.0.0.0:162",
+		OIDNames: map[string]string{
+			"1.3.6.1.4.1.9.9.41.1.2.3.1.5": "clogHistMsgText",
+		},
+	}
+
+	expectedConfigNoPort := factory.CreateDefaultConfig().(*Config)
+	expectedConfigNoPort.TrapListener = &TrapListenerConfig{
+		Endpoint: "udp://0.0.0.0",
+	}
+
+	expectedConfigBadEndpointScheme := factory.CreateDefaultConfig().(*Config)
+	expectedConfigBadEndpointScheme.TrapListener = &TrapListenerConfig{
+		Endpoint: "tcp://0.0.0.0:162",
+	}
+
+	expectedConfigBadOIDName := factory.CreateDefaultConfig().(*Config)
+	expectedConfigBadOIDName.TrapListener = &TrapListenerConfig{
+		Endpoint: "udp://0.0.0.0:162",
+		OIDNames: map[string]string{
+			"ifIndex": "interfaceIndex",
+		},
+	}
+
+	expectedConfigV3Simple := factory.CreateDefaultConfig().(*Config)
+	expectedConfigV3Simple.Version = "v3"
+	expectedConfigV3Simple.User = "u"
+	expectedConfigV3Simple.SecurityLevel = "auth_priv"
+	expectedConfigV3Simple.AuthType = "SHA"
+	expectedConfigV3Simple.AuthPassword = "p"
+	expectedConfigV3Simple.PrivacyType = "AES"
+	expectedConfigV3Simple.PrivacyPassword = "pp"
+	expectedConfigV3Simple.TrapListener = &TrapListenerConfig{
+		Endpoint: "udp://0.0.0.0:162",
+		EngineID: "80001f8880e9630000d61ff449",
+	}
+
+	expectedConfigV3NoEngineID := factory.CreateDefaultConfig().(*Config)
+	expectedConfigV3NoEngineID.Version = "v3"
+	expectedConfigV3NoEngineID.User = "u"
+	expectedConfigV3NoEngineID.SecurityLevel = "no_auth_no_priv"
+	expectedConfigV3NoEngineID.TrapListener = &TrapListenerConfig{
+		Endpoint: "udp://0.0.0.0:162",
+	}
+
+	expectedConfigV3BadEngineID := factory.CreateDefaultConfig().(*Config)
+	expectedConfigV3BadEngineID.Version = "v3"
+	expectedConfigV3BadEngineID.User = "u"
+	expectedConfigV3BadEngineID.SecurityLevel = "no_auth_no_priv"
+	expectedConfigV3BadEngineID.TrapListener = &TrapListenerConfig{
+		Endpoint: "udp://0.0.0.0:162",
+		EngineID: "80001f",
+	}
+
+	testCases := []testCase{
+		{
+			name:        "GoodTrapListenerNoErrors",
+			nameVal:     "trap_listener_good",
+			expectedCfg: expectedConfigSimple,
+			expectedErr: "",
+		},
+		{
+			name:        "NoPortErrors",
+			nameVal:     "trap_listener_no_port",
+			expectedCfg: expectedConfigNoPort,
+			expectedErr: fmt.Sprintf(errMsgInvalidTrapEndpoint, "udp://0.0.0.0"),
+		},
+		{
+			name:        "BadEndpointSchemeErrors",
+			nameVal:     "trap_listener_bad_endpoint_scheme",
+			expectedCfg: expectedConfigBadEndpointScheme,
+			expectedErr: errTrapEndpointBadScheme.Error(),
+		},
+		{
+			name:        "BadOIDNameErrors",
+			nameVal:     "trap_listener_bad_oid_name",
+			expectedCfg: expectedConfigBadOIDName,
+			expectedErr: fmt.Sprintf(errMsgTrapOIDNameBadOID, "ifIndex"),
+		},
+		{
+			name:        "GoodV3TrapListenerNoErrors",
+			nameVal:     "trap_listener_v3_good",
+			expectedCfg: expectedConfigV3Simple,
+			expectedErr: "",
+		},
+		{
+			name:        "V3NoEngineIDErrors",
+			nameVal:     "trap_listener_v3_no_engine_id",
+			expectedCfg: expectedConfigV3NoEngineID,
+			expectedErr: errEmptyTrapEngineID.Error(),
+		},
+		{
+			name:        "V3BadEngineIDErrors",
+			nameVal:     "trap_listener_v3_bad_engine_id",
+			expectedCfg: expectedConfigV3BadEngineID,
+			expectedErr: errBadTrapEngineID.Error(),
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			sub, err := cm.Sub(component.NewIDWithName(metadata.Type, test.nameVal).String())
+			require.NoError(t, err)
+
+			cfg := factory.CreateDefaultConfig()
+			require.NoError(t, sub.Unmarshal(cfg))
+			if test.expectedErr == "" {
+				require.NoError(t, xconfmap.Validate(cfg))
+			} else {
+				require.ErrorContains(t, xconfmap.Validate(cfg), test.expectedErr)
+			}
+
+			// The MIBs of unmarshalled configs are resolved
+			require.True(t, cfg.(*Config).mibsResolved)
+			cfg.(*Config).mibsResolved = false
+			require.Equal(t, test.expectedCfg, cfg)
+		})
+	}
+}
+
+func TestLoadConfigMIBConfigs(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	factory := NewFactory()
+
+	t.Run("GoodMIBNoErrors", func(t *testing.T) {
+		sub, err := cm.Sub(component.NewIDWithName(metadata.Type, "mib_good").String())
+		require.NoError(t, err)
+
+		cfg := factory.CreateDefaultConfig().(*Config)
+		require.NoError(t, sub.Unmarshal(cfg))
+		require.NoError(t, xconfmap.Validate(cfg))
+
+		expectedCfg := factory.CreateDefaultConfig().(*Config)
+		expectedCfg.MIBDirectories = []string{"testdata/mibs"}
+		expectedCfg.ResourceAttributes = map[string]*ResourceAttributeConfig{
+			"host.name": {
+				Description: "The name of the host.",
+				ScalarOID:   ".1.3.6.1.4.1.99999.1.1.0",
+			},
+		}
+		expectedCfg.Attributes = map[string]*AttributeConfig{
+			"port.name": {
+				Description: "The name of the port.",
+				OID:         ".1.3.6.1.4.1.99999.1.3.1.2",
+			},
+			"port.status": {
+				Description: "The status of the port.",
+				Enum:        []string{"up", "down"},
+				OID:         ".1.3.6.1.4.1.99999.1.3.1.3",
+			},
+		}
+		expectedCfg.Metrics = map[string]*MetricConfig{
+			"port.io": {
+				Description: "The octets received on the port.",
+				Unit:        "By",
+				Sum: &SumMetric{
+					Aggregation: "cumulative",
+					Monotonic:   true,
+					ValueType:   "int",
+				},
+				ColumnOIDs: []ColumnOID{
+					{
+						OID: ".1.3.6.1.4.1.99999.1.3.1.4",
+						Attributes: []Attribute{
+							{Name: "port.name"},
+							{Name: "port.status"},
+						},
+					},
+				},
+			},
+			"uptime": {
+				Description: "The uptime of the host.",
+				Unit:        "cs",
+				Gauge: &GaugeMetric{
+					ValueType: "int",
+				},
+				ScalarOIDs: []ScalarOID{
+					{
+						OID:                ".1.3.6.1.4.1.99999.1.2.0",
+						ResourceAttributes: []string{"host.name"},
+					},
+				},
+			},
+		}
+		expectedCfg.enumLabels = map[string]map[int64]string{
+			".1.3.6.1.4.1.99999.1.3.1.3": {1: "up", 2: "down"},
+		}
+
+		require.Equal(t, "testOctets", cfg.mibOIDNames[".1.3.6.1.4.1.99999.1.3.1.4"])
+		cfg.mibOIDNames = nil
+		cfg.mibsResolved = false
+		require.Equal(t, expectedCfg, cfg)
+	})
+
+	t.Run("ProfilesNoErrors", func(t *testing.T) {
+		sub, err := cm.Sub(component.NewIDWithName(metadata.Type, "profiles").String())
+		require.NoError(t, err)
+
+		cfg := factory.CreateDefaultConfig().(*Config)
+		require.NoError(t, sub.Unmarshal(cfg))
+		require.NoError(t, xconfmap.Validate(cfg))
+
+		require.Len(t, cfg.Metrics, 7)
+		require.Len(t, cfg.Attributes, 2)
+		// Configured metrics take precedence over those of the profiles
+		require.Equal(t, "{session}", cfg.Metrics["system.users.count"].Unit)
+		require.Equal(t, ".1.3.6.1.2.1.25.3.3.1.2", cfg.Metrics["system.cpu.load"].ColumnOIDs[0].OID)
+	})
+
+	unmarshalErrTestCases := []struct {
+		name        string
+		nameVal     string
+		expectedErr string
+	}{
+		{
+			name:        "NoMIBDirectoriesErrors",
+			nameVal:     "mib_no_directories",
+			expectedErr: "attribute 'port.name': " + fmt.Sprintf(errMsgOIDNameNoMIB, "TEST-MIB::testName"),
+		},
+		{
+			name:        "UnknownObjectErrors",
+			nameVal:     "mib_unknown_object",
+			expectedErr: "metric 'port.io': unknown MIB object 'TEST-MIB::testUnknown'",
+		},
+	}
+
+	for _, test := range unmarshalErrTestCases {
+		t.Run(test.name, func(t *testing.T) {
+			sub, err := cm.Sub(component.NewIDWithName(metadata.Type, test.nameVal).String())
+			require.NoError(t, err)
+
+			cfg := factory.CreateDefaultConfig()
+			require.ErrorContains(t, sub.Unmarshal(cfg), test.expectedErr)
+		})
+	}
+
+	t.Run("ConfigBuiltInCode", func(t *testing.T) {
+		cfg := factory.CreateDefaultConfig().(*Config)
+		cfg.MIBDirectories = []string{"testdata/mibs"}
+		cfg.Profiles = []string{"HOST-RESOURCES-MIB"}
+		cfg.Metrics = map[string]*MetricConfig{
+			"uptime": {
+				Unit:       "cs",
+				Gauge:      &GaugeMetric{ValueType: "int"},
+				ScalarOIDs: []ScalarOID{{OID: "TEST-MIB::testUptime"}},
+			},
+		}
+
+		// Validating the config only checks that the names resolve
+		require.NoError(t, xconfmap.Validate(cfg))
+		require.Equal(t, "TEST-MIB::testUptime", cfg.Metrics["uptime"].ScalarOIDs[0].OID)
+		require.NotContains(t, cfg.Metrics, "system.processes.count")
+		cfg.Metrics["uptime"].ScalarOIDs[0].OID = "TEST-MIB::testUnknown"
+		require.ErrorContains(t, xconfmap.Validate(cfg), "metric 'uptime': unknown MIB object 'TEST-MIB::testUnknown'")
+		cfg.Metrics["uptime"].ScalarOIDs[0].OID = "TEST-MIB::testUptime"
+
+		// The MIBs are resolved once, when the receiver is created
+		require.NoError(t, addMissingConfigDefaults(cfg))
+		require.Equal(t, ".1.3.6.1.4.1.99999.1.2.0", cfg.Metrics["uptime"].ScalarOIDs[0].OID)
+		require.Contains(t, cfg.Metrics, "system.processes.count")
+		require.NoError(t, addMissingConfigDefaults(cfg))
+		require.Equal(t, ".1.3.6.1.4.1.99999.1.2.0", cfg.Metrics["uptime"].ScalarOIDs[0].OID)
+	})
+
+	t.Run("BadProfileErrors", func(t *testing.T) {
+		sub, err := cm.Sub(component.NewIDWithName(metadata.Type, "bad_profile").String())
+		require.NoError(t, err)
+
+		cfg := factory.CreateDefaultConfig()
+		require.NoError(t, sub.Unmarshal(cfg))
+		require.ErrorContains(t, xconfmap.Validate(cfg), fmt.Sprintf(errMsgBadProfile, "FOO-MIB"))
+	})
+}
+
 // Testing Validate directly to test that missing data errors when no defaults are provided
 func TestValidate(t *testing.T) {
 	type testCase struct {
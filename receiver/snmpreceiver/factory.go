@@ -17,17 +17,22 @@ import (
 	"go.opentelemetry.io/collector/scraper"
 	"go.opentelemetry.io/collector/scraper/scraperhelper"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/sharedcomponent"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/snmpreceiver/internal/metadata"
 )
 
-var errConfigNotSNMP = errors.New("config was not a SNMP receiver config")
+var (
+	errConfigNotSNMP      = errors.New("config was not a SNMP receiver config")
+	errLogsNoTrapListener = errors.New("logs can only be received with trap_listener configured")
+)
 
 // NewFactory creates a new receiver factory for SNMP
 func NewFactory() receiver.Factory {
 	return receiver.NewFactory(
 		metadata.Type,
 		createDefaultConfig,
-		receiver.WithMetrics(createMetricsReceiver, metadata.MetricsStability))
+		receiver.WithMetrics(createMetricsReceiver, metadata.MetricsStability),
+		receiver.WithLogs(createLogsReceiver, metadata.LogsStability))
 }
 
 // createDefaultConfig creates a config for SNMP with as many default values as possible
@@ -62,6 +67,16 @@ func createMetricsReceiver(
 		return nil, fmt.Errorf("failed to validate added config defaults: %w", err)
 	}
 
+	// Metrics are created from traps instead of scrapes in trap listener mode
+	if snmpConfig.TrapListener != nil {
+		r, err := getOrAddTrapReceiver(params, snmpConfig)
+		if err != nil {
+			return nil, err
+		}
+		r.Unwrap().(*trapReceiver).metricsConsumer = consumer
+		return r, nil
+	}
+
 	snmpScraper := newScraper(params.Logger, snmpConfig, params)
 	s, err := scraper.NewMetrics(snmpScraper.scrape, scraper.WithStart(snmpScraper.start))
 	if err != nil {
@@ -71,8 +86,59 @@ func createMetricsReceiver(
 	return scraperhelper.NewMetricsController(&snmpConfig.ControllerConfig, params, consumer, scraperhelper.AddScraper(metadata.Type, s))
 }
 
+// createLogsReceiver creates the logs receiver for SNMP traps
+func createLogsReceiver(
+	_ context.Context,
+	params receiver.Settings,
+	config component.Config,
+	consumer consumer.Logs,
+) (receiver.Logs, error) {
+	snmpConfig, ok := config.(*Config)
+	if !ok {
+		return nil, errConfigNotSNMP
+	}
+
+	if snmpConfig.TrapListener == nil {
+		return nil, errLogsNoTrapListener
+	}
+
+	if err := addMissingConfigDefaults(snmpConfig); err != nil {
+		return nil, fmt.Errorf("failed to validate added config defaults: %w", err)
+	}
+
+	r, err := getOrAddTrapReceiver(params, snmpConfig)
+	if err != nil {
+		return nil, err
+	}
+	r.Unwrap().(*trapReceiver).logsConsumer = consumer
+	return r, nil
+}
+
+// getOrAddTrapReceiver returns the trap receiver shared by the logs and metrics receivers of the config
+func getOrAddTrapReceiver(params receiver.Settings, cfg *Config) (*sharedcomponent.SharedComponent, error) {
+	var err error
+	r := trapReceivers.GetOrAdd(cfg, func() component.Component {
+		var rcvr *trapReceiver
+		rcvr, err = newTrapReceiver(params, cfg)
+		return rcvr
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// trapReceivers contains the trap receivers, so that a single listener is shared by the logs and
+// metrics receivers of a config
+var trapReceivers = sharedcomponent.NewSharedComponents()
+
 // addMissingConfigDefaults adds any missing config parameters that have defaults
 func addMissingConfigDefaults(cfg *Config) error {
+	// Configs built in code are not unmarshalled, so their profiles and MIB object names are resolved here
+	if err := cfg.resolveMIBs(); err != nil {
+		return err
+	}
+
 	// Add the schema prefix to the endpoint if it doesn't contain one
 	if !strings.Contains(cfg.Endpoint, "://") {
 		cfg.Endpoint = "udp://" + cfg.Endpoint
@@ -88,6 +154,24 @@ func addMissingConfigDefaults(cfg *Config) error {
 		cfg.Endpoint += portSuffix
 	}
 
+	// Add the schema prefix and default port to the trap listener endpoint if it doesn't contain them
+	if cfg.TrapListener != nil {
+		if cfg.TrapListener.Endpoint == "" {
+			cfg.TrapListener.Endpoint = defaultTrapEndpoint
+		}
+		if !strings.Contains(cfg.TrapListener.Endpoint, "://") {
+			cfg.TrapListener.Endpoint = "udp://" + cfg.TrapListener.Endpoint
+		}
+		u, err := url.Parse(cfg.TrapListener.Endpoint)
+		if err == nil && u.Port() == "" {
+			portSuffix := "162"
+			if cfg.TrapListener.Endpoint[len(cfg.TrapListener.Endpoint)-1:] != ":" {
+				portSuffix = ":" + portSuffix
+			}
+			cfg.TrapListener.Endpoint += portSuffix
+		}
+	}
+
 	// Set defaults for metric configs
 	for _, metricCfg := range cfg.Metrics {
 		if metricCfg.Unit == "" {
@@ -260,6 +260,80 @@ func TestNewFactory(t *testing.T) {
 				require.Equal(t, "1", snmpCfg.Metrics["m1"].Unit)
 			},
 		},
+		{
+			desc: "CreateLogs returns error without trap listener",
+			testFunc: func(t *testing.T) {
+				factory := NewFactory()
+				_, err := factory.CreateLogs(
+					context.Background(),
+					receivertest.NewNopSettings(metadata.Type),
+					factory.CreateDefaultConfig(),
+					consumertest.NewNop(),
+				)
+				require.ErrorIs(t, err, errLogsNoTrapListener)
+			},
+		},
+		{
+			desc: "CreateLogs adds default trap listener endpoint",
+			testFunc: func(t *testing.T) {
+				factory := NewFactory()
+				cfg := factory.CreateDefaultConfig()
+				snmpCfg := cfg.(*Config)
+				snmpCfg.TrapListener = &TrapListenerConfig{}
+				_, err := factory.CreateLogs(
+					context.Background(),
+					receivertest.NewNopSettings(metadata.Type),
+					cfg,
+					consumertest.NewNop(),
+				)
+				require.NoError(t, err)
+				require.Equal(t, defaultTrapEndpoint, snmpCfg.TrapListener.Endpoint)
+			},
+		},
+		{
+			desc: "CreateLogs adds missing scheme and port to trap listener endpoint",
+			testFunc: func(t *testing.T) {
+				factory := NewFactory()
+				cfg := factory.CreateDefaultConfig()
+				snmpCfg := cfg.(*Config)
+				snmpCfg.TrapListener = &TrapListenerConfig{
+					Endpoint: "0.0.0.0",
+				}
+				_, err := factory.CreateLogs(
+					context.Background(),
+					receivertest.NewNopSettings(metadata.Type),
+					cfg,
+					consumertest.NewNop(),
+				)
+				require.NoError(t, err)
+				require.Equal(t, "udp://0.0.0.0:162", snmpCfg.TrapListener.Endpoint)
+			},
+		},
+		{
+			desc: "CreateLogs and CreateMetrics share the trap receiver",
+			testFunc: func(t *testing.T) {
+				factory := NewFactory()
+				cfg := factory.CreateDefaultConfig()
+				snmpCfg := cfg.(*Config)
+				snmpCfg.TrapListener = &TrapListenerConfig{}
+				logsReceiver, err := factory.CreateLogs(
+					context.Background(),
+					receivertest.NewNopSettings(metadata.Type),
+					cfg,
+					consumertest.NewNop(),
+				)
+				require.NoError(t, err)
+				metricsReceiver, err := factory.CreateMetrics(
+					context.Background(),
+					receivertest.NewNopSettings(metadata.Type),
+					cfg,
+					consumertest.NewNop(),
+				)
+				require.NoError(t, err)
+				require.Same(t, logsReceiver, metricsReceiver)
+				require.NoError(t, logsReceiver.Shutdown(context.Background()))
+			},
+		},
 	}
 
 	for _, tc := range testCases {
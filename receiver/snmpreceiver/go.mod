@@ -4,6 +4,7 @@ go 1.23.0
 
 require (
 	github.com/gosnmp/gosnmp v1.42.1
+	github.com/open-telemetry/opentelemetry-collector-contrib/internal/sharedcomponent v0.131.0
 	github.com/open-telemetry/opentelemetry-collector-contrib/pkg/golden v0.131.0
 	github.com/open-telemetry/opentelemetry-collector-contrib/pkg/pdatatest v0.131.0
 	github.com/stretchr/testify v1.10.0
@@ -18,6 +19,7 @@ require (
 	go.opentelemetry.io/collector/otelcol/otelcoltest v0.131.1-0.20250801020258-8b73477b9810
 	go.opentelemetry.io/collector/pdata v1.37.1-0.20250801020258-8b73477b9810
 	go.opentelemetry.io/collector/receiver v1.37.1-0.20250801020258-8b73477b9810
+	go.opentelemetry.io/collector/receiver/receiverhelper v0.131.1-0.20250801020258-8b73477b9810
 	go.opentelemetry.io/collector/receiver/receivertest v0.131.1-0.20250801020258-8b73477b9810
 	go.opentelemetry.io/collector/scraper v0.131.1-0.20250801020258-8b73477b9810
 	go.opentelemetry.io/collector/scraper/scraperhelper v0.131.1-0.20250801020258-8b73477b9810
@@ -122,7 +124,6 @@ require (
 	go.opentelemetry.io/collector/processor v1.37.1-0.20250801020258-8b73477b9810 // indirect
 	go.opentelemetry.io/collector/processor/processortest v0.131.1-0.20250801020258-8b73477b9810 // indirect
 	go.opentelemetry.io/collector/processor/xprocessor v0.131.1-0.20250801020258-8b73477b9810 // indirect
-	go.opentelemetry.io/collector/receiver/receiverhelper v0.131.1-0.20250801020258-8b73477b9810 // indirect
 	go.opentelemetry.io/collector/receiver/xreceiver v0.131.1-0.20250801020258-8b73477b9810 // indirect
 	go.opentelemetry.io/collector/service v0.131.1-0.20250801020258-8b73477b9810 // indirect
 	go.opentelemetry.io/collector/service/hostcapabilities v0.131.1-0.20250801020258-8b73477b9810 // indirect
@@ -176,3 +177,5 @@ retract (
 )
 
 replace github.com/open-telemetry/opentelemetry-collector-contrib/pkg/golden => ../../pkg/golden
+
+replace github.com/open-telemetry/opentelemetry-collector-contrib/internal/sharedcomponent => ../../internal/sharedcomponent
@@ -0,0 +1,108 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package mib // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/snmpreceiver/internal/mib"
+
+import (
+	"fmt"
+	"strings"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenNumber
+	tokenString
+	tokenSymbol
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	line int
+}
+
+func (t token) is(text string) bool {
+	return (t.kind == tokenIdent || t.kind == tokenSymbol) && t.text == text
+}
+
+// tokenize splits the content of a MIB file into tokens, skipping whitespace and comments
+func tokenize(content string) ([]token, error) {
+	var tokens []token
+	line := 1
+	for i := 0; i < len(content); {
+		c := content[i]
+		switch {
+		case c == '\n':
+			line++
+			i++
+		case c == ' ' || c == '\t' || c == '\r' || c == '\f':
+			i++
+		case strings.HasPrefix(content[i:], "--"):
+			// A comment ends at the end of the line, or at the next "--"
+			i += 2
+			for i < len(content) && content[i] != '\n' && !strings.HasPrefix(content[i:], "--") {
+				i++
+			}
+			if strings.HasPrefix(content[i:], "--") {
+				i += 2
+			}
+		case c == '"':
+			start, startLine := i+1, line
+			end := strings.IndexByte(content[start:], '"')
+			if end < 0 {
+				return nil, fmt.Errorf("line %d: unterminated string", startLine)
+			}
+			text := content[start : start+end]
+			line += strings.Count(text, "\n")
+			tokens = append(tokens, token{kind: tokenString, text: text, line: startLine})
+			i = start + end + 1
+		case c == '\'':
+			// Binary and hexadecimal strings, such as 'ff'H
+			end := strings.IndexByte(content[i+1:], '\'')
+			if end < 0 {
+				return nil, fmt.Errorf("line %d: unterminated quoted string", line)
+			}
+			i += end + 2
+			if i < len(content) && isLetter(content[i]) {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenString, line: line})
+		case isDigit(c) || (c == '-' && i+1 < len(content) && isDigit(content[i+1])):
+			start := i
+			i++
+			for i < len(content) && isDigit(content[i]) {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenNumber, text: content[start:i], line: line})
+		case isLetter(c):
+			start := i
+			i++
+			for i < len(content) && (isLetter(content[i]) || isDigit(content[i]) || content[i] == '_' ||
+				(content[i] == '-' && !strings.HasPrefix(content[i:], "--"))) {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenIdent, text: content[start:i], line: line})
+		case strings.HasPrefix(content[i:], "::="):
+			tokens = append(tokens, token{kind: tokenSymbol, text: "::=", line: line})
+			i += 3
+		case strings.HasPrefix(content[i:], ".."):
+			tokens = append(tokens, token{kind: tokenSymbol, text: "..", line: line})
+			i += 2
+		default:
+			tokens = append(tokens, token{kind: tokenSymbol, text: string(c), line: line})
+			i++
+		}
+	}
+	return append(tokens, token{kind: tokenEOF, line: line}), nil
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isLetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
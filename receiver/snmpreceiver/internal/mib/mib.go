@@ -0,0 +1,367 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package mib loads the object definitions of SMIv1 and SMIv2 MIB modules.
+package mib // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/snmpreceiver/internal/mib"
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Enum is a named value of an enumerated INTEGER
+type Enum struct {
+	Label string
+	Value int64
+}
+
+// Kind is the kind of a MIB object
+type Kind int
+
+const (
+	// KindNode is an OID without a value, such as a MODULE-IDENTITY or an OBJECT IDENTIFIER
+	KindNode Kind = iota
+	// KindScalar is an OBJECT-TYPE with a single instance
+	KindScalar
+	// KindTable is an OBJECT-TYPE with a SEQUENCE OF syntax
+	KindTable
+	// KindRow is the OBJECT-TYPE of the entries of a table
+	KindRow
+	// KindColumn is an OBJECT-TYPE of a table row
+	KindColumn
+	// KindNotification is a NOTIFICATION-TYPE or TRAP-TYPE
+	KindNotification
+)
+
+// Object is a named OID defined in a MIB module
+type Object struct {
+	Module string
+	Name   string
+	// OID is the numeric OID of the object, with a leading "."
+	OID  string
+	Kind Kind
+	// Type is the base type of the object, such as "Counter64", "INTEGER" or "OCTET STRING"
+	Type        string
+	Units       string
+	Description string
+	Enums       []Enum
+}
+
+// MIB is a set of loaded MIB modules
+type MIB struct {
+	modules map[string]*module
+	// objects contains all the resolved objects, sorted by module and definition order
+	objects []*Object
+	byName  map[string][]*Object
+	byOID   map[string]*Object
+	// unresolved contains the errors of the objects whose OID could not be resolved, by name
+	unresolved map[string]error
+}
+
+// builtinNodes are the nodes defined by the ASN.1 standard and the SMI modules, which MIB
+// files usually import without them being available as files
+var builtinNodes = map[string]string{
+	"ccitt":           ".0",
+	"zeroDotZero":     ".0.0",
+	"iso":             ".1",
+	"joint-iso-ccitt": ".2",
+	"org":             ".1.3",
+	"dod":             ".1.3.6",
+	"internet":        ".1.3.6.1",
+	"directory":       ".1.3.6.1.1",
+	"mgmt":            ".1.3.6.1.2",
+	"mib-2":           ".1.3.6.1.2.1",
+	"transmission":    ".1.3.6.1.2.1.10",
+	"experimental":    ".1.3.6.1.3",
+	"private":         ".1.3.6.1.4",
+	"enterprises":     ".1.3.6.1.4.1",
+	"security":        ".1.3.6.1.5",
+	"snmpV2":          ".1.3.6.1.6",
+	"snmpDomains":     ".1.3.6.1.6.1",
+	"snmpProxys":      ".1.3.6.1.6.2",
+	"snmpModules":     ".1.3.6.1.6.3",
+}
+
+// applicationTypes are the base types defined by the SMI, at which type definitions are no longer followed
+var applicationTypes = map[string]bool{
+	"Counter":        true,
+	"Counter32":      true,
+	"Counter64":      true,
+	"Gauge":          true,
+	"Gauge32":        true,
+	"Integer32":      true,
+	"Unsigned32":     true,
+	"TimeTicks":      true,
+	"IpAddress":      true,
+	"NetworkAddress": true,
+	"Opaque":         true,
+}
+
+// Load loads the MIB modules of all the files in the given directories. If several files define
+// the same module, the first one loaded is used. Objects whose OID cannot be resolved, for example
+// because a module they depend on is missing, are left out.
+func Load(dirs []string) (*MIB, error) {
+	m := &MIB{modules: map[string]*module{}, unresolved: map[string]error{}}
+	var moduleNames []string
+	for _, dir := range dirs {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read MIB directory: %w", err)
+		}
+		for _, entry := range entries {
+			if !entry.Type().IsRegular() || strings.HasPrefix(entry.Name(), ".") {
+				continue
+			}
+			path := filepath.Join(dir, entry.Name())
+			content, err := os.ReadFile(path)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read MIB file: %w", err)
+			}
+			modules, err := parse(string(content))
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse MIB file %s: %w", path, err)
+			}
+			for _, module := range modules {
+				if _, ok := m.modules[module.name]; ok {
+					continue
+				}
+				m.modules[module.name] = module
+				moduleNames = append(moduleNames, module.name)
+			}
+		}
+	}
+
+	sort.Strings(moduleNames)
+	r := &resolver{mib: m, oids: map[*node]string{}, resolving: map[*node]bool{}}
+	for _, moduleName := range moduleNames {
+		module := m.modules[moduleName]
+		for _, name := range module.order {
+			n := module.nodes[name]
+			oid, err := r.resolveNode(module, n)
+			if err != nil {
+				m.unresolved[n.name] = fmt.Errorf("%s::%s: %w", module.name, n.name, err)
+				continue
+			}
+			m.objects = append(m.objects, r.newObject(module, n, oid))
+		}
+	}
+
+	m.byName = map[string][]*Object{}
+	m.byOID = map[string]*Object{}
+	for _, object := range m.objects {
+		m.byName[object.Name] = append(m.byName[object.Name], object)
+		if _, ok := m.byOID[object.OID]; !ok {
+			m.byOID[object.OID] = object
+		}
+	}
+	return m, nil
+}
+
+// Resolve returns the object with the given name, either qualified with its module such as
+// "IF-MIB::ifHCInOctets", or unqualified if it is defined by a single module
+func (m *MIB) Resolve(name string) (*Object, error) {
+	if moduleName, objectName, ok := strings.Cut(name, "::"); ok {
+		for _, object := range m.byName[objectName] {
+			if object.Module == moduleName {
+				return object, nil
+			}
+		}
+		return nil, m.unknownObjectError(name, objectName)
+	}
+
+	objects := m.byName[name]
+	switch len(objects) {
+	case 0:
+		return nil, m.unknownObjectError(name, name)
+	case 1:
+		return objects[0], nil
+	default:
+		return nil, fmt.Errorf("ambiguous MIB object '%s', qualify it with its module", name)
+	}
+}
+
+// unknownObjectError returns the error of an unknown object, including why it could not be resolved if it is defined
+func (m *MIB) unknownObjectError(name, objectName string) error {
+	if err, ok := m.unresolved[objectName]; ok {
+		return fmt.Errorf("unknown MIB object '%s': %w", name, err)
+	}
+	return fmt.Errorf("unknown MIB object '%s'", name)
+}
+
+// ObjectByOID returns the object with the given numeric OID, if any
+func (m *MIB) ObjectByOID(oid string) (*Object, bool) {
+	if !strings.HasPrefix(oid, ".") {
+		oid = "." + oid
+	}
+	object, ok := m.byOID[oid]
+	return object, ok
+}
+
+// Objects returns all the objects of the loaded modules
+func (m *MIB) Objects() []*Object {
+	return m.objects
+}
+
+// resolver resolves the OIDs and types of the nodes of the loaded modules
+type resolver struct {
+	mib       *MIB
+	oids      map[*node]string
+	resolving map[*node]bool
+}
+
+// resolveNode returns the numeric OID of a node
+func (r *resolver) resolveNode(m *module, n *node) (string, error) {
+	if oid, ok := r.oids[n]; ok {
+		return oid, nil
+	}
+	if r.resolving[n] {
+		return "", errors.New("circular OID definition")
+	}
+	r.resolving[n] = true
+	defer delete(r.resolving, n)
+
+	var sb strings.Builder
+	for i, component := range n.value {
+		if component.name == "" {
+			sb.WriteString("." + strconv.FormatUint(component.number, 10))
+			continue
+		}
+		if i > 0 {
+			return "", fmt.Errorf("unexpected name '%s' in OID value", component.name)
+		}
+		oid, err := r.resolveName(m, component.name)
+		if err != nil {
+			return "", err
+		}
+		sb.WriteString(oid)
+	}
+
+	oid := sb.String()
+	r.oids[n] = oid
+	return oid, nil
+}
+
+// resolveName returns the numeric OID of a name referenced in a module
+func (r *resolver) resolveName(m *module, name string) (string, error) {
+	if n, ok := m.nodes[name]; ok {
+		return r.resolveNode(m, n)
+	}
+	if moduleName, ok := m.imports[name]; ok {
+		if imported, ok := r.mib.modules[moduleName]; ok {
+			if n, ok := imported.nodes[name]; ok {
+				return r.resolveNode(imported, n)
+			}
+		}
+	}
+	if oid, ok := builtinNodes[name]; ok {
+		return oid, nil
+	}
+	// Some MIB files omit imports, fall back on any module defining the name
+	for _, module := range r.mib.modules {
+		if n, ok := module.nodes[name]; ok {
+			return r.resolveNode(module, n)
+		}
+	}
+	return "", fmt.Errorf("unknown OID name '%s'", name)
+}
+
+// newObject creates the object of a resolved node
+func (r *resolver) newObject(m *module, n *node, oid string) *Object {
+	object := &Object{
+		Module:      m.name,
+		Name:        n.name,
+		OID:         oid,
+		Units:       n.units,
+		Description: normalizeDescription(n.description),
+	}
+
+	switch n.macro {
+	case "NOTIFICATION-TYPE", "TRAP-TYPE":
+		object.Kind = KindNotification
+	case "OBJECT-TYPE":
+		object.Type, object.Enums = r.resolveSyntax(m, n.syntax)
+		switch {
+		case n.syntax.sequenceOf:
+			object.Kind = KindTable
+		case n.hasIndex:
+			object.Kind = KindRow
+		case r.isRow(m, n):
+			object.Kind = KindColumn
+		default:
+			object.Kind = KindScalar
+		}
+	default:
+		object.Kind = KindNode
+	}
+	return object
+}
+
+// isRow returns whether the parent of a node is a table row
+func (r *resolver) isRow(m *module, n *node) bool {
+	if len(n.value) != 2 || n.value[0].name == "" {
+		return false
+	}
+	parentModule, parent := r.lookupNode(m, n.value[0].name)
+	return parent != nil && parent.macro == "OBJECT-TYPE" && (parent.hasIndex || r.isRowType(parentModule, parent.syntax.name))
+}
+
+// isRowType returns whether a type is the SEQUENCE of the entries of a table
+func (r *resolver) isRowType(m *module, name string) bool {
+	def := r.lookupType(m, name)
+	return def != nil && def.syntax.name == "SEQUENCE"
+}
+
+// lookupNode returns the node with the given name, along with the module defining it
+func (r *resolver) lookupNode(m *module, name string) (*module, *node) {
+	if n, ok := m.nodes[name]; ok {
+		return m, n
+	}
+	if imported, ok := r.mib.modules[m.imports[name]]; ok {
+		if n, ok := imported.nodes[name]; ok {
+			return imported, n
+		}
+	}
+	return nil, nil
+}
+
+// lookupType returns the definition of the type with the given name, if it is not a builtin type
+func (r *resolver) lookupType(m *module, name string) *typeDef {
+	if def, ok := m.types[name]; ok {
+		return def
+	}
+	if imported, ok := r.mib.modules[m.imports[name]]; ok {
+		if def, ok := imported.types[name]; ok {
+			return def
+		}
+	}
+	for _, module := range r.mib.modules {
+		if def, ok := module.types[name]; ok {
+			return def
+		}
+	}
+	return nil
+}
+
+// resolveSyntax follows the type definitions of a syntax up to its base type, returning it along
+// with the first enumerated values found
+func (r *resolver) resolveSyntax(m *module, s syntax) (string, []Enum) {
+	name, enums := s.name, s.enums
+	seen := map[string]bool{}
+	for !seen[name] && !applicationTypes[name] {
+		seen[name] = true
+		def := r.lookupType(m, name)
+		if def == nil || def.syntax.name == "SEQUENCE" {
+			break
+		}
+		name = def.syntax.name
+		if len(enums) == 0 {
+			enums = def.syntax.enums
+		}
+	}
+	return name, enums
+}
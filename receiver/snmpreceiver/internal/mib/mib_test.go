@@ -0,0 +1,209 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package mib // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/snmpreceiver/internal/mib"
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad(t *testing.T) {
+	m, err := Load([]string{filepath.Join("testdata", "mibs")})
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name     string
+		expected *Object
+	}{
+		{
+			name: "TEST-MIB::testMIB",
+			expected: &Object{
+				Module:      "TEST-MIB",
+				Name:        "testMIB",
+				OID:         ".1.3.6.1.4.1.99999",
+				Kind:        KindNode,
+				Description: "A module to test MIB loading.",
+			},
+		},
+		{
+			name: "testUptime",
+			expected: &Object{
+				Module:      "TEST-MIB",
+				Name:        "testUptime",
+				OID:         ".1.3.6.1.4.1.99999.1.1",
+				Kind:        KindScalar,
+				Type:        "Integer32",
+				Units:       "seconds",
+				Description: "The time since the thing started. It is reset on every restart.",
+			},
+		},
+		{
+			name: "testTable",
+			expected: &Object{
+				Module:      "TEST-MIB",
+				Name:        "testTable",
+				OID:         ".1.3.6.1.4.1.99999.1.2",
+				Kind:        KindTable,
+				Type:        "TestEntry",
+				Description: "A table of things.",
+			},
+		},
+		{
+			name: "testEntry",
+			expected: &Object{
+				Module:      "TEST-MIB",
+				Name:        "testEntry",
+				OID:         ".1.3.6.1.4.1.99999.1.2.1",
+				Kind:        KindRow,
+				Type:        "TestEntry",
+				Description: "A thing.",
+			},
+		},
+		{
+			name: "TEST-MIB::testName",
+			expected: &Object{
+				Module:      "TEST-MIB",
+				Name:        "testName",
+				OID:         ".1.3.6.1.4.1.99999.1.2.1.2",
+				Kind:        KindColumn,
+				Type:        "DisplayString",
+				Description: "The name of a thing.",
+			},
+		},
+		{
+			name: "testStatus",
+			expected: &Object{
+				Module:      "TEST-MIB",
+				Name:        "testStatus",
+				OID:         ".1.3.6.1.4.1.99999.1.2.1.3",
+				Kind:        KindColumn,
+				Type:        "INTEGER",
+				Description: "The status of a thing.",
+				Enums:       []Enum{{Label: "up", Value: 1}, {Label: "down", Value: 2}, {Label: "testing", Value: 3}},
+			},
+		},
+		{
+			name: "testLevel",
+			expected: &Object{
+				Module:      "TEST-MIB",
+				Name:        "testLevel",
+				OID:         ".1.3.6.1.4.1.99999.1.2.1.4",
+				Kind:        KindColumn,
+				Type:        "INTEGER",
+				Description: "The level of a thing.",
+				Enums:       []Enum{{Label: "low", Value: 1}, {Label: "high", Value: 2}},
+			},
+		},
+		{
+			name: "testOctets",
+			expected: &Object{
+				Module:      "TEST-MIB",
+				Name:        "testOctets",
+				OID:         ".1.3.6.1.4.1.99999.1.2.1.5",
+				Kind:        KindColumn,
+				Type:        "Counter64",
+				Units:       "octets",
+				Description: "The octets processed by a thing.",
+			},
+		},
+		{
+			name: "testStatusChange",
+			expected: &Object{
+				Module:      "TEST-MIB",
+				Name:        "testStatusChange",
+				OID:         ".1.3.6.1.4.1.99999.0.1",
+				Kind:        KindNotification,
+				Description: "The status of a thing changed.",
+			},
+		},
+		{
+			name: "TEST-V1-MIB::testName",
+			expected: &Object{
+				Module:      "TEST-V1-MIB",
+				Name:        "testName",
+				OID:         ".1.3.6.1.4.1.99998.1",
+				Kind:        KindScalar,
+				Type:        "OCTET STRING",
+				Description: "The name of a v1 thing.",
+			},
+		},
+		{
+			name: "testV1Trap",
+			expected: &Object{
+				Module:      "TEST-V1-MIB",
+				Name:        "testV1Trap",
+				OID:         ".1.3.6.1.4.1.99998.0.1",
+				Kind:        KindNotification,
+				Description: "A v1 trap.",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			object, err := m.Resolve(tc.name)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, object)
+
+			byOID, ok := m.ObjectByOID(tc.expected.OID[1:])
+			require.True(t, ok)
+			require.Equal(t, tc.expected, byOID)
+		})
+	}
+}
+
+func TestResolveErrors(t *testing.T) {
+	m, err := Load([]string{filepath.Join("testdata", "mibs")})
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name        string
+		expectedErr string
+	}{
+		{
+			name:        "unknownObject",
+			expectedErr: "unknown MIB object 'unknownObject'",
+		},
+		{
+			name:        "IF-MIB::testUptime",
+			expectedErr: "unknown MIB object 'IF-MIB::testUptime'",
+		},
+		{
+			name:        "testName",
+			expectedErr: "ambiguous MIB object 'testName', qualify it with its module",
+		},
+		{
+			name:        "testMissing",
+			expectedErr: "unknown MIB object 'testMissing': TEST-MIB::testMissing: unknown OID name 'missingNode'",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := m.Resolve(tc.name)
+			require.EqualError(t, err, tc.expectedErr)
+		})
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	_, err := Load([]string{filepath.Join("testdata", "missing")})
+	require.ErrorContains(t, err, "failed to read MIB directory")
+
+	_, err = Load([]string{filepath.Join("testdata", "broken")})
+	require.ErrorContains(t, err, "failed to parse MIB file")
+	require.ErrorContains(t, err, "brokenObject: unterminated OID value")
+}
+
+func TestLoadFirstModuleWins(t *testing.T) {
+	m, err := Load([]string{filepath.Join("testdata", "mibs"), filepath.Join("testdata", "mibs")})
+	require.NoError(t, err)
+
+	object, err := m.Resolve("testUptime")
+	require.NoError(t, err)
+	require.Equal(t, ".1.3.6.1.4.1.99999.1.1", object.OID)
+	require.Len(t, m.Objects(), 15)
+}
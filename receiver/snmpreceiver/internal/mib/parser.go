@@ -0,0 +1,452 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package mib // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/snmpreceiver/internal/mib"
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// macroKeywords are the macros with which OID values are defined
+var macroKeywords = map[string]bool{
+	"OBJECT-TYPE":        true,
+	"MODULE-IDENTITY":    true,
+	"OBJECT-IDENTITY":    true,
+	"NOTIFICATION-TYPE":  true,
+	"OBJECT-GROUP":       true,
+	"NOTIFICATION-GROUP": true,
+	"MODULE-COMPLIANCE":  true,
+	"AGENT-CAPABILITIES": true,
+	"TRAP-TYPE":          true,
+}
+
+// module is a parsed MIB module
+type module struct {
+	name string
+	// imports maps the imported symbols to the modules they are imported from
+	imports map[string]string
+	nodes   map[string]*node
+	// order contains the names of the nodes in the order they are defined
+	order []string
+	types map[string]*typeDef
+}
+
+// oidComponent is a component of an OID value, either a name or a number
+type oidComponent struct {
+	name   string
+	number uint64
+}
+
+// node is a definition of an OID value
+type node struct {
+	name        string
+	macro       string
+	value       []oidComponent
+	description string
+	// Only set for OBJECT-TYPE
+	syntax   syntax
+	units    string
+	hasIndex bool
+}
+
+// syntax is the type of an object, or of a type definition
+type syntax struct {
+	// name is the name of the type, such as "Counter64", "INTEGER" or a textual convention
+	name       string
+	enums      []Enum
+	sequenceOf bool
+}
+
+// typeDef is the definition of a type, such as a textual convention
+type typeDef struct {
+	name   string
+	syntax syntax
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+// parse parses the modules of a MIB file
+func parse(content string) ([]*module, error) {
+	tokens, err := tokenize(content)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+
+	var modules []*module
+	for p.peek().kind != tokenEOF {
+		m, err := p.parseModule()
+		if err != nil {
+			return nil, err
+		}
+		if m != nil {
+			modules = append(modules, m)
+		}
+	}
+	return modules, nil
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) peekAt(offset int) token {
+	if p.pos+offset >= len(p.tokens) {
+		return p.tokens[len(p.tokens)-1]
+	}
+	return p.tokens[p.pos+offset]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) expect(text string) error {
+	t := p.next()
+	if !t.is(text) {
+		return fmt.Errorf("line %d: expected '%s', found '%s'", t.line, text, t.text)
+	}
+	return nil
+}
+
+// skipBalanced skips tokens up to and including the token closing the current open token
+func (p *parser) skipBalanced(open, closing string) {
+	depth := 1
+	for depth > 0 {
+		t := p.next()
+		switch {
+		case t.kind == tokenEOF:
+			return
+		case t.is(open):
+			depth++
+		case t.is(closing):
+			depth--
+		}
+	}
+}
+
+// parseModule parses a module, from its name up to and including its END
+func (p *parser) parseModule() (*module, error) {
+	// Find the start of the module: NAME [{ oid }] DEFINITIONS ::= BEGIN
+	name := ""
+	for !p.peek().is("DEFINITIONS") {
+		t := p.next()
+		switch {
+		case t.kind == tokenEOF:
+			return nil, nil
+		case t.is("{"):
+			p.skipBalanced("{", "}")
+		case t.kind == tokenIdent:
+			name = t.text
+		}
+	}
+	if name == "" {
+		return nil, fmt.Errorf("line %d: module without name", p.peek().line)
+	}
+	m := &module{
+		name:    name,
+		imports: map[string]string{},
+		nodes:   map[string]*node{},
+		types:   map[string]*typeDef{},
+	}
+	p.next()
+	if err := p.expect("::="); err != nil {
+		return nil, err
+	}
+	if err := p.expect("BEGIN"); err != nil {
+		return nil, err
+	}
+
+	for {
+		t := p.peek()
+		switch {
+		case t.kind == tokenEOF:
+			return nil, fmt.Errorf("module %s: missing END", m.name)
+		case t.is("END"):
+			p.next()
+			return m, nil
+		case t.is("IMPORTS"):
+			p.next()
+			p.parseImports(m)
+		case t.is("EXPORTS"):
+			for !p.next().is(";") && p.peek().kind != tokenEOF {
+			}
+		case t.kind == tokenIdent:
+			if err := p.parseAssignment(m); err != nil {
+				return nil, fmt.Errorf("module %s: %w", m.name, err)
+			}
+		default:
+			p.next()
+		}
+	}
+}
+
+// parseImports parses the symbols imported from other modules, up to and including the ';'
+func (p *parser) parseImports(m *module) {
+	var symbols []string
+	for {
+		t := p.next()
+		switch {
+		case t.kind == tokenEOF, t.is(";"):
+			return
+		case t.is("FROM"):
+			from := p.next().text
+			for _, symbol := range symbols {
+				m.imports[symbol] = from
+			}
+			symbols = symbols[:0]
+		case t.kind == tokenIdent:
+			symbols = append(symbols, t.text)
+		}
+	}
+}
+
+// parseAssignment parses a value or type assignment
+func (p *parser) parseAssignment(m *module) error {
+	name := p.next()
+	t := p.peek()
+	switch {
+	case t.is("MACRO"):
+		// Macro definitions are not needed to parse the values they define
+		for !p.next().is("END") && p.peek().kind != tokenEOF {
+		}
+		return nil
+	case t.is("::="):
+		p.next()
+		return p.parseTypeAssignment(m, name.text)
+	case t.is("OBJECT") && p.peekAt(1).is("IDENTIFIER"):
+		p.pos += 2
+		return p.parseNode(m, &node{name: name.text, macro: "OBJECT IDENTIFIER"})
+	case t.kind == tokenIdent && macroKeywords[t.text]:
+		p.next()
+		return p.parseNode(m, &node{name: name.text, macro: t.text})
+	case t.kind == tokenIdent && p.peekAt(1).is("::="):
+		// Value assignments of other types, such as "name INTEGER ::= 1"
+		p.pos += 2
+		if p.next().is("{") {
+			p.skipBalanced("{", "}")
+		}
+		return nil
+	default:
+		return nil
+	}
+}
+
+// parseTypeAssignment parses the type assigned to a name, such as a textual convention
+func (p *parser) parseTypeAssignment(m *module, name string) error {
+	if p.peek().is("TEXTUAL-CONVENTION") {
+		p.next()
+		for !p.peek().is("SYNTAX") {
+			if p.peek().kind == tokenEOF {
+				return fmt.Errorf("textual convention %s: missing SYNTAX", name)
+			}
+			p.next()
+		}
+		p.next()
+	}
+	s, err := p.parseSyntax()
+	if err != nil {
+		return fmt.Errorf("type %s: %w", name, err)
+	}
+	m.types[name] = &typeDef{name: name, syntax: s}
+	return nil
+}
+
+// parseNode parses the clauses of a node up to and including its OID value
+func (p *parser) parseNode(m *module, n *node) error {
+	enterprise := ""
+	for !p.peek().is("::=") {
+		t := p.next()
+		switch {
+		case t.kind == tokenEOF:
+			return fmt.Errorf("%s: missing '::='", n.name)
+		case n.macro == "TRAP-TYPE" && t.is("ENTERPRISE"):
+			enterprise = p.next().text
+		case t.is("DESCRIPTION") && p.peek().kind == tokenString:
+			// Only the first description describes the node, a MODULE-IDENTITY has one per revision
+			if n.description == "" {
+				n.description = p.next().text
+			}
+		case n.macro != "OBJECT-TYPE":
+			continue
+		case t.is("SYNTAX"):
+			s, err := p.parseSyntax()
+			if err != nil {
+				return fmt.Errorf("%s: %w", n.name, err)
+			}
+			n.syntax = s
+		case t.is("UNITS") && p.peek().kind == tokenString:
+			n.units = p.next().text
+		case t.is("INDEX"), t.is("AUGMENTS"):
+			n.hasIndex = true
+		case t.is("{"):
+			// Skip the values of clauses such as DEFVAL
+			p.skipBalanced("{", "}")
+		}
+	}
+	p.next()
+
+	// TRAP-TYPE values are specific trap numbers, their OID is the enterprise followed by 0 and the
+	// number, as defined by RFC 3584
+	if n.macro == "TRAP-TYPE" {
+		t := p.next()
+		number, err := strconv.ParseUint(t.text, 10, 32)
+		if err != nil || t.kind != tokenNumber || enterprise == "" {
+			return fmt.Errorf("%s: invalid trap value '%s'", n.name, t.text)
+		}
+		n.value = []oidComponent{{name: enterprise}, {number: 0}, {number: number}}
+		m.addNode(n)
+		return nil
+	}
+	if err := p.expect("{"); err != nil {
+		return fmt.Errorf("%s: %w", n.name, err)
+	}
+
+	var value []oidComponent
+	for !p.peek().is("}") {
+		t := p.next()
+		switch {
+		case t.kind == tokenEOF:
+			return fmt.Errorf("%s: unterminated OID value", n.name)
+		case t.kind == tokenNumber:
+			number, err := strconv.ParseUint(t.text, 10, 32)
+			if err != nil {
+				return fmt.Errorf("%s: invalid OID component '%s'", n.name, t.text)
+			}
+			value = append(value, oidComponent{number: number})
+		case t.kind == tokenIdent && p.peek().is("("):
+			// Named number, such as org(3), which also defines the name
+			p.next()
+			numberToken := p.next()
+			number, err := strconv.ParseUint(numberToken.text, 10, 32)
+			if err != nil {
+				return fmt.Errorf("%s: invalid OID component '%s'", n.name, numberToken.text)
+			}
+			if err = p.expect(")"); err != nil {
+				return err
+			}
+			if len(value) > 0 {
+				m.addNode(&node{name: t.text, macro: "OBJECT IDENTIFIER", value: append(append([]oidComponent(nil), value...), oidComponent{number: number})})
+				value = []oidComponent{{name: t.text}}
+			} else {
+				value = append(value, oidComponent{number: number})
+			}
+		case t.kind == tokenIdent:
+			value = append(value, oidComponent{name: t.text})
+		}
+	}
+	p.next()
+
+	if len(value) == 0 {
+		return fmt.Errorf("%s: empty OID value", n.name)
+	}
+	n.value = value
+	m.addNode(n)
+	return nil
+}
+
+// parseSyntax parses a type, such as "Counter64", "INTEGER { up(1), down(2) }" or "SEQUENCE OF IfEntry"
+func (p *parser) parseSyntax() (syntax, error) {
+	var s syntax
+
+	// Skip tags, such as [APPLICATION 1] IMPLICIT
+	if p.peek().is("[") {
+		p.next()
+		p.skipBalanced("[", "]")
+	}
+	if p.peek().is("IMPLICIT") {
+		p.next()
+	}
+
+	t := p.next()
+	switch {
+	case t.kind != tokenIdent:
+		return s, fmt.Errorf("line %d: expected type, found '%s'", t.line, t.text)
+	case t.is("OCTET") && p.peek().is("STRING"):
+		p.next()
+		s.name = "OCTET STRING"
+	case t.is("OBJECT") && p.peek().is("IDENTIFIER"):
+		p.next()
+		s.name = "OBJECT IDENTIFIER"
+	case t.is("SEQUENCE") && p.peek().is("OF"):
+		p.next()
+		s.name = p.next().text
+		s.sequenceOf = true
+		return s, nil
+	case t.is("SEQUENCE"), t.is("CHOICE"):
+		s.name = t.text
+		if p.peek().is("{") {
+			p.next()
+			p.skipBalanced("{", "}")
+		}
+		return s, nil
+	default:
+		s.name = t.text
+	}
+
+	// Named numbers, such as INTEGER { up(1), down(2) }
+	if p.peek().is("{") {
+		p.next()
+		enums, err := p.parseNamedNumbers()
+		if err != nil {
+			return s, err
+		}
+		s.enums = enums
+	}
+	// Constraints, such as (0..255) or (SIZE (0..255))
+	if p.peek().is("(") {
+		p.next()
+		p.skipBalanced("(", ")")
+	}
+	return s, nil
+}
+
+// parseNamedNumbers parses named numbers up to and including the closing '}'
+func (p *parser) parseNamedNumbers() ([]Enum, error) {
+	var enums []Enum
+	for {
+		t := p.next()
+		switch {
+		case t.kind == tokenEOF:
+			return nil, fmt.Errorf("line %d: unterminated named numbers", t.line)
+		case t.is("}"):
+			return enums, nil
+		case t.kind == tokenIdent:
+			if err := p.expect("("); err != nil {
+				return nil, err
+			}
+			numberToken := p.next()
+			number, err := strconv.ParseInt(numberToken.text, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid named number '%s'", numberToken.line, numberToken.text)
+			}
+			if err = p.expect(")"); err != nil {
+				return nil, err
+			}
+			enums = append(enums, Enum{Label: t.text, Value: number})
+		}
+	}
+}
+
+// addNode adds a node to the module, ignoring redefinitions
+func (m *module) addNode(n *node) {
+	if _, ok := m.nodes[n.name]; ok {
+		return
+	}
+	m.nodes[n.name] = n
+	m.order = append(m.order, n.name)
+}
+
+// normalizeDescription collapses the whitespace of a description
+func normalizeDescription(description string) string {
+	return strings.Join(strings.Fields(description), " ")
+}
@@ -0,0 +1,375 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package snmpreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/snmpreceiver"
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"go.opentelemetry.io/collector/confmap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/snmpreceiver/internal/mib"
+)
+
+var (
+	// MIB error messages
+	errMsgOIDNameNoMIB                = `OID '%s' is not numeric, mib_directories must be set to reference MIB objects by name`
+	errMsgOIDNameBadInstance          = `OID '%s' must be followed by a numeric instance`
+	errMsgMetricBadOIDName            = `metric '%s': %w`
+	errMsgAttributeBadOIDName         = `attribute '%s': %w`
+	errMsgResourceAttributeBadOIDName = `resource_attribute '%s': %w`
+)
+
+// mibUnits maps the UNITS of MIB objects to UCUM units
+var mibUnits = map[string]string{
+	"seconds":      "s",
+	"milliseconds": "ms",
+	"microseconds": "us",
+	"octets":       "By",
+	"bytes":        "By",
+	"kbytes":       "KiBy",
+	"kilobytes":    "KiBy",
+	"bits":         "bit",
+	"bits/second":  "bit/s",
+	"percent":      "%",
+	"celsius":      "Cel",
+	"watts":        "W",
+	"volts":        "V",
+	"rpm":          "{rpm}",
+}
+
+// Unmarshal a confmap.Conf into the config struct, then add the configs of the profiles and resolve the
+// MIB object names.
+func (cfg *Config) Unmarshal(conf *confmap.Conf) error {
+	if err := conf.Unmarshal(cfg); err != nil {
+		return err
+	}
+
+	return cfg.resolveMIBs()
+}
+
+// resolveMIBs adds the configs of the profiles, loads the MIB files, replaces the MIB object names of the
+// OIDs with numeric OIDs, and derives the settings that are not configured from the MIB objects. The MIBs
+// of a config are only resolved once.
+func (cfg *Config) resolveMIBs() error {
+	if cfg.mibsResolved {
+		return nil
+	}
+	cfg.mibsResolved = true
+	cfg.enumLabels = nil
+	cfg.applyProfiles()
+
+	var m *mib.MIB
+	if len(cfg.MIBDirectories) > 0 {
+		var err error
+		m, err = mib.Load(cfg.MIBDirectories)
+		if err != nil {
+			return err
+		}
+
+		cfg.mibOIDNames = make(map[string]string, len(m.Objects()))
+		for _, object := range m.Objects() {
+			cfg.mibOIDNames[object.OID] = object.Name
+		}
+	}
+
+	var combinedErr error
+	for metricName, metricCfg := range cfg.Metrics {
+		// The first OID of the metric is the one its settings are derived from
+		var object *mib.Object
+		for i := range metricCfg.ScalarOIDs {
+			oid, o, err := resolveOID(m, metricCfg.ScalarOIDs[i].OID)
+			if err != nil {
+				combinedErr = errors.Join(combinedErr, fmt.Errorf(errMsgMetricBadOIDName, metricName, err))
+				continue
+			}
+			metricCfg.ScalarOIDs[i].OID = oid
+			if object == nil {
+				object = o
+			}
+		}
+		for i := range metricCfg.ColumnOIDs {
+			oid, o, err := resolveOID(m, metricCfg.ColumnOIDs[i].OID)
+			if err != nil {
+				combinedErr = errors.Join(combinedErr, fmt.Errorf(errMsgMetricBadOIDName, metricName, err))
+				continue
+			}
+			metricCfg.ColumnOIDs[i].OID = oid
+			if object == nil {
+				object = o
+			}
+		}
+		if object != nil {
+			applyMIBObjectToMetric(metricCfg, object)
+		}
+	}
+
+	for attrName, attrCfg := range cfg.Attributes {
+		oid, object, err := resolveOID(m, attrCfg.OID)
+		if err != nil {
+			combinedErr = errors.Join(combinedErr, fmt.Errorf(errMsgAttributeBadOIDName, attrName, err))
+			continue
+		}
+		attrCfg.OID = oid
+		if object == nil {
+			continue
+		}
+		if attrCfg.Description == "" {
+			attrCfg.Description = firstSentence(object.Description)
+		}
+		if len(object.Enums) > 0 {
+			if len(attrCfg.Enum) == 0 {
+				attrCfg.Enum = enumLabels(object.Enums)
+			}
+			cfg.addEnumLabels(oid, object.Enums)
+		}
+	}
+
+	for attrName, attrCfg := range cfg.ResourceAttributes {
+		oid, object, err := resolveOID(m, attrCfg.OID)
+		if err != nil {
+			combinedErr = errors.Join(combinedErr, fmt.Errorf(errMsgResourceAttributeBadOIDName, attrName, err))
+			continue
+		}
+		attrCfg.OID = oid
+		if object == nil {
+			oid, object, err = resolveOID(m, attrCfg.ScalarOID)
+			if err != nil {
+				combinedErr = errors.Join(combinedErr, fmt.Errorf(errMsgResourceAttributeBadOIDName, attrName, err))
+				continue
+			}
+			attrCfg.ScalarOID = oid
+		}
+		if object == nil {
+			continue
+		}
+		if attrCfg.Description == "" {
+			attrCfg.Description = firstSentence(object.Description)
+		}
+		if len(object.Enums) > 0 {
+			cfg.addEnumLabels(oid, object.Enums)
+		}
+	}
+
+	return combinedErr
+}
+
+// validateMIBNames checks that the MIB object names of the OIDs of a config whose MIBs are not resolved yet,
+// such as a config built in code, can be resolved. The config is left unchanged.
+func validateMIBNames(cfg *Config) error {
+	if cfg.mibsResolved {
+		return nil
+	}
+
+	var oids []string
+	for _, metricCfg := range cfg.Metrics {
+		for _, scalarOID := range metricCfg.ScalarOIDs {
+			oids = append(oids, scalarOID.OID)
+		}
+		for _, columnOID := range metricCfg.ColumnOIDs {
+			oids = append(oids, columnOID.OID)
+		}
+	}
+	for _, attrCfg := range cfg.Attributes {
+		oids = append(oids, attrCfg.OID)
+	}
+	for _, attrCfg := range cfg.ResourceAttributes {
+		oids = append(oids, attrCfg.OID, attrCfg.ScalarOID)
+	}
+	if !slices.ContainsFunc(oids, func(oid string) bool { return oid != "" && !isNumericOID(oid) }) {
+		return nil
+	}
+
+	var m *mib.MIB
+	if len(cfg.MIBDirectories) > 0 {
+		var err error
+		m, err = mib.Load(cfg.MIBDirectories)
+		if err != nil {
+			return err
+		}
+	}
+
+	var combinedErr error
+	for metricName, metricCfg := range cfg.Metrics {
+		for _, scalarOID := range metricCfg.ScalarOIDs {
+			if _, _, err := resolveOID(m, scalarOID.OID); err != nil {
+				combinedErr = errors.Join(combinedErr, fmt.Errorf(errMsgMetricBadOIDName, metricName, err))
+			}
+		}
+		for _, columnOID := range metricCfg.ColumnOIDs {
+			if _, _, err := resolveOID(m, columnOID.OID); err != nil {
+				combinedErr = errors.Join(combinedErr, fmt.Errorf(errMsgMetricBadOIDName, metricName, err))
+			}
+		}
+	}
+	for attrName, attrCfg := range cfg.Attributes {
+		if _, _, err := resolveOID(m, attrCfg.OID); err != nil {
+			combinedErr = errors.Join(combinedErr, fmt.Errorf(errMsgAttributeBadOIDName, attrName, err))
+		}
+	}
+	for attrName, attrCfg := range cfg.ResourceAttributes {
+		for _, oid := range []string{attrCfg.OID, attrCfg.ScalarOID} {
+			if _, _, err := resolveOID(m, oid); err != nil {
+				combinedErr = errors.Join(combinedErr, fmt.Errorf(errMsgResourceAttributeBadOIDName, attrName, err))
+			}
+		}
+	}
+	return combinedErr
+}
+
+// applyProfiles adds the metric and attribute configs of the profiles which are not configured
+func (cfg *Config) applyProfiles() {
+	for _, name := range cfg.Profiles {
+		newProfile, ok := profiles[name]
+		if !ok {
+			// Reported by Validate
+			continue
+		}
+		p := newProfile()
+
+		if cfg.Attributes == nil {
+			cfg.Attributes = map[string]*AttributeConfig{}
+		}
+		for attrName, attrCfg := range p.attributes {
+			if _, ok := cfg.Attributes[attrName]; ok {
+				continue
+			}
+			cfg.Attributes[attrName] = attrCfg
+			if enums, ok := p.enums[attrCfg.OID]; ok {
+				cfg.addEnumLabels(attrCfg.OID, enums)
+			}
+		}
+
+		if cfg.Metrics == nil {
+			cfg.Metrics = map[string]*MetricConfig{}
+		}
+		for metricName, metricCfg := range p.metrics {
+			if _, ok := cfg.Metrics[metricName]; !ok {
+				cfg.Metrics[metricName] = metricCfg
+			}
+		}
+	}
+}
+
+// addEnumLabels records the labels with which the enumerated values of the OID are replaced
+func (cfg *Config) addEnumLabels(oid string, enums []mib.Enum) {
+	labels := make(map[int64]string, len(enums))
+	for _, enum := range enums {
+		labels[enum.Value] = enum.Label
+	}
+	if cfg.enumLabels == nil {
+		cfg.enumLabels = map[string]map[int64]string{}
+	}
+	cfg.enumLabels[normalizeOID(oid)] = labels
+}
+
+// resolveOID resolves an OID which may be the name of a MIB object, optionally qualified with its module and
+// followed by an instance, such as "IF-MIB::ifDescr" or "sysName.0". A scalar object without an instance
+// resolves to the OID of its instance. The MIB object of the OID, if any, is returned along with it.
+func resolveOID(m *mib.MIB, oid string) (string, *mib.Object, error) {
+	if oid == "" {
+		return oid, nil, nil
+	}
+
+	if isNumericOID(oid) {
+		if m == nil {
+			return oid, nil, nil
+		}
+		object, ok := m.ObjectByOID(oid)
+		if !ok {
+			object, ok = m.ObjectByOID(strings.TrimSuffix(oid, ".0"))
+		}
+		if !ok {
+			return oid, nil, nil
+		}
+		return oid, object, nil
+	}
+
+	if m == nil {
+		return "", nil, fmt.Errorf(errMsgOIDNameNoMIB, oid)
+	}
+
+	// Module names may contain dashes but no dots, the instance starts at the first dot after the module
+	moduleName, name, qualified := strings.Cut(oid, "::")
+	if !qualified {
+		moduleName, name = "", oid
+	}
+	name, instance, hasInstance := strings.Cut(name, ".")
+	if hasInstance && !isNumericOID(instance) {
+		return "", nil, fmt.Errorf(errMsgOIDNameBadInstance, oid)
+	}
+	if qualified {
+		name = moduleName + "::" + name
+	}
+
+	object, err := m.Resolve(name)
+	if err != nil {
+		return "", nil, err
+	}
+
+	switch {
+	case hasInstance:
+		return object.OID + "." + instance, object, nil
+	case object.Kind == mib.KindScalar:
+		return object.OID + ".0", object, nil
+	default:
+		return object.OID, object, nil
+	}
+}
+
+// applyMIBObjectToMetric derives the unit, description and type of the metric from its MIB object, if they
+// are not configured
+func applyMIBObjectToMetric(metricCfg *MetricConfig, object *mib.Object) {
+	if metricCfg.Unit == "" {
+		metricCfg.Unit = mibUnit(object)
+	}
+
+	if metricCfg.Description == "" {
+		metricCfg.Description = firstSentence(object.Description)
+	}
+
+	if metricCfg.Gauge == nil && metricCfg.Sum == nil {
+		switch object.Type {
+		case "Counter", "Counter32", "Counter64":
+			metricCfg.Sum = &SumMetric{Aggregation: "cumulative", Monotonic: true, ValueType: "int"}
+		default:
+			metricCfg.Gauge = &GaugeMetric{ValueType: "int"}
+		}
+	}
+}
+
+// mibUnit returns the UCUM unit of the MIB object, defaulting to an annotation of its UNITS
+func mibUnit(object *mib.Object) string {
+	switch {
+	case object.Units != "":
+		if unit, ok := mibUnits[strings.ToLower(object.Units)]; ok {
+			return unit
+		}
+		return "{" + object.Units + "}"
+	case object.Type == "TimeTicks":
+		// Hundredths of seconds
+		return "cs"
+	default:
+		return "1"
+	}
+}
+
+// firstSentence returns the first sentence of the description of a MIB object
+func firstSentence(description string) string {
+	if i := strings.Index(description, ". "); i >= 0 {
+		return description[:i+1]
+	}
+	return description
+}
+
+// enumLabels returns the labels of the enumerated values
+func enumLabels(enums []mib.Enum) []string {
+	labels := make([]string, 0, len(enums))
+	for _, enum := range enums {
+		labels = append(labels, enum.Label)
+	}
+	return labels
+}
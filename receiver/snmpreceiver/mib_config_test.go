@@ -0,0 +1,190 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package snmpreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/snmpreceiver"
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/snmpreceiver/internal/mib"
+)
+
+func TestResolveOID(t *testing.T) {
+	m, err := mib.Load([]string{filepath.Join("testdata", "mibs")})
+	require.NoError(t, err)
+
+	testCases := []struct {
+		desc           string
+		mib            *mib.MIB
+		oid            string
+		expectedOID    string
+		expectedObject string
+		expectedErr    string
+	}{
+		{
+			desc:        "Numeric OID without MIB",
+			oid:         "1.3.6.1.4.1.99999.1.3.1.4",
+			expectedOID: "1.3.6.1.4.1.99999.1.3.1.4",
+		},
+		{
+			desc:        "Name without MIB",
+			oid:         "TEST-MIB::testOctets",
+			expectedErr: fmt.Sprintf(errMsgOIDNameNoMIB, "TEST-MIB::testOctets"),
+		},
+		{
+			desc:           "Numeric OID of MIB object",
+			mib:            m,
+			oid:            ".1.3.6.1.4.1.99999.1.3.1.4",
+			expectedOID:    ".1.3.6.1.4.1.99999.1.3.1.4",
+			expectedObject: "testOctets",
+		},
+		{
+			desc:           "Numeric OID of MIB scalar object instance",
+			mib:            m,
+			oid:            ".1.3.6.1.4.1.99999.1.2.0",
+			expectedOID:    ".1.3.6.1.4.1.99999.1.2.0",
+			expectedObject: "testUptime",
+		},
+		{
+			desc:        "Numeric OID without MIB object",
+			mib:         m,
+			oid:         ".1.3.6.1.4.1.99999.1.3.1.9",
+			expectedOID: ".1.3.6.1.4.1.99999.1.3.1.9",
+		},
+		{
+			desc:           "Qualified column name",
+			mib:            m,
+			oid:            "TEST-MIB::testOctets",
+			expectedOID:    ".1.3.6.1.4.1.99999.1.3.1.4",
+			expectedObject: "testOctets",
+		},
+		{
+			desc:           "Unqualified scalar name resolves to its instance",
+			mib:            m,
+			oid:            "testUptime",
+			expectedOID:    ".1.3.6.1.4.1.99999.1.2.0",
+			expectedObject: "testUptime",
+		},
+		{
+			desc:           "Name with instance",
+			mib:            m,
+			oid:            "TEST-MIB::testName.3",
+			expectedOID:    ".1.3.6.1.4.1.99999.1.3.1.2.3",
+			expectedObject: "testName",
+		},
+		{
+			desc:        "Name with bad instance",
+			mib:         m,
+			oid:         "TEST-MIB::testName.x",
+			expectedErr: fmt.Sprintf(errMsgOIDNameBadInstance, "TEST-MIB::testName.x"),
+		},
+		{
+			desc:        "Unknown name",
+			mib:         m,
+			oid:         "IF-MIB::ifDescr",
+			expectedErr: "unknown MIB object 'IF-MIB::ifDescr'",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			oid, object, err := resolveOID(tc.mib, tc.oid)
+			if tc.expectedErr != "" {
+				require.EqualError(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedOID, oid)
+			if tc.expectedObject == "" {
+				require.Nil(t, object)
+			} else {
+				require.Equal(t, tc.expectedObject, object.Name)
+			}
+		})
+	}
+}
+
+func TestApplyMIBObjectToMetric(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		object   *mib.Object
+		metric   *MetricConfig
+		expected *MetricConfig
+	}{
+		{
+			desc:   "Counter with known units",
+			object: &mib.Object{Type: "Counter64", Units: "Octets", Description: "The octets. More details."},
+			metric: &MetricConfig{},
+			expected: &MetricConfig{
+				Description: "The octets.",
+				Unit:        "By",
+				Sum:         &SumMetric{Aggregation: "cumulative", Monotonic: true, ValueType: "int"},
+			},
+		},
+		{
+			desc:   "Gauge with unknown units",
+			object: &mib.Object{Type: "Gauge32", Units: "packets per second", Description: "The rate"},
+			metric: &MetricConfig{},
+			expected: &MetricConfig{
+				Description: "The rate",
+				Unit:        "{packets per second}",
+				Gauge:       &GaugeMetric{ValueType: "int"},
+			},
+		},
+		{
+			desc:   "Without units",
+			object: &mib.Object{Type: "INTEGER"},
+			metric: &MetricConfig{},
+			expected: &MetricConfig{
+				Unit:  "1",
+				Gauge: &GaugeMetric{ValueType: "int"},
+			},
+		},
+		{
+			desc:   "Configured settings are kept",
+			object: &mib.Object{Type: "Counter32", Units: "seconds", Description: "The time."},
+			metric: &MetricConfig{
+				Description: "The configured time.",
+				Unit:        "ms",
+				Gauge:       &GaugeMetric{ValueType: "double"},
+			},
+			expected: &MetricConfig{
+				Description: "The configured time.",
+				Unit:        "ms",
+				Gauge:       &GaugeMetric{ValueType: "double"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			applyMIBObjectToMetric(tc.metric, tc.object)
+			require.Equal(t, tc.expected, tc.metric)
+		})
+	}
+}
+
+func TestProfiles(t *testing.T) {
+	for name := range profiles {
+		t.Run(name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.Profiles = []string{name}
+			require.NoError(t, cfg.resolveMIBs())
+			require.NotEmpty(t, cfg.Metrics)
+			require.NoError(t, cfg.Validate())
+		})
+	}
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Profiles = []string{"ENTITY-SENSOR-MIB"}
+	require.NoError(t, cfg.resolveMIBs())
+	require.Equal(t, "celsius", cfg.enumLabels[".1.3.6.1.2.1.99.1.1.1.1"][8])
+	require.Equal(t, []string{"ok", "unavailable", "nonoperational"}, cfg.Attributes["entity.sensor.status"].Enum)
+	require.Equal(t, ".1.3.6.1.2.1.99.1.1.1.3", cfg.Attributes["entity.sensor.precision"].OID)
+	require.Len(t, cfg.Metrics, 1)
+	require.Len(t, cfg.Metrics["entity.sensor.value"].ColumnOIDs[0].Attributes, 5)
+}
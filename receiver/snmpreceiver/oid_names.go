@@ -0,0 +1,89 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package snmpreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/snmpreceiver"
+
+import (
+	"strings"
+)
+
+const (
+	// snmpTrapOID is the OID of the variable binding holding the OID of a v2c or v3 trap
+	snmpTrapOID = ".1.3.6.1.6.3.1.1.4.1.0"
+	// snmpTrapsOID is the prefix of the OIDs of the generic traps
+	snmpTrapsOID = ".1.3.6.1.6.3.1.1.5"
+)
+
+// builtinOIDNames contains the names of the OIDs commonly found in traps
+var builtinOIDNames = map[string]string{
+	// SNMPv2-MIB system group
+	".1.3.6.1.2.1.1.1": "sysDescr",
+	".1.3.6.1.2.1.1.2": "sysObjectID",
+	".1.3.6.1.2.1.1.3": "sysUpTime",
+	".1.3.6.1.2.1.1.4": "sysContact",
+	".1.3.6.1.2.1.1.5": "sysName",
+	".1.3.6.1.2.1.1.6": "sysLocation",
+	// SNMPv2-MIB trap objects
+	".1.3.6.1.6.3.1.1.4.1": "snmpTrapOID",
+	".1.3.6.1.6.3.1.1.4.3": "snmpTrapEnterprise",
+	// SNMPv2-MIB generic traps
+	".1.3.6.1.6.3.1.1.5.1": "coldStart",
+	".1.3.6.1.6.3.1.1.5.2": "warmStart",
+	".1.3.6.1.6.3.1.1.5.3": "linkDown",
+	".1.3.6.1.6.3.1.1.5.4": "linkUp",
+	".1.3.6.1.6.3.1.1.5.5": "authenticationFailure",
+	".1.3.6.1.6.3.1.1.5.6": "egpNeighborLoss",
+	// SNMP-COMMUNITY-MIB trap objects
+	".1.3.6.1.6.3.18.1.3": "snmpTrapAddress",
+	".1.3.6.1.6.3.18.1.4": "snmpTrapCommunity",
+	// IF-MIB interface table columns
+	".1.3.6.1.2.1.2.2.1.1":     "ifIndex",
+	".1.3.6.1.2.1.2.2.1.2":     "ifDescr",
+	".1.3.6.1.2.1.2.2.1.3":     "ifType",
+	".1.3.6.1.2.1.2.2.1.7":     "ifAdminStatus",
+	".1.3.6.1.2.1.2.2.1.8":     "ifOperStatus",
+	".1.3.6.1.2.1.31.1.1.1.1":  "ifName",
+	".1.3.6.1.2.1.31.1.1.1.18": "ifAlias",
+}
+
+// oidNames resolves OIDs to names
+type oidNames struct {
+	names map[string]string
+}
+
+// newOIDNames creates an oidNames with the builtin names, overridden by each of the given names in turn
+func newOIDNames(names ...map[string]string) *oidNames {
+	n := &oidNames{
+		names: make(map[string]string, len(builtinOIDNames)),
+	}
+	for oid, name := range builtinOIDNames {
+		n.names[oid] = name
+	}
+	for _, overrides := range names {
+		for oid, name := range overrides {
+			n.names[normalizeOID(oid)] = name
+		}
+	}
+	return n
+}
+
+// resolve returns the name of the OID. An OID without a name is named after its longest named
+// prefix, followed by the rest of the OID, e.g. "ifIndex.3". The OID itself is returned if none
+// of its prefixes are named.
+func (n *oidNames) resolve(oid string) string {
+	oid = normalizeOID(oid)
+	for prefix := oid; prefix != ""; prefix = prefix[:strings.LastIndex(prefix, ".")] {
+		if name, ok := n.names[prefix]; ok {
+			return name + oid[len(prefix):]
+		}
+	}
+	return oid
+}
+
+// normalizeOID adds the '.' prefix to the OID, matching the OIDs returned by gosnmp
+func normalizeOID(oid string) string {
+	if !strings.HasPrefix(oid, ".") {
+		return "." + oid
+	}
+	return oid
+}
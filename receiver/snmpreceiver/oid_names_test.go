@@ -0,0 +1,75 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package snmpreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/snmpreceiver"
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOIDNamesResolve(t *testing.T) {
+	names := newOIDNames(map[string]string{
+		"1.3.6.1.4.1.9.9.41.1.2.3.1.5": "clogHistMsgText",
+		".1.3.6.1.2.1.1.5":             "hostname",
+	})
+
+	testCases := []struct {
+		desc     string
+		oid      string
+		expected string
+	}{
+		{
+			desc:     "Builtin name",
+			oid:      ".1.3.6.1.6.3.1.1.5.3",
+			expected: "linkDown",
+		},
+		{
+			desc:     "Builtin name of scalar instance",
+			oid:      ".1.3.6.1.2.1.1.3.0",
+			expected: "sysUpTime.0",
+		},
+		{
+			desc:     "Builtin name of column instance",
+			oid:      ".1.3.6.1.2.1.2.2.1.8.12",
+			expected: "ifOperStatus.12",
+		},
+		{
+			desc:     "Configured name without leading dot",
+			oid:      ".1.3.6.1.4.1.9.9.41.1.2.3.1.5.42",
+			expected: "clogHistMsgText.42",
+		},
+		{
+			desc:     "Configured name overrides builtin name",
+			oid:      ".1.3.6.1.2.1.1.5.0",
+			expected: "hostname.0",
+		},
+		{
+			desc:     "OID without leading dot",
+			oid:      "1.3.6.1.2.1.2.2.1.1.3",
+			expected: "ifIndex.3",
+		},
+		{
+			desc:     "Longest prefix wins",
+			oid:      ".1.3.6.1.6.3.1.1.4.1.0",
+			expected: "snmpTrapOID.0",
+		},
+		{
+			desc:     "Prefix must end on a number boundary",
+			oid:      ".1.3.6.1.2.1.1.55.0",
+			expected: ".1.3.6.1.2.1.1.55.0",
+		},
+		{
+			desc:     "Unknown OID",
+			oid:      ".1.3.6.1.4.1.99999.1.0",
+			expected: ".1.3.6.1.4.1.99999.1.0",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			require.Equal(t, tc.expected, names.resolve(tc.oid))
+		})
+	}
+}
@@ -0,0 +1,272 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package snmpreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/snmpreceiver"
+
+import (
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/snmpreceiver/internal/mib"
+)
+
+// profile is a prebuilt set of metric and attribute configs for the objects of a common MIB.
+// OIDs are numeric so that profiles can be used without MIB files.
+type profile struct {
+	attributes map[string]*AttributeConfig
+	metrics    map[string]*MetricConfig
+	// enums are the labels of the enumerated values of attribute OIDs
+	enums map[string][]mib.Enum
+}
+
+// profiles creates the profiles by MIB name. A new profile is created for every config, as the configs
+// are modified when OIDs are normalized.
+var profiles = map[string]func() *profile{
+	"IF-MIB":             newIfMIBProfile,
+	"HOST-RESOURCES-MIB": newHostResourcesMIBProfile,
+	"ENTITY-SENSOR-MIB":  newEntitySensorMIBProfile,
+}
+
+// newIfMIBProfile creates the profile of the interfaces of IF-MIB, using the 64 bit counters of ifXTable
+func newIfMIBProfile() *profile {
+	const (
+		ifEntry  = ".1.3.6.1.2.1.2.2.1"
+		ifXEntry = ".1.3.6.1.2.1.31.1.1.1"
+	)
+
+	// directionOIDs creates the column OIDs of a metric, with the interface and direction attributes
+	directionOIDs := func(receiveOID, transmitOID string) []ColumnOID {
+		return []ColumnOID{
+			{
+				OID:        receiveOID,
+				Attributes: []Attribute{{Name: "network.interface.name"}, {Name: "network.io.direction", Value: "receive"}},
+			},
+			{
+				OID:        transmitOID,
+				Attributes: []Attribute{{Name: "network.interface.name"}, {Name: "network.io.direction", Value: "transmit"}},
+			},
+		}
+	}
+	interfaceOID := func(oid string) []ColumnOID {
+		return []ColumnOID{{OID: oid, Attributes: []Attribute{{Name: "network.interface.name"}}}}
+	}
+	counter := func() *SumMetric {
+		return &SumMetric{Aggregation: "cumulative", Monotonic: true, ValueType: "int"}
+	}
+
+	return &profile{
+		attributes: map[string]*AttributeConfig{
+			"network.interface.name": {
+				Description: "The name of the interface (ifName).",
+				OID:         ifXEntry + ".1",
+			},
+			"network.io.direction": {
+				Description: "The direction of the traffic.",
+				Enum:        []string{"receive", "transmit"},
+			},
+		},
+		metrics: map[string]*MetricConfig{
+			"network.io": {
+				Description: "The number of octets received and transmitted on the interface (ifHCInOctets, ifHCOutOctets).",
+				Unit:        "By",
+				Sum:         counter(),
+				ColumnOIDs:  directionOIDs(ifXEntry+".6", ifXEntry+".10"),
+			},
+			"network.packets": {
+				Description: "The number of unicast packets received and transmitted on the interface (ifHCInUcastPkts, ifHCOutUcastPkts).",
+				Unit:        "{packet}",
+				Sum:         counter(),
+				ColumnOIDs:  directionOIDs(ifXEntry+".7", ifXEntry+".11"),
+			},
+			"network.errors": {
+				Description: "The number of packets received and transmitted on the interface with errors (ifInErrors, ifOutErrors).",
+				Unit:        "{error}",
+				Sum:         counter(),
+				ColumnOIDs:  directionOIDs(ifEntry+".14", ifEntry+".20"),
+			},
+			"network.dropped": {
+				Description: "The number of packets received and transmitted on the interface that were discarded (ifInDiscards, ifOutDiscards).",
+				Unit:        "{packet}",
+				Sum:         counter(),
+				ColumnOIDs:  directionOIDs(ifEntry+".13", ifEntry+".19"),
+			},
+			"network.interface.speed": {
+				Description: "The bandwidth of the interface (ifHighSpeed).",
+				Unit:        "Mbit/s",
+				Gauge:       &GaugeMetric{ValueType: "int"},
+				ColumnOIDs:  interfaceOID(ifXEntry + ".15"),
+			},
+			"network.interface.status": {
+				Description: "The operational status of the interface (ifOperStatus): up(1), down(2), testing(3), unknown(4), dormant(5), notPresent(6), lowerLayerDown(7).",
+				Unit:        "1",
+				Gauge:       &GaugeMetric{ValueType: "int"},
+				ColumnOIDs:  interfaceOID(ifEntry + ".8"),
+			},
+		},
+	}
+}
+
+// newHostResourcesMIBProfile creates the profile of the system, processor and storage objects of HOST-RESOURCES-MIB
+func newHostResourcesMIBProfile() *profile {
+	const (
+		hrSystem       = ".1.3.6.1.2.1.25.1"
+		hrStorage      = ".1.3.6.1.2.1.25.2"
+		hrStorageEntry = hrStorage + ".3.1"
+		hrProcessor    = ".1.3.6.1.2.1.25.3.3.1"
+	)
+
+	storageOID := func(oid string) []ColumnOID {
+		return []ColumnOID{{OID: oid, Attributes: []Attribute{{Name: "system.storage.description"}}}}
+	}
+
+	return &profile{
+		attributes: map[string]*AttributeConfig{
+			"system.storage.description": {
+				Description: "The description of the storage area (hrStorageDescr).",
+				OID:         hrStorageEntry + ".3",
+			},
+			"cpu": {
+				Description:        "The processor, named after its device index, such as cpu.196608.",
+				IndexedValuePrefix: "cpu",
+			},
+		},
+		metrics: map[string]*MetricConfig{
+			"system.processes.count": {
+				Description: "The number of process contexts currently loaded or running on the system (hrSystemProcesses).",
+				Unit:        "{process}",
+				Gauge:       &GaugeMetric{ValueType: "int"},
+				ScalarOIDs:  []ScalarOID{{OID: hrSystem + ".6.0"}},
+			},
+			"system.users.count": {
+				Description: "The number of user sessions on the system (hrSystemNumUsers).",
+				Unit:        "{user}",
+				Gauge:       &GaugeMetric{ValueType: "int"},
+				ScalarOIDs:  []ScalarOID{{OID: hrSystem + ".5.0"}},
+			},
+			"system.memory.limit": {
+				Description: "The amount of physical memory of the system (hrMemorySize).",
+				Unit:        "KiBy",
+				Gauge:       &GaugeMetric{ValueType: "int"},
+				ScalarOIDs:  []ScalarOID{{OID: hrStorage + ".2.0"}},
+			},
+			"system.cpu.load": {
+				Description: "The average percentage of time the processor was not idle over the last minute (hrProcessorLoad).",
+				Unit:        "%",
+				Gauge:       &GaugeMetric{ValueType: "int"},
+				ColumnOIDs:  []ColumnOID{{OID: hrProcessor + ".2", Attributes: []Attribute{{Name: "cpu"}}}},
+			},
+			"system.storage.allocation_unit": {
+				Description: "The size of the allocation units of the storage area (hrStorageAllocationUnits).",
+				Unit:        "By",
+				Gauge:       &GaugeMetric{ValueType: "int"},
+				ColumnOIDs:  storageOID(hrStorageEntry + ".4"),
+			},
+			"system.storage.limit": {
+				Description: "The size of the storage area, in allocation units (hrStorageSize).",
+				Unit:        "{allocation_unit}",
+				Gauge:       &GaugeMetric{ValueType: "int"},
+				ColumnOIDs:  storageOID(hrStorageEntry + ".5"),
+			},
+			"system.storage.usage": {
+				Description: "The amount of the storage area that is allocated, in allocation units (hrStorageUsed).",
+				Unit:        "{allocation_unit}",
+				Gauge:       &GaugeMetric{ValueType: "int"},
+				ColumnOIDs:  storageOID(hrStorageEntry + ".6"),
+			},
+		},
+	}
+}
+
+// newEntitySensorMIBProfile creates the profile of the physical sensors of ENTITY-SENSOR-MIB
+func newEntitySensorMIBProfile() *profile {
+	const (
+		entPhysicalEntry  = ".1.3.6.1.2.1.47.1.1.1.1"
+		entPhySensorEntry = ".1.3.6.1.2.1.99.1.1.1"
+	)
+
+	sensorTypes := []mib.Enum{
+		{Label: "other", Value: 1},
+		{Label: "unknown", Value: 2},
+		{Label: "voltsAC", Value: 3},
+		{Label: "voltsDC", Value: 4},
+		{Label: "amperes", Value: 5},
+		{Label: "watts", Value: 6},
+		{Label: "hertz", Value: 7},
+		{Label: "celsius", Value: 8},
+		{Label: "percentRH", Value: 9},
+		{Label: "rpm", Value: 10},
+		{Label: "cmm", Value: 11},
+		{Label: "truthvalue", Value: 12},
+	}
+	sensorScales := []mib.Enum{
+		{Label: "yocto", Value: 1},
+		{Label: "zepto", Value: 2},
+		{Label: "atto", Value: 3},
+		{Label: "femto", Value: 4},
+		{Label: "pico", Value: 5},
+		{Label: "nano", Value: 6},
+		{Label: "micro", Value: 7},
+		{Label: "milli", Value: 8},
+		{Label: "units", Value: 9},
+		{Label: "kilo", Value: 10},
+		{Label: "mega", Value: 11},
+		{Label: "giga", Value: 12},
+		{Label: "tera", Value: 13},
+		{Label: "exa", Value: 14},
+		{Label: "peta", Value: 15},
+		{Label: "zetta", Value: 16},
+		{Label: "yotta", Value: 17},
+	}
+	sensorStatuses := []mib.Enum{
+		{Label: "ok", Value: 1},
+		{Label: "unavailable", Value: 2},
+		{Label: "nonoperational", Value: 3},
+	}
+
+	return &profile{
+		attributes: map[string]*AttributeConfig{
+			"entity.name": {
+				Description: "The name of the physical entity of the sensor (entPhysicalName).",
+				OID:         entPhysicalEntry + ".7",
+			},
+			"entity.sensor.type": {
+				Description: "The type of data returned by the sensor (entPhySensorType).",
+				OID:         entPhySensorEntry + ".1",
+				Enum:        enumLabels(sensorTypes),
+			},
+			"entity.sensor.scale": {
+				Description: "The exponent applied to the value of the sensor (entPhySensorScale).",
+				OID:         entPhySensorEntry + ".2",
+				Enum:        enumLabels(sensorScales),
+			},
+			"entity.sensor.precision": {
+				Description: "The number of decimal places of the value of the sensor, or the number of its significant digits if negative (entPhySensorPrecision).",
+				OID:         entPhySensorEntry + ".3",
+			},
+			"entity.sensor.status": {
+				Description: "The operational status of the sensor (entPhySensorOperStatus).",
+				OID:         entPhySensorEntry + ".5",
+				Enum:        enumLabels(sensorStatuses),
+			},
+		},
+		metrics: map[string]*MetricConfig{
+			"entity.sensor.value": {
+				Description: "The most recent measurement of the sensor (entPhySensorValue). The measurement is the value divided by 10 to the power of the entity.sensor.precision attribute, with the SI prefix of the entity.sensor.scale attribute.",
+				Unit:        "1",
+				Gauge:       &GaugeMetric{ValueType: "int"},
+				ColumnOIDs: []ColumnOID{{
+					OID: entPhySensorEntry + ".4",
+					Attributes: []Attribute{
+						{Name: "entity.name"},
+						{Name: "entity.sensor.type"},
+						{Name: "entity.sensor.scale"},
+						{Name: "entity.sensor.precision"},
+						{Name: "entity.sensor.status"},
+					},
+				}},
+			},
+		},
+		enums: map[string][]mib.Enum{
+			entPhySensorEntry + ".1": sensorTypes,
+			entPhySensorEntry + ".2": sensorScales,
+			entPhySensorEntry + ".5": sensorStatuses,
+		},
+	}
+}
@@ -394,7 +394,7 @@ func (s *snmpScraper) scrapeScalarResourceAttributes(
 
 	// For each piece of SNMP data, store the necessary info to help create resources later if needed
 	for _, data := range scalarData {
-		if err := scalarDataToResourceAttribute(data, scalarOIDAttributeValues); err != nil {
+		if err := scalarDataToResourceAttribute(data, s.cfg.enumLabels[data.oid], scalarOIDAttributeValues); err != nil {
 			scraperErrors.AddPartial(1, fmt.Errorf(errMsgScalarAttributeOIDProcessing, data.oid, err))
 		}
 	}
@@ -405,6 +405,7 @@ func (s *snmpScraper) scrapeScalarResourceAttributes(
 // (for a resource attribute) and store it in a map for later use
 func scalarDataToResourceAttribute(
 	data snmpData,
+	enumLabels map[int64]string,
 	scalarOIDAttributeValues map[string]string,
 ) error {
 	// Get the string value of the SNMP data for the {resource} attribute value
@@ -416,7 +417,7 @@ func scalarDataToResourceAttribute(
 	case stringVal:
 		stringValue = data.value.(string)
 	case integerVal:
-		stringValue = strconv.FormatInt(data.value.(int64), 10)
+		stringValue = integerAttributeValue(data.value.(int64), enumLabels)
 	case floatVal:
 		stringValue = strconv.FormatFloat(data.value.(float64), 'f', 2, 64)
 	}
@@ -446,7 +447,7 @@ func (s *snmpScraper) scrapeIndexedAttributes(
 
 	// For each piece of SNMP data, store the necessary info to help create resources later if needed
 	for _, data := range indexedData {
-		if err := indexedDataToAttribute(data, columnOIDIndexedAttributeValues); err != nil {
+		if err := indexedDataToAttribute(data, s.cfg.enumLabels[data.columnOID], columnOIDIndexedAttributeValues); err != nil {
 			scraperErrors.AddPartial(1, fmt.Errorf(errMsgIndexedAttributeOIDProcessing, data.oid, data.columnOID, err))
 		}
 	}
@@ -459,6 +460,7 @@ func (s *snmpScraper) scrapeIndexedAttributes(
 // {resource} attribute config column OID and OID index)
 func indexedDataToAttribute(
 	data snmpData,
+	enumLabels map[int64]string,
 	columnOIDIndexedAttributeValues map[string]indexedAttributeValues,
 ) error {
 	// Get the string value of the SNMP data for the {resource} attribute value
@@ -470,7 +472,7 @@ func indexedDataToAttribute(
 	case stringVal:
 		stringValue = data.value.(string)
 	case integerVal:
-		stringValue = strconv.FormatInt(data.value.(int64), 10)
+		stringValue = integerAttributeValue(data.value.(int64), enumLabels)
 	case floatVal:
 		stringValue = strconv.FormatFloat(data.value.(float64), 'f', 2, 64)
 	}
@@ -485,3 +487,11 @@ func indexedDataToAttribute(
 
 	return nil
 }
+
+// integerAttributeValue returns the label of an enumerated integer value from a MIB, or the value itself
+func integerAttributeValue(value int64, enumLabels map[int64]string) string {
+	if label, ok := enumLabels[value]; ok {
+		return label
+	}
+	return strconv.FormatInt(value, 10)
+}
@@ -3078,6 +3078,88 @@ func TestScrape(t *testing.T) {
 				require.NoError(t, err)
 			},
 		},
+		{
+			desc: "SNMP integer value for indexed attribute with MIB enum labels creates metric with labels (30)",
+			testFunc: func(t *testing.T) {
+				mockClient := new(mockClient)
+				snmpData0 := snmpData{
+					columnOID: ".0",
+					oid:       ".0.1",
+					value:     int64(1),
+					valueType: integerVal,
+				}
+				snmpData1 := snmpData{
+					columnOID: ".0",
+					oid:       ".0.2",
+					value:     int64(2),
+					valueType: integerVal,
+				}
+				snmpData2 := snmpData{
+					columnOID: ".1",
+					oid:       ".1.1",
+					value:     int64(1),
+					valueType: integerVal,
+				}
+				snmpData3 := snmpData{
+					columnOID: ".1",
+					oid:       ".1.2",
+					value:     int64(2),
+					valueType: integerVal,
+				}
+				mockClient.On("Connect").Return(nil)
+				mockClient.On("Close").Return(nil)
+				mockClient.On("GetIndexedData", []string{".0"}, mock.Anything).Return([]snmpData{snmpData0, snmpData1}).Once()
+				mockClient.On("GetIndexedData", []string{".1"}, mock.Anything).Return([]snmpData{snmpData2, snmpData3}).Once()
+				scraper := &snmpScraper{
+					cfg: &Config{
+						Attributes: map[string]*AttributeConfig{
+							"attr1": {
+								OID:  ".0",
+								Enum: []string{"up", "down"},
+							},
+						},
+						enumLabels: map[string]map[int64]string{
+							".0": {1: "up", 2: "down"},
+						},
+						Metrics: map[string]*MetricConfig{
+							"metric1": {
+								Description: "test description",
+								Unit:        "By",
+								Gauge: &GaugeMetric{
+									ValueType: "int",
+								},
+								ColumnOIDs: []ColumnOID{
+									{
+										OID: ".1",
+										Attributes: []Attribute{
+											{
+												Name: "attr1",
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+					settings: receivertest.NewNopSettings(metadata.Type),
+					client:   mockClient,
+					logger:   zap.NewNop(),
+				}
+
+				expectedMetricGen := func(t *testing.T) pmetric.Metrics {
+					goldenPath := filepath.Join("testdata", "expected_metrics",
+						"30_indexed_column_oid_enum_attr_golden.yaml")
+					expectedMetrics, err := golden.ReadMetrics(goldenPath)
+					require.NoError(t, err)
+					return expectedMetrics
+				}
+				expectedMetrics := expectedMetricGen(t)
+				metrics, err := scraper.scrape(context.Background())
+				require.NoError(t, err)
+				err = pmetrictest.CompareMetrics(expectedMetrics, metrics, pmetrictest.IgnoreTimestamp())
+				require.NoError(t, err)
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -0,0 +1,358 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package snmpreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/snmpreceiver"
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gosnmp/gosnmp"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/receiver/receiverhelper"
+	"go.opentelemetry.io/collector/scraper/scrapererror"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/snmpreceiver/internal/metadata"
+)
+
+const (
+	// Attributes of the logs created from traps
+	attributeNetworkPeerAddress = "network.peer.address"
+	attributeSNMPVersion        = "snmp.version"
+	attributeSNMPPDUType        = "snmp.pdu.type"
+	attributeSNMPTrapOID        = "snmp.trap.oid"
+	attributeSNMPTrapName       = "snmp.trap.name"
+	attributeSNMPTrapEnterprise = "snmp.trap.enterprise"
+	attributeSNMPTrapAgent      = "snmp.trap.agent_address"
+
+	// enterpriseSpecificTrap is the generic trap number of v1 enterprise specific traps
+	enterpriseSpecificTrap = 6
+)
+
+// trapReceiver listens for traps and informs, and turns them into logs and metrics
+type trapReceiver struct {
+	cfg             *Config
+	settings        receiver.Settings
+	logsConsumer    consumer.Logs
+	metricsConsumer consumer.Metrics
+	oidNames        *oidNames
+	listener        *gosnmp.TrapListener
+	obsrecv         *receiverhelper.ObsReport
+	startTime       pcommon.Timestamp
+	wg              sync.WaitGroup
+}
+
+// newTrapReceiver creates an initialized trapReceiver
+// Relies on config being validated thoroughly
+func newTrapReceiver(settings receiver.Settings, cfg *Config) (*trapReceiver, error) {
+	obsrecv, err := receiverhelper.NewObsReport(receiverhelper.ObsReportSettings{
+		ReceiverID:             settings.ID,
+		Transport:              "udp",
+		ReceiverCreateSettings: settings,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &trapReceiver{
+		cfg:      cfg,
+		settings: settings,
+		oidNames: newOIDNames(cfg.mibOIDNames, cfg.TrapListener.OIDNames),
+		obsrecv:  obsrecv,
+	}, nil
+}
+
+// Start starts listening for traps
+func (r *trapReceiver) Start(_ context.Context, _ component.Host) error {
+	// Normalize the OIDs of the metric configs once, before traps are handled concurrently
+	newConfigHelper(r.cfg)
+	r.startTime = pcommon.NewTimestampFromTime(time.Now())
+
+	params, err := newTrapListenerParams(r.cfg)
+	if err != nil {
+		return err
+	}
+	r.listener = gosnmp.NewTrapListener()
+	r.listener.Params = params
+	r.listener.OnNewTrap = r.handleTrap
+
+	// Checked in config
+	trapURL, _ := url.Parse(r.cfg.TrapListener.Endpoint)
+
+	listenErr := make(chan error, 1)
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		if err := r.listener.Listen(trapURL.Host); err != nil {
+			listenErr <- err
+		}
+	}()
+
+	select {
+	case <-r.listener.Listening():
+		return nil
+	case err := <-listenErr:
+		r.listener = nil
+		return fmt.Errorf("failed to listen for traps on '%s': %w", trapURL.Host, err)
+	}
+}
+
+// Shutdown stops listening for traps
+func (r *trapReceiver) Shutdown(context.Context) error {
+	if r.listener != nil {
+		r.listener.Close()
+	}
+	r.wg.Wait()
+	return nil
+}
+
+// newTrapListenerParams creates the gosnmp parameters with which traps are decoded, and v3 informs
+// are acknowledged
+func newTrapListenerParams(cfg *Config) (*gosnmp.GoSNMP, error) {
+	params := &gosnmp.GoSNMP{
+		Version:   gosnmp.Version2c,
+		Community: cfg.Community,
+		Timeout:   cfg.Timeout,
+		MaxOids:   gosnmp.Default.MaxOids,
+	}
+	if !strings.EqualFold(cfg.Version, "v3") {
+		return params, nil
+	}
+
+	engineID, err := decodeEngineID(cfg.TrapListener.EngineID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode trap_listener engine_id: %w", err)
+	}
+	msgFlags, securityParams := newUsmSecurityParameters(cfg)
+	securityParams.AuthoritativeEngineID = string(engineID)
+
+	params.Version = gosnmp.Version3
+	params.SecurityModel = gosnmp.UserSecurityModel
+	params.MsgFlags = msgFlags
+	params.SecurityParameters = securityParams
+	return params, nil
+}
+
+// handleTrap turns a trap into logs and metrics
+func (r *trapReceiver) handleTrap(packet *gosnmp.SnmpPacket, addr *net.UDPAddr) {
+	if !r.accepts(packet) {
+		r.settings.Logger.Debug("Dropping trap not matching the configured version or community", zap.Stringer("sender", addr))
+		return
+	}
+
+	peerAddress := addr.IP.String()
+	if r.logsConsumer != nil {
+		r.consumeLogs(packet, peerAddress)
+	}
+	if r.metricsConsumer != nil && len(r.cfg.Metrics) > 0 {
+		r.consumeMetrics(packet, peerAddress)
+	}
+}
+
+// accepts returns true if the trap matches the configured version and community.
+// v1 and v2c traps are accepted for both versions v1 and v2c.
+func (r *trapReceiver) accepts(packet *gosnmp.SnmpPacket) bool {
+	if strings.EqualFold(r.cfg.Version, "v3") {
+		return packet.Version == gosnmp.Version3
+	}
+	if packet.Version == gosnmp.Version3 {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(packet.Community), []byte(r.cfg.Community)) == 1
+}
+
+// consumeLogs creates a log record from the trap, with the variable bindings of the trap as body
+func (r *trapReceiver) consumeLogs(packet *gosnmp.SnmpPacket, peerAddress string) {
+	logs := plog.NewLogs()
+	resourceLogs := logs.ResourceLogs().AppendEmpty()
+	resourceLogs.Resource().Attributes().PutStr(attributeNetworkPeerAddress, peerAddress)
+	scopeLogs := resourceLogs.ScopeLogs().AppendEmpty()
+	scopeLogs.Scope().SetName(metadata.ScopeName)
+	scopeLogs.Scope().SetVersion(r.settings.BuildInfo.Version)
+
+	logRecord := scopeLogs.LogRecords().AppendEmpty()
+	now := pcommon.NewTimestampFromTime(time.Now())
+	logRecord.SetTimestamp(now)
+	logRecord.SetObservedTimestamp(now)
+
+	attributes := logRecord.Attributes()
+	attributes.PutStr(attributeSNMPVersion, getVersionName(packet.Version))
+	if packet.PDUType == gosnmp.InformRequest {
+		attributes.PutStr(attributeSNMPPDUType, "inform")
+	} else {
+		attributes.PutStr(attributeSNMPPDUType, "trap")
+	}
+	if trapOID := getTrapOID(packet); trapOID != "" {
+		attributes.PutStr(attributeSNMPTrapOID, trapOID)
+		attributes.PutStr(attributeSNMPTrapName, r.oidNames.resolve(trapOID))
+	}
+	if packet.PDUType == gosnmp.Trap {
+		attributes.PutStr(attributeSNMPTrapEnterprise, normalizeOID(packet.Enterprise))
+		attributes.PutStr(attributeSNMPTrapAgent, packet.AgentAddress)
+	}
+
+	body := logRecord.Body().SetEmptyMap()
+	body.EnsureCapacity(len(packet.Variables))
+	for _, variable := range packet.Variables {
+		putVariable(body, r.oidNames.resolve(variable.Name), variable)
+	}
+
+	ctx := r.obsrecv.StartLogsOp(context.Background())
+	err := r.logsConsumer.ConsumeLogs(ctx, logs)
+	r.obsrecv.EndLogsOp(ctx, metadata.Type.String(), logs.LogRecordCount(), err)
+	if err != nil {
+		r.settings.Logger.Error("Failed to consume logs from trap", zap.Error(err))
+	}
+}
+
+// consumeMetrics creates metrics from the variable bindings of the trap matching the metric configs
+func (r *trapReceiver) consumeMetrics(packet *gosnmp.SnmpPacket, peerAddress string) {
+	trapScraper := &snmpScraper{
+		client:    &trapClient{variables: packet.Variables},
+		logger:    r.settings.Logger,
+		cfg:       r.cfg,
+		settings:  r.settings,
+		startTime: r.startTime,
+	}
+	metrics, err := trapScraper.scrape(context.Background())
+	if err != nil {
+		r.settings.Logger.Debug("Problem creating metrics from trap", zap.Error(err))
+	}
+	if metrics.DataPointCount() == 0 {
+		return
+	}
+
+	resourceMetrics := metrics.ResourceMetrics()
+	for i := 0; i < resourceMetrics.Len(); i++ {
+		resourceMetrics.At(i).Resource().Attributes().PutStr(attributeNetworkPeerAddress, peerAddress)
+	}
+
+	ctx := r.obsrecv.StartMetricsOp(context.Background())
+	err = r.metricsConsumer.ConsumeMetrics(ctx, metrics)
+	r.obsrecv.EndMetricsOp(ctx, metadata.Type.String(), metrics.DataPointCount(), err)
+	if err != nil {
+		r.settings.Logger.Error("Failed to consume metrics from trap", zap.Error(err))
+	}
+}
+
+// getVersionName returns the name of the SNMP version, as used in the config
+func getVersionName(version gosnmp.SnmpVersion) string {
+	switch version {
+	case gosnmp.Version1:
+		return "v1"
+	case gosnmp.Version3:
+		return "v3"
+	default:
+		return "v2c"
+	}
+}
+
+// getTrapOID returns the OID of the trap. The OID of a v1 trap is derived from its generic trap,
+// specific trap and enterprise as described in RFC 3584.
+func getTrapOID(packet *gosnmp.SnmpPacket) string {
+	if packet.PDUType == gosnmp.Trap {
+		if packet.GenericTrap == enterpriseSpecificTrap {
+			return normalizeOID(packet.Enterprise) + ".0." + strconv.Itoa(packet.SpecificTrap)
+		}
+		return snmpTrapsOID + "." + strconv.Itoa(packet.GenericTrap+1)
+	}
+
+	for _, variable := range packet.Variables {
+		if normalizeOID(variable.Name) == snmpTrapOID {
+			return normalizeOID(toString(variable.Value))
+		}
+	}
+	return ""
+}
+
+// putVariable puts the value of a variable binding in the map, with its simplified data type
+func putVariable(m pcommon.Map, name string, variable gosnmp.SnmpPDU) {
+	data := convertSnmpPDUToSnmpData(variable)
+	switch data.valueType {
+	case integerVal:
+		m.PutInt(name, data.value.(int64))
+	case floatVal:
+		m.PutDouble(name, data.value.(float64))
+	case stringVal:
+		m.PutStr(name, data.value.(string))
+	default:
+		if variable.Value == nil {
+			m.PutEmpty(name)
+			return
+		}
+		m.PutStr(name, toString(variable.Value))
+	}
+}
+
+// trapClient implements the client interface over the variable bindings of a trap, so that the
+// metrics of traps are created the same way as the metrics of scrapes
+type trapClient struct {
+	variables []gosnmp.SnmpPDU
+}
+
+// Verify trapClient implements client interface
+var _ client = (*trapClient)(nil)
+
+// Connect does nothing as the data is already received
+func (*trapClient) Connect() error {
+	return nil
+}
+
+// Close does nothing as the data is already received
+func (*trapClient) Close() error {
+	return nil
+}
+
+// GetScalarData returns the variable bindings matching the passed in scalar OIDs.
+// OIDs not found in the trap are ignored.
+func (c *trapClient) GetScalarData(oids []string, _ *scrapererror.ScrapeErrors) []snmpData {
+	var scalarData []snmpData
+	for _, oid := range oids {
+		for _, variable := range c.variables {
+			if normalizeOID(variable.Name) != oid {
+				continue
+			}
+			clientSNMPData := convertSnmpPDUToSnmpData(variable)
+			if clientSNMPData.valueType == notSupportedVal {
+				continue
+			}
+			clientSNMPData.oid = oid
+			scalarData = append(scalarData, clientSNMPData)
+		}
+	}
+	return scalarData
+}
+
+// GetIndexedData returns the variable bindings within the passed in column OIDs.
+// OIDs not found in the trap are ignored.
+func (c *trapClient) GetIndexedData(oids []string, _ *scrapererror.ScrapeErrors) []snmpData {
+	var indexedData []snmpData
+	for _, oid := range oids {
+		for _, variable := range c.variables {
+			name := normalizeOID(variable.Name)
+			if !strings.HasPrefix(name, oid+".") {
+				continue
+			}
+			clientSNMPData := convertSnmpPDUToSnmpData(variable)
+			if clientSNMPData.valueType == notSupportedVal {
+				continue
+			}
+			clientSNMPData.oid = name
+			clientSNMPData.columnOID = oid
+			indexedData = append(indexedData, clientSNMPData)
+		}
+	}
+	return indexedData
+}
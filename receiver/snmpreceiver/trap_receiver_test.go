@@ -0,0 +1,353 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package snmpreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/snmpreceiver"
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/gosnmp/gosnmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/snmpreceiver/internal/metadata"
+)
+
+const testEngineID = "80001f8880e9630000d61ff449"
+
+func TestTrapReceiverV2cTrap(t *testing.T) {
+	port := getAvailableUDPPort(t)
+	cfg := newTestTrapConfig(port)
+	sink := new(consumertest.LogsSink)
+	startTestTrapReceiver(t, cfg, sink, nil)
+
+	sendTestTrap(t, newTestTrapSender(port, gosnmp.Version2c, "public"), gosnmp.SnmpTrap{
+		Variables: []gosnmp.SnmpPDU{
+			{Name: ".1.3.6.1.2.1.1.3.0", Type: gosnmp.TimeTicks, Value: uint32(1000)},
+			{Name: snmpTrapOID, Type: gosnmp.ObjectIdentifier, Value: ".1.3.6.1.6.3.1.1.5.3"},
+			{Name: ".1.3.6.1.2.1.2.2.1.1.3", Type: gosnmp.Integer, Value: 3},
+			{Name: ".1.3.6.1.2.1.2.2.1.2.3", Type: gosnmp.OctetString, Value: "eth0"},
+			{Name: ".1.3.6.1.4.1.99999.1.0", Type: gosnmp.OctetString, Value: "custom"},
+		},
+	})
+
+	require.Eventually(t, func() bool {
+		return sink.LogRecordCount() == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	resourceLogs := sink.AllLogs()[0].ResourceLogs().At(0)
+	peerAddress, ok := resourceLogs.Resource().Attributes().Get(attributeNetworkPeerAddress)
+	require.True(t, ok)
+	assert.Equal(t, "127.0.0.1", peerAddress.Str())
+
+	logRecord := resourceLogs.ScopeLogs().At(0).LogRecords().At(0)
+	assert.Equal(t, map[string]any{
+		attributeSNMPVersion:  "v2c",
+		attributeSNMPPDUType:  "trap",
+		attributeSNMPTrapOID:  ".1.3.6.1.6.3.1.1.5.3",
+		attributeSNMPTrapName: "linkDown",
+	}, logRecord.Attributes().AsRaw())
+	assert.Equal(t, map[string]any{
+		"sysUpTime.0":            int64(1000),
+		"snmpTrapOID.0":          ".1.3.6.1.6.3.1.1.5.3",
+		"ifIndex.3":              int64(3),
+		"ifDescr.3":              "eth0",
+		".1.3.6.1.4.1.99999.1.0": "custom",
+	}, logRecord.Body().Map().AsRaw())
+}
+
+func TestTrapReceiverV1Trap(t *testing.T) {
+	testCases := []struct {
+		desc         string
+		genericTrap  int
+		specificTrap int
+		expectedOID  string
+		expectedName string
+	}{
+		{
+			desc:         "Generic trap",
+			genericTrap:  2,
+			expectedOID:  ".1.3.6.1.6.3.1.1.5.3",
+			expectedName: "linkDown",
+		},
+		{
+			desc:         "Enterprise specific trap",
+			genericTrap:  enterpriseSpecificTrap,
+			specificTrap: 17,
+			expectedOID:  ".1.3.6.1.4.1.99999.0.17",
+			expectedName: ".1.3.6.1.4.1.99999.0.17",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			port := getAvailableUDPPort(t)
+			cfg := newTestTrapConfig(port)
+			cfg.Version = "v1"
+			sink := new(consumertest.LogsSink)
+			startTestTrapReceiver(t, cfg, sink, nil)
+
+			sendTestTrap(t, newTestTrapSender(port, gosnmp.Version1, "public"), gosnmp.SnmpTrap{
+				Variables: []gosnmp.SnmpPDU{
+					{Name: ".1.3.6.1.2.1.2.2.1.1.3", Type: gosnmp.Integer, Value: 3},
+				},
+				Enterprise:   ".1.3.6.1.4.1.99999",
+				AgentAddress: "10.0.0.1",
+				GenericTrap:  tc.genericTrap,
+				SpecificTrap: tc.specificTrap,
+				Timestamp:    300,
+			})
+
+			require.Eventually(t, func() bool {
+				return sink.LogRecordCount() == 1
+			}, 5*time.Second, 10*time.Millisecond)
+
+			logRecord := sink.AllLogs()[0].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+			assert.Equal(t, map[string]any{
+				attributeSNMPVersion:        "v1",
+				attributeSNMPPDUType:        "trap",
+				attributeSNMPTrapOID:        tc.expectedOID,
+				attributeSNMPTrapName:       tc.expectedName,
+				attributeSNMPTrapEnterprise: ".1.3.6.1.4.1.99999",
+				attributeSNMPTrapAgent:      "10.0.0.1",
+			}, logRecord.Attributes().AsRaw())
+			assert.Equal(t, map[string]any{
+				"ifIndex.3": int64(3),
+			}, logRecord.Body().Map().AsRaw())
+		})
+	}
+}
+
+func TestTrapReceiverDropsWrongCommunity(t *testing.T) {
+	port := getAvailableUDPPort(t)
+	cfg := newTestTrapConfig(port)
+	sink := new(consumertest.LogsSink)
+	startTestTrapReceiver(t, cfg, sink, nil)
+
+	trapWithMarker := func(marker string) gosnmp.SnmpTrap {
+		return gosnmp.SnmpTrap{
+			Variables: []gosnmp.SnmpPDU{
+				{Name: ".1.3.6.1.2.1.1.3.0", Type: gosnmp.TimeTicks, Value: uint32(1000)},
+				{Name: ".1.3.6.1.2.1.1.5.0", Type: gosnmp.OctetString, Value: marker},
+			},
+		}
+	}
+	sendTestTrap(t, newTestTrapSender(port, gosnmp.Version2c, "private"), trapWithMarker("dropped"))
+	sendTestTrap(t, newTestTrapSender(port, gosnmp.Version2c, "public"), trapWithMarker("received"))
+
+	require.Eventually(t, func() bool {
+		return sink.LogRecordCount() > 0
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// Traps are handled in order, so the trap with the wrong community has been handled already
+	require.Equal(t, 1, sink.LogRecordCount())
+	logRecord := sink.AllLogs()[0].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+	name, ok := logRecord.Body().Map().Get("sysName.0")
+	require.True(t, ok)
+	assert.Equal(t, "received", name.Str())
+}
+
+func TestTrapReceiverV3Inform(t *testing.T) {
+	port := getAvailableUDPPort(t)
+	cfg := newTestTrapConfig(port)
+	cfg.Version = "v3"
+	cfg.User = "u"
+	cfg.SecurityLevel = "auth_priv"
+	cfg.AuthType = "SHA"
+	cfg.AuthPassword = "authpassword"
+	cfg.PrivacyType = "AES"
+	cfg.PrivacyPassword = "privpassword"
+	cfg.TrapListener.EngineID = testEngineID
+	sink := new(consumertest.LogsSink)
+	startTestTrapReceiver(t, cfg, sink, nil)
+
+	engineID, err := hex.DecodeString(testEngineID)
+	require.NoError(t, err)
+	sender := newTestTrapSender(port, gosnmp.Version3, "")
+	sender.SecurityModel = gosnmp.UserSecurityModel
+	sender.MsgFlags = gosnmp.AuthPriv
+	sender.SecurityParameters = &gosnmp.UsmSecurityParameters{
+		UserName:                 "u",
+		AuthoritativeEngineID:    string(engineID),
+		AuthenticationProtocol:   gosnmp.SHA,
+		AuthenticationPassphrase: "authpassword",
+		PrivacyProtocol:          gosnmp.AES,
+		PrivacyPassphrase:        "privpassword",
+	}
+	sendTestTrap(t, sender, gosnmp.SnmpTrap{
+		Variables: []gosnmp.SnmpPDU{
+			{Name: ".1.3.6.1.2.1.1.3.0", Type: gosnmp.TimeTicks, Value: uint32(1000)},
+			{Name: snmpTrapOID, Type: gosnmp.ObjectIdentifier, Value: ".1.3.6.1.6.3.1.1.5.1"},
+		},
+		IsInform: true,
+	})
+
+	require.Eventually(t, func() bool {
+		return sink.LogRecordCount() == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	logRecord := sink.AllLogs()[0].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+	assert.Equal(t, map[string]any{
+		attributeSNMPVersion:  "v3",
+		attributeSNMPPDUType:  "inform",
+		attributeSNMPTrapOID:  ".1.3.6.1.6.3.1.1.5.1",
+		attributeSNMPTrapName: "coldStart",
+	}, logRecord.Attributes().AsRaw())
+}
+
+func TestTrapReceiverMetrics(t *testing.T) {
+	port := getAvailableUDPPort(t)
+	cfg := newTestTrapConfig(port)
+	cfg.Attributes = map[string]*AttributeConfig{
+		"interface.index": {
+			IndexedValuePrefix: "if",
+		},
+	}
+	cfg.Metrics = map[string]*MetricConfig{
+		"interface.status": {
+			Unit:  "1",
+			Gauge: &GaugeMetric{ValueType: "int"},
+			ColumnOIDs: []ColumnOID{{
+				OID:        "1.3.6.1.2.1.2.2.1.8",
+				Attributes: []Attribute{{Name: "interface.index"}},
+			}},
+		},
+		"system.uptime": {
+			Unit:  "1",
+			Gauge: &GaugeMetric{ValueType: "int"},
+			ScalarOIDs: []ScalarOID{{
+				OID: "1.3.6.1.2.1.1.3.0",
+			}},
+		},
+		"not.in.trap": {
+			Unit:  "1",
+			Gauge: &GaugeMetric{ValueType: "int"},
+			ScalarOIDs: []ScalarOID{{
+				OID: "1.3.6.1.2.1.1.7.0",
+			}},
+		},
+	}
+	logsSink := new(consumertest.LogsSink)
+	metricsSink := new(consumertest.MetricsSink)
+	startTestTrapReceiver(t, cfg, logsSink, metricsSink)
+
+	sendTestTrap(t, newTestTrapSender(port, gosnmp.Version2c, "public"), gosnmp.SnmpTrap{
+		Variables: []gosnmp.SnmpPDU{
+			{Name: ".1.3.6.1.2.1.1.3.0", Type: gosnmp.TimeTicks, Value: uint32(1000)},
+			{Name: snmpTrapOID, Type: gosnmp.ObjectIdentifier, Value: ".1.3.6.1.6.3.1.1.5.3"},
+			{Name: ".1.3.6.1.2.1.2.2.1.8.3", Type: gosnmp.Integer, Value: 2},
+		},
+	})
+
+	require.Eventually(t, func() bool {
+		return metricsSink.DataPointCount() == 2 && logsSink.LogRecordCount() == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	resourceMetrics := metricsSink.AllMetrics()[0].ResourceMetrics()
+	require.Equal(t, 1, resourceMetrics.Len())
+	peerAddress, ok := resourceMetrics.At(0).Resource().Attributes().Get(attributeNetworkPeerAddress)
+	require.True(t, ok)
+	assert.Equal(t, "127.0.0.1", peerAddress.Str())
+
+	metrics := resourceMetrics.At(0).ScopeMetrics().At(0).Metrics()
+	values := map[string]int64{}
+	for i := 0; i < metrics.Len(); i++ {
+		dataPoint := metrics.At(i).Gauge().DataPoints().At(0)
+		values[metrics.At(i).Name()] = dataPoint.IntValue()
+		if metrics.At(i).Name() == "interface.status" {
+			assert.Equal(t, map[string]any{"interface.index": "if.3"}, dataPoint.Attributes().AsRaw())
+		}
+	}
+	assert.Equal(t, map[string]int64{
+		"interface.status": 2,
+		"system.uptime":    1000,
+	}, values)
+}
+
+func TestTrapReceiverListenError(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer conn.Close()
+
+	cfg := newTestTrapConfig(uint16(conn.LocalAddr().(*net.UDPAddr).Port))
+	r, err := newTrapReceiver(receivertest.NewNopSettings(metadata.Type), cfg)
+	require.NoError(t, err)
+	r.logsConsumer = consumertest.NewNop()
+	require.ErrorContains(t, r.Start(context.Background(), componenttest.NewNopHost()), "failed to listen for traps")
+	require.NoError(t, r.Shutdown(context.Background()))
+}
+
+func TestPutVariable(t *testing.T) {
+	m := pcommon.NewMap()
+	putVariable(m, "int", gosnmp.SnmpPDU{Name: ".1", Type: gosnmp.Counter64, Value: uint64(5)})
+	putVariable(m, "float", gosnmp.SnmpPDU{Name: ".2", Type: gosnmp.OpaqueFloat, Value: float32(1.5)})
+	putVariable(m, "string", gosnmp.SnmpPDU{Name: ".3", Type: gosnmp.IPAddress, Value: "10.0.0.1"})
+	putVariable(m, "null", gosnmp.SnmpPDU{Name: ".4", Type: gosnmp.Null})
+	putVariable(m, "unsupported", gosnmp.SnmpPDU{Name: ".5", Type: gosnmp.NoSuchInstance, Value: "x"})
+	assert.Equal(t, map[string]any{
+		"int":         int64(5),
+		"float":       float64(1.5),
+		"string":      "10.0.0.1",
+		"null":        nil,
+		"unsupported": "x",
+	}, m.AsRaw())
+}
+
+// getAvailableUDPPort returns a UDP port which is not in use
+func getAvailableUDPPort(t *testing.T) uint16 {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	port := conn.LocalAddr().(*net.UDPAddr).Port
+	require.NoError(t, conn.Close())
+	return uint16(port)
+}
+
+func newTestTrapConfig(port uint16) *Config {
+	cfg := createDefaultConfig().(*Config)
+	cfg.TrapListener = &TrapListenerConfig{
+		Endpoint: fmt.Sprintf("udp://127.0.0.1:%d", port),
+	}
+	return cfg
+}
+
+func startTestTrapReceiver(t *testing.T, cfg *Config, logsConsumer consumer.Logs, metricsConsumer consumer.Metrics) {
+	require.NoError(t, addMissingConfigDefaults(cfg))
+	r, err := newTrapReceiver(receivertest.NewNopSettings(metadata.Type), cfg)
+	require.NoError(t, err)
+	r.logsConsumer = logsConsumer
+	r.metricsConsumer = metricsConsumer
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		require.NoError(t, r.Shutdown(context.Background()))
+	})
+}
+
+func newTestTrapSender(port uint16, version gosnmp.SnmpVersion, community string) *gosnmp.GoSNMP {
+	return &gosnmp.GoSNMP{
+		Target:    "127.0.0.1",
+		Port:      port,
+		Transport: "udp",
+		Version:   version,
+		Community: community,
+		Timeout:   2 * time.Second,
+		Retries:   1,
+		MaxOids:   gosnmp.Default.MaxOids,
+	}
+}
+
+func sendTestTrap(t *testing.T, sender *gosnmp.GoSNMP, trap gosnmp.SnmpTrap) {
+	require.NoError(t, sender.Connect())
+	defer sender.Conn.Close()
+	_, err := sender.SendTrap(trap)
+	require.NoError(t, err)
+}
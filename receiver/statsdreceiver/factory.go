@@ -13,6 +13,7 @@ import (
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/receiver"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/sharedcomponent"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/internal/metadata"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/protocol"
 )
@@ -33,6 +34,7 @@ func NewFactory() receiver.Factory {
 		metadata.Type,
 		createDefaultConfig,
 		receiver.WithMetrics(createMetricsReceiver, metadata.MetricsStability),
+		receiver.WithLogs(createLogsReceiver, metadata.LogsStability),
 	)
 }
 
@@ -56,6 +58,42 @@ func createMetricsReceiver(
 	cfg component.Config,
 	consumer consumer.Metrics,
 ) (receiver.Metrics, error) {
-	c := cfg.(*Config)
-	return newReceiver(params, *c, consumer)
+	r, err := getOrAddReceiver(params, cfg.(*Config))
+	if err != nil {
+		return nil, err
+	}
+	r.Unwrap().(*statsdReceiver).nextConsumer = consumer
+	return r, nil
 }
+
+// createLogsReceiver creates a receiver turning DogStatsD events and service checks into logs.
+func createLogsReceiver(
+	_ context.Context,
+	params receiver.Settings,
+	cfg component.Config,
+	consumer consumer.Logs,
+) (receiver.Logs, error) {
+	r, err := getOrAddReceiver(params, cfg.(*Config))
+	if err != nil {
+		return nil, err
+	}
+	r.Unwrap().(*statsdReceiver).logsConsumer = consumer
+	return r, nil
+}
+
+// getOrAddReceiver returns the receiver shared by the metrics and logs receivers of the config,
+// so that a single server listens on the endpoint when the receiver is in both pipelines.
+func getOrAddReceiver(params receiver.Settings, cfg *Config) (*sharedcomponent.SharedComponent, error) {
+	var err error
+	r := receivers.GetOrAdd(cfg, func() component.Component {
+		var rcv receiver.Metrics
+		rcv, err = newReceiver(params, *cfg, nil)
+		return rcv
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+var receivers = sharedcomponent.NewSharedComponents()
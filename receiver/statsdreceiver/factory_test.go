@@ -12,6 +12,7 @@ import (
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/receiver/receivertest"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/sharedcomponent"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/internal/metadata"
 )
 
@@ -31,3 +32,22 @@ func TestCreateReceiver(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotNil(t, tReceiver, "receiver creation failed")
 }
+
+func TestCreateLogsReceiver(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.NetAddr.Endpoint = "localhost:0" // Endpoint is required, not going to be used here.
+
+	params := receivertest.NewNopSettings(metadata.Type)
+	logsReceiver, err := createLogsReceiver(context.Background(), params, cfg, consumertest.NewNop())
+	assert.NoError(t, err)
+	assert.NotNil(t, logsReceiver, "receiver creation failed")
+
+	// The metrics and logs receivers of a config share the server
+	metricsReceiver, err := createMetricsReceiver(context.Background(), params, cfg, consumertest.NewNop())
+	assert.NoError(t, err)
+	assert.Same(t, logsReceiver, metricsReceiver)
+
+	r := logsReceiver.(*sharedcomponent.SharedComponent).Unwrap().(*statsdReceiver)
+	assert.NotNil(t, r.logsConsumer)
+	assert.NotNil(t, r.nextConsumer)
+}
@@ -0,0 +1,281 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package parser // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/internal/parser"
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	semconv "go.opentelemetry.io/otel/semconv/v1.22.0"
+)
+
+// DogStatsD events and service checks, as described in
+// https://docs.datadoghq.com/developers/dogstatsd/datagram_shell/
+const (
+	eventPrefix        = "_e{"
+	serviceCheckPrefix = "_sc|"
+
+	eventName        = "dogstatsd.event"
+	serviceCheckName = "dogstatsd.service_check"
+
+	attrEventTitle          = "dogstatsd.event.title"
+	attrEventPriority       = "dogstatsd.event.priority"
+	attrEventAlertType      = "dogstatsd.event.alert_type"
+	attrEventAggregationKey = "dogstatsd.event.aggregation_key"
+	attrEventSourceTypeName = "dogstatsd.event.source_type_name"
+	attrServiceCheckName    = "dogstatsd.service_check.name"
+	attrServiceCheckStatus  = "dogstatsd.service_check.status"
+)
+
+var (
+	errEmptyEventTitle       = errors.New("empty event title")
+	errEmptyServiceCheckName = errors.New("empty service check name")
+)
+
+// eventAlertTypes maps the alert types of events to the severity of their log records.
+var eventAlertTypes = map[string]plog.SeverityNumber{
+	"error":   plog.SeverityNumberError,
+	"warning": plog.SeverityNumberWarn,
+	"info":    plog.SeverityNumberInfo,
+	"success": plog.SeverityNumberInfo,
+}
+
+// serviceCheckStatuses are the names of the service check statuses, by value.
+var serviceCheckStatuses = []struct {
+	name     string
+	severity plog.SeverityNumber
+}{
+	{"ok", plog.SeverityNumberInfo},
+	{"warning", plog.SeverityNumberWarn},
+	{"critical", plog.SeverityNumberError},
+	{"unknown", plog.SeverityNumberUnspecified},
+}
+
+// BatchLogs are the log records received from a client.
+type BatchLogs struct {
+	Info client.Info
+	Logs plog.Logs
+}
+
+// logRecords holds the events and service checks received from a client since the last GetLogs.
+type logRecords struct {
+	addr        net.Addr
+	containerID string
+	records     plog.LogRecordSlice
+}
+
+func (p *StatsDParser) addLogRecord(record plog.LogRecord, addr net.Addr, containerID string) {
+	if containerID != "" && !ContainerIDAsResourceAttribute.IsEnabled() {
+		record.Attributes().PutStr(string(semconv.ContainerIDKey), containerID)
+		containerID = ""
+	}
+	key := p.newInstrumentsKey(addr, containerID)
+	logs, ok := p.logsByAddress[key]
+	if !ok {
+		logs = &logRecords{
+			addr:        addr,
+			containerID: containerID,
+			records:     plog.NewLogRecordSlice(),
+		}
+		p.logsByAddress[key] = logs
+	}
+	record.MoveTo(logs.records.AppendEmpty())
+}
+
+// GetLogs gets the events and service checks received since the last call, and resets them.
+func (p *StatsDParser) GetLogs() []BatchLogs {
+	batchLogs := make([]BatchLogs, 0, len(p.logsByAddress))
+	for _, logs := range p.logsByAddress {
+		batch := BatchLogs{
+			Info: client.Info{
+				Addr: logs.addr,
+			},
+			Logs: plog.NewLogs(),
+		}
+		rl := batch.Logs.ResourceLogs().AppendEmpty()
+		if logs.containerID != "" {
+			rl.Resource().Attributes().PutStr(string(semconv.ContainerIDKey), logs.containerID)
+		}
+		sl := rl.ScopeLogs().AppendEmpty()
+		p.setVersionAndNameScope(sl.Scope())
+		logs.records.MoveAndAppendTo(sl.LogRecords())
+
+		batchLogs = append(batchLogs, batch)
+	}
+	p.logsByAddress = make(map[instrumentsKey]*logRecords)
+	return batchLogs
+}
+
+// parseEvent parses an event of the form
+// _e{<title length>,<text length>}:<title>|<text>|d:<timestamp>|h:<hostname>|p:<priority>|t:<alert type>|#<tags>
+// into a log record, returning the container ID of the event along with it.
+func parseEvent(line string, enableSimpleTags bool) (plog.LogRecord, string, error) {
+	record := plog.NewLogRecord()
+
+	lengths, rest, found := strings.Cut(strings.TrimPrefix(line, eventPrefix), "}:")
+	if !found {
+		return record, "", fmt.Errorf("invalid event format: %s", line)
+	}
+	titleLengthStr, textLengthStr, found := strings.Cut(lengths, ",")
+	if !found {
+		return record, "", fmt.Errorf("invalid event lengths: %s", lengths)
+	}
+	titleLength, err := strconv.Atoi(titleLengthStr)
+	if err != nil || titleLength < 0 {
+		return record, "", fmt.Errorf("invalid event title length: %s", titleLengthStr)
+	}
+	textLength, err := strconv.Atoi(textLengthStr)
+	if err != nil || textLength < 0 {
+		return record, "", fmt.Errorf("invalid event text length: %s", textLengthStr)
+	}
+
+	// The lengths are in bytes, the title and the text may contain '|'. They are compared without adding
+	// them, which could overflow.
+	if titleLength > len(rest) || textLength > len(rest)-titleLength-1 || rest[titleLength] != '|' {
+		return record, "", fmt.Errorf("event title and text do not match their lengths: %s", line)
+	}
+	title := rest[:titleLength]
+	text := rest[titleLength+1 : titleLength+1+textLength]
+	rest = rest[titleLength+1+textLength:]
+	if rest != "" && rest[0] != '|' {
+		return record, "", fmt.Errorf("event title and text do not match their lengths: %s", line)
+	}
+	if title == "" {
+		return record, "", errEmptyEventTitle
+	}
+
+	attrs := record.Attributes()
+	attrs.PutStr(attrEventTitle, unescapeNewlines(title))
+	priority := "normal"
+	alertType := "info"
+	var containerID string
+
+	var part string
+	part, rest, _ = strings.Cut(strings.TrimPrefix(rest, "|"), "|")
+	for ; part != ""; part, rest, _ = strings.Cut(rest, "|") {
+		switch {
+		case strings.HasPrefix(part, "d:"):
+			if err := setTimestamp(record, strings.TrimPrefix(part, "d:")); err != nil {
+				return record, "", err
+			}
+		case strings.HasPrefix(part, "h:"):
+			attrs.PutStr(string(semconv.HostNameKey), strings.TrimPrefix(part, "h:"))
+		case strings.HasPrefix(part, "p:"):
+			priority = strings.TrimPrefix(part, "p:")
+			if priority != "normal" && priority != "low" {
+				return record, "", fmt.Errorf("unsupported event priority: %s", priority)
+			}
+		case strings.HasPrefix(part, "t:"):
+			alertType = strings.TrimPrefix(part, "t:")
+			if _, ok := eventAlertTypes[alertType]; !ok {
+				return record, "", fmt.Errorf("unsupported event alert type: %s", alertType)
+			}
+		case strings.HasPrefix(part, "k:"):
+			attrs.PutStr(attrEventAggregationKey, strings.TrimPrefix(part, "k:"))
+		case strings.HasPrefix(part, "s:"):
+			attrs.PutStr(attrEventSourceTypeName, strings.TrimPrefix(part, "s:"))
+		case strings.HasPrefix(part, "c:"):
+			containerID = strings.TrimPrefix(part, "c:")
+		case strings.HasPrefix(part, "#"):
+			tags, err := parseTags(strings.TrimPrefix(part, "#"), enableSimpleTags)
+			if err != nil {
+				return record, "", err
+			}
+			for _, tag := range tags {
+				attrs.PutStr(string(tag.Key), tag.Value.AsString())
+			}
+		default:
+			return record, "", fmt.Errorf("unrecognized event part: %s", part)
+		}
+	}
+
+	attrs.PutStr(attrEventPriority, priority)
+	attrs.PutStr(attrEventAlertType, alertType)
+	record.SetEventName(eventName)
+	record.SetSeverityNumber(eventAlertTypes[alertType])
+	record.SetSeverityText(alertType)
+	record.SetObservedTimestamp(pcommon.NewTimestampFromTime(timeNowFunc()))
+	record.Body().SetStr(unescapeNewlines(text))
+
+	return record, containerID, nil
+}
+
+// parseServiceCheck parses a service check of the form
+// _sc|<name>|<status>|d:<timestamp>|h:<hostname>|#<tags>|m:<message>
+// into a log record, returning the container ID of the service check along with it.
+func parseServiceCheck(line string, enableSimpleTags bool) (plog.LogRecord, string, error) {
+	record := plog.NewLogRecord()
+
+	name, rest, _ := strings.Cut(strings.TrimPrefix(line, serviceCheckPrefix), "|")
+	if name == "" {
+		return record, "", errEmptyServiceCheckName
+	}
+	statusStr, rest, _ := strings.Cut(rest, "|")
+	status, err := strconv.Atoi(statusStr)
+	if err != nil || status < 0 || status >= len(serviceCheckStatuses) {
+		return record, "", fmt.Errorf("invalid service check status: %s", statusStr)
+	}
+
+	attrs := record.Attributes()
+	attrs.PutStr(attrServiceCheckName, name)
+	attrs.PutStr(attrServiceCheckStatus, serviceCheckStatuses[status].name)
+	var containerID string
+
+	var part string
+	part, rest, _ = strings.Cut(rest, "|")
+	for ; part != ""; part, rest, _ = strings.Cut(rest, "|") {
+		switch {
+		case strings.HasPrefix(part, "d:"):
+			if err := setTimestamp(record, strings.TrimPrefix(part, "d:")); err != nil {
+				return record, "", err
+			}
+		case strings.HasPrefix(part, "h:"):
+			attrs.PutStr(string(semconv.HostNameKey), strings.TrimPrefix(part, "h:"))
+		case strings.HasPrefix(part, "m:"):
+			record.Body().SetStr(unescapeNewlines(strings.TrimPrefix(part, "m:")))
+		case strings.HasPrefix(part, "c:"):
+			containerID = strings.TrimPrefix(part, "c:")
+		case strings.HasPrefix(part, "#"):
+			tags, err := parseTags(strings.TrimPrefix(part, "#"), enableSimpleTags)
+			if err != nil {
+				return record, "", err
+			}
+			for _, tag := range tags {
+				attrs.PutStr(string(tag.Key), tag.Value.AsString())
+			}
+		default:
+			return record, "", fmt.Errorf("unrecognized service check part: %s", part)
+		}
+	}
+
+	record.SetEventName(serviceCheckName)
+	record.SetSeverityNumber(serviceCheckStatuses[status].severity)
+	record.SetSeverityText(serviceCheckStatuses[status].name)
+	record.SetObservedTimestamp(pcommon.NewTimestampFromTime(timeNowFunc()))
+
+	return record, containerID, nil
+}
+
+// setTimestamp sets the timestamp of a log record from a Unix timestamp in seconds.
+func setTimestamp(record plog.LogRecord, timestampStr string) error {
+	timestampSeconds, err := strconv.ParseInt(timestampStr, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid timestamp: %s", timestampStr)
+	}
+	record.SetTimestamp(pcommon.NewTimestampFromTime(time.Unix(timestampSeconds, 0)))
+	return nil
+}
+
+// unescapeNewlines restores the newlines of a title, text or message, which clients send as "\\n"
+// since a newline separates the messages of a packet.
+func unescapeNewlines(s string) string {
+	return strings.ReplaceAll(s, `\n`, "\n")
+}
@@ -0,0 +1,320 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package parser
+
+import (
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/testutil"
+)
+
+func Test_ParseEvent(t *testing.T) {
+	timeNowFunc = func() time.Time {
+		return time.Unix(711, 0)
+	}
+
+	tests := []struct {
+		name             string
+		input            string
+		enableSimpleTags bool
+		wantBody         string
+		wantAttrs        map[string]any
+		wantSeverity     plog.SeverityNumber
+		wantTimestamp    pcommon.Timestamp
+		wantContainerID  string
+		err              error
+	}{
+		{
+			name:     "title and text",
+			input:    "_e{5,4}:title|text",
+			wantBody: "text",
+			wantAttrs: map[string]any{
+				attrEventTitle:     "title",
+				attrEventPriority:  "normal",
+				attrEventAlertType: "info",
+			},
+			wantSeverity: plog.SeverityNumberInfo,
+		},
+		{
+			name:     "title and text with pipes and newlines",
+			input:    `_e{6,11}:ti|tle|text\nmore|`,
+			wantBody: "text\nmore|",
+			wantAttrs: map[string]any{
+				attrEventTitle:     "ti|tle",
+				attrEventPriority:  "normal",
+				attrEventAlertType: "info",
+			},
+			wantSeverity: plog.SeverityNumberInfo,
+		},
+		{
+			name:     "all fields",
+			input:    "_e{5,4}:title|text|d:1656581400|h:myhost|p:low|t:error|k:key|s:source|#env:prod,team:a|c:abc123",
+			wantBody: "text",
+			wantAttrs: map[string]any{
+				attrEventTitle:          "title",
+				attrEventPriority:       "low",
+				attrEventAlertType:      "error",
+				attrEventAggregationKey: "key",
+				attrEventSourceTypeName: "source",
+				"host.name":             "myhost",
+				"env":                   "prod",
+				"team":                  "a",
+			},
+			wantSeverity:    plog.SeverityNumberError,
+			wantTimestamp:   pcommon.NewTimestampFromTime(time.Unix(1656581400, 0)),
+			wantContainerID: "abc123",
+		},
+		{
+			name:             "simple tags",
+			input:            "_e{5,0}:title||#mytag",
+			enableSimpleTags: true,
+			wantBody:         "",
+			wantAttrs: map[string]any{
+				attrEventTitle:     "title",
+				attrEventPriority:  "normal",
+				attrEventAlertType: "info",
+				"mytag":            "",
+			},
+			wantSeverity: plog.SeverityNumberInfo,
+		},
+		{
+			name:  "simple tags disabled",
+			input: "_e{5,0}:title||#mytag",
+			err:   errors.New(`invalid tag format: "mytag"`),
+		},
+		{
+			name:  "missing lengths",
+			input: "_e{5}:title|text",
+			err:   errors.New("invalid event lengths: 5"),
+		},
+		{
+			name:  "invalid title length",
+			input: "_e{a,4}:title|text",
+			err:   errors.New("invalid event title length: a"),
+		},
+		{
+			name:  "invalid text length",
+			input: "_e{5,-1}:title|text",
+			err:   errors.New("invalid event text length: -1"),
+		},
+		{
+			name:  "lengths too long",
+			input: "_e{5,10}:title|text",
+			err:   errors.New("event title and text do not match their lengths: _e{5,10}:title|text"),
+		},
+		{
+			name:  "lengths too short",
+			input: "_e{5,2}:title|text",
+			err:   errors.New("event title and text do not match their lengths: _e{5,2}:title|text"),
+		},
+		{
+			name:  "overflowing lengths",
+			input: "_e{9223372036854775807,0}:a|b",
+			err:   errors.New("event title and text do not match their lengths: _e{9223372036854775807,0}:a|b"),
+		},
+		{
+			name:  "empty title",
+			input: "_e{0,4}:|text",
+			err:   errEmptyEventTitle,
+		},
+		{
+			name:  "invalid timestamp",
+			input: "_e{5,4}:title|text|d:abc",
+			err:   errors.New("invalid timestamp: abc"),
+		},
+		{
+			name:  "unsupported priority",
+			input: "_e{5,4}:title|text|p:high",
+			err:   errors.New("unsupported event priority: high"),
+		},
+		{
+			name:  "unsupported alert type",
+			input: "_e{5,4}:title|text|t:fatal",
+			err:   errors.New("unsupported event alert type: fatal"),
+		},
+		{
+			name:  "unrecognized part",
+			input: "_e{5,4}:title|text|x:y",
+			err:   errors.New("unrecognized event part: x:y"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			record, containerID, err := parseEvent(tt.input, tt.enableSimpleTags)
+			if tt.err != nil {
+				assert.Equal(t, tt.err, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, eventName, record.EventName())
+			assert.Equal(t, tt.wantBody, record.Body().Str())
+			assert.Equal(t, tt.wantAttrs, record.Attributes().AsRaw())
+			assert.Equal(t, tt.wantSeverity, record.SeverityNumber())
+			assert.Equal(t, tt.wantTimestamp, record.Timestamp())
+			assert.Equal(t, pcommon.NewTimestampFromTime(time.Unix(711, 0)), record.ObservedTimestamp())
+			assert.Equal(t, tt.wantContainerID, containerID)
+		})
+	}
+}
+
+func Test_ParseServiceCheck(t *testing.T) {
+	timeNowFunc = func() time.Time {
+		return time.Unix(711, 0)
+	}
+
+	tests := []struct {
+		name            string
+		input           string
+		wantBody        string
+		wantAttrs       map[string]any
+		wantSeverity    plog.SeverityNumber
+		wantTimestamp   pcommon.Timestamp
+		wantContainerID string
+		err             error
+	}{
+		{
+			name:  "name and status",
+			input: "_sc|my.check|0",
+			wantAttrs: map[string]any{
+				attrServiceCheckName:   "my.check",
+				attrServiceCheckStatus: "ok",
+			},
+			wantSeverity: plog.SeverityNumberInfo,
+		},
+		{
+			name:     "all fields",
+			input:    `_sc|my.check|2|d:1656581400|h:myhost|#env:prod|c:abc123|m:connection\nrefused`,
+			wantBody: "connection\nrefused",
+			wantAttrs: map[string]any{
+				attrServiceCheckName:   "my.check",
+				attrServiceCheckStatus: "critical",
+				"host.name":            "myhost",
+				"env":                  "prod",
+			},
+			wantSeverity:    plog.SeverityNumberError,
+			wantTimestamp:   pcommon.NewTimestampFromTime(time.Unix(1656581400, 0)),
+			wantContainerID: "abc123",
+		},
+		{
+			name:  "unknown status",
+			input: "_sc|my.check|3",
+			wantAttrs: map[string]any{
+				attrServiceCheckName:   "my.check",
+				attrServiceCheckStatus: "unknown",
+			},
+			wantSeverity: plog.SeverityNumberUnspecified,
+		},
+		{
+			name:  "empty name",
+			input: "_sc||0",
+			err:   errEmptyServiceCheckName,
+		},
+		{
+			name:  "missing status",
+			input: "_sc|my.check",
+			err:   errors.New("invalid service check status: "),
+		},
+		{
+			name:  "invalid status",
+			input: "_sc|my.check|4",
+			err:   errors.New("invalid service check status: 4"),
+		},
+		{
+			name:  "unrecognized part",
+			input: "_sc|my.check|0|x:y",
+			err:   errors.New("unrecognized service check part: x:y"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			record, containerID, err := parseServiceCheck(tt.input, false)
+			if tt.err != nil {
+				assert.Equal(t, tt.err, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, serviceCheckName, record.EventName())
+			assert.Equal(t, tt.wantBody, record.Body().AsString())
+			assert.Equal(t, tt.wantAttrs, record.Attributes().AsRaw())
+			assert.Equal(t, tt.wantSeverity, record.SeverityNumber())
+			assert.Equal(t, tt.wantTimestamp, record.Timestamp())
+			assert.Equal(t, pcommon.NewTimestampFromTime(time.Unix(711, 0)), record.ObservedTimestamp())
+			assert.Equal(t, tt.wantContainerID, containerID)
+		})
+	}
+}
+
+func TestStatsDParser_GetLogs(t *testing.T) {
+	defer testutil.SetFeatureGateForTest(t, ContainerIDAsResourceAttribute, true)()
+
+	const devVersion = "dev-0.0.1"
+	p := &StatsDParser{
+		BuildInfo: component.BuildInfo{
+			Version: devVersion,
+		},
+	}
+	require.NoError(t, p.Initialize(false, false, false, false, nil))
+	addr, _ := net.ResolveUDPAddr("udp", "1.2.3.4:5678")
+
+	require.NoError(t, p.Aggregate("_e{5,4}:title|text", addr))
+	require.NoError(t, p.Aggregate("_sc|my.check|1|c:abc123", addr))
+	require.NoError(t, p.Aggregate("_e{5,4}:title|text|c:abc123", addr))
+	require.NoError(t, p.Aggregate("test.metric:1|c", addr))
+	require.Empty(t, p.GetMetrics()[0].Metrics.ResourceMetrics().At(0).Resource().Attributes().AsRaw())
+
+	logs := p.GetLogs()
+	require.Len(t, logs, 2)
+	records := map[string][]string{}
+	for _, batch := range logs {
+		assert.Equal(t, addr, batch.Info.Addr)
+		require.Equal(t, 1, batch.Logs.ResourceLogs().Len())
+		rl := batch.Logs.ResourceLogs().At(0)
+		containerID, _ := rl.Resource().Attributes().AsRaw()["container.id"].(string)
+		require.Equal(t, 1, rl.ScopeLogs().Len())
+		sl := rl.ScopeLogs().At(0)
+		assert.Equal(t, receiverName, sl.Scope().Name())
+		assert.Equal(t, devVersion, sl.Scope().Version())
+		for i := 0; i < sl.LogRecords().Len(); i++ {
+			records[containerID] = append(records[containerID], sl.LogRecords().At(i).EventName())
+		}
+	}
+	assert.Equal(t, map[string][]string{
+		"":       {eventName},
+		"abc123": {serviceCheckName, eventName},
+	}, records)
+
+	assert.Empty(t, p.GetLogs())
+}
+
+func TestStatsDParser_GetLogsContainerIDAttribute(t *testing.T) {
+	p := &StatsDParser{}
+	require.NoError(t, p.Initialize(false, false, false, false, nil))
+	addr, _ := net.ResolveUDPAddr("udp", "1.2.3.4:5678")
+
+	require.NoError(t, p.Aggregate("_e{5,4}:title|text", addr))
+	require.NoError(t, p.Aggregate("_sc|my.check|1|c:abc123", addr))
+
+	logs := p.GetLogs()
+	require.Len(t, logs, 1)
+	rl := logs[0].Logs.ResourceLogs().At(0)
+	assert.Equal(t, 0, rl.Resource().Attributes().Len())
+	records := rl.ScopeLogs().At(0).LogRecords()
+	require.Equal(t, 2, records.Len())
+	_, ok := records.At(0).Attributes().Get("container.id")
+	assert.False(t, ok)
+	containerID, ok := records.At(1).Attributes().Get("container.id")
+	assert.True(t, ok)
+	assert.Equal(t, "abc123", containerID.Str())
+}
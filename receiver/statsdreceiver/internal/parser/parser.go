@@ -7,15 +7,27 @@ import (
 	"net"
 
 	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/featuregate"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/protocol"
 )
 
-// Parser is something that can map input StatsD strings to OTLP Metric representations.
+// ContainerIDAsResourceAttribute sets the DogStatsD container ID as a resource attribute, aggregating
+// the metrics by container, instead of as an attribute of the data points and log records.
+var ContainerIDAsResourceAttribute = featuregate.GlobalRegistry().MustRegister(
+	"receiver.statsd.containerIDAsResourceAttribute",
+	featuregate.StageAlpha,
+	featuregate.WithRegisterDescription("When enabled, the DogStatsD container ID is set as the container.id resource attribute of the metrics and logs, and the metrics are aggregated by container."),
+	featuregate.WithRegisterFromVersion("v0.132.0"),
+)
+
+// Parser is something that can map input StatsD strings to OTLP Metric representations,
+// and DogStatsD events and service checks to OTLP Log representations.
 type Parser interface {
 	Initialize(enableMetricType, enableSimpleTags, isMonotonicCounter, enableIPOnlyAggregation bool, sendTimerHistogram []protocol.TimerHistogramMapping) error
 	GetMetrics() []BatchMetrics
+	GetLogs() []BatchLogs
 	Aggregate(line string, addr net.Addr) error
 }
 
@@ -53,7 +53,8 @@ var defaultObserverCategory = ObserverCategory{
 
 // StatsDParser supports the Parse method for parsing StatsD messages with Tags.
 type StatsDParser struct {
-	instrumentsByAddress    map[netAddr]*instruments
+	instrumentsByAddress    map[instrumentsKey]*instruments
+	logsByAddress           map[instrumentsKey]*logRecords
 	enableMetricType        bool
 	enableSimpleTags        bool
 	isMonotonicCounter      bool
@@ -64,8 +65,16 @@ type StatsDParser struct {
 	BuildInfo               component.BuildInfo
 }
 
+// instrumentsKey identifies the client sending the messages, and the container they were sent
+// from when the client is shared by several containers.
+type instrumentsKey struct {
+	addr        netAddr
+	containerID string
+}
+
 type instruments struct {
 	addr                   net.Addr
+	containerID            string
 	gauges                 map[statsDMetricDescription]pmetric.ScopeMetrics
 	counters               map[statsDMetricDescription]pmetric.ScopeMetrics
 	summaries              map[statsDMetricDescription]summaryMetric
@@ -73,13 +82,14 @@ type instruments struct {
 	timersAndDistributions []pmetric.ScopeMetrics
 }
 
-func newInstruments(addr net.Addr) *instruments {
+func newInstruments(addr net.Addr, containerID string) *instruments {
 	return &instruments{
-		addr:       addr,
-		gauges:     make(map[statsDMetricDescription]pmetric.ScopeMetrics),
-		counters:   make(map[statsDMetricDescription]pmetric.ScopeMetrics),
-		summaries:  make(map[statsDMetricDescription]summaryMetric),
-		histograms: make(map[statsDMetricDescription]histogramMetric),
+		addr:        addr,
+		containerID: containerID,
+		gauges:      make(map[statsDMetricDescription]pmetric.ScopeMetrics),
+		counters:    make(map[statsDMetricDescription]pmetric.ScopeMetrics),
+		summaries:   make(map[statsDMetricDescription]summaryMetric),
+		histograms:  make(map[statsDMetricDescription]histogramMetric),
 	}
 }
 
@@ -107,6 +117,7 @@ type statsDMetric struct {
 	unit        string
 	sampleRate  float64
 	timestamp   uint64
+	containerID string
 }
 
 type statsDMetricDescription struct {
@@ -133,11 +144,12 @@ func (t MetricType) FullName() protocol.TypeName {
 
 func (p *StatsDParser) resetState(when time.Time) {
 	p.lastIntervalTime = when
-	p.instrumentsByAddress = make(map[netAddr]*instruments)
+	p.instrumentsByAddress = make(map[instrumentsKey]*instruments)
 }
 
 func (p *StatsDParser) Initialize(enableMetricType, enableSimpleTags, isMonotonicCounter, enableIPOnlyAggregation bool, sendTimerHistogram []protocol.TimerHistogramMapping) error {
 	p.resetState(timeNowFunc())
+	p.logsByAddress = make(map[instrumentsKey]*logRecords)
 
 	p.histogramEvents = defaultObserverCategory
 	p.timerEvents = defaultObserverCategory
@@ -183,6 +195,9 @@ func (p *StatsDParser) GetMetrics() []BatchMetrics {
 			Metrics: pmetric.NewMetrics(),
 		}
 		rm := batch.Metrics.ResourceMetrics().AppendEmpty()
+		if instrument.containerID != "" {
+			rm.Resource().Attributes().PutStr(string(semconv.ContainerIDKey), instrument.containerID)
+		}
 		for _, metric := range instrument.gauges {
 			p.copyMetricAndScope(rm, metric)
 		}
@@ -257,21 +272,35 @@ func (p *StatsDParser) observerCategoryFor(t MetricType) ObserverCategory {
 }
 
 // Aggregate for each metric line.
+// DogStatsD events and service checks are kept as log records until the next GetLogs.
 func (p *StatsDParser) Aggregate(line string, addr net.Addr) error {
+	switch {
+	case strings.HasPrefix(line, eventPrefix):
+		record, containerID, err := parseEvent(line, p.enableSimpleTags)
+		if err != nil {
+			return err
+		}
+		p.addLogRecord(record, addr, containerID)
+		return nil
+	case strings.HasPrefix(line, serviceCheckPrefix):
+		record, containerID, err := parseServiceCheck(line, p.enableSimpleTags)
+		if err != nil {
+			return err
+		}
+		p.addLogRecord(record, addr, containerID)
+		return nil
+	}
+
 	parsedMetric, err := parseMessageToMetric(line, p.enableMetricType, p.enableSimpleTags)
 	if err != nil {
 		return err
 	}
 
-	addrKey := newNetAddr(addr)
-	if p.enableIPOnlyAggregation {
-		addrKey = newIPOnlyNetAddr(addr)
-	}
-
-	instrument, ok := p.instrumentsByAddress[addrKey]
+	key := p.newInstrumentsKey(addr, parsedMetric.containerID)
+	instrument, ok := p.instrumentsByAddress[key]
 	if !ok {
-		instrument = newInstruments(addr)
-		p.instrumentsByAddress[addrKey] = instrument
+		instrument = newInstruments(addr, parsedMetric.containerID)
+		p.instrumentsByAddress[key] = instrument
 	}
 
 	switch parsedMetric.description.metricType {
@@ -392,37 +421,23 @@ func parseMessageToMetric(line string, enableMetricType, enableSimpleTags bool)
 
 			result.sampleRate = f
 		case strings.HasPrefix(part, "#"):
-			tagsStr := strings.TrimPrefix(part, "#")
-
-			// handle an empty tag set
-			// where the tags part was still sent (some clients do this)
-			if tagsStr == "" {
-				continue
-			}
-
-			var tagSet string
-			tagSet, tagsStr, _ = strings.Cut(tagsStr, ",")
-			for ; tagSet != ""; tagSet, tagsStr, _ = strings.Cut(tagsStr, ",") {
-				k, v, _ := strings.Cut(tagSet, ":")
-				if k == "" {
-					return result, fmt.Errorf("invalid tag format: %q", tagSet)
-				}
-
-				// support both simple tags (w/o value) and dimension tags (w/ value).
-				// dogstatsd notably allows simple tags.
-				if v == "" && !enableSimpleTags {
-					return result, fmt.Errorf("invalid tag format: %q", tagSet)
-				}
-
-				kvs = append(kvs, attribute.String(k, v))
+			tags, err := parseTags(strings.TrimPrefix(part, "#"), enableSimpleTags)
+			if err != nil {
+				return result, err
 			}
+			kvs = append(kvs, tags...)
 		case strings.HasPrefix(part, "c:"):
 			// As per DogStatD protocol v1.2:
 			// https://docs.datadoghq.com/developers/dogstatsd/datagram_shell/?tab=metrics#dogstatsd-protocol-v12
 			containerID := strings.TrimPrefix(part, "c:")
 
 			if containerID != "" {
-				kvs = append(kvs, attribute.String(string(semconv.ContainerIDKey), containerID))
+				if ContainerIDAsResourceAttribute.IsEnabled() {
+					// The container ID is set as a resource attribute, metrics are aggregated by container.
+					result.containerID = containerID
+				} else {
+					kvs = append(kvs, attribute.String(string(semconv.ContainerIDKey), containerID))
+				}
 			}
 		case strings.HasPrefix(part, "T"):
 			// As per DogStatD protocol v1.3:
@@ -462,6 +477,38 @@ func parseMessageToMetric(line string, enableMetricType, enableSimpleTags bool)
 	return result, nil
 }
 
+func parseTags(tagsStr string, enableSimpleTags bool) ([]attribute.KeyValue, error) {
+	var kvs []attribute.KeyValue
+
+	// an empty tag set, where the tags part was still sent (some clients do this),
+	// yields no tags
+	var tagSet string
+	tagSet, tagsStr, _ = strings.Cut(tagsStr, ",")
+	for ; tagSet != ""; tagSet, tagsStr, _ = strings.Cut(tagsStr, ",") {
+		k, v, _ := strings.Cut(tagSet, ":")
+		if k == "" {
+			return nil, fmt.Errorf("invalid tag format: %q", tagSet)
+		}
+
+		// support both simple tags (w/o value) and dimension tags (w/ value).
+		// dogstatsd notably allows simple tags.
+		if v == "" && !enableSimpleTags {
+			return nil, fmt.Errorf("invalid tag format: %q", tagSet)
+		}
+
+		kvs = append(kvs, attribute.String(k, v))
+	}
+	return kvs, nil
+}
+
+func (p *StatsDParser) newInstrumentsKey(addr net.Addr, containerID string) instrumentsKey {
+	addrKey := newNetAddr(addr)
+	if p.enableIPOnlyAggregation {
+		addrKey = newIPOnlyNetAddr(addr)
+	}
+	return instrumentsKey{addr: addrKey, containerID: containerID}
+}
+
 type netAddr struct {
 	Network string
 	String  string
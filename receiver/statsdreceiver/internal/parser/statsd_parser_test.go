@@ -17,6 +17,7 @@ import (
 	"go.opentelemetry.io/otel/attribute"
 	semconv "go.opentelemetry.io/otel/semconv/v1.22.0"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/testutil"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/metricstestutil"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/protocol"
 )
@@ -1053,7 +1054,7 @@ func TestStatsDParser_Aggregate(t *testing.T) {
 			assert.NoError(t, p.Initialize(false, false, false, false, []protocol.TimerHistogramMapping{{StatsdType: "timer", ObserverType: "gauge"}, {StatsdType: "histogram", ObserverType: "gauge"}}))
 			p.lastIntervalTime = time.Unix(611, 0)
 			addr, _ := net.ResolveUDPAddr("udp", "1.2.3.4:5678")
-			addrKey := newNetAddr(addr)
+			addrKey := instrumentsKey{addr: newNetAddr(addr)}
 			for _, line := range tt.input {
 				err = p.Aggregate(line, addr)
 			}
@@ -1167,7 +1168,7 @@ func TestStatsDParser_AggregateByAddress(t *testing.T) {
 				}
 			}
 			for i, addr := range tt.addresses {
-				addrKey := newNetAddr(addr)
+				addrKey := instrumentsKey{addr: newNetAddr(addr)}
 				assert.Equal(t, tt.expectedGauges[i], p.instrumentsByAddress[addrKey].gauges)
 			}
 		})
@@ -1270,7 +1271,7 @@ func TestStatsDParser_AggregateWithMetricType(t *testing.T) {
 			assert.NoError(t, p.Initialize(true, false, false, false, []protocol.TimerHistogramMapping{{StatsdType: "timer", ObserverType: "gauge"}, {StatsdType: "histogram", ObserverType: "gauge"}}))
 			p.lastIntervalTime = time.Unix(611, 0)
 			addr, _ := net.ResolveUDPAddr("udp", "1.2.3.4:5678")
-			addrKey := newNetAddr(addr)
+			addrKey := instrumentsKey{addr: newNetAddr(addr)}
 			for _, line := range tt.input {
 				err = p.Aggregate(line, addr)
 			}
@@ -1340,7 +1341,7 @@ func TestStatsDParser_AggregateWithIsMonotonicCounter(t *testing.T) {
 			assert.NoError(t, p.Initialize(false, false, true, false, []protocol.TimerHistogramMapping{{StatsdType: "timer", ObserverType: "gauge"}, {StatsdType: "histogram", ObserverType: "gauge"}}))
 			p.lastIntervalTime = time.Unix(611, 0)
 			addr, _ := net.ResolveUDPAddr("udp", "1.2.3.4:5678")
-			addrKey := newNetAddr(addr)
+			addrKey := instrumentsKey{addr: newNetAddr(addr)}
 			for _, line := range tt.input {
 				err = p.Aggregate(line, addr)
 			}
@@ -1466,7 +1467,7 @@ func TestStatsDParser_AggregateTimerWithSummary(t *testing.T) {
 			p := &StatsDParser{}
 			assert.NoError(t, p.Initialize(false, false, false, false, []protocol.TimerHistogramMapping{{StatsdType: "timer", ObserverType: "summary"}, {StatsdType: "histogram", ObserverType: "summary", Summary: protocol.SummaryConfig{Percentiles: []float64{0, 95, 99}}}}))
 			addr, _ := net.ResolveUDPAddr("udp", "1.2.3.4:5678")
-			addrKey := newNetAddr(addr)
+			addrKey := instrumentsKey{addr: newNetAddr(addr)}
 			for _, line := range tt.input {
 				err = p.Aggregate(line, addr)
 			}
@@ -1488,8 +1489,8 @@ func TestStatsDParser_Initialize(t *testing.T) {
 		attrs:      *attribute.EmptySet(),
 	}
 	addr, _ := net.ResolveUDPAddr("udp", "1.2.3.4:5678")
-	addrKey := newNetAddr(addr)
-	instrument := newInstruments(addr)
+	addrKey := instrumentsKey{addr: newNetAddr(addr)}
+	instrument := newInstruments(addr, "")
 	instrument.gauges[teststatsdDMetricdescription] = pmetric.ScopeMetrics{}
 	p.instrumentsByAddress[addrKey] = instrument
 	assert.Len(t, p.instrumentsByAddress, 1)
@@ -1501,7 +1502,7 @@ func TestStatsDParser_Initialize(t *testing.T) {
 func TestStatsDParser_GetMetricsWithMetricType(t *testing.T) {
 	p := &StatsDParser{}
 	assert.NoError(t, p.Initialize(true, false, false, false, []protocol.TimerHistogramMapping{{StatsdType: "timer", ObserverType: "gauge"}, {StatsdType: "histogram", ObserverType: "gauge"}}))
-	instrument := newInstruments(nil)
+	instrument := newInstruments(nil, "")
 	instrument.gauges[testDescription("statsdTestMetric1", "g",
 		[]string{"mykey", "metric_type"}, []string{"myvalue", "gauge"})] = buildGaugeMetric(
 		testStatsDMetric(
@@ -1556,7 +1557,7 @@ func TestStatsDParser_GetMetricsWithMetricType(t *testing.T) {
 			weights: []float64{1, 1, 1, 1},
 		},
 	}
-	p.instrumentsByAddress[netAddr{}] = instrument
+	p.instrumentsByAddress[instrumentsKey{}] = instrument
 	metrics := p.GetMetrics()[0].Metrics
 	assert.Equal(t, 5, metrics.ResourceMetrics().At(0).ScopeMetrics().Len())
 }
@@ -1950,8 +1951,8 @@ func TestStatsDParser_IPOnlyAggregation(t *testing.T) {
 	require.Len(t, p.instrumentsByAddress, 1)
 
 	for k := range p.instrumentsByAddress {
-		assert.Equal(t, "1.2.3.4", k.String)
-		assert.Equal(t, "udp", k.Network)
+		assert.Equal(t, "1.2.3.4", k.addr.String)
+		assert.Equal(t, "udp", k.addr.Network)
 	}
 	metrics := p.GetMetrics()
 	require.Len(t, metrics, 1)
@@ -1963,3 +1964,56 @@ func TestStatsDParser_IPOnlyAggregation(t *testing.T) {
 
 	assert.Equal(t, int64(4), value)
 }
+
+func TestStatsDParser_ContainerID(t *testing.T) {
+	tests := []struct {
+		name                  string
+		containerIDAsResource bool
+		wantInstruments       int
+		wantResourceValues    map[string]int64
+	}{
+		{
+			name:               "data point attribute",
+			wantInstruments:    1,
+			wantResourceValues: map[string]int64{"": 4},
+		},
+		{
+			name:                  "resource attribute",
+			containerIDAsResource: true,
+			wantInstruments:       2,
+			wantResourceValues:    map[string]int64{"abc123": 3, "": 4},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer testutil.SetFeatureGateForTest(t, ContainerIDAsResourceAttribute, tt.containerIDAsResource)()
+
+			p := &StatsDParser{}
+			require.NoError(t, p.Initialize(false, false, false, false, nil))
+			addr, _ := net.ResolveUDPAddr("udp", "1.2.3.4:5678")
+
+			require.NoError(t, p.Aggregate("test.metric:1|c|c:abc123", addr))
+			require.NoError(t, p.Aggregate("test.metric:2|c|c:abc123", addr))
+			require.NoError(t, p.Aggregate("test.metric:4|c", addr))
+			require.Len(t, p.instrumentsByAddress, tt.wantInstruments)
+
+			values := map[string]int64{}
+			for _, batch := range p.GetMetrics() {
+				assert.Equal(t, addr, batch.Info.Addr)
+				rm := batch.Metrics.ResourceMetrics().At(0)
+				containerID, _ := rm.Resource().Attributes().AsRaw()[string(semconv.ContainerIDKey)].(string)
+				for i := 0; i < rm.ScopeMetrics().Len(); i++ {
+					dp := rm.ScopeMetrics().At(i).Metrics().At(0).Sum().DataPoints().At(0)
+					if attrContainerID, ok := dp.Attributes().Get(string(semconv.ContainerIDKey)); ok {
+						assert.False(t, tt.containerIDAsResource)
+						assert.Equal(t, "abc123", attrContainerID.Str())
+						assert.Equal(t, int64(3), dp.IntValue())
+						continue
+					}
+					values[containerID] = dp.IntValue()
+				}
+			}
+			assert.Equal(t, tt.wantResourceValues, values)
+		})
+	}
+}
@@ -25,9 +25,13 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/internal/transport"
 )
 
-var _ receiver.Metrics = (*statsdReceiver)(nil)
+var (
+	_ receiver.Metrics = (*statsdReceiver)(nil)
+	_ receiver.Logs    = (*statsdReceiver)(nil)
+)
 
-// statsdReceiver implements the receiver.Metrics for StatsD protocol.
+// statsdReceiver implements the receiver.Metrics for StatsD protocol,
+// and the receiver.Logs for DogStatsD events and service checks.
 type statsdReceiver struct {
 	settings receiver.Settings
 	config   *Config
@@ -37,6 +41,7 @@ type statsdReceiver struct {
 	obsrecv      *receiverhelper.ObsReport
 	parser       parser.Parser
 	nextConsumer consumer.Metrics
+	logsConsumer consumer.Logs
 	cancel       context.CancelFunc
 }
 
@@ -118,8 +123,16 @@ func (r *statsdReceiver) Start(ctx context.Context, host component.Host) error {
 	if err != nil {
 		return err
 	}
+	nextConsumer := r.nextConsumer
+	if nextConsumer == nil {
+		// The receiver is only in a logs pipeline, its metrics are not flushed
+		nextConsumer, err = consumer.NewMetrics(func(context.Context, pmetric.Metrics) error { return nil })
+		if err != nil {
+			return err
+		}
+	}
 	go func() {
-		if err := r.server.ListenAndServe(r.nextConsumer, r.reporter, transferChan); err != nil {
+		if err := r.server.ListenAndServe(nextConsumer, r.reporter, transferChan); err != nil {
 			if !errors.Is(err, net.ErrClosed) {
 				componentstatus.ReportStatus(host, componentstatus.NewFatalErrorEvent(err))
 			}
@@ -130,17 +143,8 @@ func (r *statsdReceiver) Start(ctx context.Context, host component.Host) error {
 		for {
 			select {
 			case <-ticker.C:
-				batchMetrics := r.parser.GetMetrics()
-				for _, batch := range batchMetrics {
-					batchCtx := client.NewContext(ctx, batch.Info)
-					numPoints := batch.Metrics.DataPointCount()
-					flushCtx := r.obsrecv.StartMetricsOp(batchCtx)
-					err := r.Flush(flushCtx, batch.Metrics, r.nextConsumer)
-					if err != nil {
-						r.reporter.OnDebugf("Error flushing metrics", zap.Error(err))
-					}
-					r.obsrecv.EndMetricsOp(flushCtx, metadata.Type.String(), numPoints, err)
-				}
+				r.flushMetrics(ctx)
+				r.flushLogs(ctx)
 			case metric := <-transferChan:
 				err := r.parser.Aggregate(metric.Raw, metric.Addr)
 				if err != nil {
@@ -178,6 +182,42 @@ func (r *statsdReceiver) Shutdown(context.Context) error {
 	return err
 }
 
+// flushMetrics sends the metrics aggregated during the interval, when the receiver is in a metrics pipeline.
+func (r *statsdReceiver) flushMetrics(ctx context.Context) {
+	batchMetrics := r.parser.GetMetrics()
+	if r.nextConsumer == nil {
+		return
+	}
+	for _, batch := range batchMetrics {
+		batchCtx := client.NewContext(ctx, batch.Info)
+		numPoints := batch.Metrics.DataPointCount()
+		flushCtx := r.obsrecv.StartMetricsOp(batchCtx)
+		err := r.Flush(flushCtx, batch.Metrics, r.nextConsumer)
+		if err != nil {
+			r.reporter.OnDebugf("Error flushing metrics", zap.Error(err))
+		}
+		r.obsrecv.EndMetricsOp(flushCtx, metadata.Type.String(), numPoints, err)
+	}
+}
+
+// flushLogs sends the events and service checks received during the interval, when the receiver is in a logs pipeline.
+func (r *statsdReceiver) flushLogs(ctx context.Context) {
+	batchLogs := r.parser.GetLogs()
+	if r.logsConsumer == nil {
+		return
+	}
+	for _, batch := range batchLogs {
+		batchCtx := client.NewContext(ctx, batch.Info)
+		numRecords := batch.Logs.LogRecordCount()
+		flushCtx := r.obsrecv.StartLogsOp(batchCtx)
+		err := r.logsConsumer.ConsumeLogs(flushCtx, batch.Logs)
+		if err != nil {
+			r.reporter.OnDebugf("Error flushing logs", zap.Error(err))
+		}
+		r.obsrecv.EndLogsOp(flushCtx, metadata.Type.String(), numRecords, err)
+	}
+}
+
 func (*statsdReceiver) Flush(ctx context.Context, metrics pmetric.Metrics, nextConsumer consumer.Metrics) error {
 	return nextConsumer.ConsumeMetrics(ctx, metrics)
 }
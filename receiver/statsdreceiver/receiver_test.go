@@ -6,6 +6,7 @@ package statsdreceiver
 import (
 	"context"
 	"errors"
+	"net"
 	"runtime"
 	"testing"
 	"time"
@@ -184,3 +185,31 @@ func Test_statsdreceiver_EndToEnd(t *testing.T) {
 		})
 	}
 }
+
+func TestStatsdReceiver_Logs(t *testing.T) {
+	addr := testutil.GetAvailableLocalNetworkAddress(t, "udp")
+	cfg := createDefaultConfig().(*Config)
+	cfg.NetAddr.Endpoint = addr
+	cfg.AggregationInterval = time.Second
+
+	rcv, err := newReceiver(receivertest.NewNopSettings(metadata.Type), *cfg, nil)
+	require.NoError(t, err)
+	r := rcv.(*statsdReceiver)
+	sink := new(consumertest.LogsSink)
+	r.logsConsumer = sink
+
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() {
+		assert.NoError(t, r.Shutdown(context.Background()))
+	}()
+
+	conn, err := net.Dial("udp", addr)
+	require.NoError(t, err)
+	defer conn.Close()
+	_, err = conn.Write([]byte("_e{5,4}:title|text|t:error\n_sc|my.check|0|c:abc123\ntest.metric:42|c"))
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		return sink.LogRecordCount() == 2
+	}, 5*time.Second, 100*time.Millisecond)
+}